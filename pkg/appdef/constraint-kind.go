@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2023-present Sigma-Soft, Ltd.
+ * @author: Nikolay Nikitin
+ */
+
+package appdef
+
+import (
+	"strconv"
+	"strings"
+)
+
+//go:generate stringer -type=ConstraintKind -output=constraint-kind_string.go
+
+const (
+	ConstraintKind_null ConstraintKind = iota
+
+	// Minimum length of string (in runes) or bytes (in bytes)
+	ConstraintKind_MinLen
+
+	// Maximum length of string (in runes) or bytes (in bytes)
+	ConstraintKind_MaxLen
+
+	// Regular expression pattern what string must match
+	ConstraintKind_Pattern
+
+	// Minimum inclusive value of number
+	ConstraintKind_MinIncl
+
+	// Minimum exclusive value of number
+	ConstraintKind_MinExcl
+
+	// Maximum inclusive value of number
+	ConstraintKind_MaxIncl
+
+	// Maximum exclusive value of number
+	ConstraintKind_MaxExcl
+
+	// List of allowed values
+	ConstraintKind_Enum
+
+	ConstraintKind_FakeLast
+)
+
+func (k ConstraintKind) MarshalText() ([]byte, error) {
+	var s string
+	if k < ConstraintKind_FakeLast {
+		s = k.String()
+	} else {
+		const base = 10
+		s = strconv.FormatUint(uint64(k), base)
+	}
+	return []byte(s), nil
+}
+
+// Renders an ConstraintKind in human-readable form, without "ConstraintKind_" prefix,
+// suitable for debugging or error messages
+func (k ConstraintKind) ToString() string {
+	const pref = "ConstraintKind_"
+	return strings.TrimPrefix(k.String(), pref)
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2023-present Sigma-Soft, Ltd.
+ * @author: Nikolay Nikitin
+ */
+
+package appdef
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestConstraintKind_MarshalText(t *testing.T) {
+	tests := []struct {
+		name string
+		k    ConstraintKind
+		want string
+	}{
+		{
+			name: `1 —> "ConstraintKind_MinLen"`,
+			k:    ConstraintKind_MinLen,
+			want: `ConstraintKind_MinLen`,
+		},
+		{
+			name: `ConstraintKind_FakeLast —> 9`,
+			k:    ConstraintKind_FakeLast,
+			want: strconv.FormatUint(uint64(ConstraintKind_FakeLast), 10),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.k.MarshalText()
+			if err != nil {
+				t.Errorf("ConstraintKind.MarshalText() unexpected error %v", err)
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("ConstraintKind.MarshalText() = %s, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraintKind_ToString(t *testing.T) {
+	tests := []struct {
+		name string
+		k    ConstraintKind
+		want string
+	}{
+		{name: "basic", k: ConstraintKind_Pattern, want: "Pattern"},
+		{name: "out of range", k: ConstraintKind_FakeLast + 1, want: (ConstraintKind_FakeLast + 1).String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.k.ToString(); got != tt.want {
+				t.Errorf("ConstraintKind.ToString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataKind_IsSupportedConstraint(t *testing.T) {
+	tests := []struct {
+		k    DataKind
+		c    ConstraintKind
+		want bool
+	}{
+		{DataKind_string, ConstraintKind_MaxLen, true},
+		{DataKind_bytes, ConstraintKind_MinLen, true},
+		{DataKind_bytes, ConstraintKind_Pattern, false},
+		{DataKind_int32, ConstraintKind_MinIncl, true},
+		{DataKind_decimal, ConstraintKind_MaxExcl, true},
+		{DataKind_string, ConstraintKind_MaxIncl, false},
+		{DataKind_int64, ConstraintKind_Enum, true},
+		{DataKind_bool, ConstraintKind_Enum, false},
+		{DataKind_int32, ConstraintKind_null, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.k.ToString()+" "+tt.c.ToString(), func(t *testing.T) {
+			if got := tt.k.IsSupportedConstraint(tt.c); got != tt.want {
+				t.Errorf("%v.IsSupportedConstraint(%v) = %v, want %v", tt.k, tt.c, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2023-present Sigma-Soft, Ltd.
+ * @author: Nikolay Nikitin
+ */
+
+package appdef
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Implements IConstraint interface
+type constraint struct {
+	kind  ConstraintKind
+	value interface{}
+}
+
+func newConstraint(kind ConstraintKind, value interface{}) *constraint {
+	return &constraint{kind, value}
+}
+
+// Returns constraint what string or bytes length must be greater or equal to specified value
+func MinLen(v uint16) IConstraint {
+	return newConstraint(ConstraintKind_MinLen, v)
+}
+
+// Returns constraint what string or bytes length must be less or equal to specified value
+func MaxLen(v uint16) IConstraint {
+	return newConstraint(ConstraintKind_MaxLen, v)
+}
+
+// Returns constraint what string must match specified regular expression.
+//
+// # Panics:
+//   - if expression is not valid regular expression
+func Pattern(expr string) IConstraint {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		panic(fmt.Errorf("invalid pattern «%s»: %w: %w", expr, err, ErrInvalidConstraint))
+	}
+	return newConstraint(ConstraintKind_Pattern, re)
+}
+
+// Returns constraint what number must be greater or equal to specified value
+func MinIncl(v float64) IConstraint {
+	return newConstraint(ConstraintKind_MinIncl, v)
+}
+
+// Returns constraint what number must be greater than specified value
+func MinExcl(v float64) IConstraint {
+	return newConstraint(ConstraintKind_MinExcl, v)
+}
+
+// Returns constraint what number must be less or equal to specified value
+func MaxIncl(v float64) IConstraint {
+	return newConstraint(ConstraintKind_MaxIncl, v)
+}
+
+// Returns constraint what number must be less than specified value
+func MaxExcl(v float64) IConstraint {
+	return newConstraint(ConstraintKind_MaxExcl, v)
+}
+
+// Returns constraint what value must be one of specified values.
+//
+// Values are converted to field data kind then constraint is set to field.
+//
+// # Panics:
+//   - if no values specified
+func Enum(values ...interface{}) IConstraint {
+	if len(values) == 0 {
+		panic(fmt.Errorf("enumeration values missed: %w", ErrInvalidConstraint))
+	}
+	return newConstraint(ConstraintKind_Enum, values)
+}
+
+func (c constraint) Kind() ConstraintKind { return c.kind }
+
+func (c constraint) String() string {
+	return fmt.Sprintf("%s: %v", c.kind.ToString(), c.value)
+}
+
+func (c constraint) Value() interface{} { return c.value }
@@ -28,6 +28,18 @@ const (
 
 	DataKind_RecordID
 
+	// Fixed-point decimal number with precision and scale, see IField.Precision() and IField.Scale()
+	DataKind_decimal
+
+	// Moment of time with milliseconds precision, stored as UTC unix milliseconds
+	DataKind_timestamp
+
+	// Calendar date without time, stored as days from unix epoch
+	DataKind_date
+
+	// Universally unique identifier, 16 bytes
+	DataKind_UUID
+
 	// Complex types
 
 	DataKind_Record
@@ -36,6 +48,17 @@ const (
 	DataKind_FakeLast
 )
 
+// Decimal precision and scale limits.
+//
+// Decimal values are stored as unscaled int64 numbers, so precision can not exceed 18 digits
+const (
+	MaxDecimalPrecision = 18
+
+	// Used then decimal field added by AddField() without precision and scale
+	DefaultDecimalPrecision = MaxDecimalPrecision
+	DefaultDecimalScale     = 0
+)
+
 // Returns is fixed width data kind
 func (k DataKind) IsFixed() bool {
 	switch k {
@@ -46,12 +69,37 @@ func (k DataKind) IsFixed() bool {
 		DataKind_float64,
 		DataKind_QName,
 		DataKind_bool,
-		DataKind_RecordID:
+		DataKind_RecordID,
+		DataKind_decimal,
+		DataKind_timestamp,
+		DataKind_date,
+		DataKind_UUID:
 		return true
 	}
 	return false
 }
 
+// Returns is constraint kind supported by data kind
+func (k DataKind) IsSupportedConstraint(c ConstraintKind) bool {
+	switch c {
+	case ConstraintKind_MinLen, ConstraintKind_MaxLen:
+		return (k == DataKind_string) || (k == DataKind_bytes)
+	case ConstraintKind_Pattern:
+		return k == DataKind_string
+	case ConstraintKind_MinIncl, ConstraintKind_MinExcl, ConstraintKind_MaxIncl, ConstraintKind_MaxExcl:
+		switch k {
+		case DataKind_int32, DataKind_int64, DataKind_float32, DataKind_float64, DataKind_decimal:
+			return true
+		}
+	case ConstraintKind_Enum:
+		switch k {
+		case DataKind_int32, DataKind_int64, DataKind_string:
+			return true
+		}
+	}
+	return false
+}
+
 func (k DataKind) MarshalText() ([]byte, error) {
 	var s string
 	if k < DataKind_FakeLast {
@@ -18,14 +18,18 @@ func _() {
 	_ = x[DataKind_QName-7]
 	_ = x[DataKind_bool-8]
 	_ = x[DataKind_RecordID-9]
-	_ = x[DataKind_Record-10]
-	_ = x[DataKind_Event-11]
-	_ = x[DataKind_FakeLast-12]
+	_ = x[DataKind_decimal-10]
+	_ = x[DataKind_timestamp-11]
+	_ = x[DataKind_date-12]
+	_ = x[DataKind_UUID-13]
+	_ = x[DataKind_Record-14]
+	_ = x[DataKind_Event-15]
+	_ = x[DataKind_FakeLast-16]
 }
 
-const _DataKind_name = "DataKind_nullDataKind_int32DataKind_int64DataKind_float32DataKind_float64DataKind_bytesDataKind_stringDataKind_QNameDataKind_boolDataKind_RecordIDDataKind_RecordDataKind_EventDataKind_FakeLast"
+const _DataKind_name = "DataKind_nullDataKind_int32DataKind_int64DataKind_float32DataKind_float64DataKind_bytesDataKind_stringDataKind_QNameDataKind_boolDataKind_RecordIDDataKind_decimalDataKind_timestampDataKind_dateDataKind_UUIDDataKind_RecordDataKind_EventDataKind_FakeLast"
 
-var _DataKind_index = [...]uint8{0, 13, 27, 41, 57, 73, 87, 102, 116, 129, 146, 161, 175, 192}
+var _DataKind_index = [...]uint8{0, 13, 27, 41, 57, 73, 87, 102, 116, 129, 146, 162, 180, 193, 206, 221, 235, 252}
 
 func (i DataKind) String() string {
 	if i >= DataKind(len(_DataKind_index)-1) {
@@ -52,7 +52,7 @@ func TestDataKindType_MarshalText(t *testing.T) {
 			want: `DataKind_int32`,
 		},
 		{
-			name: `DataKind_FakeLast —> 12`,
+			name: `DataKind_FakeLast —> 16`,
 			k:    DataKind_FakeLast,
 			want: strconv.FormatUint(uint64(DataKind_FakeLast), 10),
 		},
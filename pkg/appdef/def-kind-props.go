@@ -26,15 +26,19 @@ var defKindProps = map[DefKind]struct {
 		structure:     true,
 		fieldsAllowed: true,
 		availableFieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_timestamp: true,
+			DataKind_date:      true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_ID:       true,
@@ -50,15 +54,19 @@ var defKindProps = map[DefKind]struct {
 		structure:     true,
 		fieldsAllowed: true,
 		availableFieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_timestamp: true,
+			DataKind_date:      true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_ID:       true,
@@ -74,15 +82,19 @@ var defKindProps = map[DefKind]struct {
 		structure:     true,
 		fieldsAllowed: true,
 		availableFieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_timestamp: true,
+			DataKind_date:      true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_ID:    true,
@@ -98,15 +110,19 @@ var defKindProps = map[DefKind]struct {
 		structure:     true,
 		fieldsAllowed: true,
 		availableFieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_timestamp: true,
+			DataKind_date:      true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_ID:       true,
@@ -122,15 +138,19 @@ var defKindProps = map[DefKind]struct {
 		structure:     true,
 		fieldsAllowed: true,
 		availableFieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_timestamp: true,
+			DataKind_date:      true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_ID:        true,
@@ -148,15 +168,19 @@ var defKindProps = map[DefKind]struct {
 		structure:     true,
 		fieldsAllowed: true,
 		availableFieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_timestamp: true,
+			DataKind_date:      true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_ID:        true,
@@ -174,15 +198,19 @@ var defKindProps = map[DefKind]struct {
 		structure:     true,
 		fieldsAllowed: true,
 		availableFieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_timestamp: true,
+			DataKind_date:      true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_ID:        true,
@@ -199,15 +227,19 @@ var defKindProps = map[DefKind]struct {
 		structure:     true,
 		fieldsAllowed: true,
 		availableFieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_timestamp: true,
+			DataKind_date:      true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_ID:        true,
@@ -237,13 +269,17 @@ var defKindProps = map[DefKind]struct {
 		structure:     false,
 		fieldsAllowed: true,
 		availableFieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_timestamp: true,
+			DataKind_date:      true,
+			DataKind_UUID:      true,
 		},
 		systemFields:            map[string]bool{},
 		containersAllowed:       false,
@@ -253,15 +289,19 @@ var defKindProps = map[DefKind]struct {
 		structure:     false,
 		fieldsAllowed: true,
 		availableFieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true, // last field
-			DataKind_string:   true, // last field
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true, // last field
+			DataKind_string:    true, // last field
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_timestamp: true,
+			DataKind_date:      true,
+			DataKind_UUID:      true,
 		},
 		systemFields:            map[string]bool{},
 		containersAllowed:       false,
@@ -271,17 +311,21 @@ var defKindProps = map[DefKind]struct {
 		structure:     false,
 		fieldsAllowed: true,
 		availableFieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
-			DataKind_Record:   true, // +
-			DataKind_Event:    true, // +
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_timestamp: true,
+			DataKind_date:      true,
+			DataKind_UUID:      true,
+			DataKind_Record:    true, // +
+			DataKind_Event:     true, // +
 		},
 		systemFields: map[string]bool{
 			SystemField_QName: true,
@@ -293,15 +337,19 @@ var defKindProps = map[DefKind]struct {
 		structure:     true,
 		fieldsAllowed: true,
 		availableFieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_timestamp: true,
+			DataKind_date:      true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_QName: true,
@@ -315,15 +363,19 @@ var defKindProps = map[DefKind]struct {
 		structure:     true,
 		fieldsAllowed: true,
 		availableFieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_timestamp: true,
+			DataKind_date:      true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_QName:     true,
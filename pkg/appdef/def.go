@@ -81,6 +81,18 @@ func (d *def) AddField(name string, kind DataKind, required bool) IDefBuilder {
 	return d
 }
 
+func (d *def) AddDecimalField(name string, precision, scale uint8, required bool) IDefBuilder {
+	if (precision == 0) || (precision > MaxDecimalPrecision) {
+		panic(fmt.Errorf("decimal field «%v» precision (%d) must be in range 1…%d: %w", name, precision, MaxDecimalPrecision, ErrInvalidDecimalPrecision))
+	}
+	if scale > precision {
+		panic(fmt.Errorf("decimal field «%v» scale (%d) must be less or equal to precision (%d): %w", name, scale, precision, ErrInvalidDecimalPrecision))
+	}
+	fld := d.addField(name, DataKind_decimal, required, false)
+	fld.precision, fld.scale = precision, scale
+	return d
+}
+
 func (d *def) App() IAppDef {
 	return d.app
 }
@@ -147,11 +159,27 @@ func (d *def) SetSingleton() {
 	d.changed()
 }
 
+func (d *def) SetFieldConstraints(name string, c ...IConstraint) IDefBuilder {
+	d.userField(name).setConstraints(c...)
+	d.changed()
+	return d
+}
+
+func (d *def) SetFieldDefault(name string, value interface{}) IDefBuilder {
+	fld := d.userField(name)
+	if fld.Verifiable() {
+		panic(fmt.Errorf("verifiable field «%v» can not have default value: %w", name, ErrIncompatibleValue))
+	}
+	fld.setDefault(value)
+	d.changed()
+	return d
+}
+
 func (d *def) Singleton() bool {
 	return d.singleton && (d.Kind() == DefKind_CDoc)
 }
 
-func (d *def) addField(name string, kind DataKind, required, verified bool, vk ...VerificationKind) {
+func (d *def) addField(name string, kind DataKind, required, verified bool, vk ...VerificationKind) *field {
 	if name == NullName {
 		panic(fmt.Errorf("empty field name: %w", ErrNameMissed))
 	}
@@ -160,9 +188,9 @@ func (d *def) addField(name string, kind DataKind, required, verified bool, vk .
 			panic(fmt.Errorf("field name «%v» is invalid: %w", name, err))
 		}
 	}
-	if d.Field(name) != nil {
+	if f, ok := d.fields[name]; ok {
 		if IsSysField(name) {
-			return
+			return f
 		}
 		panic(fmt.Errorf("field «%v» is already exists: %w", name, ErrNameUniqueViolation))
 	}
@@ -182,6 +210,20 @@ func (d *def) addField(name string, kind DataKind, required, verified bool, vk .
 	d.fieldsOrdered = append(d.fieldsOrdered, name)
 
 	d.changed()
+
+	return fld
+}
+
+// Returns user (not system) field by name. Panics if field not found or system
+func (d *def) userField(name string) *field {
+	if IsSysField(name) {
+		panic(fmt.Errorf("system field «%v» can not be changed: %w", name, ErrInvalidName))
+	}
+	fld, ok := d.fields[name]
+	if !ok {
+		panic(fmt.Errorf("field «%v» not found in definition «%v»: %w", name, d.QName(), ErrNameNotFound))
+	}
+	return fld
 }
 
 func (d *def) changed() {
@@ -6,7 +6,9 @@
 package appdef
 
 import (
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -66,6 +68,166 @@ func Test_def_AddField(t *testing.T) {
 	})
 }
 
+func Test_def_AddDecimalField(t *testing.T) {
+	require := require.New(t)
+
+	def := New().AddStruct(NewQName("test", "object"), DefKind_Object)
+	require.NotNil(def)
+
+	t.Run("must be ok to add decimal field", func(t *testing.T) {
+		def.AddDecimalField("amount", 12, 2, true)
+
+		f := def.Field("amount")
+		require.NotNil(f)
+		require.Equal(DataKind_decimal, f.DataKind())
+		require.True(f.IsFixedWidth())
+		require.True(f.Required())
+		require.EqualValues(12, f.Precision())
+		require.EqualValues(2, f.Scale())
+	})
+
+	t.Run("must be default precision and scale if decimal field added by AddField", func(t *testing.T) {
+		def.AddField("qty", DataKind_decimal, false)
+
+		f := def.Field("qty")
+		require.EqualValues(DefaultDecimalPrecision, f.Precision())
+		require.EqualValues(DefaultDecimalScale, f.Scale())
+	})
+
+	t.Run("must be zero precision and scale for non decimal fields", func(t *testing.T) {
+		def.AddField("moment", DataKind_timestamp, false)
+
+		f := def.Field("moment")
+		require.Zero(f.Precision())
+		require.Zero(f.Scale())
+	})
+
+	t.Run("must be panic if invalid precision or scale", func(t *testing.T) {
+		require.Panics(func() { def.AddDecimalField("f1", 0, 0, true) })
+		require.Panics(func() { def.AddDecimalField("f2", MaxDecimalPrecision+1, 0, true) })
+		require.Panics(func() { def.AddDecimalField("f3", 4, 5, true) })
+		require.Nil(def.Field("f1"))
+	})
+
+	t.Run("must be panic if field name dupe", func(t *testing.T) {
+		require.Panics(func() { def.AddDecimalField("amount", 12, 2, true) })
+	})
+}
+
+func Test_def_SetFieldConstraints(t *testing.T) {
+	require := require.New(t)
+
+	def := New().AddStruct(NewQName("test", "object"), DefKind_Object)
+	def.
+		AddField("name", DataKind_string, true).
+		AddField("code", DataKind_int32, false).
+		AddField("price", DataKind_float64, false).
+		AddField("flag", DataKind_bool, false)
+
+	t.Run("must be ok to set constraints", func(t *testing.T) {
+		def.SetFieldConstraints("name", MinLen(1), MaxLen(100), Pattern(`^\w+$`))
+		def.SetFieldConstraints("code", Enum(1, 2, 3.0))
+		def.SetFieldConstraints("price", MinExcl(0), MaxIncl(1000))
+
+		name := def.Field("name")
+		require.EqualValues(1, name.Constraint(ConstraintKind_MinLen).Value())
+		require.EqualValues(100, name.Constraint(ConstraintKind_MaxLen).Value())
+		require.Equal(`^\w+$`, name.Constraint(ConstraintKind_Pattern).Value().(*regexp.Regexp).String())
+		require.Nil(name.Constraint(ConstraintKind_Enum))
+
+		kinds := []ConstraintKind{}
+		name.Constraints(func(c IConstraint) { kinds = append(kinds, c.Kind()) })
+		require.Equal([]ConstraintKind{ConstraintKind_MinLen, ConstraintKind_MaxLen, ConstraintKind_Pattern}, kinds)
+
+		require.Equal([]interface{}{int32(1), int32(2), int32(3)}, def.Field("code").Constraint(ConstraintKind_Enum).Value())
+		require.Equal("MaxIncl: 1000", def.Field("price").Constraint(ConstraintKind_MaxIncl).String())
+	})
+
+	t.Run("must be replaced constraint of the same kind", func(t *testing.T) {
+		def.SetFieldConstraints("name", MaxLen(50))
+		require.EqualValues(50, def.Field("name").Constraint(ConstraintKind_MaxLen).Value())
+	})
+
+	t.Run("must be panic if invalid constraints", func(t *testing.T) {
+		require.Panics(func() { def.SetFieldConstraints("unknown", MaxLen(1)) })
+		require.Panics(func() { def.SetFieldConstraints(SystemField_QName, MaxLen(1)) })
+		require.Panics(func() { def.SetFieldConstraints("flag", MaxLen(1)) }, "not supported by data kind")
+		require.Panics(func() { def.SetFieldConstraints("code", Pattern(`^\d$`)) }, "not supported by data kind")
+		require.Panics(func() { def.SetFieldConstraints("code", Enum("a")) }, "enum value is not convertible")
+		require.Panics(func() { def.SetFieldConstraints("code", Enum(1.5)) }, "enum value is not convertible")
+		require.Panics(func() { def.SetFieldConstraints("name", MinLen(51)) }, "min length greater than max")
+		require.Panics(func() { def.SetFieldConstraints("price", MinIncl(1001)) }, "min value greater than max")
+		require.Panics(func() { Pattern(`[`) })
+		require.Panics(func() { Enum() })
+	})
+}
+
+func Test_def_SetFieldDefault(t *testing.T) {
+	require := require.New(t)
+
+	def := New().AddStruct(NewQName("test", "object"), DefKind_Object)
+	def.
+		AddField("int32", DataKind_int32, false).
+		AddField("int64", DataKind_int64, false).
+		AddField("float32", DataKind_float32, false).
+		AddField("float64", DataKind_float64, false).
+		AddField("bytes", DataKind_bytes, false).
+		AddField("string", DataKind_string, false).
+		AddField("qname", DataKind_QName, false).
+		AddField("bool", DataKind_bool, false).
+		AddField("decimal", DataKind_decimal, false).
+		AddField("timestamp", DataKind_timestamp, false).
+		AddField("date", DataKind_date, false).
+		AddField("uuid", DataKind_UUID, false).
+		AddField("id", DataKind_RecordID, false).
+		AddVerifiedField("email", DataKind_string, false, VerificationKind_EMail)
+
+	t.Run("must be ok to set defaults", func(t *testing.T) {
+		def.
+			SetFieldDefault("int32", 1).
+			SetFieldDefault("int64", 2.0).
+			SetFieldDefault("float32", 3).
+			SetFieldDefault("float64", 4.5).
+			SetFieldDefault("bytes", "bytes").
+			SetFieldDefault("string", "str").
+			SetFieldDefault("qname", "test.qname").
+			SetFieldDefault("bool", true).
+			SetFieldDefault("decimal", 1.25).
+			SetFieldDefault("timestamp", "2023-06-17T10:20:30Z").
+			SetFieldDefault("date", "2023-06-17").
+			SetFieldDefault("uuid", "3F2504E0-4F89-11D3-9A0C-0305E82C3301")
+
+		require.Equal(int32(1), def.Field("int32").Default())
+		require.Equal(int64(2), def.Field("int64").Default())
+		require.Equal(float32(3), def.Field("float32").Default())
+		require.Equal(4.5, def.Field("float64").Default())
+		require.Equal([]byte("bytes"), def.Field("bytes").Default())
+		require.Equal("str", def.Field("string").Default())
+		require.Equal(NewQName("test", "qname"), def.Field("qname").Default())
+		require.Equal(true, def.Field("bool").Default())
+		require.Equal("1.25", def.Field("decimal").Default())
+		require.Equal(time.Date(2023, time.June, 17, 10, 20, 30, 0, time.UTC), def.Field("timestamp").Default())
+		require.Equal(time.Date(2023, time.June, 17, 0, 0, 0, 0, time.UTC), def.Field("date").Default())
+		require.Equal("3f2504e0-4f89-11d3-9a0c-0305e82c3301", def.Field("uuid").Default())
+		require.Nil(def.Field("id").Default())
+	})
+
+	t.Run("must be panic if invalid default", func(t *testing.T) {
+		require.Panics(func() { def.SetFieldDefault("unknown", 1) })
+		require.Panics(func() { def.SetFieldDefault(SystemField_QName, "test.qname") })
+		require.Panics(func() { def.SetFieldDefault("email", "test@test.io") }, "verifiable field")
+		require.Panics(func() { def.SetFieldDefault("id", 1) }, "record ID")
+		require.Panics(func() { def.SetFieldDefault("int32", 1<<40) }, "out of int32 range")
+		require.Panics(func() { def.SetFieldDefault("int64", 1.5) }, "not integral")
+		require.Panics(func() { def.SetFieldDefault("string", 1) })
+		require.Panics(func() { def.SetFieldDefault("qname", "qname") })
+		require.Panics(func() { def.SetFieldDefault("decimal", "1,5") })
+		require.Panics(func() { def.SetFieldDefault("timestamp", "yesterday") })
+		require.Panics(func() { def.SetFieldDefault("date", "17.06.2023") })
+		require.Panics(func() { def.SetFieldDefault("uuid", "123") })
+	})
+}
+
 func Test_def_AddVerifiedField(t *testing.T) {
 	require := require.New(t)
 
@@ -28,5 +28,11 @@ var ErrInvalidDataKind = errors.New("invalid data kind")
 
 var ErrInvalidOccurs = errors.New("invalid occurs value")
 
+var ErrInvalidDecimalPrecision = errors.New("invalid decimal precision or scale")
+
 var ErrFieldsMissed = errors.New("fields missed")
 
+
+var ErrInvalidConstraint = errors.New("invalid constraint")
+
+var ErrIncompatibleValue = errors.New("value is incompatible with data kind")
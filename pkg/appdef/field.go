@@ -7,7 +7,13 @@
 package appdef
 
 import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -25,15 +31,22 @@ type field struct {
 	required   bool
 	verifiable bool
 	verify     map[VerificationKind]bool
+	precision  uint8
+	scale      uint8
+	cons       map[ConstraintKind]IConstraint
+	defVal     interface{}
 }
 
 func newField(name string, kind DataKind, required, verified bool, vk ...VerificationKind) *field {
-	f := field{name, kind, required, verified, make(map[VerificationKind]bool)}
+	f := field{name: name, kind: kind, required: required, verifiable: verified, verify: make(map[VerificationKind]bool), cons: make(map[ConstraintKind]IConstraint)}
 	if verified {
 		for _, kind := range vk {
 			f.verify[kind] = true
 		}
 	}
+	if kind == DataKind_decimal {
+		f.precision, f.scale = DefaultDecimalPrecision, DefaultDecimalScale
+	}
 	return &f
 }
 
@@ -45,18 +58,86 @@ func (fld *field) IsFixedWidth() bool {
 	return fld.DataKind().IsFixed()
 }
 
+func (fld *field) Constraint(kind ConstraintKind) IConstraint {
+	return fld.cons[kind]
+}
+
+func (fld *field) Constraints(cb func(IConstraint)) {
+	kinds := make([]ConstraintKind, 0, len(fld.cons))
+	for k := range fld.cons {
+		kinds = append(kinds, k)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+	for _, k := range kinds {
+		cb(fld.cons[k])
+	}
+}
+
 func (fld *field) DataKind() DataKind { return fld.kind }
 
+func (fld *field) Default() interface{} { return fld.defVal }
+
 func (fld *field) Name() string { return fld.name }
 
+func (fld *field) Precision() uint8 { return fld.precision }
+
 func (fld *field) Required() bool { return fld.required }
 
+func (fld *field) Scale() uint8 { return fld.scale }
+
 func (fld *field) Verifiable() bool { return fld.verifiable }
 
 func (fld *field) VerificationKind(vk VerificationKind) bool {
 	return fld.verifiable && fld.verify[vk]
 }
 
+// Sets field constraints. Panics if constraints are not applicable to field
+func (fld *field) setConstraints(cc ...IConstraint) {
+	for _, c := range cc {
+		if !fld.DataKind().IsSupportedConstraint(c.Kind()) {
+			panic(fmt.Errorf("field «%s» data kind «%v» does not support constraint «%v»: %w", fld.Name(), fld.DataKind().ToString(), c, ErrInvalidConstraint))
+		}
+		if c.Kind() == ConstraintKind_Enum {
+			values := c.Value().([]interface{})
+			converted := make([]interface{}, len(values))
+			for i, v := range values {
+				cv, err := convertValue(fld.DataKind(), v)
+				if err != nil {
+					panic(fmt.Errorf("field «%s» enumeration value «%v»: %w", fld.Name(), v, err))
+				}
+				converted[i] = cv
+			}
+			c = newConstraint(ConstraintKind_Enum, converted)
+		}
+		fld.cons[c.Kind()] = c
+	}
+
+	if min, max := fld.Constraint(ConstraintKind_MinLen), fld.Constraint(ConstraintKind_MaxLen); (min != nil) && (max != nil) {
+		if min.Value().(uint16) > max.Value().(uint16) {
+			panic(fmt.Errorf("field «%s» minimum length %v is greater than maximum length %v: %w", fld.Name(), min.Value(), max.Value(), ErrInvalidConstraint))
+		}
+	}
+
+	for _, minK := range []ConstraintKind{ConstraintKind_MinIncl, ConstraintKind_MinExcl} {
+		for _, maxK := range []ConstraintKind{ConstraintKind_MaxIncl, ConstraintKind_MaxExcl} {
+			if min, max := fld.Constraint(minK), fld.Constraint(maxK); (min != nil) && (max != nil) {
+				if min.Value().(float64) > max.Value().(float64) {
+					panic(fmt.Errorf("field «%s» constraint «%v» is greater than «%v»: %w", fld.Name(), min, max, ErrInvalidConstraint))
+				}
+			}
+		}
+	}
+}
+
+// Sets field default value. Panics if value can not to be converted to field data kind
+func (fld *field) setDefault(value interface{}) {
+	v, err := convertValue(fld.DataKind(), value)
+	if err != nil {
+		panic(fmt.Errorf("field «%s» default value «%v»: %w", fld.Name(), value, err))
+	}
+	fld.defVal = v
+}
+
 // Returns is field system
 func IsSysField(n string) bool {
 	return strings.HasPrefix(n, SystemPackagePrefix) && // fast check
@@ -67,3 +148,152 @@ func IsSysField(n string) bool {
 			(n == SystemField_Container) ||
 			(n == SystemField_IsActive))
 }
+
+var (
+	decimalRegexp = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?$`)
+	uuidRegexp    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// Converts value to type corresponding to data kind. See IField.Default() for result types
+func convertValue(kind DataKind, value interface{}) (interface{}, error) {
+	incompatible := func() error {
+		return fmt.Errorf("%T is not applicable for «%s»: %w", value, kind.ToString(), ErrIncompatibleValue)
+	}
+
+	switch kind {
+	case DataKind_int32:
+		if i, ok := toInt64(value); ok && (i >= math.MinInt32) && (i <= math.MaxInt32) {
+			return int32(i), nil
+		}
+	case DataKind_int64:
+		if i, ok := toInt64(value); ok {
+			return i, nil
+		}
+	case DataKind_float32:
+		if f, ok := toFloat64(value); ok {
+			return float32(f), nil
+		}
+	case DataKind_float64:
+		if f, ok := toFloat64(value); ok {
+			return f, nil
+		}
+	case DataKind_bytes:
+		switch v := value.(type) {
+		case []byte:
+			return v, nil
+		case string:
+			return []byte(v), nil
+		}
+	case DataKind_string:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	case DataKind_QName:
+		switch v := value.(type) {
+		case QName:
+			return v, nil
+		case string:
+			q, err := ParseQName(v)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", err, ErrIncompatibleValue)
+			}
+			return q, nil
+		}
+	case DataKind_bool:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	case DataKind_decimal:
+		switch v := value.(type) {
+		case string:
+			if decimalRegexp.MatchString(v) {
+				return v, nil
+			}
+		case float32, float64:
+			f, _ := toFloat64(v)
+			return strconv.FormatFloat(f, 'f', -1, 64), nil
+		default:
+			if i, ok := toInt64(v); ok {
+				return strconv.FormatInt(i, 10), nil
+			}
+		}
+	case DataKind_timestamp:
+		switch v := value.(type) {
+		case time.Time:
+			return v.UTC(), nil
+		case string:
+			t, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", err, ErrIncompatibleValue)
+			}
+			return t.UTC(), nil
+		}
+	case DataKind_date:
+		switch v := value.(type) {
+		case time.Time:
+			return time.Date(v.Year(), v.Month(), v.Day(), 0, 0, 0, 0, time.UTC), nil
+		case string:
+			t, err := time.Parse(time.DateOnly, v)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", err, ErrIncompatibleValue)
+			}
+			return t, nil
+		}
+	case DataKind_UUID:
+		if s, ok := value.(string); ok && uuidRegexp.MatchString(s) {
+			return strings.ToLower(s), nil
+		}
+	}
+
+	return nil, incompatible()
+}
+
+// Returns integer value of integer or integral float value
+func toInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint:
+		if v <= math.MaxInt64 {
+			return int64(v), true
+		}
+	case uint64:
+		if v <= math.MaxInt64 {
+			return int64(v), true
+		}
+	case float32, float64:
+		f, _ := toFloat64(v)
+		if (f == math.Trunc(f)) && (f >= math.MinInt64) && (f < math.MaxInt64) {
+			return int64(f), true
+		}
+	}
+	return 0, false
+}
+
+// Returns float value of any numeric value
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	if i, ok := toInt64(value); ok {
+		return float64(i), true
+	}
+	return 0, false
+}
@@ -30,6 +30,11 @@ type DataKind uint8
 // Ref. verification-king.go for constants and methods
 type VerificationKind uint8
 
+// Field constraint kind enumeration.
+//
+// Ref. constraint-kind.go for constants and methods
+type ConstraintKind uint8
+
 // Numeric with OccursUnbounded value
 //
 // Ref. occurs.go for constants and methods
@@ -156,6 +161,39 @@ type IDefBuilder interface {
 	//   - if no verification kinds are specified
 	AddVerifiedField(name string, kind DataKind, required bool, vk ...VerificationKind) IDefBuilder
 
+	// Adds decimal field specified name, precision and scale.
+	//
+	// # Panics:
+	//   - if name is empty,
+	//   - if name is invalid,
+	//   - if field with name is already exists,
+	//   - if definition kind not supports fields,
+	//   - if decimal data kind is not allowed by definition kind,
+	//   - if precision is zero or greater than MaxDecimalPrecision,
+	//   - if scale is greater than precision.
+	AddDecimalField(name string, precision, scale uint8, required bool) IDefBuilder
+
+	// Sets constraints for field specified name.
+	// Constraint replaces previously set constraint of the same kind.
+	//
+	// # Panics:
+	//   - if field is not found,
+	//   - if field is system,
+	//   - if constraint kind is not supported by field data kind,
+	//   - if enumeration values can not to be converted to field data kind,
+	//   - if minimum length is greater than maximum length,
+	//   - if minimum value is greater than maximum value.
+	SetFieldConstraints(name string, c ...IConstraint) IDefBuilder
+
+	// Sets default value for field specified name.
+	// Value is converted to field data kind, see IField.Default() for result types.
+	//
+	// # Panics:
+	//   - if field is not found,
+	//   - if field is system or verifiable,
+	//   - if value can not to be converted to field data kind.
+	SetFieldDefault(name string, value interface{}) IDefBuilder
+
 	// Adds container specified name and occurs.
 	//
 	// # Panics:
@@ -235,10 +273,54 @@ type IField interface {
 	// Returns is field has fixed width data kind
 	IsFixedWidth() bool
 
+	// Returns total number of digits for decimal field.
+	//
+	// Returns zero if field data kind is not decimal
+	Precision() uint8
+
+	// Returns number of digits after decimal point for decimal field.
+	//
+	// Returns zero if field data kind is not decimal
+	Scale() uint8
+
+	// Returns field constraint of specified kind.
+	//
+	// Returns nil if field has not constraint of this kind
+	Constraint(ConstraintKind) IConstraint
+
+	// Enumerates all field constraints in kind order
+	Constraints(func(IConstraint))
+
+	// Returns field default value or nil if field has no default.
+	//
+	// Default value type depends on field data kind:
+	//   - int32, int64, float32, float64, bool, string, []byte and QName for the same data kinds,
+	//   - string for decimal and UUID data kinds,
+	//   - time.Time for timestamp and date data kinds.
+	Default() interface{}
+
 	// Returns is field system
 	IsSys() bool
 }
 
+// Describes field value constraint.
+//
+// Ref to constraint.go for constructors and implementation
+type IConstraint interface {
+	// Returns constraint kind
+	Kind() ConstraintKind
+
+	// Returns constraint value:
+	//   - uint16 for MinLen and MaxLen,
+	//   - *regexp.Regexp for Pattern,
+	//   - float64 for MinIncl, MinExcl, MaxIncl and MaxExcl,
+	//   - []interface{} for Enum, values are of the same type as IField.Default()
+	Value() interface{}
+
+	// Renders constraint in human-readable form, e.g. `MaxLen: 100`
+	String() string
+}
+
 // Describes single inclusion of child definition in parent definition.
 //
 // Ref to container.go for constants and implementation
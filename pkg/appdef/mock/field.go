@@ -14,6 +14,8 @@ type Field struct {
 	appdef.IField
 	mock.Mock
 	verify map[appdef.VerificationKind]bool
+	cons   []appdef.IConstraint
+	defVal interface{}
 }
 
 func NewField(name string, kind appdef.DataKind, req bool) *Field {
@@ -26,6 +28,14 @@ func NewField(name string, kind appdef.DataKind, req bool) *Field {
 	return &fld
 }
 
+func NewDecimalField(name string, precision, scale uint8, req bool) *Field {
+	fld := NewField(name, appdef.DataKind_decimal, req)
+	fld.
+		On("Precision").Return(precision).
+		On("Scale").Return(scale)
+	return fld
+}
+
 func NewVerifiedField(name string, kind appdef.DataKind, req bool, vk ...appdef.VerificationKind) *Field {
 	fld := Field{verify: make(map[appdef.VerificationKind]bool)}
 	for _, k := range vk {
@@ -51,3 +61,41 @@ func (fld *Field) VerificationKind(vk appdef.VerificationKind) bool {
 }
 func (fld *Field) IsFixedWidth() bool { return fld.DataKind().IsFixed() }
 func (fld *Field) IsSys() bool        { return appdef.IsSysField(fld.Name()) }
+func (fld *Field) Precision() uint8 {
+	if fld.DataKind() == appdef.DataKind_decimal {
+		return fld.Called().Get(0).(uint8)
+	}
+	return 0
+}
+func (fld *Field) Scale() uint8 {
+	if fld.DataKind() == appdef.DataKind_decimal {
+		return fld.Called().Get(0).(uint8)
+	}
+	return 0
+}
+func (fld *Field) Constraint(kind appdef.ConstraintKind) appdef.IConstraint {
+	for _, c := range fld.cons {
+		if c.Kind() == kind {
+			return c
+		}
+	}
+	return nil
+}
+func (fld *Field) Constraints(cb func(appdef.IConstraint)) {
+	for _, c := range fld.cons {
+		cb(c)
+	}
+}
+func (fld *Field) Default() interface{} { return fld.defVal }
+
+// Sets field constraints. Constraints values are not converted, so enumeration values must have field data kind type
+func (fld *Field) SetConstraints(c ...appdef.IConstraint) *Field {
+	fld.cons = append(fld.cons, c...)
+	return fld
+}
+
+// Sets field default value. Value is not converted, so must have type described by appdef.IField.Default()
+func (fld *Field) SetDefault(value interface{}) *Field {
+	fld.defVal = value
+	return fld
+}
@@ -103,12 +103,15 @@ func (app *appDef) prepareViewFullKeyDef(def IDef) {
 	// recreate full key definition fields
 	fkDef = app.addDef(fkName, DefKind_ViewRecord_ClusteringColumns)
 
-	pkDef.Fields(func(f IField) {
-		fkDef.AddField(f.Name(), f.DataKind(), true)
-	})
-	ccDef.Fields(func(f IField) {
-		fkDef.AddField(f.Name(), f.DataKind(), false)
-	})
+	addField := func(f IField, required bool) {
+		if f.DataKind() == DataKind_decimal {
+			fkDef.AddDecimalField(f.Name(), f.Precision(), f.Scale(), required)
+			return
+		}
+		fkDef.AddField(f.Name(), f.DataKind(), required)
+	}
+	pkDef.Fields(func(f IField) { addField(f, true) })
+	ccDef.Fields(func(f IField) { addField(f, false) })
 
 	app.changed()
 }
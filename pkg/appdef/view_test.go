@@ -96,3 +96,34 @@ func TestAddView(t *testing.T) {
 		require.NoError(err)
 	})
 }
+
+func TestAddViewDecimalDateTimeUUIDKeys(t *testing.T) {
+	require := require.New(t)
+
+	app := New()
+	viewName := NewQName("test", "view")
+	view := app.AddView(viewName)
+
+	view.
+		AddPartField("pkDate", DataKind_date).
+		AddPartField("pkUUID", DataKind_UUID).
+		AddClustColumn("ccTime", DataKind_timestamp).
+		AddValueField("valSum", DataKind_decimal, true)
+	view.ClustColsDef().AddDecimalField("ccAmount", 10, 2, false)
+
+	result, err := app.Build()
+	require.NoError(err)
+
+	fk := result.Def(ViewFullKeyColumsDefName(viewName))
+	require.Equal(4, fk.FieldCount())
+	require.Equal(DataKind_date, fk.Field("pkDate").DataKind())
+	require.Equal(DataKind_UUID, fk.Field("pkUUID").DataKind())
+	require.Equal(DataKind_timestamp, fk.Field("ccTime").DataKind())
+
+	t.Run("must be decimal precision and scale copied to full key", func(t *testing.T) {
+		f := fk.Field("ccAmount")
+		require.Equal(DataKind_decimal, f.DataKind())
+		require.EqualValues(10, f.Precision())
+		require.EqualValues(2, f.Scale())
+	})
+}
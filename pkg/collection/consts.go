@@ -0,0 +1,33 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package collection
+
+import "github.com/voedger/voedger/pkg/appdef"
+
+var (
+	// View with the index of all documents and their records in the workspace
+	QNameCollectionView = appdef.NewQName(appdef.SysPackage, "CollectionView")
+
+	// Sync projector which maintains the collection view
+	QNameProjectorCollection = appdef.NewQName(appdef.SysPackage, "collection")
+
+	// q.sys.Collection query function which returns documents with their nested records
+	QNameQueryCollection       = appdef.NewQName(appdef.SysPackage, "Collection")
+	QNameQueryCollectionParams = appdef.NewQName(appdef.SysPackage, "CollectionParams")
+)
+
+// sys.CollectionView fields
+const (
+	Field_DocQName   = "DocQName"   // qualified name of the document, partition key
+	Field_DocID      = "DocID"      // ID of the document
+	Field_ElementID  = "ElementID"  // ID of the nested record, NullRecordID for the document itself
+	Field_WLogOffset = "WLogOffset" // offset of the event which created the record
+)
+
+// q.sys.Collection query function parameters
+const (
+	Field_Schema = "Schema" // qualified name of the document
+	Field_ID     = "ID"     // ID of the document, NullRecordID means all documents
+)
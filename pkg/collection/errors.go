@@ -0,0 +1,11 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package collection
+
+import "errors"
+
+var ErrDocumentNotFound = errors.New("document not found")
+var ErrNotCollectionDocument = errors.New("not a CDoc or WDoc")
+var ErrSelectNotAuthorizable = errors.New("query workpiece does not authorize select")
@@ -0,0 +1,230 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package collection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/state"
+)
+
+func collectionProjectorFactory(appDef func() appdef.IAppDef) istructs.ProjectorFactory {
+	return func(istructs.PartitionID) istructs.Projector {
+		return istructs.Projector{
+			Name:         QNameProjectorCollection,
+			Func:         collectionProjector(appDef),
+			UpdatedViews: []appdef.QName{QNameCollectionView},
+		}
+	}
+}
+
+// collectionProjector adds new documents and records to the collection view.
+// Records are indexed by the document they belong to, so the document is searched through the records parents
+func collectionProjector(appDef func() appdef.IAppDef) func(event istructs.IPLogEvent, s istructs.IState, intents istructs.IIntents) error {
+	return func(event istructs.IPLogEvent, s istructs.IState, intents istructs.IIntents) (err error) {
+		ad := appDef()
+		newRecords := make(map[istructs.RecordID]istructs.ICUDRow)
+		_ = event.CUDs(func(rec istructs.ICUDRow) error {
+			if rec.IsNew() {
+				newRecords[rec.ID()] = rec
+			}
+			return nil
+		})
+		return event.CUDs(func(rec istructs.ICUDRow) error {
+			if !rec.IsNew() {
+				return nil
+			}
+			kind := ad.Def(rec.QName()).Kind()
+			if !isCollectionKind(kind) {
+				return nil
+			}
+			docQName, docID, elementID := rec.QName(), rec.ID(), istructs.NullRecordID
+			if !isDocKind(kind) {
+				elementID = rec.ID()
+				if docQName, docID, err = findDoc(ad, s, newRecords, rec.AsRecordID(appdef.SystemField_ParentID)); err != nil {
+					return fmt.Errorf("record %d %s: %w", rec.ID(), rec.QName(), err)
+				}
+			}
+			kb, err := s.KeyBuilder(state.ViewRecordsStorage, QNameCollectionView)
+			if err != nil {
+				return err
+			}
+			kb.PutQName(Field_DocQName, docQName)
+			kb.PutRecordID(Field_DocID, docID)
+			kb.PutRecordID(Field_ElementID, elementID)
+			vb, err := intents.NewValue(kb)
+			if err != nil {
+				return err
+			}
+			vb.PutInt64(Field_WLogOffset, int64(event.WLogOffset()))
+			return nil
+		})
+	}
+}
+
+// findDoc returns the document which the record with the parent ID belongs to.
+// Parents are searched among the event new records first, then among the stored records
+func findDoc(appDef appdef.IAppDef, s istructs.IState, newRecords map[istructs.RecordID]istructs.ICUDRow, parentID istructs.RecordID) (docQName appdef.QName, docID istructs.RecordID, err error) {
+	for id := parentID; id != istructs.NullRecordID; {
+		var qName appdef.QName
+		next := istructs.NullRecordID
+		if rec, ok := newRecords[id]; ok {
+			qName, next = rec.QName(), rec.AsRecordID(appdef.SystemField_ParentID)
+		} else {
+			kb, err := s.KeyBuilder(state.RecordsStorage, appdef.NullQName)
+			if err != nil {
+				return appdef.NullQName, istructs.NullRecordID, err
+			}
+			kb.PutRecordID(state.Field_ID, id)
+			value, ok, err := s.CanExist(kb)
+			if err != nil {
+				return appdef.NullQName, istructs.NullRecordID, err
+			}
+			if !ok {
+				break
+			}
+			rec := value.AsRecord("")
+			qName, next = rec.QName(), rec.Parent()
+		}
+		if isDocKind(appDef.Def(qName).Kind()) {
+			return qName, id, nil
+		}
+		id = next
+	}
+	return appdef.NullQName, istructs.NullRecordID, fmt.Errorf("parent %d: %w", parentID, ErrDocumentNotFound)
+}
+
+func isCollectionKind(kind appdef.DefKind) bool {
+	switch kind {
+	case appdef.DefKind_CDoc, appdef.DefKind_CRecord, appdef.DefKind_WDoc, appdef.DefKind_WRecord:
+		return true
+	}
+	return false
+}
+
+func isDocKind(kind appdef.DefKind) bool {
+	return kind == appdef.DefKind_CDoc || kind == appdef.DefKind_WDoc
+}
+
+// collectionResultDef returns the document as the result definition, so the query params refer to the document fields
+// and to its containers
+func collectionResultDef(args istructs.PrepareArgs) appdef.QName {
+	qName, err := appdef.ParseQName(args.ArgumentObject.AsString(Field_Schema))
+	if err != nil {
+		return appdef.NullQName
+	}
+	return qName
+}
+
+// collectionQueryExec reads documents of the schema from the collection view and sends active documents
+// with their active nested records in the document IDs order
+func collectionQueryExec(appDef func() appdef.IAppDef) istructsmem.ExecQueryClosure {
+	return func(_ context.Context, _ istructs.IQueryFunction, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) (err error) {
+		docQName, err := appdef.ParseQName(args.ArgumentObject.AsString(Field_Schema))
+		if err != nil {
+			return err
+		}
+		if !isDocKind(appDef().Def(docQName).Kind()) {
+			return fmt.Errorf("%s: %w", docQName, ErrNotCollectionDocument)
+		}
+		if err = authorizeSelect(appDef(), args.Workpiece, docQName); err != nil {
+			return err
+		}
+		kb, err := args.State.KeyBuilder(state.ViewRecordsStorage, QNameCollectionView)
+		if err != nil {
+			return err
+		}
+		kb.PutQName(Field_DocQName, docQName)
+		if id := args.ArgumentObject.AsRecordID(Field_ID); id != istructs.NullRecordID {
+			kb.PutRecordID(Field_DocID, id)
+		}
+		docID := istructs.NullRecordID
+		ids := make([]istructs.RecordID, 0)
+		err = args.State.Read(kb, func(key istructs.IKey, _ istructs.IStateValue) error {
+			if id := key.AsRecordID(Field_DocID); id != docID {
+				if err := sendDocument(args.State, docID, ids, callback); err != nil {
+					return err
+				}
+				docID, ids = id, ids[:0]
+			}
+			ids = append(ids, key.AsRecordID(Field_ElementID))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return sendDocument(args.State, docID, ids, callback)
+	}
+}
+
+// authorizeSelect checks that principals are allowed to read the fields of the document and of its nested records
+func authorizeSelect(appDef appdef.IAppDef, workpiece interface{}, docQName appdef.QName) error {
+	authorizer, ok := workpiece.(selectAuthorizer)
+	if !ok {
+		return fmt.Errorf("%s: %w", docQName, ErrSelectNotAuthorizable)
+	}
+	authorized := make(map[appdef.QName]bool)
+	var authorize func(def appdef.IDef) error
+	authorize = func(def appdef.IDef) (err error) {
+		if authorized[def.QName()] {
+			return nil
+		}
+		authorized[def.QName()] = true
+		fields := make([]string, 0, def.FieldCount())
+		def.Fields(func(f appdef.IField) { fields = append(fields, f.Name()) })
+		if err = authorizer.AuthorizeSelect(def.QName(), fields); err != nil {
+			return err
+		}
+		def.Containers(func(c appdef.IContainer) {
+			if err == nil {
+				err = authorize(def.ContainerDef(c.Name()))
+			}
+		})
+		return err
+	}
+	return authorize(appDef.Def(docQName))
+}
+
+// sendDocument reads the document and its records and sends the document if it is active
+func sendDocument(s istructs.IState, docID istructs.RecordID, elementIDs []istructs.RecordID, callback istructs.ExecQueryCallback) error {
+	if docID == istructs.NullRecordID {
+		return nil
+	}
+	keys := make([]istructs.IStateKeyBuilder, 0, len(elementIDs))
+	for _, id := range elementIDs {
+		if id == istructs.NullRecordID {
+			id = docID
+		}
+		kb, err := s.KeyBuilder(state.RecordsStorage, appdef.NullQName)
+		if err != nil {
+			return err
+		}
+		kb.PutRecordID(state.Field_ID, id)
+		keys = append(keys, kb)
+	}
+	var doc *collectionElement
+	elements := make([]*collectionElement, 0, len(keys))
+	err := s.CanExistAll(keys, func(_ istructs.IKeyBuilder, value istructs.IStateValue, ok bool) error {
+		if !ok {
+			return nil
+		}
+		el := newCollectionElement(value.AsRecord(""))
+		if el.ID() == docID {
+			doc = el
+		} else {
+			elements = append(elements, el)
+		}
+		return nil
+	})
+	if err != nil || doc == nil || !doc.active() {
+		return err
+	}
+	doc.addElements(elements)
+	return callback(doc)
+}
@@ -0,0 +1,223 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package collection
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorageimpl"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+	"github.com/voedger/voedger/pkg/pipeline"
+	"github.com/voedger/voedger/pkg/projectors"
+	"github.com/voedger/voedger/pkg/state"
+)
+
+var (
+	qNameMenu   = appdef.NewQName("test", "Menu")
+	qNameItem   = appdef.NewQName("test", "MenuItem")
+	qNameOption = appdef.NewQName("test", "MenuItemOption")
+)
+
+const (
+	testWSID      = istructs.WSID(1)
+	testPartition = istructs.PartitionID(1)
+)
+
+var errSelectDenied = errors.New("select denied")
+
+// testWorkpiece denies select of the resources which are set to denied
+type testWorkpiece struct {
+	denied     appdef.QName
+	authorized map[appdef.QName][]string
+}
+
+func (w *testWorkpiece) AuthorizeSelect(resource appdef.QName, fields []string) error {
+	w.authorized[resource] = fields
+	if resource == w.denied {
+		return errSelectDenied
+	}
+	return nil
+}
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+
+	adb := appdef.New()
+	adb.AddStruct(qNameMenu, appdef.DefKind_CDoc).
+		AddField("name", appdef.DataKind_string, true).
+		AddContainer("items", qNameItem, appdef.Occurs(0), appdef.Occurs_Unbounded)
+	adb.AddStruct(qNameItem, appdef.DefKind_CRecord).
+		AddField("name", appdef.DataKind_string, true).
+		AddContainer("options", qNameOption, appdef.Occurs(0), appdef.Occurs_Unbounded)
+	adb.AddStruct(qNameOption, appdef.DefKind_CRecord).
+		AddField("name", appdef.DataKind_string, true)
+
+	cfgs := make(istructsmem.AppConfigsType)
+	cfg := cfgs.AddConfig(istructs.AppQName_test1_app1, adb)
+	cfg.Resources.Add(istructsmem.NewCommandFunction(istructs.QNameCommandCUD, appdef.NullQName, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec))
+	Provide(cfg, adb)
+
+	tokens := itokensjwt.TestTokensJWT()
+	asp := istructsmem.Provide(cfgs, iratesce.TestBucketsFactory, payloads.TestAppTokensFactory(tokens), istorageimpl.Provide(istorage.ProvideMem()))
+	app, err := asp.AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	actualizer := projectors.ProvideSyncActualizerFactory()(projectors.SyncActualizerConf{
+		Ctx:        context.Background(),
+		Partition:  testPartition,
+		AppStructs: func() istructs.IAppStructs { return app },
+	}, app.SyncProjectors()[0])
+	processor := pipeline.NewSyncPipeline(context.Background(), "partition processor", pipeline.WireSyncOperator("actualizer", actualizer))
+	defer processor.Close()
+
+	offset := istructs.Offset(0)
+	nextID := istructs.FirstBaseRecordID
+	ids := make(map[istructs.RecordID]istructs.RecordID)
+	putEvent := func(cuds func(cud istructs.ICUD)) {
+		offset++
+		bld := app.Events().GetSyncRawEventBuilder(istructs.SyncRawEventBuilderParams{
+			GenericRawEventBuilderParams: istructs.GenericRawEventBuilderParams{
+				HandlingPartition: testPartition,
+				PLogOffset:        offset,
+				Workspace:         testWSID,
+				WLogOffset:        offset,
+				QName:             istructs.QNameCommandCUD,
+				RegisteredAt:      istructs.UnixMilli(offset),
+			},
+		})
+		cuds(bld.CUDBuilder())
+		rawEvent, err := bld.BuildRawEvent()
+		require.NoError(err)
+		pLogEvent, err := app.Events().PutPlog(rawEvent, nil, func(rawID istructs.RecordID, _ appdef.IDef) (istructs.RecordID, error) {
+			ids[rawID] = nextID
+			nextID++
+			return ids[rawID], nil
+		})
+		require.NoError(err)
+		require.NoError(app.Records().Apply(pLogEvent))
+		require.NoError(processor.SendSync(pLogEvent))
+	}
+	create := func(cud istructs.ICUD, qName appdef.QName, id, parentID istructs.RecordID, container, name string) {
+		rec := cud.Create(qName)
+		rec.PutRecordID(appdef.SystemField_ID, id)
+		if parentID != istructs.NullRecordID {
+			rec.PutRecordID(appdef.SystemField_ParentID, parentID)
+			rec.PutString(appdef.SystemField_Container, container)
+		}
+		rec.PutString("name", name)
+	}
+	update := func(cud istructs.ICUD, id istructs.RecordID, isActive bool) {
+		rec, err := app.Records().Get(testWSID, true, id)
+		require.NoError(err)
+		cud.Update(rec).PutBool(appdef.SystemField_IsActive, isActive)
+	}
+
+	putEvent(func(cud istructs.ICUD) {
+		create(cud, qNameMenu, 1, istructs.NullRecordID, "", "Breakfast")
+		create(cud, qNameItem, 2, 1, "items", "Omelette")
+		create(cud, qNameOption, 3, 2, "options", "Cheese")
+		create(cud, qNameItem, 4, 1, "items", "Coffee")
+		create(cud, qNameMenu, 5, istructs.NullRecordID, "", "Lunch")
+	})
+	// records are added to the stored records
+	putEvent(func(cud istructs.ICUD) {
+		create(cud, qNameOption, 6, ids[2], "options", "Bacon")
+		create(cud, qNameItem, 7, ids[5], "items", "Soup")
+	})
+
+	workpiece := &testWorkpiece{authorized: make(map[appdef.QName][]string)}
+	exec := func(id istructs.RecordID) (docs []istructs.IObject, err error) {
+		qf := app.Resources().QueryResource(QNameQueryCollection).(istructs.IQueryFunction)
+		ab := istructsmem.NewIObjectBuilder(cfg, QNameQueryCollectionParams)
+		ab.PutString(Field_Schema, qNameMenu.String())
+		ab.PutRecordID(Field_ID, id)
+		argument, err := ab.Build()
+		require.NoError(err)
+		args := istructs.ExecQueryArgs{
+			PrepareArgs: istructs.PrepareArgs{ArgumentObject: argument, Workspace: testWSID, Workpiece: workpiece},
+			State: state.ProvideQueryProcessorStateFactory()(context.Background(), app, state.SimplePartitionIDFunc(testPartition),
+				state.SimpleWSIDFunc(testWSID), nil, nil, nil),
+		}
+		require.Equal(qNameMenu, qf.ResultDef(args.PrepareArgs))
+		err = qf.Exec(context.Background(), args, func(object istructs.IObject) error {
+			docs = append(docs, object)
+			return nil
+		})
+		return docs, err
+	}
+	collection := func(id istructs.RecordID) []istructs.IObject {
+		docs, err := exec(id)
+		require.NoError(err)
+		return docs
+	}
+	names := func(el istructs.IElement, container string) (res []string) {
+		el.Elements(container, func(el istructs.IElement) {
+			res = append(res, el.AsString("name"))
+		})
+		return res
+	}
+
+	t.Run("Should return documents with nested records", func(t *testing.T) {
+		docs := collection(istructs.NullRecordID)
+
+		require.Len(docs, 2)
+		require.Equal("Breakfast", docs[0].AsString("name"))
+		require.Equal([]string{"Omelette", "Coffee"}, names(docs[0], "items"))
+		docs[0].Elements("items", func(item istructs.IElement) {
+			if item.AsString("name") == "Omelette" {
+				require.Equal([]string{"Cheese", "Bacon"}, names(item, "options"))
+			}
+		})
+		containers := make([]string, 0)
+		docs[0].Containers(func(container string) { containers = append(containers, container) })
+		require.Equal([]string{"items"}, containers)
+		require.Equal("Lunch", docs[1].AsString("name"))
+		require.Equal([]string{"Soup"}, names(docs[1], "items"))
+	})
+	t.Run("Should return document by ID", func(t *testing.T) {
+		docs := collection(ids[5])
+
+		require.Len(docs, 1)
+		require.Equal("Lunch", docs[0].AsString("name"))
+	})
+	t.Run("Should skip inactive documents and records", func(t *testing.T) {
+		putEvent(func(cud istructs.ICUD) {
+			update(cud, ids[2], false)
+			update(cud, ids[5], false)
+		})
+
+		docs := collection(istructs.NullRecordID)
+
+		require.Len(docs, 1)
+		require.Equal([]string{"Coffee"}, names(docs[0], "items"))
+	})
+	t.Run("Should authorize select of the document and nested records fields", func(t *testing.T) {
+		collection(istructs.NullRecordID)
+
+		require.Len(workpiece.authorized, 3)
+		for _, qName := range []appdef.QName{qNameMenu, qNameItem, qNameOption} {
+			require.Contains(workpiece.authorized[qName], "name")
+			require.Contains(workpiece.authorized[qName], appdef.SystemField_ID)
+		}
+	})
+	t.Run("Should fail if select is not authorized", func(t *testing.T) {
+		workpiece.denied = qNameOption
+		defer func() { workpiece.denied = appdef.NullQName }()
+
+		docs, err := exec(istructs.NullRecordID)
+
+		require.ErrorIs(err, errSelectDenied)
+		require.Empty(docs)
+	})
+}
@@ -0,0 +1,28 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package collection
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructsmem"
+)
+
+// Provide adds the collection view, the sync projector which maintains it and q.sys.Collection query function to the application.
+// appDefBuilder is the application definition builder which the config is created with
+func Provide(cfg *istructsmem.AppConfigType, appDefBuilder appdef.IAppDefBuilder) {
+	appDefBuilder.AddView(QNameCollectionView).
+		AddPartField(Field_DocQName, appdef.DataKind_QName).
+		AddClustColumn(Field_DocID, appdef.DataKind_RecordID).
+		AddClustColumn(Field_ElementID, appdef.DataKind_RecordID).
+		AddValueField(Field_WLogOffset, appdef.DataKind_int64, true)
+	appDefBuilder.AddStruct(QNameQueryCollectionParams, appdef.DefKind_Object).
+		AddField(Field_Schema, appdef.DataKind_string, true).
+		AddField(Field_ID, appdef.DataKind_RecordID, false)
+
+	appDef := func() appdef.IAppDef { return cfg.AppDef }
+	cfg.AddSyncProjectors(collectionProjectorFactory(appDef))
+	cfg.Resources.Add(istructsmem.NewQueryFunctionCustomResult(QNameQueryCollection, QNameQueryCollectionParams, collectionResultDef,
+		collectionQueryExec(appDef)))
+}
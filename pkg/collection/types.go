@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package collection
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// selectAuthorizer is implemented by the query processor workpiece
+type selectAuthorizer interface {
+	AuthorizeSelect(resource appdef.QName, fields []string) error
+}
+
+// collectionElement is the document or the record with its nested records
+type collectionElement struct {
+	istructs.IRecord
+	// container names in the order of the first element appearance
+	containers []string
+	elements   map[string][]*collectionElement
+}
+
+func newCollectionElement(rec istructs.IRecord) *collectionElement {
+	return &collectionElement{
+		IRecord:  rec,
+		elements: make(map[string][]*collectionElement),
+	}
+}
+
+func (e *collectionElement) active() bool {
+	return e.AsBool(appdef.SystemField_IsActive)
+}
+
+// addElements builds the tree of the active records, records of the inactive parents are skipped
+func (e *collectionElement) addElements(elements []*collectionElement) {
+	byID := map[istructs.RecordID]*collectionElement{e.ID(): e}
+	for _, el := range elements {
+		if el.active() {
+			byID[el.ID()] = el
+		}
+	}
+	for _, el := range elements {
+		if !el.active() {
+			continue
+		}
+		if parent, ok := byID[el.Parent()]; ok {
+			parent.addElement(el)
+		}
+	}
+}
+
+func (e *collectionElement) addElement(el *collectionElement) {
+	if _, ok := e.elements[el.Container()]; !ok {
+		e.containers = append(e.containers, el.Container())
+	}
+	e.elements[el.Container()] = append(e.elements[el.Container()], el)
+}
+
+func (e *collectionElement) Elements(container string, cb func(el istructs.IElement)) {
+	for _, el := range e.elements[container] {
+		cb(el)
+	}
+}
+
+func (e *collectionElement) Containers(cb func(container string)) {
+	for _, container := range e.containers {
+		cb(container)
+	}
+}
+
+func (e *collectionElement) AsRecord() istructs.IRecord { return e.IRecord }
@@ -36,6 +36,7 @@ var (
 	qNameQryDescribePackage                         = appdef.NewQName(appdef.SysPackage, "DescribePackage")
 	qNameCmdInitiateJoinWorkspace                   = appdef.NewQName(appdef.SysPackage, "InitiateJoinWorkspace")
 	qNameCmdInitiateLeaveWorkspace                  = appdef.NewQName(appdef.SysPackage, "InitiateLeaveWorkspace")
+	qNameCmdBatch                                   = appdef.NewQName(appdef.SysPackage, "Batch")
 	qNameCmdChangePassword                          = appdef.NewQName(appdef.SysPackage, "ChangePassword")
 	qNameCmdInitiateInvitationByEmail               = appdef.NewQName(appdef.SysPackage, "InitiateInvitationByEMail")
 	qNameQryCollection                              = appdef.NewQName(appdef.SysPackage, "Collection")
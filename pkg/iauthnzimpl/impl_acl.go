@@ -136,6 +136,20 @@ var defaultACL = ACL{
 		},
 		policy: ACPolicy_Allow,
 	},
+	{
+		desc: "c.sys.Batch is allowed for authenticated users and devices, commands of the batch are authorized separately",
+		pattern: PatternType{
+			qNamesPattern: []appdef.QName{
+				qNameCmdBatch,
+			},
+			principalsPattern: [][]iauthnz.Principal{
+				// OR
+				{{Kind: iauthnz.PrincipalKind_User}},
+				{{Kind: iauthnz.PrincipalKind_Device}},
+			},
+		},
+		policy: ACPolicy_Allow,
+	},
 	{
 		desc: "c.sys.InitiateLeaveWorkspace is allowed for authenticated users",
 		pattern: PatternType{
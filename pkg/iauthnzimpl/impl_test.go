@@ -990,6 +990,7 @@ func (as *implIAppStructs) IsFunctionRateLimitsExceeded(appdef.QName, istructs.W
 func (as *implIAppStructs) DescribePackageNames() []string               { panic("") }
 func (as *implIAppStructs) DescribePackage(string) interface{}           { panic("") }
 func (as *implIAppStructs) Uniques() istructs.IUniques                   { panic("") }
+func (as *implIAppStructs) Sequences() istructs.ISequences               { panic("") }
 func (as *implIAppStructs) SyncProjectors() []istructs.ProjectorFactory  { panic("") }
 func (as *implIAppStructs) AsyncProjectors() []istructs.ProjectorFactory { panic("") }
 func (as *implIAppStructs) CUDValidators() []istructs.CUDValidator       { panic("") }
@@ -1020,6 +1021,9 @@ func (r *implIRecords) Get(wsid istructs.WSID, _ bool, id istructs.RecordID) (re
 func (r *implIRecords) GetBatch(workspace istructs.WSID, highConsistency bool, ids []istructs.RecordGetBatchItem) (err error) {
 	panic("")
 }
+func (r *implIRecords) GetUniqueID(workspace istructs.WSID, qName appdef.QName, values map[string]interface{}) (id istructs.RecordID, err error) {
+	panic("")
+}
 func (r *implIRecords) GetSingleton(wsid istructs.WSID, qName appdef.QName) (record istructs.IRecord, err error) {
 	if wsData, ok := r.data[wsid]; ok {
 		if qNameRecs, ok := wsData[qName]; ok {
@@ -1099,6 +1103,9 @@ func (vr *implIViewRecords) GetBatch(workspace istructs.WSID, kv []istructs.View
 func (vr *implIViewRecords) Read(ctx context.Context, workspace istructs.WSID, key istructs.IKeyBuilder, cb istructs.ValuesCallback) (err error) {
 	panic("")
 }
+func (vr *implIViewRecords) DeletePartition(workspace istructs.WSID, key istructs.IKeyBuilder) (err error) {
+	panic("")
+}
 
 type implIKeyBuilder struct {
 	coreutils.TestObject
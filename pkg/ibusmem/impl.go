@@ -24,7 +24,7 @@ type bus struct {
 	requestContextsPool        chan *requestContextType
 }
 
-type requestContextType2 struct {
+type requestContextType struct {
 	mu                    sync.Mutex
 	refCount              int
 	responseChannel       responseChannelType
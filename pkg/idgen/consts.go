@@ -0,0 +1,28 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package idgen
+
+import "github.com/voedger/voedger/pkg/appdef"
+
+var (
+	// View with the reserved bounds of the sequences of the workspaces
+	ViewQNameIDSequences = appdef.NewQName(appdef.SysPackage, "IDSequences")
+
+	// Sequence of the CDoc and CRecord IDs
+	QNameCDocCRecordIDs = appdef.NewQName(appdef.SysPackage, "CDocCRecordIDs")
+
+	// Sequence of the IDs of other records
+	QNameRecordIDs = appdef.NewQName(appdef.SysPackage, "RecordIDs")
+)
+
+// sys.IDSequences fields
+const (
+	Field_WSID     = "WSID"     // workspace, partition key
+	Field_Sequence = "Sequence" // sequence name, record ID sequences are not application QNames so name is string
+	Field_Reserved = "Reserved" // amount of the sequence values reserved in the workspace
+)
+
+// DefaultBlockSize is the amount of the sequence values reserved at once
+const DefaultBlockSize = BlockSize(100)
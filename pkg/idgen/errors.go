@@ -0,0 +1,11 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package idgen
+
+import "errors"
+
+var ErrUnknownSequence = errors.New("unknown sequence")
+var ErrSequenceOverflow = errors.New("sequence overflow")
+var ErrSequencesNotStored = errors.New("sequences are not stored, application must define sys.IDSequences view")
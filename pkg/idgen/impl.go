@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package idgen
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+)
+
+// istructs.IIDGenerator.NextID
+func (g *generator) NextID(ws istructs.WSID, def appdef.IDef) (istructs.RecordID, error) {
+	if def.Kind() == appdef.DefKind_CDoc || def.Kind() == appdef.DefKind_CRecord {
+		base, err := g.next(ws, recordIDs{QNameCDocCRecordIDs})
+		return istructs.NewCDocCRecordID(istructs.RecordID(base)), err
+	}
+	base, err := g.next(ws, recordIDs{QNameRecordIDs})
+	return istructs.NewRecordID(istructs.RecordID(base)), err
+}
+
+// istructs.IIDGenerator.NextVal
+func (g *generator) NextVal(ws istructs.WSID, sequence appdef.QName) (int64, error) {
+	if !g.stored {
+		return 0, fmt.Errorf("%v: %w", sequence, ErrSequencesNotStored)
+	}
+	seq := g.appStructs.Sequences().Sequence(sequence)
+	if seq == nil {
+		return 0, fmt.Errorf("%w: %v", ErrUnknownSequence, sequence)
+	}
+	return g.next(ws, seq)
+}
+
+// istructs.IIDGenerator.UpdateOnSync
+func (g *generator) UpdateOnSync(ws istructs.WSID, id istructs.RecordID) {
+	var seq recordIDs
+	switch id / istructs.RegisterFactor {
+	case istructs.ClusterAsCRecordRegisterID:
+		seq = recordIDs{QNameCDocCRecordIDs}
+	case istructs.ClusterAsRegisterID:
+		seq = recordIDs{QNameRecordIDs}
+	default:
+		return
+	}
+	base := int64(id.BaseRecordID())
+	if base < seq.StartWith() {
+		// singletons and other reserved IDs
+		return
+	}
+	// counter is not loaded from the storage here: the stored reserved bound covers all IDs used before restart
+	c := g.counter(ws, seq)
+	if used := base - seq.StartWith() + 1; used > c.used {
+		c.used = used
+	}
+}
+
+// returns the next value of the sequence, reserves the next block of values if the reserved ones are used
+func (g *generator) next(ws istructs.WSID, seq istructs.ISequence) (int64, error) {
+	c := g.counter(ws, seq)
+	if g.stored && !c.loaded {
+		if err := g.load(ws, c); err != nil {
+			return 0, err
+		}
+	}
+	if c.used > c.maxUsed() {
+		return 0, fmt.Errorf("workspace %d: %w: %v", ws, ErrSequenceOverflow, seq.QName())
+	}
+	if g.stored && (c.used >= c.reserved) {
+		if err := g.reserve(ws, c, c.used+g.blockSize); err != nil {
+			return 0, err
+		}
+	}
+	val := seq.StartWith() + c.used*seq.Increment()
+	c.used++
+	return val, nil
+}
+
+func (g *generator) counter(ws istructs.WSID, seq istructs.ISequence) *counter {
+	wsCounters, ok := g.counters[ws]
+	if !ok {
+		wsCounters = map[appdef.QName]*counter{}
+		g.counters[ws] = wsCounters
+	}
+	c, ok := wsCounters[seq.QName()]
+	if !ok {
+		c = &counter{seq: seq}
+		wsCounters[seq.QName()] = c
+	}
+	return c
+}
+
+// reads the reserved bound of the counter, values before the bound could be returned before restart so they are skipped
+func (g *generator) load(ws istructs.WSID, c *counter) error {
+	value, err := g.appStructs.ViewRecords().Get(ws, g.key(ws, c.seq.QName()))
+	if err != nil {
+		if errors.Is(err, istructsmem.ErrRecordNotFound) {
+			c.loaded = true
+			return nil
+		}
+		return err
+	}
+	c.loaded = true
+	c.reserved = value.AsInt64(Field_Reserved)
+	if c.used < c.reserved {
+		c.used = c.reserved
+	}
+	return nil
+}
+
+// stores the reserved bound of the counter
+func (g *generator) reserve(ws istructs.WSID, c *counter, reserved int64) error {
+	vb := g.appStructs.ViewRecords().NewValueBuilder(ViewQNameIDSequences)
+	vb.PutInt64(Field_Reserved, reserved)
+	if err := g.appStructs.ViewRecords().Put(ws, g.key(ws, c.seq.QName()), vb); err != nil {
+		return fmt.Errorf("workspace %d: failed to reserve values of %v: %w", ws, c.seq.QName(), err)
+	}
+	c.reserved = reserved
+	return nil
+}
+
+func (g *generator) key(ws istructs.WSID, sequence appdef.QName) istructs.IKeyBuilder {
+	kb := g.appStructs.ViewRecords().KeyBuilder(ViewQNameIDSequences)
+	kb.PutInt64(Field_WSID, int64(ws))
+	kb.PutString(Field_Sequence, sequence.String())
+	return kb
+}
+
+// returns the maximum amount of the used values of the counter sequence which still allows the next value
+func (c *counter) maxUsed() int64 {
+	if inc := c.seq.Increment(); inc > 0 {
+		return (c.seq.MaxValue() - c.seq.StartWith()) / inc
+	}
+	return (c.seq.StartWith() - c.seq.MinValue()) / -c.seq.Increment()
+}
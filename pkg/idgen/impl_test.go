@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package idgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorageimpl"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+)
+
+var (
+	testCDoc       = appdef.NewQName("test", "CDoc")
+	testWDoc       = appdef.NewQName("test", "WDoc")
+	testNumbers    = appdef.NewQName("test", "Numbers")
+	testCountdown  = appdef.NewQName("test", "Countdown")
+	testWorkspace  = istructs.WSID(1)
+	testWorkspace2 = istructs.WSID(2)
+)
+
+func testAppStructs(t *testing.T, stored bool) istructs.IAppStructs {
+	adb := appdef.New()
+	adb.AddStruct(testCDoc, appdef.DefKind_CDoc)
+	adb.AddStruct(testWDoc, appdef.DefKind_WDoc)
+	if stored {
+		ProvideViewDef(adb)
+	}
+	cfgs := make(istructsmem.AppConfigsType)
+	cfg := cfgs.AddConfig(istructs.AppQName_test1_app1, adb)
+	cfg.Sequences.Add(testNumbers, appdef.DataKind_int32, 1000, 1, 1002, 1)
+	cfg.Sequences.Add(testCountdown, appdef.DataKind_int64, 3, 1, 3, -1)
+
+	asp := istructsmem.Provide(cfgs, iratesce.TestBucketsFactory, payloads.TestAppTokensFactory(itokensjwt.TestTokensJWT()), istorageimpl.Provide(istorage.ProvideMem()))
+	as, err := asp.AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(t, err)
+	return as
+}
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+	as := testAppStructs(t, true)
+	cDoc := as.AppDef().Def(testCDoc)
+	wDoc := as.AppDef().Def(testWDoc)
+
+	gen := Provide(as, BlockSize(10))
+
+	t.Run("Should generate IDs in separate ranges", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			id, err := gen.NextID(testWorkspace, cDoc)
+			require.NoError(err)
+			require.Equal(istructs.NewCDocCRecordID(istructs.FirstBaseRecordID+istructs.RecordID(i)), id)
+
+			id, err = gen.NextID(testWorkspace, wDoc)
+			require.NoError(err)
+			require.Equal(istructs.NewRecordID(istructs.FirstBaseRecordID+istructs.RecordID(i)), id)
+		}
+
+		id, err := gen.NextID(testWorkspace2, wDoc)
+		require.NoError(err)
+		require.Equal(istructs.NewRecordID(istructs.FirstBaseRecordID), id, "workspaces must have own sequences")
+	})
+
+	t.Run("Should generate named sequences values", func(t *testing.T) {
+		for _, expected := range []int64{1000, 1001, 1002} {
+			val, err := gen.NextVal(testWorkspace, testNumbers)
+			require.NoError(err)
+			require.Equal(expected, val)
+		}
+		_, err := gen.NextVal(testWorkspace, testNumbers)
+		require.ErrorIs(err, ErrSequenceOverflow)
+
+		for _, expected := range []int64{3, 2, 1} {
+			val, err := gen.NextVal(testWorkspace, testCountdown)
+			require.NoError(err)
+			require.Equal(expected, val)
+		}
+		_, err = gen.NextVal(testWorkspace, testCountdown)
+		require.ErrorIs(err, ErrSequenceOverflow)
+
+		_, err = gen.NextVal(testWorkspace, appdef.NewQName("test", "Unknown"))
+		require.ErrorIs(err, ErrUnknownSequence)
+	})
+
+	t.Run("Should continue from reserved bound after restart", func(t *testing.T) {
+		gen := Provide(as, BlockSize(10))
+
+		id, err := gen.NextID(testWorkspace, cDoc)
+		require.NoError(err)
+		require.Equal(istructs.NewCDocCRecordID(istructs.FirstBaseRecordID+10), id)
+
+		id, err = gen.NextID(testWorkspace2, wDoc)
+		require.NoError(err)
+		require.Equal(istructs.NewRecordID(istructs.FirstBaseRecordID+10), id)
+
+		_, err = gen.NextVal(testWorkspace, testNumbers)
+		require.ErrorIs(err, ErrSequenceOverflow, "reserved values must not be repeated")
+	})
+
+	t.Run("Should reserve next block when reserved values are used", func(t *testing.T) {
+		gen := Provide(as, BlockSize(2))
+		for i := 0; i < 3; i++ {
+			_, err := gen.NextID(testWorkspace, wDoc)
+			require.NoError(err)
+		}
+
+		gen = Provide(as, BlockSize(2))
+		id, err := gen.NextID(testWorkspace, wDoc)
+		require.NoError(err)
+		require.Equal(istructs.NewRecordID(istructs.FirstBaseRecordID+14), id)
+	})
+}
+
+func TestNotStored(t *testing.T) {
+	require := require.New(t)
+	as := testAppStructs(t, false)
+	cDoc := as.AppDef().Def(testCDoc)
+	wDoc := as.AppDef().Def(testWDoc)
+
+	gen := Provide(as, DefaultBlockSize)
+
+	id, err := gen.NextID(testWorkspace, wDoc)
+	require.NoError(err)
+	require.Equal(istructs.NewRecordID(istructs.FirstBaseRecordID), id)
+
+	t.Run("Should continue after synced IDs", func(t *testing.T) {
+		gen := Provide(as, DefaultBlockSize)
+		gen.UpdateOnSync(testWorkspace, istructs.NewRecordID(istructs.FirstBaseRecordID+5))
+		gen.UpdateOnSync(testWorkspace, istructs.NewRecordID(istructs.FirstBaseRecordID+2))
+		gen.UpdateOnSync(testWorkspace, istructs.NewCDocCRecordID(istructs.FirstBaseRecordID))
+		gen.UpdateOnSync(testWorkspace, istructs.NewCDocCRecordID(istructs.FirstSingletonID))
+
+		id, err := gen.NextID(testWorkspace, wDoc)
+		require.NoError(err)
+		require.Equal(istructs.NewRecordID(istructs.FirstBaseRecordID+6), id)
+
+		id, err = gen.NextID(testWorkspace, cDoc)
+		require.NoError(err)
+		require.Equal(istructs.NewCDocCRecordID(istructs.FirstBaseRecordID+1), id)
+	})
+
+	t.Run("Should not generate named sequences values", func(t *testing.T) {
+		_, err := gen.NextVal(testWorkspace, testNumbers)
+		require.ErrorIs(err, ErrSequencesNotStored)
+	})
+}
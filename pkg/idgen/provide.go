@@ -0,0 +1,31 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package idgen
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Provide returns the ID generator of the application partition.
+//
+// Reserved bounds of the sequences are stored by blocks of blockSize values only if application defines ViewQNameIDSequences.
+// Otherwise record ID counters are kept in memory and must be restored by UpdateOnSync()
+func Provide(appStructs istructs.IAppStructs, blockSize BlockSize) istructs.IIDGenerator {
+	return &generator{
+		appStructs: appStructs,
+		stored:     appStructs.AppDef().DefByName(ViewQNameIDSequences) != nil,
+		blockSize:  int64(blockSize),
+		counters:   map[istructs.WSID]map[appdef.QName]*counter{},
+	}
+}
+
+// ProvideViewDef adds the view with the reserved bounds of the sequences, this makes the generator store them
+func ProvideViewDef(appDef appdef.IAppDefBuilder) {
+	appDef.AddView(ViewQNameIDSequences).
+		AddPartField(Field_WSID, appdef.DataKind_int64).
+		AddClustColumn(Field_Sequence, appdef.DataKind_string).
+		AddValueField(Field_Reserved, appdef.DataKind_int64, true)
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package idgen
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// BlockSize is the amount of the sequence values reserved in the storage at once
+type BlockSize int
+
+type generator struct {
+	appStructs istructs.IAppStructs
+	// reserved bounds are stored only if application defines ViewQNameIDSequences
+	stored    bool
+	blockSize int64
+	counters  map[istructs.WSID]map[appdef.QName]*counter
+}
+
+// counter of the sequence values of the workspace.
+// Values are counted rather than stored, so ascending and descending sequences are handled the same way
+type counter struct {
+	seq istructs.ISequence
+	// amount of the values returned or synced
+	used int64
+	// amount of the values reserved in the storage
+	reserved int64
+	// reserved bound is read from the storage
+	loaded bool
+}
+
+// recordIDs is the sequence of the base record IDs
+type recordIDs struct {
+	qName appdef.QName
+}
+
+func (s recordIDs) QName() appdef.QName       { return s.qName }
+func (s recordIDs) DataKind() appdef.DataKind { return appdef.DataKind_int64 }
+func (s recordIDs) StartWith() int64          { return int64(istructs.FirstBaseRecordID) }
+func (s recordIDs) MinValue() int64           { return int64(istructs.FirstBaseRecordID) }
+func (s recordIDs) MaxValue() int64           { return istructs.RegisterFactor - 1 }
+func (s recordIDs) Increment() int64          { return 1 }
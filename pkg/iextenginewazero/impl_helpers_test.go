@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"net/url"
 	"path/filepath"
+	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
@@ -242,6 +243,10 @@ func (kb *mockKeyBuilder) PutString(name, value string)                     {}
 func (kb *mockKeyBuilder) PutQName(name string, value appdef.QName)         {}
 func (kb *mockKeyBuilder) PutBool(name string, value bool)                  {}
 func (kb *mockKeyBuilder) PutRecordID(name string, value istructs.RecordID) {}
+func (kb *mockKeyBuilder) PutDecimal(name string, value istructs.Decimal)   {}
+func (kb *mockKeyBuilder) PutTimestamp(name string, value time.Time)        {}
+func (kb *mockKeyBuilder) PutDate(name string, value time.Time)             {}
+func (kb *mockKeyBuilder) PutUUID(name string, value istructs.UUID)         {}
 
 // Tries to make conversion from value to a name type
 func (kb *mockKeyBuilder) PutNumber(name string, value float64) {}
@@ -365,6 +370,10 @@ func (kb *mockValueBuilder) PutString(name, value string)                     {
 func (kb *mockValueBuilder) PutQName(name string, value appdef.QName)         {}
 func (kb *mockValueBuilder) PutBool(name string, value bool)                  {}
 func (kb *mockValueBuilder) PutRecordID(name string, value istructs.RecordID) {}
+func (kb *mockValueBuilder) PutDecimal(name string, value istructs.Decimal)   {}
+func (kb *mockValueBuilder) PutTimestamp(name string, value time.Time)        {}
+func (kb *mockValueBuilder) PutDate(name string, value time.Time)             {}
+func (kb *mockValueBuilder) PutUUID(name string, value istructs.UUID)         {}
 
 // Tries to make conversion from value to a name type
 func (kb *mockValueBuilder) PutNumber(name string, value float64) {}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"io/fs"
 
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/ibus"
 	"github.com/voedger/voedger/pkg/iservices"
 	"github.com/voedger/voedger/pkg/istructs"
@@ -57,6 +58,28 @@ type IHTTPProcessorAPI interface {
 	// ErrUnknownApplication
 	DeployAppPartition(ctx context.Context, app istructs.AppQName, partNo istructs.PartitionID, commandHandler, queryHandler ibus.ISender) (err error)
 
+	/*
+		GraphQL
+
+		<cluster-domain>/api/<AppQName.owner>/<AppQName.name>/<wsid>/graphql
+
+		- schema is generated from the application definitions and resources, GET without query returns the schema in SDL
+		- queries are sent to the queryHandler, mutations are sent to the commandHandler
+		- requests are sent with the original headers, so the processors authorize them
+		- same app can be deployed multiple times, the first deployed schema is used
+	*/
+	DeployGraphQL(ctx context.Context, app istructs.AppQName, appDef appdef.IAppDef, resources istructs.IResources, commandHandler, queryHandler ibus.ISender) (err error)
+
+	/*
+		OpenAPI
+
+		<cluster-domain>/api/<AppQName.owner>/<AppQName.name>/openapi.json
+
+		- OpenAPI 3 document is generated from the application packages description, see istructs.IAppStructs.DescribePackage
+		- document describes c. and q. function endpoints, their arguments and results, CUDs and errors
+	*/
+	DeployOpenAPI(ctx context.Context, app istructs.IAppStructs) (err error)
+
 	// ErrUnknownAppPartition
 	//--	UndeployAppPartition(app istructs.AppQName, partNo istructs.PartitionID) (err error)
 
@@ -12,4 +12,43 @@ const (
 	APIChannelBufferSize     = 10
 	defaultReadHeaderTimeout = time.Second
 	staticPath               = "/static/"
+	graphQLPath              = "graphql"
+	openAPIPath              = "openapi.json"
+)
+
+const (
+	gqlOperationQuery    = "query"
+	gqlOperationMutation = "mutation"
+
+	gqlTypeQuery         = "Query"
+	gqlTypeMutation      = "Mutation"
+	gqlTypeCommandResult = "CommandResult"
+
+	// Arguments of the mutations besides the command params fields
+	gqlArgUnloggedArgs = "unloggedArgs"
+	gqlArgCUDs         = "cuds"
+
+	// Fields of the command result
+	gqlFieldCurrentWLogOffset = "CurrentWLogOffset"
+	gqlFieldNewIDs            = "NewIDs"
+
+	// Custom scalars
+	gqlScalarInt64     = "Int64"
+	gqlScalarDecimal   = "Decimal"
+	gqlScalarTimestamp = "Timestamp"
+	gqlScalarDate      = "Date"
+	gqlScalarUUID      = "UUID"
+	gqlScalarJSON      = "JSON"
+)
+
+const (
+	openAPIVersion    = "3.0.3"
+	openAPIDocVersion = "1.0.0"
+
+	openAPISecurityBearer = "bearerAuth"
+
+	openAPISchemaCUD           = "CUD"
+	openAPISchemaError         = "Error"
+	openAPISchemaCommandResult = "CommandResult"
+	openAPISchemaQueryResult   = "QueryResult"
 )
@@ -0,0 +1,14 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package ihttpimpl
+
+import "errors"
+
+var ErrGraphQLSyntax = errors.New("GraphQL syntax error")
+var ErrGraphQLUnsupported = errors.New("GraphQL feature is not supported")
+var ErrGraphQLOperationNotFound = errors.New("GraphQL operation not found")
+var ErrGraphQLUnknownField = errors.New("unknown GraphQL field")
+var ErrGraphQLUnknownArgument = errors.New("unknown GraphQL argument")
+var ErrGraphQLWrongSelection = errors.New("wrong GraphQL selection")
@@ -0,0 +1,459 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package ihttpimpl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	airsibus "github.com/untillpro/airs-ibus"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/ibus"
+	"github.com/voedger/voedger/pkg/istructs"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+type gqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type gqlResponse struct {
+	Data   gqlObject  `json:"data,omitempty"`
+	Errors []gqlError `json:"errors,omitempty"`
+}
+
+type gqlError struct {
+	Message    string              `json:"message"`
+	Path       []string            `json:"path,omitempty"`
+	Extensions *gqlErrorExtensions `json:"extensions,omitempty"`
+}
+
+type gqlErrorExtensions struct {
+	HTTPStatus int `json:"HTTPStatus"`
+}
+
+// gqlObject is the result object, fields are marshaled to JSON in the selection order
+type gqlObject []gqlObjectField
+
+type gqlObjectField struct {
+	name  string
+	value interface{}
+}
+
+func (o gqlObject) MarshalJSON() ([]byte, error) {
+	b := bytes.NewBufferString("{")
+	for i, f := range o {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		name, err := json.Marshal(f.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(name)
+		b.WriteString(":")
+		b.Write(value)
+	}
+	b.WriteString("}")
+	return b.Bytes(), nil
+}
+
+// gqlHandler serves GraphQL requests of the application:
+//   - GET without query returns the schema in SDL
+//   - queries are sent to the queryHandler, mutations are sent to the commandHandler as airs-ibus requests
+//     with the original request headers, so the processors authenticate and authorize them as usual
+type gqlHandler struct {
+	app            istructs.AppQName
+	schema         *gqlSchema
+	commandHandler ibus.ISender
+	queryHandler   ibus.ISender
+}
+
+func newGQLHandler(app istructs.AppQName, appDef appdef.IAppDef, resources istructs.IResources, commandHandler, queryHandler ibus.ISender) *gqlHandler {
+	return &gqlHandler{
+		app:            app,
+		schema:         newGQLSchema(appDef, resources),
+		commandHandler: commandHandler,
+		queryHandler:   queryHandler,
+	}
+}
+
+func (h *gqlHandler) ServeHTTP(wr http.ResponseWriter, req *http.Request) {
+	// <cluster-domain>/api/<AppQName.owner>/<AppQName.name>/<wsid>/graphql
+	pathParts := strings.Split(strings.TrimSuffix(req.URL.Path, "/"+graphQLPath), "/")
+	wsid, err := strconv.ParseUint(pathParts[len(pathParts)-1], 10, 64)
+	if err != nil {
+		replyGQLError(wr, http.StatusBadRequest, fmt.Errorf("wrong wsid: %w", err))
+		return
+	}
+	gqlReq := gqlRequest{}
+	switch req.Method {
+	case http.MethodGet:
+		query := req.URL.Query()
+		if gqlReq.Query = query.Get("query"); gqlReq.Query == "" {
+			wr.Header().Set(coreutils.ContentType, "text/plain")
+			_, _ = wr.Write([]byte(h.schema.sdl))
+			return
+		}
+		gqlReq.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := unmarshalJSON([]byte(variables), &gqlReq.Variables); err != nil {
+				replyGQLError(wr, http.StatusBadRequest, fmt.Errorf("wrong variables: %w", err))
+				return
+			}
+		}
+	case http.MethodPost:
+		body := bytes.Buffer{}
+		if _, err := body.ReadFrom(req.Body); err != nil {
+			replyGQLError(wr, http.StatusBadRequest, err)
+			return
+		}
+		if err := unmarshalJSON(body.Bytes(), &gqlReq); err != nil {
+			replyGQLError(wr, http.StatusBadRequest, fmt.Errorf("wrong request body: %w", err))
+			return
+		}
+	default:
+		replyGQLError(wr, http.StatusMethodNotAllowed, fmt.Errorf("%s method is not allowed", req.Method))
+		return
+	}
+
+	op, err := h.operation(gqlReq)
+	if err != nil {
+		replyGQLError(wr, http.StatusBadRequest, err)
+		return
+	}
+	if op.kind == gqlOperationMutation && req.Method != http.MethodPost {
+		replyGQLError(wr, http.StatusMethodNotAllowed, errors.New("mutations are allowed by POST only"))
+		return
+	}
+	for name, value := range gqlReq.Variables {
+		op.variables[name] = value
+	}
+
+	resp := gqlResponse{Data: gqlObject{}}
+	for _, field := range op.selections {
+		var value interface{}
+		if op.kind == gqlOperationQuery {
+			value, err = h.query(req, istructs.WSID(wsid), field, op.variables)
+		} else {
+			value, err = h.mutation(req, istructs.WSID(wsid), field, op.variables)
+		}
+		if err != nil {
+			resp.Errors = append(resp.Errors, newGQLError(err, field.responseKey()))
+		}
+		resp.Data = append(resp.Data, gqlObjectField{field.responseKey(), value})
+	}
+	replyGQL(wr, http.StatusOK, resp)
+}
+
+// operation returns the operation of the request document to execute
+func (h *gqlHandler) operation(req gqlRequest) (*gqlOperation, error) {
+	ops, err := parseGraphQL(req.Query)
+	if err != nil {
+		return nil, err
+	}
+	if req.OperationName == "" {
+		if len(ops) > 1 {
+			return nil, fmt.Errorf("%w: operationName is required for the document with several operations", ErrGraphQLOperationNotFound)
+		}
+		return ops[0], nil
+	}
+	for _, op := range ops {
+		if op.name == req.OperationName {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrGraphQLOperationNotFound, req.OperationName)
+}
+
+// query executes the query field by the query function and returns the list of result objects
+func (h *gqlHandler) query(req *http.Request, wsid istructs.WSID, field *gqlField, variables map[string]interface{}) (interface{}, error) {
+	f, ok := h.schema.queries[field.name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s.%s", ErrGraphQLUnknownField, gqlTypeQuery, field.name)
+	}
+	args, _, err := f.buildArgs(field, variables)
+	if err != nil {
+		return nil, err
+	}
+	elements, err := queryElements(f.result, field)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(map[string]interface{}{"args": args, "elements": elements})
+	if err != nil {
+		return nil, err
+	}
+	data, err := h.send(req, h.queryHandler, wsid, "q."+f.resource.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	resp := struct {
+		Sections []struct {
+			Elements [][][][]interface{} `json:"elements"`
+		} `json:"sections"`
+	}{}
+	if err := unmarshalJSON(data, &resp); err != nil {
+		return nil, fmt.Errorf("wrong query response: %w", err)
+	}
+	res := []gqlObject{}
+	for _, section := range resp.Sections {
+		for _, row := range section.Elements {
+			obj, err := rowObject(f.result, field, row)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// mutation executes the mutation field by the command function and returns CommandResult
+func (h *gqlHandler) mutation(req *http.Request, wsid istructs.WSID, field *gqlField, variables map[string]interface{}) (interface{}, error) {
+	f, ok := h.schema.mutations[field.name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s.%s", ErrGraphQLUnknownField, gqlTypeMutation, field.name)
+	}
+	if err := checkSelection(f.result, field, false); err != nil {
+		return nil, err
+	}
+	args, extra, err := f.buildArgs(field, variables)
+	if err != nil {
+		return nil, err
+	}
+	request := map[string]interface{}{"args": args}
+	for name, value := range extra {
+		request[name] = value
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	data, err := h.send(req, h.commandHandler, wsid, "c."+f.resource.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]interface{}{}
+	if err := unmarshalJSON(data, &values); err != nil {
+		return nil, fmt.Errorf("wrong command response: %w", err)
+	}
+	res := gqlObject{}
+	for _, sel := range field.selections {
+		res = append(res, gqlObjectField{sel.responseKey(), values[f.result.byName[sel.name].field]})
+	}
+	return res, nil
+}
+
+// send sends the request to the handler and returns the response data.
+// Handlers reply with airs-ibus response as the processors do
+func (h *gqlHandler) send(req *http.Request, sender ibus.ISender, wsid istructs.WSID, resource string, body []byte) ([]byte, error) {
+	request := airsibus.Request{
+		Method:   airsibus.HTTPMethodPOST,
+		WSID:     int64(wsid),
+		Header:   req.Header,
+		Resource: resource,
+		Body:     body,
+		AppQName: h.app.String(),
+		Host:     req.Host,
+	}
+	response, status, err := sender.Send(req.Context(), request, ibus.NullHandler)
+	if err != nil {
+		return nil, coreutils.NewHTTPError(status.HTTPStatus, err)
+	}
+	resp, ok := response.(airsibus.Response)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response %T", response)
+	}
+	sysErr := struct {
+		SysError *coreutils.SysError `json:"sys.Error"`
+	}{}
+	if resp.StatusCode != http.StatusOK || bytes.Contains(resp.Data, []byte(`"sys.Error"`)) {
+		if err := json.Unmarshal(resp.Data, &sysErr); err == nil && sysErr.SysError != nil {
+			return nil, *sysErr.SysError
+		}
+		return nil, coreutils.NewHTTPErrorf(resp.StatusCode, string(resp.Data))
+	}
+	return resp.Data, nil
+}
+
+// buildArgs returns the function arguments and, for mutations, the unlogged arguments and CUDs
+func (f *gqlFunction) buildArgs(field *gqlField, variables map[string]interface{}) (args, extra map[string]interface{}, err error) {
+	args, extra = map[string]interface{}{}, map[string]interface{}{}
+	for name, value := range f.fixedArgs {
+		args[name] = value
+	}
+	for _, a := range field.arguments {
+		arg, ok := f.byName[a.name]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %s(%s)", ErrGraphQLUnknownArgument, f.name, a.name)
+		}
+		value, err := resolveValue(a.value, variables)
+		if err != nil {
+			return nil, nil, err
+		}
+		if value == nil {
+			continue
+		}
+		switch arg.field {
+		case gqlArgUnloggedArgs, gqlArgCUDs:
+			extra[arg.field] = value
+		default:
+			if args[arg.field], err = argValue(arg.kind, value); err != nil {
+				return nil, nil, fmt.Errorf("%s(%s): %w", f.name, a.name, err)
+			}
+		}
+	}
+	return args, extra, nil
+}
+
+// argValue converts the GraphQL value to the params field value: IDs may be strings
+// and JSON values are passed to the string fields as strings
+func argValue(kind appdef.DataKind, value interface{}) (interface{}, error) {
+	switch kind {
+	case appdef.DataKind_RecordID:
+		if s, ok := value.(string); ok {
+			id, err := strconv.ParseUint(s, 10, 64)
+			return json.Number(strconv.FormatUint(id, 10)), err
+		}
+	case appdef.DataKind_string:
+		if _, ok := value.(string); !ok {
+			bb, err := json.Marshal(value)
+			return string(bb), err
+		}
+	}
+	return value, nil
+}
+
+// queryElements returns the query processor elements for the selection: fields of the result are the root element,
+// fields of the containers are the elements with the container path
+func queryElements(t *gqlType, field *gqlField) ([]interface{}, error) {
+	if err := checkSelection(t, field, true); err != nil {
+		return nil, err
+	}
+	root := []string{}
+	elements := []interface{}{nil}
+	for _, sel := range field.selections {
+		f := t.byName[sel.name]
+		if f.elements == nil {
+			root = append(root, f.field)
+			continue
+		}
+		if err := checkSelection(f.elements, sel, false); err != nil {
+			return nil, err
+		}
+		fields := []string{}
+		for _, s := range sel.selections {
+			fields = append(fields, f.elements.byName[s.name].field)
+		}
+		elements = append(elements, map[string]interface{}{"path": f.field, "fields": fields})
+	}
+	elements[0] = map[string]interface{}{"path": "", "fields": root}
+	return elements, nil
+}
+
+// checkSelection checks the selection of the type fields. Containers are allowed in the root of the query result only
+func checkSelection(t *gqlType, field *gqlField, containersAllowed bool) error {
+	if len(field.selections) == 0 {
+		return fmt.Errorf("%w: %s of type %s must have a selection of subfields", ErrGraphQLWrongSelection, field.name, t.name)
+	}
+	for _, sel := range field.selections {
+		f, ok := t.byName[sel.name]
+		if !ok {
+			return fmt.Errorf("%w: %s.%s", ErrGraphQLUnknownField, t.name, sel.name)
+		}
+		if len(sel.arguments) > 0 {
+			return fmt.Errorf("%w: %s.%s(%s)", ErrGraphQLUnknownArgument, t.name, sel.name, sel.arguments[0].name)
+		}
+		if f.elements != nil && !containersAllowed {
+			return fmt.Errorf("%w: nested container %s.%s", ErrGraphQLUnsupported, t.name, sel.name)
+		}
+		if f.elements == nil && len(sel.selections) > 0 {
+			return fmt.Errorf("%w: %s.%s of type %s must not have a selection", ErrGraphQLWrongSelection, t.name, sel.name, f.typ)
+		}
+	}
+	return nil
+}
+
+// rowObject returns the result object from the query processor row, the row has the elements in queryElements order
+func rowObject(t *gqlType, field *gqlField, row [][][]interface{}) (obj gqlObject, err error) {
+	if len(row) == 0 || len(row[0]) != 1 {
+		return nil, errors.New("wrong query response: row has no result")
+	}
+	fields := func(t *gqlType, selections []*gqlField, values []interface{}) (obj gqlObject) {
+		for i, sel := range selections {
+			value := values[i]
+			if n, ok := value.(json.Number); ok && t.byName[sel.name].kind == appdef.DataKind_RecordID {
+				value = n.String()
+			}
+			obj = append(obj, gqlObjectField{sel.responseKey(), value})
+		}
+		return obj
+	}
+	root, element := 0, 1
+	for _, sel := range field.selections {
+		f := t.byName[sel.name]
+		if f.elements == nil {
+			obj = append(obj, fields(t, []*gqlField{sel}, row[0][0][root:])...)
+			root++
+			continue
+		}
+		if element >= len(row) {
+			return nil, fmt.Errorf("wrong query response: row has no %s", f.field)
+		}
+		list := []gqlObject{}
+		for _, values := range row[element] {
+			list = append(list, fields(f.elements, sel.selections, values))
+		}
+		obj = append(obj, gqlObjectField{sel.responseKey(), list})
+		element++
+	}
+	return obj, nil
+}
+
+func newGQLError(err error, path ...string) gqlError {
+	res := gqlError{Message: err.Error(), Path: path}
+	var sysErr coreutils.SysError
+	if errors.As(err, &sysErr) {
+		if res.Message = sysErr.Message; res.Message == "" {
+			res.Message = http.StatusText(sysErr.HTTPStatus)
+		}
+		res.Extensions = &gqlErrorExtensions{HTTPStatus: sysErr.HTTPStatus}
+	}
+	return res
+}
+
+func replyGQLError(wr http.ResponseWriter, status int, err error) {
+	replyGQL(wr, status, gqlResponse{Errors: []gqlError{newGQLError(err)}})
+}
+
+func replyGQL(wr http.ResponseWriter, status int, resp gqlResponse) {
+	bb, err := json.Marshal(resp)
+	if err != nil {
+		status = http.StatusInternalServerError
+		bb = []byte(fmt.Sprintf(`{"errors":[{"message":%q}]}`, err.Error()))
+	}
+	wr.Header().Set(coreutils.ContentType, coreutils.ApplicationJSON)
+	wr.WriteHeader(status)
+	_, _ = wr.Write(bb)
+}
+
+// unmarshalJSON unmarshals numbers as json.Number to pass int64 values without loss
+func unmarshalJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
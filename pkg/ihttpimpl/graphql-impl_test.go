@@ -0,0 +1,254 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package ihttpimpl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	airsibus "github.com/untillpro/airs-ibus"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/collection"
+	"github.com/voedger/voedger/pkg/ibus"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	queryprocessor "github.com/voedger/voedger/pkg/processors/query"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+func TestGraphQL(t *testing.T) {
+	require := require.New(t)
+	testApp := setUp(t)
+	defer tearDown(testApp)
+
+	adb := appdef.New()
+	adb.AddStruct(appdef.NewQName("test", "Article"), appdef.DefKind_CDoc).
+		AddField("name", appdef.DataKind_string, true)
+	adb.AddStruct(appdef.NewQName("test", "ArticlesParams"), appdef.DefKind_Object).
+		AddField("Category", appdef.DataKind_int32, true)
+	adb.AddStruct(appdef.NewQName("test", "ArticleInfo"), appdef.DefKind_Object).
+		AddField("id", appdef.DataKind_RecordID, true).
+		AddField("name", appdef.DataKind_string, true).
+		AddField("price", appdef.DataKind_int64, false).
+		AddContainer("tags", appdef.NewQName("test", "ArticleTag"), appdef.Occurs(0), appdef.Occurs_Unbounded)
+	adb.AddStruct(appdef.NewQName("test", "ArticleTag"), appdef.DefKind_Element).
+		AddField("name", appdef.DataKind_string, true)
+	adb.AddStruct(appdef.NewQName("test", "OrderParams"), appdef.DefKind_Object).
+		AddField("Article", appdef.DataKind_RecordID, true).
+		AddField("Quantity", appdef.DataKind_int32, false)
+	adb.AddStruct(appdef.NewQName("test", "OrderUnloggedParams"), appdef.DefKind_Object).
+		AddField("Card", appdef.DataKind_string, true)
+	adb.AddView(appdef.NewQName("test", "Sales")).
+		AddPartField("Department", appdef.DataKind_int32).
+		AddClustColumn("Name", appdef.DataKind_string).
+		AddValueField("Amount", appdef.DataKind_int64, true)
+
+	cfgs := make(istructsmem.AppConfigsType)
+	cfg := cfgs.AddConfig(istructs.AppQName_test1_app1, adb)
+	cfg.Resources.Add(istructsmem.NewQueryFunction(appdef.NewQName("test", "Articles"), appdef.NewQName("test", "ArticlesParams"),
+		appdef.NewQName("test", "ArticleInfo"), istructsmem.NullQueryExec))
+	cfg.Resources.Add(istructsmem.NewCommandFunction(appdef.NewQName("test", "Order"), appdef.NewQName("test", "OrderParams"),
+		appdef.NewQName("test", "OrderUnloggedParams"), appdef.NullQName, istructsmem.NullCommandExec))
+	collection.Provide(cfg, adb)
+	queryprocessor.ProvideReadViewQuery(cfg, adb)
+
+	var requests []airsibus.Request
+	var reply func(req airsibus.Request) airsibus.Response
+	receiver := func(_ context.Context, request interface{}, _ ibus.SectionsWriterType) (response interface{}, status ibus.Status, err error) {
+		req := request.(airsibus.Request)
+		requests = append(requests, req)
+		return ibus.NewResult(reply(req), nil, "", "")
+	}
+	for _, part := range []string{"c", "q"} {
+		testApp.bus.RegisterReceiver("test1", "app1", 0, part, receiver, 1, 1)
+		defer testApp.bus.UnregisterReceiver("test1", "app1", 0, part)
+	}
+	commandSender, _ := testApp.bus.QuerySender("test1", "app1", 0, "c")
+	querySender, _ := testApp.bus.QuerySender("test1", "app1", 0, "q")
+	require.NoError(testApp.api.DeployGraphQL(testApp.ctx, istructs.AppQName_test1_app1, adb, &cfg.Resources, commandSender, querySender))
+
+	graphQLURL := fmt.Sprintf("http://localhost:%d/api/test1/app1/5/graphql", testApp.listeningPort)
+	post := func(body string, expectedCode int) string {
+		req, err := http.NewRequest(http.MethodPost, graphQLURL, bytes.NewReader([]byte(body)))
+		require.NoError(err)
+		req.Header.Set(coreutils.Authorization, coreutils.BearerPrefix+"token")
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(err)
+		defer res.Body.Close()
+		require.Equal(expectedCode, res.StatusCode)
+		bb, err := io.ReadAll(res.Body)
+		require.NoError(err)
+		return string(bb)
+	}
+	query := func(query string, variables map[string]interface{}) string {
+		bb, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+		require.NoError(err)
+		return post(string(bb), http.StatusOK)
+	}
+	okResponse := func(data string) airsibus.Response {
+		return airsibus.Response{ContentType: coreutils.ApplicationJSON, StatusCode: http.StatusOK, Data: []byte(data)}
+	}
+
+	t.Run("Should return schema", func(t *testing.T) {
+		schema := string(testApp.get("/api/test1/app1/5/graphql"))
+
+		require.Contains(schema, "scalar Int64\n")
+		require.Contains(schema, "  q_test_Articles(Category: Int!): [test_ArticleInfo!]\n")
+		require.Contains(schema, "  test_Article(ID: ID): [test_Article!]\n")
+		require.Contains(schema, "  test_Sales(Key: JSON!, Range: JSON, Limit: Int): [test_Sales!]\n")
+		require.Contains(schema, "  c_test_Order(Article: ID!, Quantity: Int, unloggedArgs: JSON, cuds: [JSON!]): CommandResult\n")
+		require.Contains(schema, "type test_ArticleInfo {\n  id: ID!\n  name: String!\n  price: Int64\n  tags: [test_ArticleTag!]\n}\n")
+		require.Contains(schema, "type test_Sales {\n  Department: Int!\n  Name: String\n  Amount: Int64!\n}\n")
+		require.Contains(schema, "type CommandResult {\n  CurrentWLogOffset: Int64!\n  NewIDs: JSON\n}\n")
+		require.NotContains(schema, "q_sys_Collection")
+	})
+	t.Run("Should execute query by the query function", func(t *testing.T) {
+		requests = nil
+		reply = func(airsibus.Request) airsibus.Response {
+			return okResponse(`{"sections":[{"type":"","elements":[` +
+				`[[["Cola",322685000131073]],[["cold"],["sweet"]]],` +
+				`[[["Tea",322685000131074]],[]]]}]}`)
+		}
+
+		resp := query(`query Articles($category: Int!) {
+			articles: q_test_Articles(Category: $category) { title: name, tags { name }, id }
+		}`, map[string]interface{}{"category": 3})
+
+		require.Equal(`{"data":{"articles":[`+
+			`{"title":"Cola","tags":[{"name":"cold"},{"name":"sweet"}],"id":"322685000131073"},`+
+			`{"title":"Tea","tags":[],"id":"322685000131074"}]}}`, resp)
+		require.Len(requests, 1)
+		require.Equal("q.test.Articles", requests[0].Resource)
+		require.Equal(int64(5), requests[0].WSID)
+		require.Equal(istructs.AppQName_test1_app1.String(), requests[0].AppQName)
+		require.Equal([]string{coreutils.BearerPrefix + "token"}, requests[0].Header[coreutils.Authorization])
+		require.JSONEq(`{"args":{"Category":3},"elements":[{"path":"","fields":["name","id"]},{"path":"tags","fields":["name"]}]}`,
+			string(requests[0].Body))
+	})
+	t.Run("Should read documents and views", func(t *testing.T) {
+		requests = nil
+		reply = func(req airsibus.Request) airsibus.Response {
+			if req.Resource == "q."+collection.QNameQueryCollection.String() {
+				return okResponse(`{"sections":[{"type":"","elements":[[[["Cola"]]]]}]}`)
+			}
+			return okResponse(`{"sections":[{"type":"","elements":[[[["Cola",10]]]]}]}`)
+		}
+
+		resp := query(`{
+			test_Article(ID: "42") { name }
+			test_Sales(Key: {Department: 1}, Limit: 10) { Name Amount }
+		}`, nil)
+
+		require.Equal(`{"data":{"test_Article":[{"name":"Cola"}],"test_Sales":[{"Name":"Cola","Amount":10}]}}`, resp)
+		require.Len(requests, 2)
+		require.JSONEq(`{"args":{"Schema":"test.Article","ID":42},"elements":[{"path":"","fields":["name"]}]}`, string(requests[0].Body))
+		require.Equal("q."+queryprocessor.QNameQueryReadView.String(), requests[1].Resource)
+		require.JSONEq(`{"args":{"View":"test.Sales","Key":"{\"Department\":1}","Limit":10},"elements":[{"path":"","fields":["Name","Amount"]}]}`,
+			string(requests[1].Body))
+	})
+	t.Run("Should execute mutation by the command function", func(t *testing.T) {
+		requests = nil
+		reply = func(airsibus.Request) airsibus.Response {
+			return okResponse(`{"CurrentWLogOffset":10,"NewIDs":{"1":322685000131073}}`)
+		}
+
+		resp := query(`mutation {
+			c_test_Order(Article: 7, Quantity: 2, unloggedArgs: {Card: "1234"}) { NewIDs CurrentWLogOffset }
+		}`, nil)
+
+		require.Equal(`{"data":{"c_test_Order":{"NewIDs":{"1":322685000131073},"CurrentWLogOffset":10}}}`, resp)
+		require.Len(requests, 1)
+		require.Equal("c.test.Order", requests[0].Resource)
+		require.JSONEq(`{"args":{"Article":7,"Quantity":2},"unloggedArgs":{"Card":"1234"}}`, string(requests[0].Body))
+	})
+	t.Run("Should return processor errors", func(t *testing.T) {
+		reply = func(airsibus.Request) airsibus.Response {
+			return airsibus.Response{
+				ContentType: coreutils.ApplicationJSON,
+				StatusCode:  http.StatusForbidden,
+				Data:        []byte(coreutils.NewHTTPErrorf(http.StatusForbidden, "access denied").ToJSON()),
+			}
+		}
+
+		resp := query(`{ q_test_Articles(Category: 1) { name } }`, nil)
+
+		require.Equal(`{"data":{"q_test_Articles":null},"errors":[{"message":"access denied","path":["q_test_Articles"],"extensions":{"HTTPStatus":403}}]}`, resp)
+	})
+	t.Run("Should return errors of the wrong selection", func(t *testing.T) {
+		requests = nil
+		for q, expected := range map[string]error{
+			`{ q_test_Unknown { name } }`:                          ErrGraphQLUnknownField,
+			`{ q_test_Articles(Category: 1) { unknown } }`:         ErrGraphQLUnknownField,
+			`{ q_test_Articles(Unknown: 1) { name } }`:             ErrGraphQLUnknownArgument,
+			`{ q_test_Articles(Category: 1) }`:                     ErrGraphQLWrongSelection,
+			`{ q_test_Articles(Category: 1) { name { length } } }`: ErrGraphQLWrongSelection,
+			`mutation { c_test_Order(Article: 1) { unknown } }`:    ErrGraphQLUnknownField,
+		} {
+			resp := gqlResponse{}
+			require.NoError(json.Unmarshal([]byte(query(q, nil)), &struct{ Errors *[]gqlError }{&resp.Errors}), q)
+			require.Len(resp.Errors, 1, q)
+			require.Contains(resp.Errors[0].Message, expected.Error(), q)
+		}
+		require.Empty(requests)
+	})
+	t.Run("Should reply bad request on wrong document", func(t *testing.T) {
+		for _, q := range []string{`{`, `{ q_test_Articles(Category: 1) { ...Fields } }`, `query A { a } query B { b }`} {
+			resp := post(`{"query":`+fmt.Sprintf("%q", q)+`}`, http.StatusBadRequest)
+			require.Contains(resp, `"errors"`, q)
+		}
+		post(`{wrong`, http.StatusBadRequest)
+	})
+	t.Run("Should not allow mutations by GET", func(t *testing.T) {
+		testApp.get("/api/test1/app1/5/graphql?query="+url.QueryEscape(`mutation { c_test_Order(Article: 1) { NewIDs } }`), http.StatusMethodNotAllowed)
+	})
+}
+
+func TestGraphQLParser(t *testing.T) {
+	require := require.New(t)
+
+	ops, err := parseGraphQL(`
+		# comment
+		query Q($id: ID! = "1", $list: [Int!]) {
+			a: f(s: "x\"y", i: -1, fl: 1.5e3, b: true, n: null, e: ENUM, l: [1, 2], o: {k: $id}, v: $list) { x y { z } }
+		}
+		mutation M { m }`)
+	require.NoError(err)
+	require.Len(ops, 2)
+	q := ops[0]
+	require.Equal(gqlOperationQuery, q.kind)
+	require.Equal("Q", q.name)
+	require.Equal(map[string]interface{}{"id": "1", "list": nil}, q.variables)
+	require.Len(q.selections, 1)
+	f := q.selections[0]
+	require.Equal("a", f.responseKey())
+	require.Equal("f", f.name)
+	values := map[string]interface{}{}
+	for _, arg := range f.arguments {
+		values[arg.name], err = resolveValue(arg.value, q.variables)
+		require.NoError(err)
+	}
+	require.Equal(map[string]interface{}{
+		"s": `x"y`, "i": json.Number("-1"), "fl": json.Number("1.5e3"), "b": true, "n": nil, "e": "ENUM",
+		"l": []interface{}{json.Number("1"), json.Number("2")}, "o": map[string]interface{}{"k": "1"}, "v": nil,
+	}, values)
+	require.Equal("x", f.selections[0].name)
+	require.Equal("z", f.selections[1].selections[0].name)
+	require.Equal(gqlOperationMutation, ops[1].kind)
+
+	for _, src := range []string{``, `{}`, `{ a`, `{ a(b: ) }`, `{ a(b: "x) }`, `subscription { a }`, `{ a @skip(if: true) }`, `{ a(b: $c) }`} {
+		ops, err := parseGraphQL(src)
+		if err == nil {
+			_, err = resolveValue(ops[0].selections[0].arguments[0].value, ops[0].variables)
+		}
+		require.Error(err, src)
+	}
+}
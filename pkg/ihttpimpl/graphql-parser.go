@@ -0,0 +1,469 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package ihttpimpl
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// GraphQL executable document subset: operations with variables, fields with aliases and arguments.
+// Fragments and directives are not supported
+type gqlOperation struct {
+	kind       string // gqlOperationQuery or gqlOperationMutation
+	name       string
+	variables  map[string]interface{} // default values of the declared variables
+	selections []*gqlField
+}
+
+type gqlField struct {
+	alias      string
+	name       string
+	arguments  []gqlArgument
+	selections []*gqlField
+}
+
+type gqlArgument struct {
+	name  string
+	value interface{}
+}
+
+// gqlVariable is the argument value which refers to the operation variable
+type gqlVariable string
+
+// gqlEnum is the enum argument value, it is passed as string
+type gqlEnum string
+
+// responseKey returns the alias of the field if any, else the field name
+func (f *gqlField) responseKey() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+const (
+	gqlTokenEOF = iota
+	gqlTokenPunctuator
+	gqlTokenName
+	gqlTokenInt
+	gqlTokenFloat
+	gqlTokenString
+)
+
+type gqlToken struct {
+	kind  int
+	value string
+	pos   int
+}
+
+type gqlParser struct {
+	src string
+	pos int
+	tok gqlToken
+}
+
+// parseGraphQL parses the document and returns its operations in the document order
+func parseGraphQL(src string) (ops []*gqlOperation, err error) {
+	p := &gqlParser{src: src}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	for p.tok.kind != gqlTokenEOF {
+		op, err := p.parseOperation()
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("%w: document has no operations", ErrGraphQLSyntax)
+	}
+	return ops, nil
+}
+
+func (p *gqlParser) parseOperation() (op *gqlOperation, err error) {
+	op = &gqlOperation{kind: gqlOperationQuery, variables: map[string]interface{}{}}
+	if p.tok.kind == gqlTokenName {
+		switch p.tok.value {
+		case gqlOperationQuery, gqlOperationMutation:
+			op.kind = p.tok.value
+		case "fragment", "subscription":
+			return nil, p.errorf(ErrGraphQLUnsupported, "%s", p.tok.value)
+		default:
+			return nil, p.errorf(ErrGraphQLSyntax, "unexpected %q", p.tok.value)
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == gqlTokenName {
+			op.name = p.tok.value
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+		if p.is("(") {
+			if err := p.parseVariableDefinitions(op); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if p.is("@") {
+		return nil, p.errorf(ErrGraphQLUnsupported, "directives")
+	}
+	if op.selections, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (p *gqlParser) parseVariableDefinitions(op *gqlOperation) error {
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for !p.is(")") {
+		if err := p.expect("$"); err != nil {
+			return err
+		}
+		name, err := p.expectName()
+		if err != nil {
+			return err
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		if err := p.skipType(); err != nil {
+			return err
+		}
+		op.variables[name] = nil
+		if p.is("=") {
+			if err := p.next(); err != nil {
+				return err
+			}
+			if op.variables[name], err = p.parseValue(true); err != nil {
+				return err
+			}
+		}
+	}
+	return p.next()
+}
+
+// skipType skips the variable type, types are checked by the application
+func (p *gqlParser) skipType() error {
+	if p.is("[") {
+		if err := p.next(); err != nil {
+			return err
+		}
+		if err := p.skipType(); err != nil {
+			return err
+		}
+		if err := p.expect("]"); err != nil {
+			return err
+		}
+	} else if _, err := p.expectName(); err != nil {
+		return err
+	}
+	if p.is("!") {
+		return p.next()
+	}
+	return nil
+}
+
+func (p *gqlParser) parseSelectionSet() (fields []*gqlField, err error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	for !p.is("}") {
+		if p.is("...") {
+			return nil, p.errorf(ErrGraphQLUnsupported, "fragments")
+		}
+		f, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+	if len(fields) == 0 {
+		return nil, p.errorf(ErrGraphQLSyntax, "empty selection set")
+	}
+	return fields, p.next()
+}
+
+func (p *gqlParser) parseField() (f *gqlField, err error) {
+	f = &gqlField{}
+	if f.name, err = p.expectName(); err != nil {
+		return nil, err
+	}
+	if p.is(":") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		f.alias = f.name
+		if f.name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+	if p.is("(") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		for !p.is(")") {
+			arg := gqlArgument{}
+			if arg.name, err = p.expectName(); err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if arg.value, err = p.parseValue(false); err != nil {
+				return nil, err
+			}
+			f.arguments = append(f.arguments, arg)
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if p.is("@") {
+		return nil, p.errorf(ErrGraphQLUnsupported, "directives")
+	}
+	if p.is("{") {
+		if f.selections, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// parseValue returns the value as it is unmarshaled from JSON, variables are returned as gqlVariable
+func (p *gqlParser) parseValue(isConst bool) (value interface{}, err error) {
+	tok := p.tok
+	switch {
+	case tok.kind == gqlTokenPunctuator && tok.value == "$" && !isConst:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		name, err := p.expectName()
+		return gqlVariable(name), err
+	case tok.kind == gqlTokenPunctuator && tok.value == "[":
+		list := []interface{}{}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		for !p.is("]") {
+			v, err := p.parseValue(isConst)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, p.next()
+	case tok.kind == gqlTokenPunctuator && tok.value == "{":
+		obj := map[string]interface{}{}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		for !p.is("}") {
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if obj[name], err = p.parseValue(isConst); err != nil {
+				return nil, err
+			}
+		}
+		return obj, p.next()
+	case tok.kind == gqlTokenInt, tok.kind == gqlTokenFloat:
+		return json.Number(tok.value), p.next()
+	case tok.kind == gqlTokenString:
+		return tok.value, p.next()
+	case tok.kind == gqlTokenName:
+		switch tok.value {
+		case "true":
+			value = true
+		case "false":
+			value = false
+		case "null":
+			value = nil
+		default:
+			value = gqlEnum(tok.value)
+		}
+		return value, p.next()
+	}
+	return nil, p.errorf(ErrGraphQLSyntax, "unexpected %q", tok.value)
+}
+
+func (p *gqlParser) is(punctuator string) bool {
+	return p.tok.kind == gqlTokenPunctuator && p.tok.value == punctuator
+}
+
+func (p *gqlParser) expect(punctuator string) error {
+	if !p.is(punctuator) {
+		return p.errorf(ErrGraphQLSyntax, "expected %q, got %q", punctuator, p.tok.value)
+	}
+	return p.next()
+}
+
+func (p *gqlParser) expectName() (name string, err error) {
+	if p.tok.kind != gqlTokenName {
+		return "", p.errorf(ErrGraphQLSyntax, "expected name, got %q", p.tok.value)
+	}
+	name = p.tok.value
+	return name, p.next()
+}
+
+func (p *gqlParser) errorf(err error, format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at position %d", err, fmt.Sprintf(format, args...), p.tok.pos)
+}
+
+// next reads the next token. Commas, white spaces and comments are ignored
+func (p *gqlParser) next() error {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == '#' {
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' && p.src[p.pos] != '\r' {
+				p.pos++
+			}
+			continue
+		}
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' && c != ',' {
+			break
+		}
+		p.pos++
+	}
+	p.tok = gqlToken{pos: p.pos}
+	if p.pos >= len(p.src) {
+		p.tok.kind = gqlTokenEOF
+		return nil
+	}
+	start := p.pos
+	c := p.src[p.pos]
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], "..."):
+		p.pos += len("...")
+		p.tok.kind, p.tok.value = gqlTokenPunctuator, "..."
+	case strings.IndexByte("!$():=@[]{}|&", c) >= 0:
+		p.pos++
+		p.tok.kind, p.tok.value = gqlTokenPunctuator, string(c)
+	case isNameStart(c):
+		for p.pos < len(p.src) && (isNameStart(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok.kind, p.tok.value = gqlTokenName, p.src[start:p.pos]
+	case c == '-' || isDigit(c):
+		return p.readNumber()
+	case c == '"':
+		return p.readString()
+	default:
+		return p.errorf(ErrGraphQLSyntax, "unexpected character %q", c)
+	}
+	return nil
+}
+
+func (p *gqlParser) readNumber() error {
+	start := p.pos
+	p.tok.kind = gqlTokenInt
+	if p.src[p.pos] == '-' {
+		p.pos++
+	}
+	digits := func() {
+		for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+			p.pos++
+		}
+	}
+	digits()
+	if p.pos < len(p.src) && p.src[p.pos] == '.' {
+		p.pos++
+		p.tok.kind = gqlTokenFloat
+		digits()
+	}
+	if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+		p.pos++
+		p.tok.kind = gqlTokenFloat
+		if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+			p.pos++
+		}
+		digits()
+	}
+	p.tok.value = p.src[start:p.pos]
+	if !json.Valid([]byte(p.tok.value)) {
+		return p.errorf(ErrGraphQLSyntax, "invalid number %q", p.tok.value)
+	}
+	return nil
+}
+
+// readString reads the string value. GraphQL string escapes are the same as JSON ones
+func (p *gqlParser) readString() error {
+	if strings.HasPrefix(p.src[p.pos:], `"""`) {
+		return p.errorf(ErrGraphQLUnsupported, "block strings")
+	}
+	start := p.pos
+	for p.pos++; p.pos < len(p.src); p.pos++ {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos++
+		case '\n', '\r':
+			return p.errorf(ErrGraphQLSyntax, "unterminated string")
+		case '"':
+			p.pos++
+			p.tok.kind = gqlTokenString
+			if err := json.Unmarshal([]byte(p.src[start:p.pos]), &p.tok.value); err != nil {
+				return p.errorf(ErrGraphQLSyntax, "invalid string: %v", err)
+			}
+			return nil
+		}
+	}
+	return p.errorf(ErrGraphQLSyntax, "unterminated string")
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// gqlName returns the GraphQL name for the qualified name or the field name: dots are not allowed in GraphQL names
+func gqlName(name string) string {
+	return strings.ReplaceAll(name, ".", "_")
+}
+
+// resolveValue replaces variables in the argument value with their values
+func resolveValue(value interface{}, variables map[string]interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case gqlVariable:
+		res, ok := variables[string(v)]
+		if !ok {
+			return nil, fmt.Errorf("%w: variable $%s is not defined", ErrGraphQLSyntax, v)
+		}
+		return res, nil
+	case gqlEnum:
+		return string(v), nil
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, item := range v {
+			var err error
+			if res[i], err = resolveValue(item, variables); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for name, item := range v {
+			var err error
+			if res[name], err = resolveValue(item, variables); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,285 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package ihttpimpl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/collection"
+	"github.com/voedger/voedger/pkg/istructs"
+	queryprocessor "github.com/voedger/voedger/pkg/processors/query"
+)
+
+// gqlSchema is the GraphQL schema of the application.
+//
+//   - query functions are the Query fields named q_<pkg>_<name>, their results are the result definitions
+//   - documents are the Query fields named <pkg>_<name> which are read by sys.Collection query function
+//   - views are the Query fields named <pkg>_<name> which are read by sys.ReadView query function
+//   - command functions are the Mutation fields named c_<pkg>_<name>, their results are CommandResult
+//
+// Query functions which result definition depends on the arguments are skipped.
+// sys.QName and sys.Container fields are not included in the types
+type gqlSchema struct {
+	types     map[string]*gqlType
+	queries   map[string]*gqlFunction
+	mutations map[string]*gqlFunction
+	sdl       string
+}
+
+// gqlType is the GraphQL object type of the definition
+type gqlType struct {
+	name   string
+	fields []*gqlTypeField
+	byName map[string]*gqlTypeField
+}
+
+type gqlTypeField struct {
+	name string // GraphQL name
+	// field or container name of the definition
+	field string
+	// GraphQL type of the field
+	typ string
+	// data kind of the field, DataKind_null for containers
+	kind appdef.DataKind
+	// type of the container elements, nil for fields
+	elements *gqlType
+}
+
+type gqlFunction struct {
+	name     string
+	resource appdef.QName
+	args     []*gqlArg
+	byName   map[string]*gqlArg
+	result   *gqlType
+	// arguments of the function which are not GraphQL arguments, e.g. document QName for sys.Collection
+	fixedArgs map[string]interface{}
+}
+
+type gqlArg struct {
+	name string // GraphQL name
+	// params field, container name or gqlArgUnloggedArgs, gqlArgCUDs for mutations
+	field string
+	typ   string
+	// data kind of the params field, DataKind_null for JSON values
+	kind appdef.DataKind
+}
+
+func newGQLSchema(appDef appdef.IAppDef, resources istructs.IResources) *gqlSchema {
+	s := &gqlSchema{
+		types:     map[string]*gqlType{},
+		queries:   map[string]*gqlFunction{},
+		mutations: map[string]*gqlFunction{},
+	}
+	names := []appdef.QName{}
+	resources.Resources(func(name appdef.QName) { names = append(names, name) })
+	sort.Slice(names, func(i, j int) bool { return names[i].String() < names[j].String() })
+	for _, name := range names {
+		switch r := resources.QueryResource(name).(type) {
+		case istructs.IQueryFunction:
+			result := appDef.DefByName(r.ResultDef(istructs.PrepareArgs{ArgumentObject: istructs.NewNullObject()}))
+			if result == nil {
+				continue
+			}
+			f := s.newFunction("q_"+gqlName(name.String()), name, s.typeOf(result))
+			s.addParams(f, appDef.DefByName(r.ParamsDef()))
+			s.queries[f.name] = f
+		case istructs.ICommandFunction:
+			f := s.newFunction("c_"+gqlName(name.String()), name, s.commandResultType())
+			s.addParams(f, appDef.DefByName(r.ParamsDef()))
+			if r.UnloggedParamsDef() != appdef.NullQName {
+				f.addArg(&gqlArg{name: gqlArgUnloggedArgs, field: gqlArgUnloggedArgs, typ: gqlScalarJSON})
+			}
+			f.addArg(&gqlArg{name: gqlArgCUDs, field: gqlArgCUDs, typ: "[" + gqlScalarJSON + "!]"})
+			s.mutations[f.name] = f
+		}
+	}
+	_, hasCollection := resources.QueryResource(collection.QNameQueryCollection).(istructs.IQueryFunction)
+	_, hasReadView := resources.QueryResource(queryprocessor.QNameQueryReadView).(istructs.IQueryFunction)
+	appDef.Defs(func(def appdef.IDef) {
+		name := gqlName(def.QName().String())
+		switch {
+		case hasCollection && (def.Kind() == appdef.DefKind_CDoc || def.Kind() == appdef.DefKind_WDoc):
+			f := s.newFunction(name, collection.QNameQueryCollection, s.typeOf(def))
+			f.fixedArgs[collection.Field_Schema] = def.QName().String()
+			f.addArg(&gqlArg{name: collection.Field_ID, field: collection.Field_ID, typ: "ID", kind: appdef.DataKind_RecordID})
+			s.queries[f.name] = f
+		case hasReadView && def.Kind() == appdef.DefKind_ViewRecord:
+			f := s.newFunction(name, queryprocessor.QNameQueryReadView, s.typeOf(def))
+			f.fixedArgs[queryprocessor.Field_ReadView_View] = def.QName().String()
+			f.addArg(&gqlArg{name: queryprocessor.Field_ReadView_Key, field: queryprocessor.Field_ReadView_Key, typ: gqlScalarJSON + "!", kind: appdef.DataKind_string})
+			f.addArg(&gqlArg{name: queryprocessor.Field_ReadView_Range, field: queryprocessor.Field_ReadView_Range, typ: gqlScalarJSON, kind: appdef.DataKind_string})
+			f.addArg(&gqlArg{name: queryprocessor.Field_ReadView_Limit, field: queryprocessor.Field_ReadView_Limit, typ: "Int", kind: appdef.DataKind_int32})
+			s.queries[f.name] = f
+		}
+	})
+	s.sdl = s.renderSDL()
+	return s
+}
+
+func (s *gqlSchema) newFunction(name string, resource appdef.QName, result *gqlType) *gqlFunction {
+	return &gqlFunction{
+		name:      name,
+		resource:  resource,
+		byName:    map[string]*gqlArg{},
+		result:    result,
+		fixedArgs: map[string]interface{}{},
+	}
+}
+
+func (f *gqlFunction) addArg(arg *gqlArg) {
+	f.args = append(f.args, arg)
+	f.byName[arg.name] = arg
+}
+
+// addParams adds params fields as scalar arguments and params containers as JSON arguments
+func (s *gqlSchema) addParams(f *gqlFunction, params appdef.IDef) {
+	if params == nil {
+		return
+	}
+	params.Fields(func(field appdef.IField) {
+		if field.IsSys() {
+			return
+		}
+		f.addArg(&gqlArg{name: gqlName(field.Name()), field: field.Name(), typ: gqlFieldType(field), kind: field.DataKind()})
+	})
+	params.Containers(func(cont appdef.IContainer) {
+		if cont.IsSys() {
+			return
+		}
+		f.addArg(&gqlArg{name: gqlName(cont.Name()), field: cont.Name(), typ: "[" + gqlScalarJSON + "!]"})
+	})
+}
+
+// typeOf returns the type of the definition, the type is created with the types of its containers if not exists yet
+func (s *gqlSchema) typeOf(def appdef.IDef) *gqlType {
+	name := gqlName(def.QName().String())
+	if t, ok := s.types[name]; ok {
+		return t
+	}
+	t := &gqlType{name: name, byName: map[string]*gqlTypeField{}}
+	s.types[name] = t
+	addField := func(field appdef.IField) {
+		if field.Name() == appdef.SystemField_QName || field.Name() == appdef.SystemField_Container {
+			return
+		}
+		t.addField(&gqlTypeField{name: gqlName(field.Name()), field: field.Name(), typ: gqlFieldType(field), kind: field.DataKind()})
+	}
+	if def.Kind() == appdef.DefKind_ViewRecord {
+		for _, cont := range []string{appdef.SystemContainer_ViewPartitionKey, appdef.SystemContainer_ViewClusteringCols, appdef.SystemContainer_ViewValue} {
+			def.ContainerDef(cont).Fields(addField)
+		}
+		return t
+	}
+	def.Fields(addField)
+	def.Containers(func(cont appdef.IContainer) {
+		elements := s.typeOf(def.App().Def(cont.Def()))
+		typ := "[" + elements.name + "!]"
+		if cont.MinOccurs() > 0 {
+			typ += "!"
+		}
+		t.addField(&gqlTypeField{name: gqlName(cont.Name()), field: cont.Name(), typ: typ, elements: elements})
+	})
+	return t
+}
+
+func (s *gqlSchema) commandResultType() *gqlType {
+	if t, ok := s.types[gqlTypeCommandResult]; ok {
+		return t
+	}
+	t := &gqlType{name: gqlTypeCommandResult, byName: map[string]*gqlTypeField{}}
+	t.addField(&gqlTypeField{name: gqlFieldCurrentWLogOffset, field: gqlFieldCurrentWLogOffset, typ: gqlScalarInt64 + "!", kind: appdef.DataKind_int64})
+	t.addField(&gqlTypeField{name: gqlFieldNewIDs, field: gqlFieldNewIDs, typ: gqlScalarJSON})
+	s.types[t.name] = t
+	return t
+}
+
+func (t *gqlType) addField(f *gqlTypeField) {
+	t.fields = append(t.fields, f)
+	t.byName[f.name] = f
+}
+
+// renderSDL returns the schema in GraphQL schema definition language, types are sorted by names
+func (s *gqlSchema) renderSDL() string {
+	b := &strings.Builder{}
+	for _, scalar := range []string{gqlScalarInt64, gqlScalarDecimal, gqlScalarTimestamp, gqlScalarDate, gqlScalarUUID, gqlScalarJSON} {
+		fmt.Fprintf(b, "scalar %s\n", scalar)
+	}
+	renderFunctions := func(typeName string, functions map[string]*gqlFunction) {
+		if len(functions) == 0 {
+			return
+		}
+		fmt.Fprintf(b, "\ntype %s {\n", typeName)
+		for _, name := range sortedKeys(functions) {
+			f := functions[name]
+			args := make([]string, 0, len(f.args))
+			for _, arg := range f.args {
+				args = append(args, arg.name+": "+arg.typ)
+			}
+			result := f.result.name
+			if typeName == gqlTypeQuery {
+				result = "[" + result + "!]"
+			}
+			if len(args) > 0 {
+				fmt.Fprintf(b, "  %s(%s): %s\n", f.name, strings.Join(args, ", "), result)
+			} else {
+				fmt.Fprintf(b, "  %s: %s\n", f.name, result)
+			}
+		}
+		b.WriteString("}\n")
+	}
+	renderFunctions(gqlTypeQuery, s.queries)
+	renderFunctions(gqlTypeMutation, s.mutations)
+	for _, name := range sortedKeys(s.types) {
+		fmt.Fprintf(b, "\ntype %s {\n", name)
+		for _, f := range s.types[name].fields {
+			fmt.Fprintf(b, "  %s: %s\n", f.name, f.typ)
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+// gqlFieldType returns GraphQL type of the field, required fields are non-null
+func gqlFieldType(field appdef.IField) string {
+	typ := gqlScalarJSON
+	switch field.DataKind() {
+	case appdef.DataKind_int32:
+		typ = "Int"
+	case appdef.DataKind_int64:
+		typ = gqlScalarInt64
+	case appdef.DataKind_float32, appdef.DataKind_float64:
+		typ = "Float"
+	case appdef.DataKind_bytes, appdef.DataKind_string, appdef.DataKind_QName:
+		typ = "String"
+	case appdef.DataKind_bool:
+		typ = "Boolean"
+	case appdef.DataKind_RecordID:
+		typ = "ID"
+	case appdef.DataKind_decimal:
+		typ = gqlScalarDecimal
+	case appdef.DataKind_timestamp:
+		typ = gqlScalarTimestamp
+	case appdef.DataKind_date:
+		typ = gqlScalarDate
+	case appdef.DataKind_UUID:
+		typ = gqlScalarUUID
+	}
+	if field.Required() {
+		typ += "!"
+	}
+	return typ
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
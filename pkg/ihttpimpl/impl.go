@@ -19,6 +19,7 @@ import (
 	"strconv"
 	"sync"
 
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
 	coreutils "github.com/voedger/voedger/pkg/utils"
 
@@ -164,6 +165,18 @@ type msgDeployAppPartition struct {
 	queryHandler   ibus.ISender
 }
 
+type msgDeployGraphQL struct {
+	app            istructs.AppQName
+	appDef         appdef.IAppDef
+	resources      istructs.IResources
+	commandHandler ibus.ISender
+	queryHandler   ibus.ISender
+}
+
+type msgDeployOpenAPI struct {
+	app istructs.IAppStructs
+}
+
 type msgCreateSubRoute struct {
 	resource string
 	subRoute string
@@ -191,6 +204,23 @@ func (api *processorAPI) DeployAppPartition(ctx context.Context, app istructs.Ap
 	return err
 }
 
+func (api *processorAPI) DeployGraphQL(ctx context.Context, app istructs.AppQName, appDef appdef.IAppDef, resources istructs.IResources, commandHandler, queryHandler ibus.ISender) (err error) {
+	msg := msgDeployGraphQL{
+		app:            app,
+		appDef:         appDef,
+		resources:      resources,
+		commandHandler: commandHandler,
+		queryHandler:   queryHandler,
+	}
+	_, _, err = api.senderHttp.Send(ctx, msg, ibus.NullHandler)
+	return err
+}
+
+func (api *processorAPI) DeployOpenAPI(ctx context.Context, app istructs.IAppStructs) (err error) {
+	_, _, err = api.senderHttp.Send(ctx, msgDeployOpenAPI{app: app}, ibus.NullHandler)
+	return err
+}
+
 func (api *processorAPI) ExportApi(resource string, subRoute string) (err error) {
 	msg := msgCreateSubRoute{
 		resource: resource,
@@ -285,6 +315,42 @@ func (hs *httpProcessor) Receiver(_ context.Context, request interface{}, _ ibus
 		route.HandlerFunc(handleAppPart(v.commandHandler, v.queryHandler))
 		return ibus.NewResult(nil, nil, "", "")
 
+	case msgDeployGraphQL:
+		// <cluster-domain>/api/<AppQName.owner>/<AppQName.name>/<wsid>/graphql
+		route, err := hs.router.Path(
+			fmt.Sprintf("/api/%s/%s/[0-9]+/%s", regexp.QuoteMeta(v.app.Owner()), regexp.QuoteMeta(v.app.Name()), graphQLPath),
+		)
+		if err != nil {
+			return ibus.NewResult(nil, err, "", "")
+		}
+		route.HandlerFunc(newGQLHandler(v.app, v.appDef, v.resources, v.commandHandler, v.queryHandler).ServeHTTP)
+		logger.Info("GraphQL handler added for app", v.app)
+		return ibus.NewResult(nil, nil, "", "")
+
+	case msgDeployOpenAPI:
+		// <cluster-domain>/api/<AppQName.owner>/<AppQName.name>/openapi.json
+		doc, err := newOpenAPI(v.app)
+		if err != nil {
+			return ibus.NewResult(nil, err, "", "")
+		}
+		body, err := json.Marshal(doc)
+		if err != nil {
+			return ibus.NewResult(nil, err, "", "")
+		}
+		appQName := v.app.AppQName()
+		route, err := hs.router.Path(
+			fmt.Sprintf("/api/%s/%s/%s", regexp.QuoteMeta(appQName.Owner()), regexp.QuoteMeta(appQName.Name()), regexp.QuoteMeta(openAPIPath)),
+		)
+		if err != nil {
+			return ibus.NewResult(nil, err, "", "")
+		}
+		route.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+			wr.Header().Set(coreutils.ContentType, coreutils.ApplicationJSON)
+			_, _ = wr.Write(body)
+		})
+		logger.Info("OpenAPI handler added for app", appQName)
+		return ibus.NewResult(nil, nil, "", "")
+
 	case msgDeployStaticContent:
 		resource := staticPath + v.resource
 		f := func(wr http.ResponseWriter, req *http.Request) {
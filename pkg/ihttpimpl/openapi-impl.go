@@ -0,0 +1,372 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package ihttpimpl
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+// descrPackage is the package description returned by istructs.IAppStructs.DescribePackage.
+// The description is read through JSON, kinds are the names of the kind constants
+type descrPackage struct {
+	Name       string
+	Defs       map[string]*descrDef
+	Resources  map[string]*descrResource
+	RateLimits map[string][]*descrRateLimit
+}
+
+type descrDef struct {
+	Name       string
+	Kind       string
+	Fields     []*descrField
+	Containers []*descrContainer
+}
+
+type descrField struct {
+	Name       string
+	Kind       string
+	Required   bool
+	Verifiable bool
+}
+
+type descrContainer struct {
+	Name      string
+	Type      string
+	MinOccurs appdef.Occurs
+	MaxOccurs appdef.Occurs
+}
+
+type descrResource struct {
+	Kind    string
+	Name    string
+	Command *descrFunction
+	Query   *descrFunction
+}
+
+type descrFunction struct {
+	Params   *string
+	Unlogged *string
+	Result   *string
+}
+
+type descrRateLimit struct {
+	Kind                  string
+	Period                time.Duration
+	MaxAllowedPerDuration uint32
+}
+
+// openAPIObject is the JSON object of the OpenAPI document, keys are sorted on marshaling
+type openAPIObject = map[string]interface{}
+
+// newOpenAPI returns OpenAPI 3 document which describes the command and query functions of the application:
+//   - function params, unlogged params and results are the component schemas named by the definitions QNames
+//   - CUD schema describes the records which can be created or updated by "cuds" of the commands
+//   - errors are described by Error schema, see coreutils.SysError
+func newOpenAPI(app istructs.IAppStructs) (doc openAPIObject, err error) {
+	pkgNames := app.DescribePackageNames()
+	sort.Strings(pkgNames)
+	pkgs := make([]*descrPackage, 0, len(pkgNames))
+	defs := map[string]*descrDef{}
+	for _, name := range pkgNames {
+		bb, err := json.Marshal(app.DescribePackage(name))
+		if err != nil {
+			return nil, err
+		}
+		pkg := &descrPackage{}
+		if err := json.Unmarshal(bb, pkg); err != nil {
+			return nil, fmt.Errorf("package %s description: %w", name, err)
+		}
+		for n, def := range pkg.Defs {
+			defs[n] = def
+		}
+		pkgs = append(pkgs, pkg)
+	}
+
+	schemas := openAPIObject{}
+	cudRecords := []interface{}{}
+	for _, name := range sortedKeys(defs) {
+		def := defs[name]
+		switch def.Kind {
+		case appdef.DefKind_ViewRecord.String(), appdef.DefKind_ViewRecord_PartitionKey.String(),
+			appdef.DefKind_ViewRecord_ClusteringColumns.String(), appdef.DefKind_ViewRecord_Value.String():
+			continue
+		case appdef.DefKind_CDoc.String(), appdef.DefKind_CRecord.String(), appdef.DefKind_WDoc.String(), appdef.DefKind_WRecord.String():
+			cudRecords = append(cudRecords, openAPIRef(name))
+		}
+		schemas[name] = openAPIDefSchema(def)
+	}
+	schemas[openAPISchemaCUD] = openAPIObject{
+		"type":     "object",
+		"required": []string{"fields"},
+		"properties": openAPIObject{
+			appdef.SystemField_ID: openAPIObject{
+				"type": "integer", "format": "int64",
+				"description": "ID of the record to update, omitted to create the record",
+			},
+			"fields": openAPIObject{
+				"description": "Fields of the record. To create the record sys.ID (raw ID) and sys.QName are required, " +
+					"to update the record only the changed fields are specified",
+				"anyOf": cudRecords,
+			},
+		},
+	}
+	schemas[openAPISchemaError] = openAPIObject{
+		"type": "object",
+		"properties": openAPIObject{
+			"sys.Error": openAPIObject{
+				"type":     "object",
+				"required": []string{"HTTPStatus", "Message"},
+				"properties": openAPIObject{
+					"HTTPStatus": openAPIObject{"type": "integer"},
+					"Message":    openAPIObject{"type": "string"},
+					"QName":      openAPIObject{"type": "string"},
+					"Data":       openAPIObject{"type": "string"},
+				},
+			},
+		},
+	}
+	schemas[openAPISchemaCommandResult] = openAPIObject{
+		"type":     "object",
+		"required": []string{"CurrentWLogOffset"},
+		"properties": openAPIObject{
+			"CurrentWLogOffset": openAPIObject{"type": "integer", "format": "int64"},
+			"NewIDs": openAPIObject{
+				"type":                 "object",
+				"description":          "IDs of the created records by their raw IDs",
+				"additionalProperties": openAPIObject{"type": "integer", "format": "int64"},
+			},
+		},
+	}
+	schemas[openAPISchemaQueryResult] = openAPIObject{
+		"type": "object",
+		"properties": openAPIObject{
+			"sections": openAPIObject{
+				"type": "array",
+				"items": openAPIObject{
+					"type": "object",
+					"properties": openAPIObject{
+						"type": openAPIObject{"type": "string"},
+						"elements": openAPIObject{
+							"type":        "array",
+							"description": "Rows of the result. Row contains the values of the requested elements fields in the request order",
+							"items":       openAPIObject{"type": "array", "items": openAPIObject{}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	appQName := app.AppQName()
+	paths := openAPIObject{}
+	for _, pkg := range pkgs {
+		for _, name := range sortedKeys(pkg.Resources) {
+			r := pkg.Resources[name]
+			var op openAPIObject
+			switch {
+			case r.Command != nil:
+				op = openAPICommand(r)
+				name = "c." + name
+			case r.Query != nil:
+				op = openAPIQuery(r)
+				name = "q." + name
+			default:
+				continue
+			}
+			op["tags"] = []string{pkg.Name}
+			op["operationId"] = name
+			if limits := pkg.RateLimits[r.Name]; len(limits) > 0 {
+				op["x-rate-limits"] = limits
+				op["responses"].(openAPIObject)["429"] = openAPIErrorResponse("Rate limit exceeded")
+			}
+			paths[fmt.Sprintf("/api/%s/%s/{wsid}/%s", appQName.Owner(), appQName.Name(), name)] = openAPIObject{"post": op}
+		}
+	}
+
+	return openAPIObject{
+		"openapi": openAPIVersion,
+		"info": openAPIObject{
+			"title":   appQName.String(),
+			"version": openAPIDocVersion,
+		},
+		"paths": paths,
+		"components": openAPIObject{
+			"schemas": schemas,
+			"securitySchemes": openAPIObject{
+				openAPISecurityBearer: openAPIObject{"type": "http", "scheme": "bearer"},
+			},
+		},
+		"security": []interface{}{openAPIObject{openAPISecurityBearer: []string{}}},
+	}, nil
+}
+
+func openAPICommand(r *descrResource) openAPIObject {
+	props := openAPIObject{
+		"cuds": openAPIObject{"type": "array", "items": openAPIRef(openAPISchemaCUD)},
+	}
+	required := []string{}
+	if r.Command.Params != nil {
+		props["args"] = openAPIRef(*r.Command.Params)
+		required = append(required, "args")
+	}
+	if r.Command.Unlogged != nil {
+		props["unloggedArgs"] = openAPIRef(*r.Command.Unlogged)
+	}
+	body := openAPIObject{"type": "object", "properties": props}
+	if len(required) > 0 {
+		body["required"] = required
+	}
+	op := openAPIOperation(r, body, openAPIRef(openAPISchemaCommandResult))
+	op["responses"].(openAPIObject)["404"] = openAPIErrorResponse("Record to update not found")
+	if r.Command.Result != nil {
+		op["x-result-schema"] = openAPIRef(*r.Command.Result)
+	}
+	return op
+}
+
+func openAPIQuery(r *descrResource) openAPIObject {
+	props := openAPIObject{
+		"elements": openAPIObject{
+			"type": "array",
+			"items": openAPIObject{
+				"type": "object",
+				"properties": openAPIObject{
+					"path":   openAPIObject{"type": "string", "description": "Path of the result container, empty for the result itself"},
+					"fields": openAPIObject{"type": "array", "items": openAPIObject{"type": "string"}},
+					"refs":   openAPIObject{"type": "array", "items": openAPIObject{"type": "array", "items": openAPIObject{"type": "string"}}},
+				},
+			},
+		},
+		"filters":   openAPIObject{"type": "array", "items": openAPIObject{"type": "object"}},
+		"orderBy":   openAPIObject{"type": "array", "items": openAPIObject{"type": "object", "properties": openAPIObject{"field": openAPIObject{"type": "string"}, "desc": openAPIObject{"type": "boolean"}}}},
+		"aggregate": openAPIObject{"type": "object"},
+		"count":     openAPIObject{"type": "integer", "format": "int64"},
+		"startFrom": openAPIObject{"type": "integer", "format": "int64"},
+		"cursor":    openAPIObject{"type": "string", "description": "Continuation token from the cursor section of the previous page, requires orderBy"},
+	}
+	if r.Query.Params != nil {
+		props["args"] = openAPIRef(*r.Query.Params)
+	}
+	op := openAPIOperation(r, openAPIObject{"type": "object", "properties": props}, openAPIRef(openAPISchemaQueryResult))
+	op["responses"].(openAPIObject)["503"] = openAPIErrorResponse("Lazy projection is not active")
+	if r.Query.Result != nil {
+		op["description"] = fmt.Sprintf("Result rows fields are the fields of %s", *r.Query.Result)
+		op["x-result-schema"] = openAPIRef(*r.Query.Result)
+	}
+	return op
+}
+
+func openAPIOperation(r *descrResource, body openAPIObject, result openAPIObject) openAPIObject {
+	return openAPIObject{
+		"summary": r.Name,
+		"parameters": []interface{}{openAPIObject{
+			"name":     "wsid",
+			"in":       "path",
+			"required": true,
+			"schema":   openAPIObject{"type": "integer", "format": "int64"},
+		}},
+		"requestBody": openAPIObject{
+			"required": true,
+			"content":  openAPIObject{coreutils.ApplicationJSON: openAPIObject{"schema": body}},
+		},
+		"responses": openAPIObject{
+			"200": openAPIObject{
+				"description": "OK",
+				"content":     openAPIObject{coreutils.ApplicationJSON: openAPIObject{"schema": result}},
+			},
+			"400": openAPIErrorResponse(http.StatusText(http.StatusBadRequest)),
+			"401": openAPIErrorResponse(http.StatusText(http.StatusUnauthorized)),
+			"403": openAPIErrorResponse(http.StatusText(http.StatusForbidden)),
+			"500": openAPIErrorResponse(http.StatusText(http.StatusInternalServerError)),
+		},
+	}
+}
+
+func openAPIErrorResponse(description string) openAPIObject {
+	return openAPIObject{
+		"description": description,
+		"content":     openAPIObject{coreutils.ApplicationJSON: openAPIObject{"schema": openAPIRef(openAPISchemaError)}},
+	}
+}
+
+// openAPIDefSchema returns the schema of the definition. Containers are the arrays of the container definitions
+func openAPIDefSchema(def *descrDef) openAPIObject {
+	props := openAPIObject{}
+	required := []string{}
+	for _, f := range def.Fields {
+		props[f.Name] = openAPIFieldSchema(f)
+		if f.Required {
+			required = append(required, f.Name)
+		}
+	}
+	for _, c := range def.Containers {
+		cont := openAPIObject{"type": "array", "items": openAPIRef(c.Type)}
+		if c.MinOccurs > 0 {
+			cont["minItems"] = c.MinOccurs
+			required = append(required, c.Name)
+		}
+		if c.MaxOccurs != appdef.Occurs_Unbounded {
+			cont["maxItems"] = c.MaxOccurs
+		}
+		props[c.Name] = cont
+	}
+	schema := openAPIObject{
+		"type":        "object",
+		"description": strings.TrimPrefix(def.Kind, "DefKind_"),
+		"properties":  props,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func openAPIFieldSchema(f *descrField) openAPIObject {
+	if f.Verifiable {
+		return openAPIObject{"type": "string", "description": "Verification token of the verified value"}
+	}
+	switch f.Kind {
+	case appdef.DataKind_int32.String():
+		return openAPIObject{"type": "integer", "format": "int32"}
+	case appdef.DataKind_int64.String():
+		return openAPIObject{"type": "integer", "format": "int64"}
+	case appdef.DataKind_float32.String():
+		return openAPIObject{"type": "number", "format": "float"}
+	case appdef.DataKind_float64.String():
+		return openAPIObject{"type": "number", "format": "double"}
+	case appdef.DataKind_bytes.String():
+		return openAPIObject{"type": "string", "format": "byte"}
+	case appdef.DataKind_string.String():
+		return openAPIObject{"type": "string"}
+	case appdef.DataKind_QName.String():
+		return openAPIObject{"type": "string", "description": "Qualified name <pkg>.<entity>"}
+	case appdef.DataKind_bool.String():
+		return openAPIObject{"type": "boolean"}
+	case appdef.DataKind_RecordID.String():
+		return openAPIObject{"type": "integer", "format": "int64", "description": "Record ID"}
+	case appdef.DataKind_decimal.String():
+		return openAPIObject{"type": "number"}
+	case appdef.DataKind_timestamp.String():
+		return openAPIObject{"type": "string", "format": "date-time"}
+	case appdef.DataKind_date.String():
+		return openAPIObject{"type": "string", "format": "date"}
+	case appdef.DataKind_UUID.String():
+		return openAPIObject{"type": "string", "format": "uuid"}
+	}
+	return openAPIObject{"type": "object"}
+}
+
+func openAPIRef(schema string) openAPIObject {
+	return openAPIObject{"$ref": "#/components/schemas/" + schema}
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package ihttpimpl
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/collection"
+	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorageimpl"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+)
+
+func TestOpenAPI(t *testing.T) {
+	require := require.New(t)
+	testApp := setUp(t)
+	defer tearDown(testApp)
+
+	qNameArticles := appdef.NewQName("test", "Articles")
+	adb := appdef.New()
+	adb.AddStruct(appdef.NewQName("test", "Article"), appdef.DefKind_CDoc).
+		AddField("name", appdef.DataKind_string, true).
+		AddField("price", appdef.DataKind_int64, false).
+		AddContainer("tags", appdef.NewQName("test", "ArticleTag"), appdef.Occurs(0), appdef.Occurs(3))
+	adb.AddStruct(appdef.NewQName("test", "ArticleTag"), appdef.DefKind_CRecord).
+		AddField("name", appdef.DataKind_string, true)
+	adb.AddStruct(appdef.NewQName("test", "ArticlesParams"), appdef.DefKind_Object).
+		AddField("Category", appdef.DataKind_int32, true)
+	adb.AddStruct(appdef.NewQName("test", "OrderParams"), appdef.DefKind_Object).
+		AddField("Article", appdef.DataKind_RecordID, true).
+		AddVerifiedField("Email", appdef.DataKind_string, false, appdef.VerificationKind_EMail)
+	adb.AddStruct(appdef.NewQName("test", "OrderUnloggedParams"), appdef.DefKind_Object).
+		AddField("Card", appdef.DataKind_string, true)
+
+	cfgs := make(istructsmem.AppConfigsType)
+	cfg := cfgs.AddConfig(istructs.AppQName_test1_app1, adb)
+	cfg.Resources.Add(istructsmem.NewQueryFunction(qNameArticles, appdef.NewQName("test", "ArticlesParams"),
+		appdef.NewQName("test", "Article"), istructsmem.NullQueryExec))
+	cfg.Resources.Add(istructsmem.NewCommandFunction(appdef.NewQName("test", "Order"), appdef.NewQName("test", "OrderParams"),
+		appdef.NewQName("test", "OrderUnloggedParams"), appdef.NullQName, istructsmem.NullCommandExec))
+	cfg.FunctionRateLimits.AddWorkspaceLimit(qNameArticles, istructs.RateLimit{Period: time.Minute, MaxAllowedPerDuration: 10})
+	collection.Provide(cfg, adb)
+
+	asp := istructsmem.Provide(cfgs, iratesce.TestBucketsFactory, payloads.TestAppTokensFactory(itokensjwt.TestTokensJWT()), istorageimpl.Provide(istorage.ProvideMem()))
+	app, err := asp.AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	require.NoError(testApp.api.DeployOpenAPI(testApp.ctx, app))
+
+	doc := map[string]interface{}{}
+	require.NoError(json.Unmarshal(testApp.get("/api/test1/app1/openapi.json"), &doc))
+	// get returns the document value by the space separated keys path
+	get := func(path string) interface{} {
+		var res interface{} = doc
+		for _, name := range strings.Fields(path) {
+			m, ok := res.(map[string]interface{})
+			require.True(ok, path)
+			res, ok = m[name]
+			require.True(ok, path)
+		}
+		return res
+	}
+	schema := func(name string) string { return "#/components/schemas/" + name }
+
+	require.Equal("3.0.3", doc["openapi"])
+	require.Equal("test1/app1", get("info title"))
+
+	t.Run("Should describe command", func(t *testing.T) {
+		op := "paths /api/test1/app1/{wsid}/c.test.Order post "
+		body := op + "requestBody content application/json schema "
+		require.Equal(schema("test.OrderParams"), get(body+"properties args $ref"))
+		require.Equal(schema("test.OrderUnloggedParams"), get(body+"properties unloggedArgs $ref"))
+		require.Equal(schema("CUD"), get(body+"properties cuds items $ref"))
+		require.Equal(schema("CommandResult"), get(op+"responses 200 content application/json schema $ref"))
+		require.Equal(schema("Error"), get(op+"responses 403 content application/json schema $ref"))
+		require.Equal("wsid", get(op + "parameters").([]interface{})[0].(map[string]interface{})["name"])
+	})
+	t.Run("Should describe query", func(t *testing.T) {
+		op := "paths /api/test1/app1/{wsid}/q.test.Articles post "
+		require.Equal(schema("test.ArticlesParams"), get(op+"requestBody content application/json schema properties args $ref"))
+		require.Equal(schema("QueryResult"), get(op+"responses 200 content application/json schema $ref"))
+		require.Equal(schema("test.Article"), get(op+"x-result-schema $ref"))
+		require.Equal(schema("Error"), get(op+"responses 429 content application/json schema $ref"))
+		require.Len(get(op+"x-rate-limits"), 1)
+	})
+	t.Run("Should describe definitions", func(t *testing.T) {
+		article := "components schemas test.Article "
+		require.Equal([]interface{}{"sys.QName", "sys.ID", "name"}, get(article+"required"))
+		require.Equal("int64", get(article+"properties price format"))
+		require.Equal(schema("test.ArticleTag"), get(article+"properties tags items $ref"))
+		require.Equal(float64(3), get(article+"properties tags maxItems"))
+		require.Equal("string", get("components schemas test.OrderParams properties Email type"))
+		require.Contains(get("components schemas CUD properties fields anyOf"), map[string]interface{}{"$ref": schema("test.Article")})
+		require.Contains(get("components schemas CUD properties fields anyOf"), map[string]interface{}{"$ref": schema("test.ArticleTag")})
+	})
+	t.Run("Should refer existing schemas only", func(t *testing.T) {
+		schemas := get("components schemas").(map[string]interface{})
+		var check func(v interface{})
+		check = func(v interface{}) {
+			switch v := v.(type) {
+			case map[string]interface{}:
+				if ref, ok := v["$ref"]; ok {
+					require.Contains(schemas, strings.TrimPrefix(ref.(string), schema("")))
+				}
+				for _, item := range v {
+					check(item)
+				}
+			case []interface{}:
+				for _, item := range v {
+					check(item)
+				}
+			}
+		}
+		check(doc)
+	})
+}
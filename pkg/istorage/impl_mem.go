@@ -9,10 +9,11 @@ import (
 	"context"
 	"sort"
 	"sync"
+	"time"
 )
 
 type appStorageFactory struct {
-	storages map[string]map[string]map[string][]byte
+	storages map[string]*appStorage
 }
 
 func (s *appStorageFactory) AppStorage(appName SafeAppName) (IAppStorage, error) {
@@ -20,59 +21,165 @@ func (s *appStorageFactory) AppStorage(appName SafeAppName) (IAppStorage, error)
 	if !ok {
 		return nil, ErrStorageDoesNotExist
 	}
-	return &appStorage{storage: storage}, nil
+	return storage, nil
 }
 
 func (s *appStorageFactory) Init(appName SafeAppName) error {
 	if _, ok := s.storages[appName.String()]; ok {
 		return ErrStorageAlreadyExists
 	}
-	s.storages[appName.String()] = map[string]map[string][]byte{}
+	s.storages[appName.String()] = &appStorage{
+		storage:   map[string]map[string][]byte{},
+		deadlines: map[string]map[string]time.Time{},
+	}
 	return nil
 }
 
 type appStorage struct {
-	storage map[string]map[string][]byte
-	lock    sync.RWMutex
+	storage   map[string]map[string][]byte
+	deadlines map[string]map[string]time.Time // expiration time of records which are put with TTL
+	lock      sync.RWMutex
+}
+
+// returns is record expired. Must be called under lock
+func (s *appStorage) expired(pKey, cCols string) bool {
+	if d, ok := s.deadlines[pKey]; ok {
+		if deadline, ok := d[cCols]; ok {
+			return !time.Now().Before(deadline)
+		}
+	}
+	return false
+}
+
+// returns record value if it exists and is not expired. Must be called under lock
+func (s *appStorage) get(pKey, cCols string) (value []byte, ok bool) {
+	if value, ok = s.storage[pKey][cCols]; ok && s.expired(pKey, cCols) {
+		return nil, false
+	}
+	return value, ok
+}
+
+// puts record and sets its expiration time, zero ttl means record never expires. Must be called under lock
+func (s *appStorage) put(pKey, cCols string, value []byte, ttl time.Duration) {
+	p := s.storage[pKey]
+	if p == nil {
+		p = make(map[string][]byte)
+		s.storage[pKey] = p
+	}
+	p[cCols] = copySlice(value)
+
+	d := s.deadlines[pKey]
+	if ttl <= 0 {
+		if d != nil {
+			delete(d, cCols)
+		}
+		return
+	}
+	if d == nil {
+		d = make(map[string]time.Time)
+		s.deadlines[pKey] = d
+	}
+	d[cCols] = time.Now().Add(ttl)
+}
+
+// deletes record. Must be called under lock
+func (s *appStorage) delete(pKey, cCols string) {
+	delete(s.storage[pKey], cCols)
+	delete(s.deadlines[pKey], cCols)
 }
 
 func (s *appStorage) Put(pKey []byte, cCols []byte, value []byte) (err error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	p := s.storage[string(pKey)]
-	if p == nil {
-		p = make(map[string][]byte)
-		s.storage[string(pKey)] = p
+	s.put(string(pKey), string(cCols), value, 0)
+	return
+}
+
+func (s *appStorage) PutWithTTL(pKey []byte, cCols []byte, value []byte, ttl time.Duration) (err error) {
+	if ttl <= 0 {
+		return ErrInvalidTTL
 	}
-	p[string(cCols)] = copySlice(value)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.put(string(pKey), string(cCols), value, ttl)
 	return
 }
 
 func (s *appStorage) PutBatch(items []BatchItem) (err error) {
 	for _, item := range items {
-		err = s.Put(item.PKey, item.CCols, item.Value)
-		if err != nil {
-			return err
+		if item.TTL < 0 {
+			return ErrInvalidTTL
 		}
 	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, item := range items {
+		s.put(string(item.PKey), string(item.CCols), item.Value, item.TTL)
+	}
 	return nil
 }
 
+func (s *appStorage) Delete(pKey []byte, cCols []byte) (err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.delete(string(pKey), string(cCols))
+	return nil
+}
+
+func (s *appStorage) DeleteRange(pKey []byte, startCCols, finishCCols []byte) (err error) {
+	if (len(startCCols) > 0) && (len(finishCCols) > 0) && (bytes.Compare(startCCols, finishCCols) >= 0) {
+		return nil // absurd range
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for col := range s.storage[string(pKey)] {
+		if inRange([]byte(col), startCCols, finishCCols) {
+			s.delete(string(pKey), col)
+		}
+	}
+	return nil
+}
+
+// returns is cCols in range [startCCols, finishCCols). Empty bound means opened range
+func inRange(cCols, startCCols, finishCCols []byte) bool {
+	if (len(startCCols) > 0) && (bytes.Compare(startCCols, cCols) > 0) {
+		return false
+	}
+	if (len(finishCCols) > 0) && (bytes.Compare(cCols, finishCCols) >= 0) {
+		return false
+	}
+	return true
+}
+
+func (s *appStorage) InsertIfNotExists(pKey []byte, cCols []byte, value []byte) (ok bool, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, exists := s.get(string(pKey), string(cCols)); exists {
+		return false, nil
+	}
+	s.put(string(pKey), string(cCols), value, 0)
+	return true, nil
+}
+
+func (s *appStorage) CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte) (ok bool, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	value, exists := s.get(string(pKey), string(cCols))
+	if !exists || !bytes.Equal(value, oldValue) {
+		return false, nil
+	}
+	s.put(string(pKey), string(cCols), newValue, 0)
+	return true, nil
+}
+
 func (s *appStorage) readPartSort(ctx context.Context, part map[string][]byte, startCCols, finishCCols []byte) (sortKeys []string) {
 	sortKeys = make([]string, 0)
 	for col := range part {
 		if ctx.Err() != nil {
 			return nil
 		}
-		if len(startCCols) > 0 {
-			if bytes.Compare(startCCols, []byte(col)) > 0 {
-				continue
-			}
-		}
-		if len(finishCCols) > 0 {
-			if bytes.Compare([]byte(col), finishCCols) >= 0 {
-				continue
-			}
+		if !inRange([]byte(col), startCCols, finishCCols) {
+			continue
 		}
 		sortKeys = append(sortKeys, col)
 	}
@@ -102,6 +209,9 @@ func (s *appStorage) readPart(ctx context.Context, pKey []byte, startCCols, fini
 		if ctx.Err() != nil {
 			return nil, nil
 		}
+		if s.expired(string(pKey), col) {
+			continue
+		}
 		cCols = append(cCols, copySlice([]byte(col)))
 		values = append(values, copySlice(v[col]))
 	}
@@ -132,11 +242,7 @@ func (s *appStorage) Read(ctx context.Context, pKey []byte, startCCols, finishCC
 func (s *appStorage) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
-	p, ok := s.storage[string(pKey)]
-	if !ok {
-		return
-	}
-	viewRecord, ok := p[string(cCols)]
+	viewRecord, ok := s.get(string(pKey), string(cCols))
 	if !ok {
 		return
 	}
@@ -145,11 +251,17 @@ func (s *appStorage) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err
 }
 
 func (s *appStorage) GetBatch(pKey []byte, items []GetBatchItem) (err error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 	for i := range items {
-		items[i].Ok, err = s.Get(pKey, items[i].CCols, items[i].Data)
-		if err != nil {
-			return
+		viewRecord, ok := s.get(string(pKey), string(items[i].CCols))
+		items[i].Ok = ok
+		items[i].Deadline = time.Time{}
+		if !ok {
+			continue
 		}
+		*items[i].Data = append((*items[i].Data)[0:0], viewRecord...)
+		items[i].Deadline = s.deadlines[string(pKey)][string(items[i].CCols)]
 	}
 	return
 }
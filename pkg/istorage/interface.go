@@ -7,6 +7,7 @@ package istorage
 
 import (
 	"context"
+	"time"
 
 	"github.com/voedger/voedger/pkg/istructs"
 )
@@ -41,8 +42,34 @@ type IAppStorage interface {
 	// @ConcurrentAccess
 	Put(pKey []byte, cCols []byte, value []byte) (err error)
 
+	// Puts value which expires after ttl. Expired record is not returned by Get, GetBatch and Read
+	// ttl must be positive, drivers may round it up to whole seconds
+	// Subsequent Put without TTL makes record persistent
+	// @ConcurrentAccess
+	PutWithTTL(pKey []byte, cCols []byte, value []byte, ttl time.Duration) (err error)
+
+	// Items with non-zero TTL expire, see PutWithTTL
 	PutBatch(items []BatchItem) (err error)
 
+	// Deletes record. Deleting not existing record is not an error
+	// @ConcurrentAccess
+	Delete(pKey []byte, cCols []byte) (err error)
+
+	// Deletes records from partition in clustering columns range, range bounds are the same as for Read
+	// If both startCCols and finishCCols are empty then whole partition is deleted
+	// @ConcurrentAccess
+	DeleteRange(pKey []byte, startCCols, finishCCols []byte) (err error)
+
+	// Puts value only if record with specified pKey and cCols does not exist
+	// ok == false means that record already exists, its value is not changed
+	// @ConcurrentAccess
+	InsertIfNotExists(pKey []byte, cCols []byte, value []byte) (ok bool, err error)
+
+	// Replaces record value with newValue only if current value equals to oldValue
+	// ok == false means that record does not exist or its value is not equal to oldValue
+	// @ConcurrentAccess
+	CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte) (ok bool, err error)
+
 	// len(cCols) may be 0, in this case the record which was written with zero len(cCols) will be returned
 	// ok == false means that viewrecord does not exist
 	// @ConcurrentAccess
@@ -50,7 +77,8 @@ type IAppStorage interface {
 
 	// get and appends result to items[i].Data
 	// items[i].Ok==false means record is not found
-	// items[i].Ok & Data are undefined in case of error
+	// items[i].Deadline is the expiration time of the record put with TTL, zero if record never expires
+	// items[i].Ok, Data & Deadline are undefined in case of error
 	GetBatch(pKey []byte, items []GetBatchItem) (err error)
 
 	// startCCols can be empty (nil or zero len), in this case reads from start of partition.
@@ -66,10 +94,12 @@ type BatchItem struct {
 	PKey  []byte
 	CCols []byte
 	Value []byte
+	TTL   time.Duration // zero means record never expires
 }
 
 type GetBatchItem struct {
-	CCols []byte
-	Ok    bool
-	Data  *[]byte
+	CCols    []byte
+	Ok       bool
+	Data     *[]byte
+	Deadline time.Time
 }
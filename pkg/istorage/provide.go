@@ -5,5 +5,5 @@
 package istorage
 
 func ProvideMem() IAppStorageFactory {
-	return &appStorageFactory{storages: map[string]map[string]map[string][]byte{}}
+	return &appStorageFactory{storages: map[string]*appStorage{}}
 }
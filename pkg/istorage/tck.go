@@ -6,8 +6,12 @@ package istorage
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -26,6 +30,10 @@ func TechnologyCompatibilityKit_Storage(t *testing.T, storage IAppStorage) {
 	t.Run("TestAppStorage_GetPutRead", func(t *testing.T) { testAppStorage_GetPutRead(t, storage) })
 	t.Run("TestAppStorage_PutBatch", func(t *testing.T) { testAppStorage_PutBatch(t, storage) })
 	t.Run("TestAppStorage_GetBatch", func(t *testing.T) { testAppStorage_GetBatch(t, storage) })
+	t.Run("TestAppStorage_InsertIfNotExists", func(t *testing.T) { testAppStorage_InsertIfNotExists(t, storage) })
+	t.Run("TestAppStorage_CompareAndSwap", func(t *testing.T) { testAppStorage_CompareAndSwap(t, storage) })
+	t.Run("TestAppStorage_Delete", func(t *testing.T) { testAppStorage_Delete(t, storage) })
+	t.Run("TestAppStorage_TTL", func(t *testing.T) { testAppStorage_TTL(t, storage) })
 }
 
 func testAppStorageFactory(t *testing.T, sf IAppStorageFactory, testAppQName istructs.AppQName) IAppStorage {
@@ -608,3 +616,340 @@ func testAppStorage_GetBatch(t *testing.T, storage IAppStorage) {
 	})
 
 }
+
+// nolint
+func testAppStorage_InsertIfNotExists(t *testing.T, storage IAppStorage) {
+	t.Run("Should insert not existing record only", func(t *testing.T) {
+		require := require.New(t)
+		pKey := []byte("insert")
+
+		ok, err := storage.InsertIfNotExists(pKey, []byte("Beverages"), []byte("Cola"))
+		require.NoError(err)
+		require.True(ok)
+
+		ok, err = storage.InsertIfNotExists(pKey, []byte("Beverages"), []byte("Pepsi"))
+		require.NoError(err)
+		require.False(ok)
+
+		data := make([]byte, 0)
+		ok, err = storage.Get(pKey, []byte("Beverages"), &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal([]byte("Cola"), data)
+
+		t.Run("nil clustering columns must be same as zero-length", func(t *testing.T) {
+			ok, err := storage.InsertIfNotExists(pKey, nil, []byte("Tea"))
+			require.NoError(err)
+			require.True(ok)
+
+			ok, err = storage.InsertIfNotExists(pKey, []byte{}, []byte("Coffee"))
+			require.NoError(err)
+			require.False(ok)
+		})
+	})
+
+	t.Run("Should insert only once under contention", func(t *testing.T) {
+		require := require.New(t)
+		const workers = 16
+		pKey := []byte("insert-contention")
+
+		wg := sync.WaitGroup{}
+		winners := make(chan string, workers)
+		errs := make(chan error, workers)
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				value := fmt.Sprintf("worker %d", i)
+				ok, err := storage.InsertIfNotExists(pKey, []byte("lease"), []byte(value))
+				if err != nil {
+					errs <- err
+					return
+				}
+				if ok {
+					winners <- value
+				}
+			}(i)
+		}
+		wg.Wait()
+		close(winners)
+		close(errs)
+
+		for err := range errs {
+			require.NoError(err)
+		}
+		require.Len(winners, 1)
+
+		data := make([]byte, 0)
+		ok, err := storage.Get(pKey, []byte("lease"), &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal(<-winners, string(data))
+	})
+}
+
+// nolint
+func testAppStorage_CompareAndSwap(t *testing.T, storage IAppStorage) {
+	t.Run("Should swap only if old value matches", func(t *testing.T) {
+		require := require.New(t)
+		pKey := []byte("cas")
+
+		ok, err := storage.CompareAndSwap(pKey, []byte("Beverages"), []byte("Cola"), []byte("Pepsi"))
+		require.NoError(err)
+		require.False(ok, "must not swap not existing record")
+
+		require.NoError(storage.Put(pKey, []byte("Beverages"), []byte("Cola")))
+
+		ok, err = storage.CompareAndSwap(pKey, []byte("Beverages"), []byte("Fanta"), []byte("Pepsi"))
+		require.NoError(err)
+		require.False(ok, "must not swap if old value is not matched")
+
+		ok, err = storage.CompareAndSwap(pKey, []byte("Beverages"), []byte("Cola"), []byte("Pepsi"))
+		require.NoError(err)
+		require.True(ok)
+
+		data := make([]byte, 0)
+		ok, err = storage.Get(pKey, []byte("Beverages"), &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal([]byte("Pepsi"), data)
+	})
+
+	t.Run("Should not lose updates under contention", func(t *testing.T) {
+		require := require.New(t)
+		const (
+			workers    = 8
+			increments = 16
+		)
+		pKey := []byte("cas-contention")
+		cCols := []byte("counter")
+
+		toBytes := func(v uint64) []byte {
+			b := make([]byte, 8)
+			binary.BigEndian.PutUint64(b, v)
+			return b
+		}
+
+		ok, err := storage.InsertIfNotExists(pKey, cCols, toBytes(0))
+		require.NoError(err)
+		require.True(ok)
+
+		wg := sync.WaitGroup{}
+		errs := make(chan error, workers)
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				data := make([]byte, 0, 8)
+				for n := 0; n < increments; {
+					if _, err := storage.Get(pKey, cCols, &data); err != nil {
+						errs <- err
+						return
+					}
+					old := binary.BigEndian.Uint64(data)
+					ok, err := storage.CompareAndSwap(pKey, cCols, toBytes(old), toBytes(old+1))
+					if err != nil {
+						errs <- err
+						return
+					}
+					if ok {
+						n++
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(err)
+		}
+
+		data := make([]byte, 0)
+		ok, err = storage.Get(pKey, cCols, &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal(uint64(workers*increments), binary.BigEndian.Uint64(data))
+	})
+}
+
+// nolint
+func testAppStorage_Delete(t *testing.T, storage IAppStorage) {
+	ctx := context.Background()
+
+	readAll := func(require *require.Assertions, pKey []byte) (cCols []string) {
+		cCols = make([]string, 0)
+		require.NoError(storage.Read(ctx, pKey, nil, nil, func(c []byte, _ []byte) error {
+			cCols = append(cCols, string(c))
+			return nil
+		}))
+		return cCols
+	}
+
+	t.Run("Should delete record", func(t *testing.T) {
+		require := require.New(t)
+		pKey := []byte("delete")
+
+		require.NoError(storage.Put(pKey, []byte("Cola"), []byte("Cola")))
+		require.NoError(storage.Put(pKey, []byte("Pepsi"), []byte("Pepsi")))
+
+		require.NoError(storage.Delete(pKey, []byte("Cola")))
+
+		data := make([]byte, 0)
+		ok, err := storage.Get(pKey, []byte("Cola"), &data)
+		require.NoError(err)
+		require.False(ok)
+		require.Equal([]string{"Pepsi"}, readAll(require, pKey))
+
+		t.Run("Should not fail if record does not exist", func(t *testing.T) {
+			require.NoError(storage.Delete(pKey, []byte("Cola")))
+			require.NoError(storage.Delete([]byte("delete-unknown"), []byte("Cola")))
+		})
+
+		t.Run("nil clustering columns must be same as zero-length", func(t *testing.T) {
+			require.NoError(storage.Put(pKey, nil, []byte("Tea")))
+			require.NoError(storage.Delete(pKey, []byte{}))
+			ok, err := storage.Get(pKey, nil, &data)
+			require.NoError(err)
+			require.False(ok)
+		})
+
+		t.Run("Should insert deleted record again", func(t *testing.T) {
+			ok, err := storage.InsertIfNotExists(pKey, []byte("Cola"), []byte("Cola"))
+			require.NoError(err)
+			require.True(ok)
+		})
+	})
+
+	t.Run("Should delete records range", func(t *testing.T) {
+		require := require.New(t)
+		pKey := []byte("delete-range")
+
+		put := func() {
+			for _, c := range []string{"a1", "a2", "b1", "b2", "c1"} {
+				require.NoError(storage.Put(pKey, []byte(c), []byte(c)))
+			}
+		}
+
+		put()
+		require.NoError(storage.DeleteRange(pKey, []byte("a2"), []byte("b5")))
+		require.Equal([]string{"a1", "c1"}, readAll(require, pKey))
+
+		data := make([]byte, 0)
+		ok, err := storage.Get(pKey, []byte("b1"), &data)
+		require.NoError(err)
+		require.False(ok)
+
+		put()
+		require.NoError(storage.DeleteRange(pKey, nil, []byte("b")))
+		require.Equal([]string{"b1", "b2", "c1"}, readAll(require, pKey))
+
+		put()
+		require.NoError(storage.DeleteRange(pKey, []byte("b"), nil))
+		require.Equal([]string{"a1", "a2"}, readAll(require, pKey))
+
+		put()
+		require.NoError(storage.DeleteRange(pKey, []byte("b"), []byte("a")))
+		require.Len(readAll(require, pKey), 5, "absurd range must not delete anything")
+
+		require.NoError(storage.DeleteRange(pKey, nil, nil))
+		require.Empty(readAll(require, pKey))
+
+		require.NoError(storage.DeleteRange([]byte("delete-range-unknown"), nil, nil))
+	})
+}
+
+// nolint
+func testAppStorage_TTL(t *testing.T, storage IAppStorage) {
+	ctx := context.Background()
+	pKey := []byte("ttl")
+	data := make([]byte, 0)
+
+	t.Run("Should return error if TTL is invalid", func(t *testing.T) {
+		require := require.New(t)
+		require.ErrorIs(storage.PutWithTTL(pKey, []byte("invalid"), []byte("value"), 0), ErrInvalidTTL)
+		require.ErrorIs(storage.PutBatch([]BatchItem{{PKey: pKey, CCols: []byte("invalid"), Value: []byte("value"), TTL: -time.Second}}), ErrInvalidTTL)
+	})
+
+	t.Run("Should keep records with TTL until expired", func(t *testing.T) {
+		require := require.New(t)
+		require.NoError(storage.PutWithTTL(pKey, []byte("expired"), []byte("Cola"), time.Second))
+		require.NoError(storage.PutWithTTL(pKey, []byte("persisted"), []byte("Cola"), time.Second))
+		require.NoError(storage.Put(pKey, []byte("persisted"), []byte("Pepsi")))
+		require.NoError(storage.PutWithTTL(pKey, []byte("long"), []byte("Fanta"), time.Hour))
+		require.NoError(storage.PutBatch([]BatchItem{
+			{PKey: pKey, CCols: []byte("batch-expired"), Value: []byte("Tea"), TTL: time.Second},
+			{PKey: pKey, CCols: []byte("batch-persisted"), Value: []byte("Coffee")},
+		}))
+		require.NoError(storage.PutWithTTL(pKey, []byte("cas"), []byte("Kvass"), time.Second))
+
+		ok, err := storage.Get(pKey, []byte("expired"), &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal([]byte("Cola"), data)
+	})
+
+	time.Sleep(2 * time.Second)
+
+	t.Run("Get should not return expired records", func(t *testing.T) {
+		require := require.New(t)
+		for c, exists := range map[string]bool{"expired": false, "batch-expired": false, "persisted": true, "batch-persisted": true, "long": true} {
+			ok, err := storage.Get(pKey, []byte(c), &data)
+			require.NoError(err)
+			require.Equal(exists, ok, c)
+		}
+	})
+
+	t.Run("GetBatch should not return expired records", func(t *testing.T) {
+		require := require.New(t)
+		items := []GetBatchItem{
+			{CCols: []byte("expired"), Data: new([]byte)},
+			{CCols: []byte("persisted"), Data: new([]byte)},
+			{CCols: []byte("batch-expired"), Data: new([]byte)},
+		}
+		require.NoError(storage.GetBatch(pKey, items))
+		require.False(items[0].Ok)
+		require.True(items[1].Ok)
+		require.Equal([]byte("Pepsi"), *items[1].Data)
+		require.False(items[2].Ok)
+	})
+
+	t.Run("GetBatch should return expiration time of records put with TTL", func(t *testing.T) {
+		require := require.New(t)
+		items := []GetBatchItem{
+			{CCols: []byte("long"), Data: new([]byte)},
+			{CCols: []byte("persisted"), Data: new([]byte), Deadline: time.Now()},
+		}
+		require.NoError(storage.GetBatch(pKey, items))
+		require.True(items[0].Ok)
+		require.WithinDuration(time.Now().Add(time.Hour), items[0].Deadline, 5*time.Second)
+		require.True(items[1].Ok)
+		require.True(items[1].Deadline.IsZero(), "persistent record never expires")
+	})
+
+	t.Run("Read should not return expired records", func(t *testing.T) {
+		require := require.New(t)
+		cCols := make([]string, 0)
+		require.NoError(storage.Read(ctx, pKey, nil, nil, func(c []byte, _ []byte) error {
+			cCols = append(cCols, string(c))
+			return nil
+		}))
+		require.Equal([]string{"batch-persisted", "long", "persisted"}, cCols)
+	})
+
+	t.Run("Expired records must be considered as not existing by conditional writes", func(t *testing.T) {
+		require := require.New(t)
+		ok, err := storage.CompareAndSwap(pKey, []byte("cas"), []byte("Kvass"), []byte("Tea"))
+		require.NoError(err)
+		require.False(ok)
+
+		ok, err = storage.InsertIfNotExists(pKey, []byte("expired"), []byte("Sprite"))
+		require.NoError(err)
+		require.True(ok)
+
+		ok, err = storage.Get(pKey, []byte("expired"), &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal([]byte("Sprite"), data)
+	})
+}
@@ -61,6 +61,21 @@ func (s *cachedAppStorage) Put(pKey []byte, cCols []byte, value []byte) (err err
 	return err
 }
 
+func (s *cachedAppStorage) PutWithTTL(pKey []byte, cCols []byte, value []byte, ttl time.Duration) (err error) {
+	start := time.Now()
+	defer func() {
+		s.metrics.IncreaseApp(putSeconds, s.hvm, s.appQName, time.Since(start).Seconds())
+	}()
+	s.metrics.IncreaseApp(putTotal, s.hvm, s.appQName, 1.0)
+
+	if ttl <= 0 {
+		return istorage.ErrInvalidTTL
+	}
+	err = s.storage.PutWithTTL(pKey, cCols, value, ttl)
+	s.cache.Del(key(pKey, cCols))
+	return err
+}
+
 func (s *cachedAppStorage) PutBatch(items []istorage.BatchItem) (err error) {
 	start := time.Now()
 	defer func() {
@@ -70,14 +85,87 @@ func (s *cachedAppStorage) PutBatch(items []istorage.BatchItem) (err error) {
 	s.metrics.IncreaseApp(putBatchItemsTotal, s.hvm, s.appQName, float64(len(items)))
 
 	err = s.storage.PutBatch(items)
-	if err == nil {
-		for _, i := range items {
+	for _, i := range items {
+		switch {
+		case i.TTL > 0:
+			s.cache.Del(key(i.PKey, i.CCols))
+		case err == nil:
 			s.cache.Set(key(i.PKey, i.CCols), i.Value)
 		}
 	}
 	return err
 }
 
+func (s *cachedAppStorage) Delete(pKey []byte, cCols []byte) (err error) {
+	start := time.Now()
+	defer func() {
+		s.metrics.IncreaseApp(deleteSeconds, s.hvm, s.appQName, time.Since(start).Seconds())
+	}()
+	s.metrics.IncreaseApp(deleteTotal, s.hvm, s.appQName, 1.0)
+
+	err = s.storage.Delete(pKey, cCols)
+	s.cache.Del(key(pKey, cCols))
+	return err
+}
+
+// Cache can not enumerate keys, so keys of range are read from storage before deletion.
+// Records put into range concurrently with DeleteRange may remain in cache
+func (s *cachedAppStorage) DeleteRange(pKey []byte, startCCols, finishCCols []byte) (err error) {
+	start := time.Now()
+	defer func() {
+		s.metrics.IncreaseApp(deleteRangeSeconds, s.hvm, s.appQName, time.Since(start).Seconds())
+	}()
+	s.metrics.IncreaseApp(deleteRangeTotal, s.hvm, s.appQName, 1.0)
+
+	keys := make([][]byte, 0)
+	err = s.storage.Read(context.Background(), pKey, startCCols, finishCCols, func(cCols []byte, _ []byte) error {
+		keys = append(keys, key(append([]byte(nil), pKey...), cCols))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	err = s.storage.DeleteRange(pKey, startCCols, finishCCols)
+	for _, k := range keys {
+		s.cache.Del(k)
+	}
+	return err
+}
+
+func (s *cachedAppStorage) InsertIfNotExists(pKey []byte, cCols []byte, value []byte) (ok bool, err error) {
+	start := time.Now()
+	defer func() {
+		s.metrics.IncreaseApp(insertSeconds, s.hvm, s.appQName, time.Since(start).Seconds())
+	}()
+	s.metrics.IncreaseApp(insertTotal, s.hvm, s.appQName, 1.0)
+
+	ok, err = s.storage.InsertIfNotExists(pKey, cCols, value)
+	s.applyConditional(pKey, cCols, value, ok, err)
+	return ok, err
+}
+
+func (s *cachedAppStorage) CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte) (ok bool, err error) {
+	start := time.Now()
+	defer func() {
+		s.metrics.IncreaseApp(casSeconds, s.hvm, s.appQName, time.Since(start).Seconds())
+	}()
+	s.metrics.IncreaseApp(casTotal, s.hvm, s.appQName, 1.0)
+
+	ok, err = s.storage.CompareAndSwap(pKey, cCols, oldValue, newValue)
+	s.applyConditional(pKey, cCols, newValue, ok, err)
+	return ok, err
+}
+
+// applyConditional keeps cache coherent after conditional write.
+// If write is not applied or result is unknown then cached value may be stale and is removed from cache
+func (s *cachedAppStorage) applyConditional(pKey []byte, cCols []byte, value []byte, ok bool, err error) {
+	if ok && (err == nil) {
+		s.cache.Set(key(pKey, cCols), value)
+		return
+	}
+	s.cache.Del(key(pKey, cCols))
+}
+
 func (s *cachedAppStorage) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
 	start := time.Now()
 	defer func() {
@@ -91,14 +179,16 @@ func (s *cachedAppStorage) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool
 		s.metrics.IncreaseApp(getCachedTotal, s.hvm, s.appQName, 1.0)
 		return true, err
 	}
-	ok, err = s.storage.Get(pKey, cCols, data)
-	if err != nil {
+	// record is read by GetBatch which returns record expiration time, records which expire are not cached,
+	// so records put with TTL by another process or before restart are not returned after they expire
+	items := []istorage.GetBatchItem{{CCols: cCols, Data: data}}
+	if err = s.storage.GetBatch(pKey, items); err != nil {
 		return false, err
 	}
-	if ok {
+	if items[0].Ok && items[0].Deadline.IsZero() {
 		s.cache.Set(key(pKey, cCols), *data)
 	}
-	return
+	return items[0].Ok, nil
 }
 
 func (s *cachedAppStorage) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err error) {
@@ -120,6 +210,7 @@ func (s *cachedAppStorage) getBatchFromCache(pKey []byte, items []istorage.GetBa
 			return false
 		}
 		items[i].Ok = true
+		items[i].Deadline = time.Time{}
 	}
 	s.metrics.IncreaseApp(getBatchCachedTotal, s.hvm, s.appQName, 1.0)
 	return true
@@ -132,7 +223,9 @@ func (s *cachedAppStorage) getBatchFromStorage(pKey []byte, items []istorage.Get
 	}
 	for _, item := range items {
 		if item.Ok {
-			s.cache.Set(key(pKey, item.CCols), *item.Data)
+			if item.Deadline.IsZero() {
+				s.cache.Set(key(pKey, item.CCols), *item.Data)
+			}
 		} else {
 			s.cache.Del(key(pKey, item.CCols))
 		}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	istorage "github.com/voedger/voedger/pkg/istorage"
@@ -266,11 +267,12 @@ func TestAppStorage_GetBatch(t *testing.T) {
 				return err
 			},
 			getBatch: func(pKey []byte, items []istorage.GetBatchItem) (err error) {
-				items[0].Ok = false
-				*items[1].Data = append((*items[1].Data)[0:0], []byte("Burger ver.1.1")...)
-				items[1].Ok = true
-				*items[2].Data = append((*items[2].Data)[0:0], []byte("Napkin ver.1.0")...)
-				items[2].Ok = true
+				stored := map[string]string{"Food": "Burger ver.1.1", "Misc": "Napkin ver.1.0"}
+				for i := range items {
+					value, ok := stored[string(items[i].CCols)]
+					*items[i].Data = append((*items[i].Data)[0:0], value...)
+					items[i].Ok = ok
+				}
 				return err
 			}}
 		tsp := &testStorageProvider{storage: ts}
@@ -310,6 +312,186 @@ func TestAppStorage_GetBatch(t *testing.T) {
 	})
 }
 
+func TestAppStorage_ConditionalWrites(t *testing.T) {
+	require := require.New(t)
+	asf := istorage.ProvideMem()
+	asp := istorageimpl.Provide(asf)
+	cachingStorageProvider := Provide(testCacheSize, asp, imetrics.Provide(), "hvm")
+	storage, err := cachingStorageProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	underlying, err := asp.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	get := func() string {
+		data := make([]byte, 0)
+		ok, err := storage.Get([]byte("UK"), []byte("Article"), &data)
+		require.NoError(err)
+		require.True(ok)
+		return string(data)
+	}
+
+	t.Run("Should cache inserted value", func(t *testing.T) {
+		ok, err := storage.InsertIfNotExists([]byte("UK"), []byte("Article"), []byte("Cola"))
+		require.NoError(err)
+		require.True(ok)
+		require.Equal("Cola", get())
+	})
+
+	t.Run("Should drop stale cached value if insert is not applied", func(t *testing.T) {
+		require.NoError(underlying.Put([]byte("UK"), []byte("Article"), []byte("Pepsi")))
+		require.Equal("Cola", get()) // stale
+
+		ok, err := storage.InsertIfNotExists([]byte("UK"), []byte("Article"), []byte("Fanta"))
+		require.NoError(err)
+		require.False(ok)
+		require.Equal("Pepsi", get())
+	})
+
+	t.Run("Should cache swapped value", func(t *testing.T) {
+		ok, err := storage.CompareAndSwap([]byte("UK"), []byte("Article"), []byte("Pepsi"), []byte("Sprite"))
+		require.NoError(err)
+		require.True(ok)
+		require.Equal("Sprite", get())
+	})
+
+	t.Run("Should drop stale cached value if swap is not applied", func(t *testing.T) {
+		require.NoError(underlying.Put([]byte("UK"), []byte("Article"), []byte("Kvass")))
+		require.Equal("Sprite", get()) // stale
+
+		ok, err := storage.CompareAndSwap([]byte("UK"), []byte("Article"), []byte("Sprite"), []byte("Tea"))
+		require.NoError(err)
+		require.False(ok)
+		require.Equal("Kvass", get())
+	})
+
+	t.Run("Should drop cached value on storage error", func(t *testing.T) {
+		testErr := errors.New("test error")
+		ts := &testStorage{
+			put: func(pKey []byte, cCols []byte, value []byte) (err error) { return nil },
+			get: func(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+				return false, nil
+			},
+			insert: func(pKey []byte, cCols []byte, value []byte) (ok bool, err error) {
+				return false, testErr
+			},
+			cas: func(pKey []byte, cCols []byte, oldValue, newValue []byte) (ok bool, err error) {
+				return false, testErr
+			},
+		}
+		storage := newCachingAppStorage(testCacheSize, ts, imetrics.Provide(), "hvm", istructs.AppQName_test1_app1)
+
+		require.NoError(storage.Put([]byte("UK"), []byte("Article"), []byte("Cola")))
+		_, err := storage.InsertIfNotExists([]byte("UK"), []byte("Article"), []byte("Cola"))
+		require.ErrorIs(err, testErr)
+		ok, err := storage.Get([]byte("UK"), []byte("Article"), &[]byte{})
+		require.NoError(err)
+		require.False(ok)
+
+		require.NoError(storage.Put([]byte("UK"), []byte("Article"), []byte("Cola")))
+		_, err = storage.CompareAndSwap([]byte("UK"), []byte("Article"), []byte("Cola"), []byte("Pepsi"))
+		require.ErrorIs(err, testErr)
+		ok, err = storage.Get([]byte("UK"), []byte("Article"), &[]byte{})
+		require.NoError(err)
+		require.False(ok)
+	})
+}
+
+func TestAppStorage_DeleteAndTTL(t *testing.T) {
+	require := require.New(t)
+	asf := istorage.ProvideMem()
+	asp := istorageimpl.Provide(asf)
+	cachingStorageProvider := Provide(testCacheSize, asp, imetrics.Provide(), "hvm")
+	storage, err := cachingStorageProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	exists := func(cCols string) bool {
+		ok, err := storage.Get([]byte("UK"), []byte(cCols), &[]byte{})
+		require.NoError(err)
+		return ok
+	}
+
+	t.Run("Should drop cached value on delete", func(t *testing.T) {
+		require.NoError(storage.Put([]byte("UK"), []byte("Article"), []byte("Cola")))
+		require.True(exists("Article"))
+
+		require.NoError(storage.Delete([]byte("UK"), []byte("Article")))
+		require.False(exists("Article"))
+	})
+
+	t.Run("Should drop cached values on delete range", func(t *testing.T) {
+		for _, c := range []string{"a1", "a2", "b1"} {
+			require.NoError(storage.Put([]byte("UK"), []byte(c), []byte(c)))
+			require.True(exists(c))
+		}
+
+		require.NoError(storage.DeleteRange([]byte("UK"), []byte("a"), []byte("b")))
+		require.False(exists("a1"))
+		require.False(exists("a2"))
+		require.True(exists("b1"))
+	})
+
+	t.Run("Should not cache records put with TTL", func(t *testing.T) {
+		require.NoError(storage.Put([]byte("UK"), []byte("Temp"), []byte("Cola")))
+		require.True(exists("Temp"))
+
+		require.NoError(storage.PutWithTTL([]byte("UK"), []byte("Temp"), []byte("Pepsi"), time.Second))
+		require.NoError(storage.PutBatch([]istorage.BatchItem{{PKey: []byte("UK"), CCols: []byte("TempBatch"), Value: []byte("Fanta"), TTL: time.Second}}))
+		require.True(exists("Temp"))
+		require.True(exists("TempBatch"))
+
+		time.Sleep(2 * time.Second)
+		require.False(exists("Temp"))
+		require.False(exists("TempBatch"))
+	})
+
+	t.Run("Should cache record again after it is put without TTL", func(t *testing.T) {
+		require.NoError(storage.PutWithTTL([]byte("UK"), []byte("Temp"), []byte("Pepsi"), time.Hour))
+		require.NoError(storage.Put([]byte("UK"), []byte("Temp"), []byte("Cola")))
+
+		data := make([]byte, 0)
+		ok, err := storage.Get([]byte("UK"), []byte("Temp"), &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal("Cola", string(data))
+	})
+
+	t.Run("Should return error if TTL is invalid", func(t *testing.T) {
+		require.ErrorIs(storage.PutWithTTL([]byte("UK"), []byte("Temp"), []byte("Pepsi"), 0), istorage.ErrInvalidTTL)
+	})
+}
+
+func TestAppStorage_TTLRecordsPutBeforeCacheCreated(t *testing.T) {
+	require := require.New(t)
+	asp := istorageimpl.Provide(istorage.ProvideMem())
+	underlying, err := asp.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	require.NoError(underlying.PutWithTTL([]byte("UK"), []byte("Temp"), []byte("Pepsi"), time.Second))
+	require.NoError(underlying.Put([]byte("UK"), []byte("Persistent"), []byte("Cola")))
+
+	// the cache knows nothing about TTL of the records, e.g. after restart or if records are put by another node
+	storage, err := Provide(testCacheSize, asp, imetrics.Provide(), "hvm").AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	get := func(cCols string) bool {
+		ok, err := storage.Get([]byte("UK"), []byte(cCols), &[]byte{})
+		require.NoError(err)
+		return ok
+	}
+	getBatch := func(cCols string) bool {
+		items := []istorage.GetBatchItem{{CCols: []byte(cCols), Data: &[]byte{}}}
+		require.NoError(storage.GetBatch([]byte("UK"), items))
+		return items[0].Ok
+	}
+	require.True(get("Temp"))
+	require.True(getBatch("Temp"))
+	require.True(get("Persistent"))
+
+	time.Sleep(2 * time.Second)
+
+	require.False(get("Temp"), "expired record must not be returned from cache")
+	require.False(getBatch("Temp"), "expired record must not be returned from cache")
+	require.True(get("Persistent"))
+}
+
 func TestTechnologyCompatibilityKit(t *testing.T) {
 	asf := istorage.ProvideMem()
 	asp := istorageimpl.Provide(asf)
@@ -336,24 +518,62 @@ type testStorage struct {
 	putBatch func(items []istorage.BatchItem) (err error)
 	get      func(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error)
 	getBatch func(pKey []byte, items []istorage.GetBatchItem) (err error)
+	insert   func(pKey []byte, cCols []byte, value []byte) (ok bool, err error)
+	cas      func(pKey []byte, cCols []byte, oldValue, newValue []byte) (ok bool, err error)
+	putTTL   func(pKey []byte, cCols []byte, value []byte, ttl time.Duration) (err error)
+	del      func(pKey []byte, cCols []byte) (err error)
+	delRange func(pKey []byte, startCCols, finishCCols []byte) (err error)
+	read     func(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error)
 }
 
 func (s *testStorage) Put(pKey []byte, cCols []byte, value []byte) (err error) {
 	return s.put(pKey, cCols, value)
 }
 
+func (s *testStorage) PutWithTTL(pKey []byte, cCols []byte, value []byte, ttl time.Duration) (err error) {
+	return s.putTTL(pKey, cCols, value, ttl)
+}
+
+func (s *testStorage) Delete(pKey []byte, cCols []byte) (err error) {
+	return s.del(pKey, cCols)
+}
+
+func (s *testStorage) DeleteRange(pKey []byte, startCCols, finishCCols []byte) (err error) {
+	return s.delRange(pKey, startCCols, finishCCols)
+}
+
 func (s *testStorage) PutBatch(items []istorage.BatchItem) (err error) {
 	return s.putBatch(items)
 }
 
+func (s *testStorage) InsertIfNotExists(pKey []byte, cCols []byte, value []byte) (ok bool, err error) {
+	return s.insert(pKey, cCols, value)
+}
+
+func (s *testStorage) CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte) (ok bool, err error) {
+	return s.cas(pKey, cCols, oldValue, newValue)
+}
+
 func (s *testStorage) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
 	return s.get(pKey, cCols, data)
 }
 
+// items are got one by one by get if getBatch is not set
 func (s *testStorage) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err error) {
+	if s.getBatch == nil {
+		for i := range items {
+			if items[i].Ok, err = s.get(pKey, items[i].CCols, items[i].Data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 	return s.getBatch(pKey, items)
 }
 
-func (s *testStorage) Read(context.Context, []byte, []byte, []byte, istorage.ReadCallback) (err error) {
-	return err
+func (s *testStorage) Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	if s.read == nil {
+		return err
+	}
+	return s.read(ctx, pKey, startCCols, finishCCols, cb)
 }
@@ -16,6 +16,14 @@ const (
 	putBatchTotal       = "heeus_istoragecache_putbatch_total"
 	putBatchItemsTotal  = "heeus_istoragecache_putbatch_items_total"
 	putBatchSeconds     = "heeus_istoragecache_putbatch_seconds"
+	insertTotal         = "heeus_istoragecache_insert_total"
+	insertSeconds       = "heeus_istoragecache_insert_seconds"
+	casTotal            = "heeus_istoragecache_cas_total"
+	casSeconds          = "heeus_istoragecache_cas_seconds"
+	deleteTotal         = "heeus_istoragecache_delete_total"
+	deleteSeconds       = "heeus_istoragecache_delete_seconds"
+	deleteRangeTotal    = "heeus_istoragecache_deleterange_total"
+	deleteRangeSeconds  = "heeus_istoragecache_deleterange_seconds"
 	readTotal           = "heeus_istoragecache_read_total"
 	readSeconds         = "heeus_istoragecache_read_seconds"
 )
@@ -4,8 +4,29 @@
 
 package istoragebbolt
 
+import "time"
+
 const (
 	rwxrwxrwx = 0777
 	rw_rw_rw_ = 0666
 	validChar = "_"
 )
+
+const (
+	// DefaultTTLSweepInterval is used if ParamsType.TTLSweepInterval is not specified
+	DefaultTTLSweepInterval = time.Minute
+
+	// max number of expired records removed by sweeper in one transaction
+	ttlSweepBatchSize = 1000
+)
+
+// Bucket which keeps expiration info of records put with TTL
+// This partition key is reserved and must not be used by applications
+var ttlBucketName = []byte("\x00_ttl_")
+
+var (
+	// nested bucket of ttlBucketName, key: deadline + record key, value: nil
+	ttlDeadlinesBucketName = []byte("deadlines")
+	// nested bucket of ttlBucketName, key: record key, value: deadline
+	ttlKeysBucketName = []byte("keys")
+)
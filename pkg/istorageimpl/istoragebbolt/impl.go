@@ -9,8 +9,11 @@ package istoragebbolt
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
+	"errors"
 	"os"
 	"path/filepath"
+	"time"
 
 	istorage "github.com/voedger/voedger/pkg/istorage"
 	bolt "go.etcd.io/bbolt"
@@ -31,7 +34,13 @@ func (p *appStorageFactory) AppStorage(appName istorage.SafeAppName) (s istorage
 		// notest
 		return nil, err
 	}
-	return &appStorageType{db}, nil
+	sweepInterval := p.bboltParams.TTLSweepInterval
+	if sweepInterval <= 0 {
+		sweepInterval = DefaultTTLSweepInterval
+	}
+	storage := &appStorageType{db}
+	go storage.sweeper(sweepInterval)
+	return storage, nil
 }
 
 func (p *appStorageFactory) Init(appName istorage.SafeAppName) error {
@@ -88,15 +97,33 @@ func (s *appStorageType) Put(pKey []byte, cCols []byte, value []byte) (err error
 			// notest
 			return e
 		}
-		return b.Put(safeKey(cCols), unSafeKey(value))
+		if e = b.Put(safeKey(cCols), unSafeKey(value)); e != nil {
+			return e
+		}
+		return clearTTL(tx, pKey, cCols)
 	})
 	return err
 }
 
+// istorage.IAppStorage.PutWithTTL(pKey []byte, cCols []byte, value []byte, ttl time.Duration) (err error)
+func (s *appStorageType) PutWithTTL(pKey []byte, cCols []byte, value []byte, ttl time.Duration) (err error) {
+	if ttl <= 0 {
+		return istorage.ErrInvalidTTL
+	}
+	return s.PutBatch([]istorage.BatchItem{{PKey: pKey, CCols: cCols, Value: value, TTL: ttl}})
+}
+
 // istorage.IAppStorage.PutBatch(items []BatchItem) (err error)
 func (s *appStorageType) PutBatch(items []istorage.BatchItem) (err error) {
+	for i := 0; i < len(items); i++ {
+		if items[i].TTL < 0 {
+			return istorage.ErrInvalidTTL
+		}
+	}
+
 	err = s.db.Update(func(tx *bolt.Tx) error {
 
+		now := time.Now()
 		for i := 0; i < len(items); i++ {
 
 			PKey := items[i].PKey
@@ -110,14 +137,127 @@ func (s *appStorageType) PutBatch(items []istorage.BatchItem) (err error) {
 			if e != nil {
 				return e
 			}
+
+			if items[i].TTL > 0 {
+				e = setTTL(tx, PKey, items[i].CCols, now.Add(items[i].TTL))
+			} else {
+				e = clearTTL(tx, PKey, items[i].CCols)
+			}
+			if e != nil {
+				// notest
+				return e
+			}
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// istorage.IAppStorage.Delete(pKey []byte, cCols []byte) (err error)
+func (s *appStorageType) Delete(pKey []byte, cCols []byte) (err error) {
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		if b := tx.Bucket(pKey); b != nil {
+			if e := b.Delete(safeKey(cCols)); e != nil {
+				// notest
+				return e
+			}
+		}
+		return clearTTL(tx, pKey, cCols)
+	})
+	return err
+}
+
+// istorage.IAppStorage.DeleteRange(pKey []byte, startCCols, finishCCols []byte) (err error)
+func (s *appStorageType) DeleteRange(pKey []byte, startCCols, finishCCols []byte) (err error) {
+
+	if (len(startCCols) > 0) && (len(finishCCols) > 0) && (bytes.Compare(startCCols, finishCCols) >= 0) {
+		return nil // absurd range
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(pKey)
+		if bucket == nil {
+			return nil
 		}
 
+		startCCols = unSafeKey(startCCols)
+		finishCCols = unSafeKey(finishCCols)
+
+		keys := make([][]byte, 0)
+		cr := bucket.Cursor()
+		var k []byte
+		if startCCols == nil {
+			k, _ = cr.First()
+		} else {
+			k, _ = cr.Seek(startCCols)
+		}
+		for (k != nil) && (finishCCols == nil || string(k) <= string(finishCCols)) {
+			keys = append(keys, append([]byte(nil), k...))
+			k, _ = cr.Next()
+		}
+
+		for _, k := range keys {
+			if e := bucket.Delete(k); e != nil {
+				// notest
+				return e
+			}
+			if e := clearTTL(tx, pKey, k); e != nil {
+				// notest
+				return e
+			}
+		}
 		return nil
 	})
 
 	return err
 }
 
+// istorage.IAppStorage.InsertIfNotExists(pKey []byte, cCols []byte, value []byte) (ok bool, err error)
+func (s *appStorageType) InsertIfNotExists(pKey []byte, cCols []byte, value []byte) (ok bool, err error) {
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		ok = false
+		b, e := tx.CreateBucketIfNotExists(pKey)
+		if e != nil {
+			// notest
+			return e
+		}
+		if (b.Get(safeKey(cCols)) != nil) && !expired(tx, pKey, cCols, time.Now()) {
+			return nil
+		}
+		if e = b.Put(safeKey(cCols), value); e != nil {
+			// notest
+			return e
+		}
+		ok = true
+		return clearTTL(tx, pKey, cCols)
+	})
+	return ok, err
+}
+
+// istorage.IAppStorage.CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte) (ok bool, err error)
+func (s *appStorageType) CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte) (ok bool, err error) {
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		ok = false
+		b := tx.Bucket(pKey)
+		if b == nil {
+			return nil
+		}
+		v := b.Get(safeKey(cCols))
+		if (v == nil) || !bytes.Equal(v, oldValue) || expired(tx, pKey, cCols, time.Now()) {
+			return nil
+		}
+		if e := b.Put(safeKey(cCols), newValue); e != nil {
+			// notest
+			return e
+		}
+		ok = true
+		return clearTTL(tx, pKey, cCols)
+	})
+	return ok, err
+}
+
 // istorage.IAppStorage.Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error)
 func (s *appStorageType) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
 	*data = (*data)[0:0]
@@ -130,7 +270,7 @@ func (s *appStorageType) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool,
 		}
 
 		v := bucket.Get(safeKey(cCols))
-		if v == nil {
+		if (v == nil) || expired(tx, pKey, cCols, time.Now()) {
 			return nil
 		}
 		*data = append(*data, v...)
@@ -172,12 +312,20 @@ func (s *appStorageType) Read(ctx context.Context, pKey []byte, startCCols, fini
 
 		var e error
 
+		checkTTL := hasTTL(tx, pKey)
+		now := time.Now()
+
 		for (k != nil) && (finishCCols == nil || string(k) <= string(finishCCols)) {
 
 			if ctx.Err() != nil {
 				return nil
 			}
 
+			if checkTTL && expired(tx, pKey, k, now) {
+				k, v = cr.Next()
+				continue
+			}
+
 			if cb != nil {
 				e = cb(unSafeKey(k), unSafeKey(v))
 				if e != nil {
@@ -204,8 +352,16 @@ func (s *appStorageType) GetBatch(pKey []byte, items []istorage.GetBatchItem) (e
 			}
 			return nil
 		}
+		checkTTL := hasTTL(tx, pKey)
+		now := time.Now()
 		for i := 0; i < len(items); i++ {
 			v := bucket.Get(safeKey(items[i].CCols))
+			items[i].Deadline = time.Time{}
+			if (v != nil) && checkTTL {
+				if items[i].Deadline = deadline(tx, pKey, items[i].CCols); !items[i].Deadline.IsZero() && !items[i].Deadline.After(now) {
+					v = nil
+				}
+			}
 			items[i].Ok = v != nil
 			*items[i].Data = append((*items[i].Data)[0:0], v...)
 		}
@@ -214,3 +370,176 @@ func (s *appStorageType) GetBatch(pKey []byte, items []istorage.GetBatchItem) (e
 
 	return err
 }
+
+// periodically removes expired records, exits when database is closed
+func (s *appStorageType) sweeper(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := s.sweep(time.Now()); errors.Is(err, bolt.ErrDatabaseNotOpen) {
+			return
+		}
+	}
+}
+
+// removes records expired before now
+func (s *appStorageType) sweep(now time.Time) (err error) {
+	for {
+		swept := 0
+		err = s.db.Update(func(tx *bolt.Tx) error {
+			ttl := tx.Bucket(ttlBucketName)
+			if ttl == nil {
+				return nil
+			}
+			deadlines := ttl.Bucket(ttlDeadlinesBucketName)
+			keys := ttl.Bucket(ttlKeysBucketName)
+
+			expiredKeys := make([][]byte, 0)
+			cr := deadlines.Cursor()
+			for k, _ := cr.First(); (k != nil) && (len(expiredKeys) < ttlSweepBatchSize); k, _ = cr.Next() {
+				if decodeDeadline(k).After(now) {
+					break
+				}
+				expiredKeys = append(expiredKeys, append([]byte(nil), k...))
+			}
+
+			for _, k := range expiredKeys {
+				key := k[deadlineSize:]
+				pKey, cCols := decodeTTLKey(key)
+				if b := tx.Bucket(pKey); b != nil {
+					if e := b.Delete(cCols); e != nil {
+						// notest
+						return e
+					}
+				}
+				if e := keys.Delete(key); e != nil {
+					// notest
+					return e
+				}
+				if e := deadlines.Delete(k); e != nil {
+					// notest
+					return e
+				}
+			}
+			swept = len(expiredKeys)
+			return nil
+		})
+		if (err != nil) || (swept < ttlSweepBatchSize) {
+			return err
+		}
+	}
+}
+
+const deadlineSize = 8 // uint64 unix nanoseconds
+
+func encodeDeadline(deadline time.Time) []byte {
+	res := make([]byte, deadlineSize)
+	binary.BigEndian.PutUint64(res, uint64(deadline.UnixNano()))
+	return res
+}
+
+func decodeDeadline(b []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(b[:deadlineSize])))
+}
+
+// key of record in TTL buckets: uint16 len(pKey) + pKey + safeKey(cCols)
+func encodeTTLKey(pKey, cCols []byte) []byte {
+	cCols = safeKey(cCols)
+	res := make([]byte, 2, 2+len(pKey)+len(cCols))
+	binary.BigEndian.PutUint16(res, uint16(len(pKey)))
+	res = append(res, pKey...)
+	return append(res, cCols...)
+}
+
+func decodeTTLKey(key []byte) (pKey, cCols []byte) {
+	l := int(binary.BigEndian.Uint16(key)) + 2
+	return key[2:l], key[l:]
+}
+
+// returns nested TTL buckets or nils if there are no records with TTL
+func ttlBuckets(tx *bolt.Tx) (keys, deadlines *bolt.Bucket) {
+	ttl := tx.Bucket(ttlBucketName)
+	if ttl == nil {
+		return nil, nil
+	}
+	return ttl.Bucket(ttlKeysBucketName), ttl.Bucket(ttlDeadlinesBucketName)
+}
+
+// returns is there any record with TTL in the partition
+func hasTTL(tx *bolt.Tx, pKey []byte) bool {
+	keys, _ := ttlBuckets(tx)
+	if keys == nil {
+		return false
+	}
+	prefix := encodeTTLKey(pKey, nil)
+	prefix = prefix[:len(prefix)-len(nullKey)]
+	k, _ := keys.Cursor().Seek(prefix)
+	return (k != nil) && bytes.HasPrefix(k, prefix)
+}
+
+// returns is record put with TTL and expired
+func expired(tx *bolt.Tx, pKey, cCols []byte, now time.Time) bool {
+	d := deadline(tx, pKey, cCols)
+	return !d.IsZero() && !d.After(now)
+}
+
+// returns expiration time of record put with TTL, zero if record never expires
+func deadline(tx *bolt.Tx, pKey, cCols []byte) time.Time {
+	keys, _ := ttlBuckets(tx)
+	if keys == nil {
+		return time.Time{}
+	}
+	d := keys.Get(encodeTTLKey(pKey, cCols))
+	if d == nil {
+		return time.Time{}
+	}
+	return decodeDeadline(d)
+}
+
+// sets record expiration time
+func setTTL(tx *bolt.Tx, pKey, cCols []byte, deadline time.Time) error {
+	if err := clearTTL(tx, pKey, cCols); err != nil {
+		// notest
+		return err
+	}
+	ttl, err := tx.CreateBucketIfNotExists(ttlBucketName)
+	if err != nil {
+		// notest
+		return err
+	}
+	keys, err := ttl.CreateBucketIfNotExists(ttlKeysBucketName)
+	if err != nil {
+		// notest
+		return err
+	}
+	deadlines, err := ttl.CreateBucketIfNotExists(ttlDeadlinesBucketName)
+	if err != nil {
+		// notest
+		return err
+	}
+	key := encodeTTLKey(pKey, cCols)
+	d := encodeDeadline(deadline)
+	if err := keys.Put(key, d); err != nil {
+		// notest
+		return err
+	}
+	return deadlines.Put(append(d, key...), nil)
+}
+
+// makes record persistent
+func clearTTL(tx *bolt.Tx, pKey, cCols []byte) error {
+	keys, deadlines := ttlBuckets(tx)
+	if keys == nil {
+		return nil
+	}
+	key := encodeTTLKey(pKey, cCols)
+	d := keys.Get(key)
+	if d == nil {
+		return nil
+	}
+	if err := deadlines.Delete(append(append([]byte(nil), d...), key...)); err != nil {
+		// notest
+		return err
+	}
+	return keys.Delete(key)
+}
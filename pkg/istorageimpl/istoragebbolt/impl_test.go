@@ -9,11 +9,13 @@ package istoragebbolt
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	istorage "github.com/voedger/voedger/pkg/istorage"
 	"github.com/voedger/voedger/pkg/istorageimpl"
 	"github.com/voedger/voedger/pkg/istructs"
+	bolt "go.etcd.io/bbolt"
 )
 
 func TestBasicUsage(t *testing.T) {
@@ -95,3 +97,63 @@ func Test_PutGet(t *testing.T) {
 	require.True(ok)
 	require.Equal("Molchanovsky Dmitry Anatolyevich", string(value))
 }
+
+func Test_TTLSweeper(t *testing.T) {
+	require := require.New(t)
+
+	params := prepareTestData()
+	defer cleanupTestData(params)
+	params.TTLSweepInterval = 10 * time.Millisecond
+
+	factory := Provide(params)
+	storageProvider := istorageimpl.Provide(factory)
+
+	appStorage, err := storageProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	require.NoError(appStorage.PutWithTTL([]byte("chunks"), []byte("1"), []byte("chunk 1"), time.Millisecond))
+	require.NoError(appStorage.PutWithTTL([]byte("chunks"), nil, []byte("chunk 0"), time.Millisecond))
+	require.NoError(appStorage.PutWithTTL([]byte("chunks"), []byte("2"), []byte("chunk 2"), time.Hour))
+
+	db := appStorage.(*appStorageType).db
+
+	count := func(tx *bolt.Tx, bucket []byte) int {
+		b := tx.Bucket(bucket)
+		if b == nil {
+			return 0
+		}
+		return b.Stats().KeyN
+	}
+
+	require.Eventually(func() bool {
+		records := 0
+		require.NoError(db.View(func(tx *bolt.Tx) error {
+			records = count(tx, []byte("chunks"))
+			return nil
+		}))
+		return records == 1
+	}, time.Second, 10*time.Millisecond, "sweeper must remove expired records")
+
+	require.NoError(db.View(func(tx *bolt.Tx) error {
+		keys, deadlines := ttlBuckets(tx)
+		require.Equal(1, keys.Stats().KeyN)
+		require.Equal(1, deadlines.Stats().KeyN)
+		return nil
+	}))
+
+	data := make([]byte, 0)
+	ok, err := appStorage.Get([]byte("chunks"), []byte("2"), &data)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal([]byte("chunk 2"), data)
+
+	t.Run("Should remove TTL info of deleted records", func(t *testing.T) {
+		require.NoError(appStorage.Delete([]byte("chunks"), []byte("2")))
+		require.NoError(db.View(func(tx *bolt.Tx) error {
+			keys, deadlines := ttlBuckets(tx)
+			require.Zero(keys.Stats().KeyN)
+			require.Zero(deadlines.Stats().KeyN)
+			return nil
+		}))
+	})
+}
@@ -5,6 +5,11 @@
 
 package istoragebbolt
 
+import "time"
+
 type ParamsType struct {
 	DBDir string
+
+	// Interval between removals of expired records, DefaultTTLSweepInterval is used if zero
+	TTLSweepInterval time.Duration
 }
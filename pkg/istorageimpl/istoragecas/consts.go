@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package istoragecas
+
+import "time"
+
+const (
+	DefaultPort              = 9042
+	DefaultProtoVersion      = 4
+	DefaultConnectionTimeout = 30 * time.Second
+	DefaultNumRetries        = 5
+
+	// SimpleStrategyReplication is replication options for single data center clusters with replication factor 1. Good for development and tests
+	SimpleStrategyReplication = "{'class': 'SimpleStrategy', 'replication_factor': '1'}"
+)
+
+// CQL statements. Keyspace name is substituted instead of %s
+const (
+	stmtKeyspaceExists = "SELECT keyspace_name FROM system_schema.keyspaces WHERE keyspace_name = ?"
+	stmtCreateKeyspace = "CREATE KEYSPACE %s WITH REPLICATION = %s"
+	stmtCreateTable    = "CREATE TABLE %s.values (p_key blob, c_col blob, value blob, PRIMARY KEY ((p_key), c_col))"
+	stmtInsert         = "INSERT INTO %s.values (p_key, c_col, value) VALUES (?, ?, ?)"
+	stmtInsertWithTTL  = "INSERT INTO %s.values (p_key, c_col, value) VALUES (?, ?, ?) USING TTL ?"
+	stmtInsertIfNotEx  = "INSERT INTO %s.values (p_key, c_col, value) VALUES (?, ?, ?) IF NOT EXISTS"
+	stmtCompareAndSwap = "UPDATE %s.values SET value = ? WHERE p_key = ? AND c_col = ? IF value = ?"
+	stmtGet            = "SELECT value FROM %s.values WHERE p_key = ? AND c_col = ?"
+	stmtGetBatch       = "SELECT c_col, value, TTL(value) FROM %s.values WHERE p_key = ? AND c_col IN ?"
+	stmtRead           = "SELECT c_col, value FROM %s.values WHERE p_key = ?"
+	stmtReadFrom       = "SELECT c_col, value FROM %s.values WHERE p_key = ? AND c_col >= ?"
+	stmtReadTo         = "SELECT c_col, value FROM %s.values WHERE p_key = ? AND c_col < ?"
+	stmtReadFromTo     = "SELECT c_col, value FROM %s.values WHERE p_key = ? AND c_col >= ? AND c_col < ?"
+	stmtDelete         = "DELETE FROM %s.values WHERE p_key = ? AND c_col = ?"
+	stmtDeletePart     = "DELETE FROM %s.values WHERE p_key = ?"
+	stmtDeleteFrom     = "DELETE FROM %s.values WHERE p_key = ? AND c_col >= ?"
+	stmtDeleteTo       = "DELETE FROM %s.values WHERE p_key = ? AND c_col < ?"
+	stmtDeleteFromTo   = "DELETE FROM %s.values WHERE p_key = ? AND c_col >= ? AND c_col < ?"
+)
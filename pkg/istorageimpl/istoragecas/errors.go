@@ -0,0 +1,11 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package istoragecas
+
+import "errors"
+
+var ErrNoHosts = errors.New("no cluster hosts specified")
+
+var ErrNoKeyspaceReplication = errors.New("no keyspace replication options specified")
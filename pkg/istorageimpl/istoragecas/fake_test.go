@@ -0,0 +1,369 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package istoragecas
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fakeSession is in-memory stand-in for Cassandra cluster.
+//
+// It understands only statements used by driver, see consts.go
+type fakeSession struct {
+	lock      sync.RWMutex
+	keyspaces map[string]*fakeKeyspace
+}
+
+type fakeKeyspace struct {
+	hasTable   bool
+	partitions map[string]map[string][]byte
+	deadlines  map[string]map[string]time.Time
+}
+
+// returns value of not expired row
+func (ks *fakeKeyspace) get(pKey, cCol []byte) (value []byte, ok bool) {
+	if value, ok = ks.partitions[string(pKey)][string(cCol)]; !ok {
+		return nil, false
+	}
+	if d, ok := ks.deadlines[string(pKey)][string(cCol)]; ok && !time.Now().Before(d) {
+		return nil, false
+	}
+	return value, true
+}
+
+func (ks *fakeKeyspace) put(pKey, cCol, value []byte, ttl int) {
+	p, ok := ks.partitions[string(pKey)]
+	if !ok {
+		p = make(map[string][]byte)
+		ks.partitions[string(pKey)] = p
+	}
+	p[string(cCol)] = append([]byte{}, value...)
+
+	d, ok := ks.deadlines[string(pKey)]
+	if !ok {
+		d = make(map[string]time.Time)
+		ks.deadlines[string(pKey)] = d
+	}
+	if ttl > 0 {
+		d[string(cCol)] = time.Now().Add(time.Duration(ttl) * time.Second)
+	} else {
+		delete(d, string(cCol))
+	}
+}
+
+func (ks *fakeKeyspace) deleteRange(pKey []byte, filter func(c []byte) bool) {
+	for c := range ks.partitions[string(pKey)] {
+		if filter([]byte(c)) {
+			delete(ks.partitions[string(pKey)], c)
+			delete(ks.deadlines[string(pKey)], c)
+		}
+	}
+}
+
+func newFakeSession() *fakeSession {
+	return &fakeSession{keyspaces: make(map[string]*fakeKeyspace)}
+}
+
+// stmtRegexp converts statement template to regular expression which captures keyspace and other substituted values
+func stmtRegexp(stmt string) *regexp.Regexp {
+	return regexp.MustCompile("^" + strings.ReplaceAll(regexp.QuoteMeta(stmt), "%s", "(.+?)") + "$")
+}
+
+var (
+	reCreateKeyspace = stmtRegexp(stmtCreateKeyspace)
+	reCreateTable    = stmtRegexp(stmtCreateTable)
+	reInsert         = stmtRegexp(stmtInsert)
+	reInsertWithTTL  = stmtRegexp(stmtInsertWithTTL)
+	reInsertIfNotEx  = stmtRegexp(stmtInsertIfNotEx)
+	reCompareAndSwap = stmtRegexp(stmtCompareAndSwap)
+	reGet            = stmtRegexp(stmtGet)
+	reGetBatch       = stmtRegexp(stmtGetBatch)
+	reRead           = stmtRegexp(stmtRead)
+	reReadFrom       = stmtRegexp(stmtReadFrom)
+	reReadTo         = stmtRegexp(stmtReadTo)
+	reReadFromTo     = stmtRegexp(stmtReadFromTo)
+	reDelete         = stmtRegexp(stmtDelete)
+	reDeletePart     = stmtRegexp(stmtDeletePart)
+	reDeleteFrom     = stmtRegexp(stmtDeleteFrom)
+	reDeleteTo       = stmtRegexp(stmtDeleteTo)
+	reDeleteFromTo   = stmtRegexp(stmtDeleteFromTo)
+)
+
+func (s *fakeSession) table(keyspace string) (*fakeKeyspace, error) {
+	ks, ok := s.keyspaces[keyspace]
+	if !ok || !ks.hasTable {
+		return nil, fmt.Errorf("unconfigured table %s.values", keyspace)
+	}
+	return ks, nil
+}
+
+func (s *fakeSession) exec(stmt string, args ...interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.execLocked(stmt, args...)
+}
+
+func (s *fakeSession) execLocked(stmt string, args ...interface{}) error {
+	if m := reCreateKeyspace.FindStringSubmatch(stmt); m != nil {
+		if _, ok := s.keyspaces[m[1]]; ok {
+			return fmt.Errorf("keyspace %s already exists", m[1])
+		}
+		s.keyspaces[m[1]] = &fakeKeyspace{
+			partitions: make(map[string]map[string][]byte),
+			deadlines:  make(map[string]map[string]time.Time),
+		}
+		return nil
+	}
+	if m := reCreateTable.FindStringSubmatch(stmt); m != nil {
+		ks, ok := s.keyspaces[m[1]]
+		if !ok {
+			return fmt.Errorf("keyspace %s does not exist", m[1])
+		}
+		ks.hasTable = true
+		return nil
+	}
+	if m := reInsert.FindStringSubmatch(stmt); m != nil {
+		ks, err := s.table(m[1])
+		if err != nil {
+			return err
+		}
+		pKey, cCol, value := args[0].([]byte), args[1].([]byte), args[2].([]byte)
+		if cCol == nil {
+			return fmt.Errorf("invalid null value for clustering key part c_col")
+		}
+		ks.put(pKey, cCol, value, 0)
+		return nil
+	}
+	if m := reInsertWithTTL.FindStringSubmatch(stmt); m != nil {
+		ks, err := s.table(m[1])
+		if err != nil {
+			return err
+		}
+		pKey, cCol, value, ttl := args[0].([]byte), args[1].([]byte), args[2].([]byte), args[3].(int)
+		if cCol == nil {
+			return fmt.Errorf("invalid null value for clustering key part c_col")
+		}
+		if ttl <= 0 {
+			return fmt.Errorf("invalid TTL %d", ttl)
+		}
+		ks.put(pKey, cCol, value, ttl)
+		return nil
+	}
+	if m := reDelete.FindStringSubmatch(stmt); m != nil {
+		ks, err := s.table(m[1])
+		if err != nil {
+			return err
+		}
+		cCol := args[1].([]byte)
+		ks.deleteRange(args[0].([]byte), func(c []byte) bool { return bytes.Equal(c, cCol) })
+		return nil
+	}
+	if m := reDeletePart.FindStringSubmatch(stmt); m != nil {
+		ks, err := s.table(m[1])
+		if err != nil {
+			return err
+		}
+		ks.deleteRange(args[0].([]byte), func([]byte) bool { return true })
+		return nil
+	}
+	if m := reDeleteFromTo.FindStringSubmatch(stmt); m != nil {
+		ks, err := s.table(m[1])
+		if err != nil {
+			return err
+		}
+		from, to := args[1].([]byte), args[2].([]byte)
+		ks.deleteRange(args[0].([]byte), func(c []byte) bool { return bytes.Compare(c, from) >= 0 && bytes.Compare(c, to) < 0 })
+		return nil
+	}
+	if m := reDeleteFrom.FindStringSubmatch(stmt); m != nil {
+		ks, err := s.table(m[1])
+		if err != nil {
+			return err
+		}
+		from := args[1].([]byte)
+		ks.deleteRange(args[0].([]byte), func(c []byte) bool { return bytes.Compare(c, from) >= 0 })
+		return nil
+	}
+	if m := reDeleteTo.FindStringSubmatch(stmt); m != nil {
+		ks, err := s.table(m[1])
+		if err != nil {
+			return err
+		}
+		to := args[1].([]byte)
+		ks.deleteRange(args[0].([]byte), func(c []byte) bool { return bytes.Compare(c, to) < 0 })
+		return nil
+	}
+	return fmt.Errorf("unexpected statement: %s", stmt)
+}
+
+func (s *fakeSession) execCAS(stmt string, args ...interface{}) (applied bool, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if m := reInsertIfNotEx.FindStringSubmatch(stmt); m != nil {
+		ks, err := s.table(m[1])
+		if err != nil {
+			return false, err
+		}
+		if _, exists := ks.get(args[0].([]byte), args[1].([]byte)); exists {
+			return false, nil
+		}
+		return true, s.execLocked(fmt.Sprintf(stmtInsert, m[1]), args...)
+	}
+	if m := reCompareAndSwap.FindStringSubmatch(stmt); m != nil {
+		ks, err := s.table(m[1])
+		if err != nil {
+			return false, err
+		}
+		newValue, pKey, cCol, oldValue := args[0].([]byte), args[1].([]byte), args[2].([]byte), args[3].([]byte)
+		value, exists := ks.get(pKey, cCol)
+		if !exists || !bytes.Equal(value, oldValue) {
+			return false, nil
+		}
+		return true, s.execLocked(fmt.Sprintf(stmtInsert, m[1]), pKey, cCol, newValue)
+	}
+	return false, fmt.Errorf("unexpected statement: %s", stmt)
+}
+
+func (s *fakeSession) execBatch(stmts []statement) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, st := range stmts {
+		if !reInsert.MatchString(st.stmt) && !reInsertWithTTL.MatchString(st.stmt) {
+			return fmt.Errorf("unexpected batch statement: %s", st.stmt)
+		}
+	}
+	for _, st := range stmts {
+		if err := s.execLocked(st.stmt, st.args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeSession) query(ctx context.Context, stmt string, args ...interface{}) iRows {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if stmt == stmtKeyspaceExists {
+		if _, ok := s.keyspaces[args[0].(string)]; ok {
+			return &fakeRows{rows: [][]interface{}{{args[0]}}}
+		}
+		return &fakeRows{}
+	}
+
+	// partition returns sorted clustering columns of partition which match filter
+	partition := func(keyspace string, pKey []byte, filter func(c []byte) bool) (cCols [][]byte, p map[string][]byte, err error) {
+		ks, err := s.table(keyspace)
+		if err != nil {
+			return nil, nil, err
+		}
+		p = ks.partitions[string(pKey)]
+		for c := range p {
+			if _, ok := ks.get(pKey, []byte(c)); !ok {
+				continue
+			}
+			if filter([]byte(c)) {
+				cCols = append(cCols, []byte(c))
+			}
+		}
+		sort.Slice(cCols, func(i, j int) bool { return bytes.Compare(cCols[i], cCols[j]) < 0 })
+		return cCols, p, nil
+	}
+
+	selectRows := func(keyspace string, withCCols bool, filter func(c []byte) bool) iRows {
+		cCols, p, err := partition(keyspace, args[0].([]byte), filter)
+		if err != nil {
+			return &fakeRows{err: err}
+		}
+		rows := make([][]interface{}, 0, len(cCols))
+		for _, c := range cCols {
+			if withCCols {
+				rows = append(rows, []interface{}{c, p[string(c)]})
+			} else {
+				rows = append(rows, []interface{}{p[string(c)]})
+			}
+		}
+		return &fakeRows{rows: rows}
+	}
+
+	if m := reGet.FindStringSubmatch(stmt); m != nil {
+		cCol := args[1].([]byte)
+		return selectRows(m[1], false, func(c []byte) bool { return bytes.Equal(c, cCol) })
+	}
+	if m := reGetBatch.FindStringSubmatch(stmt); m != nil {
+		in := args[1].([][]byte)
+		rows := selectRows(m[1], true, func(c []byte) bool {
+			for _, i := range in {
+				if bytes.Equal(c, i) {
+					return true
+				}
+			}
+			return false
+		}).(*fakeRows)
+		// TTL(value) is the remaining seconds, zero for persistent rows
+		for i, row := range rows.rows {
+			ttl := 0
+			if d, ok := s.keyspaces[m[1]].deadlines[string(args[0].([]byte))][string(row[0].([]byte))]; ok {
+				ttl = int((time.Until(d) + time.Second - 1) / time.Second)
+			}
+			rows.rows[i] = append(row, ttl)
+		}
+		return rows
+	}
+	if m := reReadFromTo.FindStringSubmatch(stmt); m != nil {
+		from, to := args[1].([]byte), args[2].([]byte)
+		return selectRows(m[1], true, func(c []byte) bool { return bytes.Compare(c, from) >= 0 && bytes.Compare(c, to) < 0 })
+	}
+	if m := reReadFrom.FindStringSubmatch(stmt); m != nil {
+		from := args[1].([]byte)
+		return selectRows(m[1], true, func(c []byte) bool { return bytes.Compare(c, from) >= 0 })
+	}
+	if m := reReadTo.FindStringSubmatch(stmt); m != nil {
+		to := args[1].([]byte)
+		return selectRows(m[1], true, func(c []byte) bool { return bytes.Compare(c, to) < 0 })
+	}
+	if m := reRead.FindStringSubmatch(stmt); m != nil {
+		return selectRows(m[1], true, func([]byte) bool { return true })
+	}
+
+	return &fakeRows{err: fmt.Errorf("unexpected query: %s", stmt)}
+}
+
+// fakeRows implements iRows for fake session query results
+type fakeRows struct {
+	rows [][]interface{}
+	err  error
+}
+
+func (r *fakeRows) scan(dest ...interface{}) bool {
+	if r.err != nil || len(r.rows) == 0 {
+		return false
+	}
+	row := r.rows[0]
+	r.rows = r.rows[1:]
+	for i, d := range dest {
+		switch v := d.(type) {
+		case *[]byte:
+			*v = append([]byte{}, row[i].([]byte)...)
+		case *string:
+			*v = row[i].(string)
+		case *int:
+			*v = row[i].(int)
+		}
+	}
+	return true
+}
+
+func (r *fakeRows) close() error {
+	return r.err
+}
@@ -0,0 +1,288 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package istoragecas
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	istorage "github.com/voedger/voedger/pkg/istorage"
+)
+
+type appStorageFactory struct {
+	params  ParamsType
+	session iSession
+}
+
+func (f *appStorageFactory) keyspaceExists(keyspace string) (bool, error) {
+	rows := f.session.query(context.Background(), stmtKeyspaceExists, keyspace)
+	name := ""
+	exists := rows.scan(&name)
+	if err := rows.close(); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// istorage.IAppStorageFactory.AppStorage
+func (f *appStorageFactory) AppStorage(appName istorage.SafeAppName) (storage istorage.IAppStorage, err error) {
+	keyspace := appName.String()
+	exists, err := f.keyspaceExists(keyspace)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, istorage.ErrStorageDoesNotExist
+	}
+	return newStorage(f.session, keyspace), nil
+}
+
+// istorage.IAppStorageFactory.Init
+func (f *appStorageFactory) Init(appName istorage.SafeAppName) error {
+	keyspace := appName.String()
+	exists, err := f.keyspaceExists(keyspace)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return istorage.ErrStorageAlreadyExists
+	}
+	if err = f.session.exec(fmt.Sprintf(stmtCreateKeyspace, keyspace, f.params.KeyspaceWithReplication)); err != nil {
+		return fmt.Errorf("can't create keyspace «%s»: %w", keyspace, err)
+	}
+	if err = f.session.exec(fmt.Sprintf(stmtCreateTable, keyspace)); err != nil {
+		return fmt.Errorf("can't create table «%s.values»: %w", keyspace, err)
+	}
+	return nil
+}
+
+// implementation for istorage.IAppStorage
+type appStorageType struct {
+	session iSession
+	insert  string
+	insTTL  string
+	insIfNE string
+	cas     string
+	get     string
+	getIn   string
+	read    string
+	readFr  string
+	readTo  string
+	readFT  string
+	del     string
+	delPart string
+	delFr   string
+	delTo   string
+	delFT   string
+}
+
+func newStorage(session iSession, keyspace string) *appStorageType {
+	return &appStorageType{
+		session: session,
+		insert:  fmt.Sprintf(stmtInsert, keyspace),
+		insTTL:  fmt.Sprintf(stmtInsertWithTTL, keyspace),
+		insIfNE: fmt.Sprintf(stmtInsertIfNotEx, keyspace),
+		cas:     fmt.Sprintf(stmtCompareAndSwap, keyspace),
+		get:     fmt.Sprintf(stmtGet, keyspace),
+		getIn:   fmt.Sprintf(stmtGetBatch, keyspace),
+		read:    fmt.Sprintf(stmtRead, keyspace),
+		readFr:  fmt.Sprintf(stmtReadFrom, keyspace),
+		readTo:  fmt.Sprintf(stmtReadTo, keyspace),
+		readFT:  fmt.Sprintf(stmtReadFromTo, keyspace),
+		del:     fmt.Sprintf(stmtDelete, keyspace),
+		delPart: fmt.Sprintf(stmtDeletePart, keyspace),
+		delFr:   fmt.Sprintf(stmtDeleteFrom, keyspace),
+		delTo:   fmt.Sprintf(stmtDeleteTo, keyspace),
+		delFT:   fmt.Sprintf(stmtDeleteFromTo, keyspace),
+	}
+}
+
+// Cassandra stores nil blob as null, so empty clustering columns must be passed as non-nil empty slice
+func safeCCols(cCols []byte) []byte {
+	if cCols == nil {
+		return []byte{}
+	}
+	return cCols
+}
+
+// Cassandra TTL is measured in seconds, so ttl is rounded up to whole seconds
+func ttlSeconds(ttl time.Duration) int {
+	return int((ttl + time.Second - 1) / time.Second)
+}
+
+// istorage.IAppStorage.Put
+func (s *appStorageType) Put(pKey []byte, cCols []byte, value []byte) (err error) {
+	return s.session.exec(s.insert, pKey, safeCCols(cCols), value)
+}
+
+// istorage.IAppStorage.PutWithTTL
+func (s *appStorageType) PutWithTTL(pKey []byte, cCols []byte, value []byte, ttl time.Duration) (err error) {
+	if ttl <= 0 {
+		return istorage.ErrInvalidTTL
+	}
+	return s.session.exec(s.insTTL, pKey, safeCCols(cCols), value, ttlSeconds(ttl))
+}
+
+// istorage.IAppStorage.PutBatch
+func (s *appStorageType) PutBatch(items []istorage.BatchItem) (err error) {
+	stmts := make([]statement, len(items))
+	for i, item := range items {
+		switch {
+		case item.TTL < 0:
+			return istorage.ErrInvalidTTL
+		case item.TTL > 0:
+			stmts[i] = statement{s.insTTL, []interface{}{item.PKey, safeCCols(item.CCols), item.Value, ttlSeconds(item.TTL)}}
+		default:
+			stmts[i] = statement{s.insert, []interface{}{item.PKey, safeCCols(item.CCols), item.Value}}
+		}
+	}
+	return s.session.execBatch(stmts)
+}
+
+// istorage.IAppStorage.Delete
+func (s *appStorageType) Delete(pKey []byte, cCols []byte) (err error) {
+	return s.session.exec(s.del, pKey, safeCCols(cCols))
+}
+
+// istorage.IAppStorage.DeleteRange
+func (s *appStorageType) DeleteRange(pKey []byte, startCCols, finishCCols []byte) (err error) {
+	switch {
+	case len(startCCols) == 0 && len(finishCCols) == 0:
+		return s.session.exec(s.delPart, pKey)
+	case len(startCCols) == 0:
+		return s.session.exec(s.delTo, pKey, finishCCols)
+	case len(finishCCols) == 0:
+		return s.session.exec(s.delFr, pKey, startCCols)
+	default:
+		if bytes.Compare(startCCols, finishCCols) >= 0 {
+			return nil // absurd range
+		}
+		return s.session.exec(s.delFT, pKey, startCCols, finishCCols)
+	}
+}
+
+// istorage.IAppStorage.InsertIfNotExists
+func (s *appStorageType) InsertIfNotExists(pKey []byte, cCols []byte, value []byte) (ok bool, err error) {
+	return s.session.execCAS(s.insIfNE, pKey, safeCCols(cCols), value)
+}
+
+// istorage.IAppStorage.CompareAndSwap
+func (s *appStorageType) CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte) (ok bool, err error) {
+	return s.session.execCAS(s.cas, newValue, pKey, safeCCols(cCols), oldValue)
+}
+
+// istorage.IAppStorage.Get
+func (s *appStorageType) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	rows := s.session.query(context.Background(), s.get, pKey, safeCCols(cCols))
+	value := make([]byte, 0)
+	if ok = rows.scan(&value); ok {
+		*data = append((*data)[0:0], value...)
+	}
+	return ok, rows.close()
+}
+
+// istorage.IAppStorage.GetBatch
+func (s *appStorageType) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err error) {
+	idx := make(map[string][]int, len(items))
+	cCols := make([][]byte, 0, len(items))
+	for i := range items {
+		items[i].Ok = false
+		items[i].Deadline = time.Time{}
+		c := string(items[i].CCols)
+		if _, ok := idx[c]; !ok {
+			cCols = append(cCols, safeCCols(items[i].CCols))
+		}
+		idx[c] = append(idx[c], i)
+	}
+
+	rows := s.session.query(context.Background(), s.getIn, pKey, cCols)
+	c := make([]byte, 0)
+	value := make([]byte, 0)
+	ttl := 0 // remaining seconds, null TTL of persistent record is scanned as zero
+	now := time.Now()
+	for rows.scan(&c, &value, &ttl) {
+		for _, i := range idx[string(c)] {
+			items[i].Ok = true
+			*items[i].Data = append((*items[i].Data)[0:0], value...)
+			if ttl > 0 {
+				items[i].Deadline = now.Add(time.Duration(ttl) * time.Second)
+			}
+		}
+	}
+	return rows.close()
+}
+
+// istorage.IAppStorage.Read
+func (s *appStorageType) Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	var rows iRows
+	switch {
+	case len(startCCols) == 0 && len(finishCCols) == 0:
+		rows = s.session.query(ctx, s.read, pKey)
+	case len(startCCols) == 0:
+		rows = s.session.query(ctx, s.readTo, pKey, finishCCols)
+	case len(finishCCols) == 0:
+		rows = s.session.query(ctx, s.readFr, pKey, startCCols)
+	default:
+		if bytes.Compare(startCCols, finishCCols) >= 0 {
+			return nil // absurd range
+		}
+		rows = s.session.query(ctx, s.readFT, pKey, startCCols, finishCCols)
+	}
+
+	c := make([]byte, 0)
+	value := make([]byte, 0)
+	for rows.scan(&c, &value) {
+		if ctx.Err() != nil {
+			break
+		}
+		if err = cb(c, value); err != nil {
+			_ = rows.close()
+			return err
+		}
+	}
+	return rows.close()
+}
+
+// gocqlSession implements iSession using gocql session
+type gocqlSession struct {
+	session *gocql.Session
+}
+
+func (s *gocqlSession) exec(stmt string, args ...interface{}) error {
+	return s.session.Query(stmt, args...).Consistency(gocql.Quorum).Exec()
+}
+
+func (s *gocqlSession) execCAS(stmt string, args ...interface{}) (applied bool, err error) {
+	return s.session.Query(stmt, args...).Consistency(gocql.Quorum).SerialConsistency(gocql.Serial).MapScanCAS(make(map[string]interface{}))
+}
+
+func (s *gocqlSession) execBatch(stmts []statement) error {
+	batch := s.session.NewBatch(gocql.LoggedBatch)
+	batch.SetConsistency(gocql.Quorum)
+	for _, st := range stmts {
+		batch.Query(st.stmt, st.args...)
+	}
+	return s.session.ExecuteBatch(batch)
+}
+
+func (s *gocqlSession) query(ctx context.Context, stmt string, args ...interface{}) iRows {
+	return &gocqlRows{s.session.Query(stmt, args...).Consistency(gocql.Quorum).WithContext(ctx).Iter()}
+}
+
+// gocqlRows implements iRows using gocql iterator
+type gocqlRows struct {
+	iter *gocql.Iter
+}
+
+func (r *gocqlRows) scan(dest ...interface{}) bool {
+	return r.iter.Scan(dest...)
+}
+
+func (r *gocqlRows) close() error {
+	return r.iter.Close()
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package istoragecas
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	istorage "github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorageimpl"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Comma separated cluster hosts to run tests against live Cassandra or ScyllaDB cluster
+const envClusterHosts = "ISTORAGECAS_TEST_HOSTS"
+
+func TestBasicUsage(t *testing.T) {
+	factory := provide(ParamsType{KeyspaceWithReplication: SimpleStrategyReplication}, newFakeSession())
+	istorage.TechnologyCompatibilityKit(t, factory)
+}
+
+func TestBasicUsage_Cluster(t *testing.T) {
+	hosts := os.Getenv(envClusterHosts)
+	if len(hosts) == 0 {
+		t.Skipf("%s is not set", envClusterHosts)
+	}
+	factory, err := Provide(ParamsType{
+		Hosts:                   strings.Split(hosts, ","),
+		KeyspaceWithReplication: SimpleStrategyReplication,
+	})
+	require.NoError(t, err)
+	istorage.TechnologyCompatibilityKit(t, factory)
+}
+
+func Test_MyTestBasicUsage(t *testing.T) {
+	require := require.New(t)
+
+	factory := provide(ParamsType{KeyspaceWithReplication: SimpleStrategyReplication}, newFakeSession())
+	storageProvider := istorageimpl.Provide(factory)
+
+	appStorage, err := storageProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	err = appStorage.Put([]byte("pKey"), []byte("cCols"), []byte("test data string"))
+	require.NoError(err)
+
+	value := make([]byte, 0)
+	ok, err := appStorage.Get([]byte("pKey"), []byte("cCols"), &value)
+	require.True(ok)
+	require.NoError(err)
+	require.Equal([]byte("test data string"), value)
+}
+
+func TestProvideErrors(t *testing.T) {
+	require := require.New(t)
+
+	_, err := Provide(ParamsType{KeyspaceWithReplication: SimpleStrategyReplication})
+	require.ErrorIs(err, ErrNoHosts)
+
+	_, err = Provide(ParamsType{Hosts: []string{"127.0.0.1"}})
+	require.ErrorIs(err, ErrNoKeyspaceReplication)
+}
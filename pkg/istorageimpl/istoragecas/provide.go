@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package istoragecas
+
+import (
+	"github.com/gocql/gocql"
+	istorage "github.com/voedger/voedger/pkg/istorage"
+)
+
+// Provide: connects to Cassandra or ScyllaDB cluster and returns storage factory.
+//
+// Application storage is a keyspace named by SafeAppName with single table «values»
+func Provide(params ParamsType) (istorage.IAppStorageFactory, error) {
+	if len(params.Hosts) == 0 {
+		return nil, ErrNoHosts
+	}
+	if len(params.KeyspaceWithReplication) == 0 {
+		return nil, ErrNoKeyspaceReplication
+	}
+
+	cluster := gocql.NewCluster(params.Hosts...)
+	cluster.Port = DefaultPort
+	if params.Port > 0 {
+		cluster.Port = params.Port
+	}
+	cluster.ProtoVersion = DefaultProtoVersion
+	if params.ProtoVersion > 0 {
+		cluster.ProtoVersion = params.ProtoVersion
+	}
+	cluster.Timeout = DefaultConnectionTimeout
+	if params.ConnectionTimeout > 0 {
+		cluster.Timeout = params.ConnectionTimeout
+	}
+	cluster.ConnectTimeout = cluster.Timeout
+	numRetries := DefaultNumRetries
+	if params.NumRetries > 0 {
+		numRetries = params.NumRetries
+	}
+	cluster.RetryPolicy = &gocql.SimpleRetryPolicy{NumRetries: numRetries}
+	cluster.Consistency = gocql.Quorum
+	if len(params.Username) > 0 {
+		cluster.Authenticator = gocql.PasswordAuthenticator{Username: params.Username, Password: params.Password}
+	}
+
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return nil, err
+	}
+	return provide(params, &gocqlSession{session}), nil
+}
+
+func provide(params ParamsType, session iSession) istorage.IAppStorageFactory {
+	return &appStorageFactory{
+		params:  params,
+		session: session,
+	}
+}
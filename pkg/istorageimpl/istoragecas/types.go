@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package istoragecas
+
+import (
+	"context"
+	"time"
+)
+
+// Cassandra or ScyllaDB cluster connection parameters
+type ParamsType struct {
+	// Cluster hosts, at least one is required
+	Hosts []string
+
+	// Native protocol port, DefaultPort is used if zero
+	Port int
+
+	Username string
+	Password string
+
+	// CQL native protocol version, DefaultProtoVersion is used if zero
+	ProtoVersion int
+
+	// DefaultConnectionTimeout is used if zero
+	ConnectionTimeout time.Duration
+
+	// Number of query retries, DefaultNumRetries is used if zero
+	NumRetries int
+
+	// Replication options used to create application keyspaces, e.g. SimpleStrategyReplication
+	KeyspaceWithReplication string
+}
+
+// iSession is CQL session abstraction.
+//
+// Implemented by gocql session wrapper and by in-memory fake in tests
+type iSession interface {
+	// Executes statement which returns no rows
+	exec(stmt string, args ...interface{}) error
+
+	// Executes lightweight transaction statement and returns is it applied
+	execCAS(stmt string, args ...interface{}) (applied bool, err error)
+
+	// Executes statements as logged batch
+	execBatch(stmts []statement) error
+
+	// Executes statement and returns result rows
+	query(ctx context.Context, stmt string, args ...interface{}) iRows
+}
+
+// iRows is query result rows iterator
+type iRows interface {
+	// Scans next row columns into dest. Returns false if no more rows or error occurs
+	scan(dest ...interface{}) bool
+
+	// Releases iterator and returns error if occurs while iterating
+	close() error
+}
+
+type statement struct {
+	stmt string
+	args []interface{}
+}
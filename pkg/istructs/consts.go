@@ -7,7 +7,11 @@
 
 package istructs
 
-import "github.com/voedger/voedger/pkg/appdef"
+import (
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
 
 // *********************************************************************************************************
 //
@@ -62,6 +66,20 @@ const (
 	ClusterAsCRecordRegisterID
 )
 
+// *********************************************************************************************************
+//
+//				Data types-related constants
+//
+
+// NullUUID is zero UUID value
+var NullUUID = UUID{}
+
+// DateLayout is used to represent dates as strings, e.g. in JSON
+const DateLayout = time.DateOnly
+
+// TimestampLayout is used to represent timestamps as strings, e.g. in JSON
+const TimestampLayout = time.RFC3339Nano
+
 // *********************************************************************************************************
 //
 //				Events-related constants
@@ -7,3 +7,9 @@ package istructs
 import "errors"
 
 var ErrAppNotFound = errors.New("application not found")
+
+var ErrInvalidDecimal = errors.New("invalid decimal value")
+
+var ErrDecimalOverflow = errors.New("decimal value overflow")
+
+var ErrInvalidUUID = errors.New("invalid UUID value")
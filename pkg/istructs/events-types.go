@@ -74,6 +74,22 @@ type ICUDRow interface {
 
 type IDGenerator func(custom RecordID, def appdef.IDef) (storage RecordID, err error)
 
+// Generates storage IDs of the new records and values of the named sequences of the workspaces.
+//
+// Generator serves the workspaces of the one partition, so it is used by the command processor of the partition only.
+// CDoc and CRecord IDs are generated in the separate range, see NewCDocCRecordID()
+type IIDGenerator interface {
+	// Returns the next storage ID for the new record of the definition
+	NextID(ws WSID, def appdef.IDef) (RecordID, error)
+
+	// Returns the next value of the named sequence, see ISequences
+	NextVal(ws WSID, sequence appdef.QName) (int64, error)
+
+	// Notifies that the ID is already used, e.g. by the event read from PLog on recovery.
+	// Next IDs of the same range will be greater than id
+	UpdateOnSync(ws WSID, id RecordID)
+}
+
 type IRawEvent interface {
 	IAbstractEvent
 	ArgumentUnloggedObject() IObject
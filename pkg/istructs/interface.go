@@ -57,6 +57,9 @@ type IAppStructs interface {
 
 	Uniques() IUniques
 
+	// Named sequences declared by the application, see SEQUENCE statement of the schema
+	Sequences() ISequences
+
 	SyncProjectors() []ProjectorFactory
 	AsyncProjectors() []ProjectorFactory
 
@@ -74,7 +77,8 @@ type IEvents interface {
 
 	// @ConcurrentAccess RW
 	// buildOrValidationErr taken either BuildRawEvent() or from extra validation
-	PutPlog(ev IRawEvent, buildOrValidationErr error, generator IDGenerator) (event IPLogEvent, saveErr error)
+	// views are the records of the event workspace views, they are written atomically with the valid event only
+	PutPlog(ev IRawEvent, buildOrValidationErr error, generator IDGenerator, views ...ViewKV) (event IPLogEvent, saveErr error)
 	PutWlog(ev IPLogEvent) (event IWLogEvent, saveErr error)
 
 	// @ConcurrentAccess R
@@ -105,6 +109,12 @@ type IRecords interface {
 	// qName must be a singletone
 	// If record not found NullRecord with QName() == NullQName is returned
 	GetSingleton(workspace WSID, qName appdef.QName) (record IRecord, err error)
+
+	// @ConcurrentAccess R
+	// Returns ID of active record which has specified unique key values.
+	// values must contain values for all fields of one of qName uniques, see IUniques
+	// If record not found NullRecordID is returned
+	GetUniqueID(workspace WSID, qName appdef.QName, values map[string]interface{}) (id RecordID, err error)
 }
 
 type RecordGetBatchItem struct {
@@ -135,6 +145,10 @@ type IViewRecords interface {
 	// Zero or more fields of key.ClusteringColumns can be specified
 	// If last clustering column has variable length it can be filled partially
 	Read(ctx context.Context, workspace WSID, key IKeyBuilder, cb ValuesCallback) (err error)
+
+	// Deletes all records of the partition
+	// All fields of key.PartitionKey MUST be specified (panic), clustering columns are ignored
+	DeletePartition(workspace WSID, key IKeyBuilder) (err error)
 }
 
 type ViewRecordGetBatchItem struct {
@@ -146,6 +160,7 @@ type ViewRecordGetBatchItem struct {
 type ViewKV struct {
 	Key   IKeyBuilder
 	Value IValueBuilder
+	TTL   time.Duration // zero means view record never expires
 }
 
 type ValuesCallback func(key IKey, value IValue) (err error)
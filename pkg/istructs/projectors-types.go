@@ -22,7 +22,23 @@ type Projector struct {
 
 	// If true, the actualizer also feds error events to istructs.Projector function. Default is false.
 	HandleErrors bool
+
+	// Dynamic by default. Lazy projection is supported by async actualizers only
+	Kind ProjectionKindType
+
+	// Views which are updated by projector. Must be specified for lazy projection
+	UpdatedViews []appdef.QName
 }
 
+type ProjectionKindType uint8
+
+const (
+	// Projection is updated with every PLog event
+	ProjectionKind_Dynamic ProjectionKindType = iota
+
+	// Projection of workspace is initialized from WLog on first request, then it is kept updated from PLog
+	ProjectionKind_Lazy
+)
+
 // ProjectorFactory creates a istructs.Projector
 type ProjectorFactory func(partition PartitionID) Projector
@@ -0,0 +1,33 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package istructs
+
+import "github.com/voedger/voedger/pkg/appdef"
+
+type ISequences interface {
+	// Returns the sequence by name. Not found -> nil
+	Sequence(name appdef.QName) ISequence
+
+	Sequences(cb func(ISequence))
+
+	// Returns the sequence which next value is the default of the record field, see DEFAULT NEXTVAL('sequence').
+	// None -> nil
+	FieldSequence(def appdef.QName, field string) ISequence
+}
+
+// Sequence values are StartWith, StartWith+Increment, StartWith+2*Increment and so on while they are in [MinValue, MaxValue]
+type ISequence interface {
+	QName() appdef.QName
+
+	// DataKind_int32 or DataKind_int64
+	DataKind() appdef.DataKind
+
+	StartWith() int64
+	MinValue() int64
+	MaxValue() int64
+
+	// Negative increment makes the descending sequence
+	Increment() int64
+}
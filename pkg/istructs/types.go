@@ -5,6 +5,8 @@
 package istructs
 
 import (
+	"time"
+
 	"github.com/voedger/voedger/pkg/appdef"
 )
 
@@ -38,6 +40,19 @@ type WSID IDType
 
 type ClusterID = uint16
 
+// Fixed-point decimal number: unscaled integer value and scale (number of digits after decimal point).
+//
+// 12.34 is represented as {value: 1234, scale: 2}.
+// Ref. utils.go for methods
+type Decimal struct {
+	value int64
+	scale uint8
+}
+
+// Universally unique identifier.
+// Ref. utils.go for methods
+type UUID [16]byte
+
 // Unique per cluster (Different clusters might have different ID for the same App)
 // 2^32 apps per clusters
 type ClusterAppID = uint32
@@ -63,6 +78,12 @@ type IRowReader interface {
 	AsQName(name string) appdef.QName
 	AsBool(name string) bool
 	AsRecordID(name string) RecordID
+	AsDecimal(name string) Decimal
+	// UTC time with milliseconds precision
+	AsTimestamp(name string) time.Time
+	// UTC midnight of date
+	AsDate(name string) time.Time
+	AsUUID(name string) UUID
 
 	// consts.NullRecord will be returned as null-values
 	RecordIDs(includeNulls bool, cb func(name string, value RecordID))
@@ -82,6 +103,14 @@ type IRowWriter interface {
 	PutQName(name string, value appdef.QName)
 	PutBool(name string, value bool)
 	PutRecordID(name string, value RecordID)
+	// Value is rescaled to field scale. If value is not fit field precision and scale then
+	// the value is not put and the error is returned when the row is built
+	PutDecimal(name string, value Decimal)
+	// Value is truncated to milliseconds
+	PutTimestamp(name string, value time.Time)
+	// Time part of value is truncated
+	PutDate(name string, value time.Time)
+	PutUUID(name string, value UUID)
 
 	// Tries to make conversion from value to a name type
 	PutNumber(name string, value float64)
@@ -9,8 +9,14 @@ package istructs
 
 import (
 	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/voedger/voedger/pkg/appdef"
 )
 
@@ -105,6 +111,254 @@ func (id RecordID) BaseRecordID() RecordID {
 	return id % RegisterFactor
 }
 
+// *********************************************************************************************************
+//
+//				Decimal
+//
+
+// Powers of ten for all available decimal scales
+var decimalPow10 = func() (p [appdef.MaxDecimalPrecision + 1]int64) {
+	p[0] = 1
+	for i := 1; i < len(p); i++ {
+		p[i] = p[i-1] * 10
+	}
+	return p
+}()
+
+// Returns decimal from unscaled value and scale, e.g. NewDecimal(1234, 2) is 12.34
+//
+// # Panics:
+//   - if scale exceeds appdef.MaxDecimalPrecision
+func NewDecimal(value int64, scale uint8) Decimal {
+	if scale > appdef.MaxDecimalPrecision {
+		panic(fmt.Errorf("scale %d exceeds %d digits: %w", scale, appdef.MaxDecimalPrecision, ErrDecimalOverflow))
+	}
+	return Decimal{value: value, scale: scale}
+}
+
+// Parses decimal from string, e.g. "-12.30". Result scale is the number of digits after decimal point
+func ParseDecimal(s string) (Decimal, error) {
+	str := s
+	neg := false
+	if strings.HasPrefix(str, "-") {
+		neg, str = true, str[1:]
+	} else {
+		str = strings.TrimPrefix(str, "+")
+	}
+
+	intPart, fracPart, _ := strings.Cut(str, ".")
+	if (intPart == "") && (fracPart == "") || !isDigits(intPart) || !isDigits(fracPart) {
+		return Decimal{}, fmt.Errorf("«%s» is not a decimal number: %w", s, ErrInvalidDecimal)
+	}
+
+	digits := strings.TrimLeft(intPart+fracPart, "0")
+	if (len(fracPart) > appdef.MaxDecimalPrecision) || (len(digits) > appdef.MaxDecimalPrecision) {
+		return Decimal{}, fmt.Errorf("«%s» exceeds %d digits: %w", s, appdef.MaxDecimalPrecision, ErrDecimalOverflow)
+	}
+
+	var value int64
+	if digits != "" {
+		v, err := strconv.ParseInt(digits, 10, 64)
+		if err != nil {
+			// notest: digits are checked above
+			return Decimal{}, fmt.Errorf("«%s» is not a decimal number: %w", s, ErrInvalidDecimal)
+		}
+		value = v
+	}
+	if neg {
+		value = -value
+	}
+
+	return NewDecimal(value, uint8(len(fracPart))), nil
+}
+
+// Returns decimal from float value rounded to specified scale
+func DecimalFromFloat64(v float64, scale uint8) (Decimal, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return Decimal{}, fmt.Errorf("%v is not a decimal number: %w", v, ErrInvalidDecimal)
+	}
+	if scale > appdef.MaxDecimalPrecision {
+		return Decimal{}, fmt.Errorf("scale %d exceeds %d digits: %w", scale, appdef.MaxDecimalPrecision, ErrDecimalOverflow)
+	}
+	f := math.Round(v * float64(decimalPow10[scale]))
+	if math.Abs(f) >= float64(decimalPow10[appdef.MaxDecimalPrecision]) {
+		return Decimal{}, fmt.Errorf("%v with scale %d exceeds %d digits: %w", v, scale, appdef.MaxDecimalPrecision, ErrDecimalOverflow)
+	}
+	return NewDecimal(int64(f), scale), nil
+}
+
+// Returns unscaled value, e.g. 1234 for 12.34
+func (d Decimal) Value() int64 { return d.value }
+
+// Returns number of digits after decimal point
+func (d Decimal) Scale() uint8 { return d.scale }
+
+// Returns number of digits in unscaled value. Zero value has no digits
+func (d Decimal) Digits() (digits int) {
+	for v := d.value; v != 0; v /= 10 {
+		digits++
+	}
+	return digits
+}
+
+// Returns decimal with specified scale and the same numeric value.
+//
+// Returns error if value can not be rescaled without digits loss or if result exceeds maximum digits
+func (d Decimal) Rescale(scale uint8) (Decimal, error) {
+	switch {
+	case scale > d.scale:
+		if scale > appdef.MaxDecimalPrecision {
+			return Decimal{}, fmt.Errorf("scale %d exceeds %d digits: %w", scale, appdef.MaxDecimalPrecision, ErrDecimalOverflow)
+		}
+		diff := int(scale - d.scale)
+		if (d.value != 0) && (d.Digits()+diff > appdef.MaxDecimalPrecision) {
+			return Decimal{}, fmt.Errorf("%v with scale %d exceeds %d digits: %w", d, scale, appdef.MaxDecimalPrecision, ErrDecimalOverflow)
+		}
+		return NewDecimal(d.value*decimalPow10[diff], scale), nil
+	case scale < d.scale:
+		m := decimalPow10[d.scale-scale]
+		if d.value%m != 0 {
+			return Decimal{}, fmt.Errorf("%v can not be rescaled to scale %d without digits loss: %w", d, scale, ErrInvalidDecimal)
+		}
+		return NewDecimal(d.value/m, scale), nil
+	}
+	return d, nil
+}
+
+// Returns exact sum of decimals with the greater scale of the two.
+//
+// Returns error if result exceeds maximum digits
+func (d Decimal) Add(x Decimal) (Decimal, error) {
+	scale := d.scale
+	if x.scale > scale {
+		scale = x.scale
+	}
+	a, err := d.Rescale(scale)
+	if err != nil {
+		return Decimal{}, err
+	}
+	b, err := x.Rescale(scale)
+	if err != nil {
+		return Decimal{}, err
+	}
+	sum := a.value + b.value // no int64 overflow: both values are less than 10^MaxDecimalPrecision
+	if (sum >= decimalPow10[appdef.MaxDecimalPrecision]) || (sum <= -decimalPow10[appdef.MaxDecimalPrecision]) {
+		return Decimal{}, fmt.Errorf("%v + %v exceeds %d digits: %w", d, x, appdef.MaxDecimalPrecision, ErrDecimalOverflow)
+	}
+	return NewDecimal(sum, scale), nil
+}
+
+// Compares decimals numeric values. Returns -1 if d < x, 0 if d == x and +1 if d > x
+func (d Decimal) Cmp(x Decimal) int {
+	scaled := func(v Decimal, scale uint8) *big.Int {
+		b := big.NewInt(v.value)
+		return b.Mul(b, big.NewInt(decimalPow10[scale-v.scale]))
+	}
+	scale := d.scale
+	if x.scale > scale {
+		scale = x.scale
+	}
+	return scaled(d, scale).Cmp(scaled(x, scale))
+}
+
+func (d Decimal) Float64() float64 {
+	return float64(d.value) / float64(decimalPow10[d.scale])
+}
+
+// Renders decimal with all scale digits, e.g. "-12.30"
+func (d Decimal) String() string {
+	var abs uint64
+	if d.value < 0 {
+		abs = uint64(-d.value)
+	} else {
+		abs = uint64(d.value)
+	}
+	s := strconv.FormatUint(abs, 10)
+	if d.scale > 0 {
+		if l := int(d.scale) + 1; len(s) < l {
+			s = strings.Repeat("0", l-len(s)) + s
+		}
+		s = s[:len(s)-int(d.scale)] + "." + s[len(s)-int(d.scale):]
+	}
+	if d.value < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// Decimal is marshaled as JSON number with all scale digits
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// Decimal can be unmarshaled from JSON number or from JSON string
+func (d *Decimal) UnmarshalJSON(text []byte) (err error) {
+	str := string(text)
+	if s, e := strconv.Unquote(str); e == nil {
+		str = s
+	}
+	*d, err = ParseDecimal(str)
+	return err
+}
+
+// Decimal is gob encoded as the string with all scale digits
+func (d Decimal) GobEncode() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// Decimal is gob decoded from the string with all scale digits
+func (d *Decimal) GobDecode(data []byte) (err error) {
+	*d, err = ParseDecimal(string(data))
+	return err
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if (c < '0') || (c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// *********************************************************************************************************
+//
+//				UUID
+//
+
+// Generates new random UUID
+func NewUUID() UUID {
+	return UUID(uuid.New())
+}
+
+// Parses UUID from string, e.g. "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+func ParseUUID(s string) (UUID, error) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return NullUUID, fmt.Errorf("«%s»: %w: %v", s, ErrInvalidUUID, err)
+	}
+	return UUID(u), nil
+}
+
+// Renders UUID in canonical form, e.g. "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+func (u UUID) String() string {
+	return uuid.UUID(u).String()
+}
+
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+func (u *UUID) UnmarshalText(text []byte) (err error) {
+	*u, err = ParseUUID(string(text))
+	return err
+}
+
+// *********************************************************************************************************
+//
+//				NullRowReader
+//
+
 // Implements IRowReader
 type NullRowReader struct{}
 
@@ -117,6 +371,10 @@ func (*NullRowReader) AsString(name string) string
 func (*NullRowReader) AsRecordID(name string) RecordID                                   { return NullRecordID }
 func (*NullRowReader) AsQName(name string) appdef.QName                                  { return appdef.NullQName }
 func (*NullRowReader) AsBool(name string) bool                                           { return false }
+func (*NullRowReader) AsDecimal(name string) Decimal                                     { return Decimal{} }
+func (*NullRowReader) AsTimestamp(name string) time.Time                                 { return time.Time{} }
+func (*NullRowReader) AsDate(name string) time.Time                                      { return time.Time{} }
+func (*NullRowReader) AsUUID(name string) UUID                                           { return NullUUID }
 func (*NullRowReader) RecordIDs(includeNulls bool, cb func(name string, value RecordID)) {}
 
 // Implements IObject
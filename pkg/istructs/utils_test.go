@@ -10,9 +10,12 @@
 package istructs
 
 import (
+	"bytes"
+	"encoding/gob"
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"strconv"
 	"testing"
 
@@ -333,6 +336,10 @@ func TestNullObject(t *testing.T) {
 	require.Equal(appdef.NullQName, null.AsQName(appdef.NullName))
 	require.Equal(false, null.AsBool(appdef.NullName))
 	require.Equal(NullRecordID, null.AsRecordID(appdef.NullName))
+	require.Equal(Decimal{}, null.AsDecimal(appdef.NullName))
+	require.True(null.AsTimestamp(appdef.NullName).IsZero())
+	require.True(null.AsDate(appdef.NullName).IsZero())
+	require.Equal(NullUUID, null.AsUUID(appdef.NullName))
 
 	require.Equal(appdef.NullQName, null.QName())
 
@@ -482,3 +489,151 @@ func TestValidatorMatchByQName(t *testing.T) {
 		require.False(ValidatorMatchByQName(v, qn3))
 	})
 }
+
+func TestBasicUsage_Decimal(t *testing.T) {
+	require := require.New(t)
+
+	d := NewDecimal(-1230, 2)
+	require.EqualValues(-1230, d.Value())
+	require.EqualValues(2, d.Scale())
+	require.Equal(4, d.Digits())
+	require.Equal("-12.30", d.String())
+	require.Equal(-12.3, d.Float64())
+
+	t.Run("parse", func(t *testing.T) {
+		tests := []struct {
+			s    string
+			want Decimal
+		}{
+			{"0", NewDecimal(0, 0)},
+			{"12.34", NewDecimal(1234, 2)},
+			{"+12.340", NewDecimal(12340, 3)},
+			{"-0.05", NewDecimal(-5, 2)},
+			{".5", NewDecimal(5, 1)},
+			{"7.", NewDecimal(7, 0)},
+			{"999999999999999999", NewDecimal(999999999999999999, 0)},
+		}
+		for _, tt := range tests {
+			d, err := ParseDecimal(tt.s)
+			require.NoError(err, tt.s)
+			require.Equal(tt.want, d, tt.s)
+		}
+
+		for _, s := range []string{"", ".", "-", "1.2.3", "1e5", "abc", "1,5"} {
+			_, err := ParseDecimal(s)
+			require.ErrorIs(err, ErrInvalidDecimal, s)
+		}
+		for _, s := range []string{"1000000000000000000", "0.0000000000000000001"} {
+			_, err := ParseDecimal(s)
+			require.ErrorIs(err, ErrDecimalOverflow, s)
+		}
+	})
+
+	t.Run("gob", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		require.NoError(gob.NewEncoder(&buf).Encode(NewDecimal(-1230, 2)))
+		d := Decimal{}
+		require.NoError(gob.NewDecoder(&buf).Decode(&d))
+		require.Equal(NewDecimal(-1230, 2), d)
+
+		require.ErrorIs(d.GobDecode([]byte("abc")), ErrInvalidDecimal)
+	})
+
+	t.Run("from float", func(t *testing.T) {
+		d, err := DecimalFromFloat64(12.345, 2)
+		require.NoError(err)
+		require.Equal(NewDecimal(1235, 2), d)
+
+		_, err = DecimalFromFloat64(1e18, 0)
+		require.ErrorIs(err, ErrDecimalOverflow)
+		_, err = DecimalFromFloat64(math.NaN(), 0)
+		require.ErrorIs(err, ErrInvalidDecimal)
+	})
+
+	t.Run("rescale", func(t *testing.T) {
+		d, err := NewDecimal(1234, 2).Rescale(4)
+		require.NoError(err)
+		require.Equal(NewDecimal(123400, 4), d)
+
+		d, err = d.Rescale(2)
+		require.NoError(err)
+		require.Equal(NewDecimal(1234, 2), d)
+
+		require.Equal(NewDecimal(123, 1), NewDecimal(1230, 2).mustRescale(1))
+		require.Equal("12.3", NewDecimal(1230, 2).mustRescale(1).String())
+		require.Equal("12.340", NewDecimal(1234, 2).mustRescale(3).String())
+
+		_, err = NewDecimal(1234, 2).Rescale(1)
+		require.ErrorIs(err, ErrInvalidDecimal)
+		_, err = NewDecimal(999999999999999999, 0).Rescale(1)
+		require.ErrorIs(err, ErrDecimalOverflow)
+	})
+
+	t.Run("add", func(t *testing.T) {
+		d, err := NewDecimal(1234, 2).Add(NewDecimal(-5, 3))
+		require.NoError(err)
+		require.Equal(NewDecimal(12335, 3), d)
+
+		_, err = NewDecimal(999999999999999999, 0).Add(NewDecimal(1, 0))
+		require.ErrorIs(err, ErrDecimalOverflow)
+		_, err = NewDecimal(-999999999999999999, 0).Add(NewDecimal(-1, 0))
+		require.ErrorIs(err, ErrDecimalOverflow)
+		_, err = NewDecimal(999999999999999999, 0).Add(NewDecimal(1, 1))
+		require.ErrorIs(err, ErrDecimalOverflow)
+	})
+
+	t.Run("compare", func(t *testing.T) {
+		require.Zero(NewDecimal(1230, 2).Cmp(NewDecimal(123, 1)))
+		require.Equal(-1, NewDecimal(-1, 0).Cmp(NewDecimal(1, 18)))
+		require.Equal(1, NewDecimal(1, 0).Cmp(NewDecimal(999, 3)))
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		b, err := json.Marshal(map[string]Decimal{"sum": NewDecimal(-5, 2)})
+		require.NoError(err)
+		require.Equal(`{"sum":-0.05}`, string(b))
+
+		m := map[string]Decimal{}
+		require.NoError(json.Unmarshal([]byte(`{"a":12.30,"b":"-7.5"}`), &m))
+		require.Equal(NewDecimal(1230, 2), m["a"])
+		require.Equal(NewDecimal(-75, 1), m["b"])
+
+		require.Error(json.Unmarshal([]byte(`{"a":true}`), &m))
+	})
+
+	require.Panics(func() { NewDecimal(1, appdef.MaxDecimalPrecision+1) })
+}
+
+func (d Decimal) mustRescale(scale uint8) Decimal {
+	r, err := d.Rescale(scale)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func TestBasicUsage_UUID(t *testing.T) {
+	require := require.New(t)
+
+	u := NewUUID()
+	require.NotEqual(NullUUID, u)
+	require.NotEqual(u, NewUUID())
+
+	u1, err := ParseUUID(u.String())
+	require.NoError(err)
+	require.Equal(u, u1)
+
+	_, err = ParseUUID("not-an-uuid")
+	require.ErrorIs(err, ErrInvalidUUID)
+
+	t.Run("JSON", func(t *testing.T) {
+		const s = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+		b, err := json.Marshal([]UUID{NullUUID})
+		require.NoError(err)
+		require.Equal(`["00000000-0000-0000-0000-000000000000"]`, string(b))
+
+		var uu []UUID
+		require.NoError(json.Unmarshal([]byte(`["`+s+`"]`), &uu))
+		require.Equal(s, uu[0].String())
+	})
+}
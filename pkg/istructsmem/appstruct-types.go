@@ -48,6 +48,7 @@ type AppConfigType struct {
 	AppDef        appdef.IAppDef
 	Resources     Resources
 	Uniques       *implIUniques
+	Sequences     *implISequences
 
 	dynoSchemes dynobuf.DynoBufSchemes
 	validators  *validators
@@ -83,6 +84,7 @@ func newAppConfig(appName istructs.AppQName, appDef appdef.IAppDefBuilder) *AppC
 	cfg.AppDef = app
 	cfg.Resources = newResources(&cfg)
 	cfg.Uniques = newUniques()
+	cfg.Sequences = newSequences()
 
 	cfg.dynoSchemes = dynobuf.New()
 	cfg.validators = newValidators()
@@ -147,6 +149,11 @@ func (cfg *AppConfigType) prepare(buckets irates.IBuckets, appStorage istorage.I
 		return err
 	}
 
+	// validate sequences
+	if err := cfg.Sequences.validate(cfg); err != nil {
+		return err
+	}
+
 	cfg.prepared = true
 	return nil
 }
@@ -5,6 +5,7 @@
 package istructsmem
 
 import (
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
@@ -43,6 +44,20 @@ const (
 
 var nullPrepareArgs = istructs.PrepareArgs{}
 
+// query function to look up record ID by unique key values, see AppConfigType.AddUniqueIDQuery
+var (
+	QNameQueryGetUniqueID       = appdef.NewQName(appdef.SysPackage, "GetUniqueID")
+	QNameQueryGetUniqueIDParams = appdef.NewQName(appdef.SysPackage, "GetUniqueIDParams")
+	QNameQueryGetUniqueIDResult = appdef.NewQName(appdef.SysPackage, "GetUniqueIDResult")
+)
+
+// sys.GetUniqueID query function parameters and result fields
+const (
+	Field_GetUniqueID_DocQName = "DocQName" // qualified name of document or record
+	Field_GetUniqueID_Values   = "Values"   // JSON object with unique key field values
+	Field_GetUniqueID_ID       = "ID"       // found record ID or NullRecordID if not found
+)
+
 // rate limits function name formats, see GetFunctionRateLimitName
 var funcRateLimitNameFmt = [istructs.RateLimitKind_FakeLast]string{
 	"func_%s_byApp",
@@ -69,10 +69,18 @@ var ErrUnknownKeyField = errors.New("unknown key field")
 
 var ErrUniquesHaveSameFields = errors.New("uniques have same fields")
 
+var ErrUniqueNotFound = errors.New("unique not found")
+
+var ErrUniqueConstraintViolation = errors.New("unique constraint violation")
+
 var ErrKeyFieldIsUsedMoreThanOnce = errors.New("key field is used more than once")
 
+var ErrWrongSequence = errors.New("wrong sequence")
+
 var ErrDefChanged = errors.New("definition has been changed")
 
+var ErrDataConstraintViolation = errors.New("data constraint violation")
+
 var ErrReferentialIntegrityViolation = errors.New("referencial integrity violation")
 
 const errFieldNotFoundWrap = "%s-type field «%s» is not found in definition «%v»: %w" // int32-type field «myField» is not found …
@@ -83,6 +91,8 @@ const errFieldMustBeVerificated = "field «%s» must be verificated, token expec
 
 const errFieldConvertErrorWrap = "field «%s» value type «%T» can not to be converted to «%s»: %w"
 
+const errDecimalOutOfRangeWrap = "decimal field «%s» value «%v» does not fit precision %d and scale %d: %w" // decimal field «amount» value «123.456» does not fit precision 5 and scale 2: …
+
 const errCantGetFieldQNameIDWrap = "QName field «%s» can not get ID for value «%v»: %w"
 
 const errDefNotFoundWrap = "definition «%v» not found: %w"
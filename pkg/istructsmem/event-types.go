@@ -432,6 +432,9 @@ func (cud *cudType) build() (err error) {
 		if _, err = rec.build(); err != nil {
 			return err
 		}
+		if err = rec.setDefaults(); err != nil {
+			return err
+		}
 	}
 
 	for _, rec := range cud.updates {
@@ -716,8 +719,10 @@ func newElement(parent *elementType) elementType {
 // build builds element record and all childs recursive
 func (el *elementType) build() (err error) {
 	return el.forEach(func(e *elementType) error {
-		_, err := e.rowType.build()
-		return err
+		if _, err := e.rowType.build(); err != nil {
+			return err
+		}
+		return e.setDefaults()
 	})
 }
 
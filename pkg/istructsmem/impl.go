@@ -7,9 +7,11 @@ package istructsmem
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"sync"
 
+	"github.com/untillpro/dynobuffers"
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/irates"
 	"github.com/voedger/voedger/pkg/istorage"
@@ -218,6 +220,10 @@ func (app *appStructsType) Uniques() istructs.IUniques {
 	return app.uniques
 }
 
+func (app *appStructsType) Sequences() istructs.ISequences {
+	return app.config.Sequences
+}
+
 // appEventsType implements IEvents
 //   - interfaces:
 //     — istructs.IEvents
@@ -244,7 +250,7 @@ func (e *appEventsType) GetNewRawEventBuilder(params istructs.NewRawEventBuilder
 }
 
 // istructs.IEvents.PutPlog
-func (e *appEventsType) PutPlog(ev istructs.IRawEvent, buildErr error, generator istructs.IDGenerator) (event istructs.IPLogEvent, err error) {
+func (e *appEventsType) PutPlog(ev istructs.IRawEvent, buildErr error, generator istructs.IDGenerator, views ...istructs.ViewKV) (event istructs.IPLogEvent, err error) {
 	dbEvent := newDbEvent(e.app.config)
 
 	dbEvent.eventType.copyFrom(ev.(*eventType))
@@ -264,7 +270,22 @@ func (e *appEventsType) PutPlog(ev istructs.IRawEvent, buildErr error, generator
 	if evData, err = dbEvent.storeToBytes(); err == nil {
 		pKey, cCols := splitLogOffset(ev.PLogOffset())
 		pKey = utils.PrefixBytes(pKey, consts.SysView_PLog, ev.HandlingPartition()) // + partition! see #18047
-		if err = e.app.config.storage.Put(pKey, cCols, evData); err == nil {
+		batch := []istorage.BatchItem{{PKey: pKey, CCols: cCols, Value: evData}}
+		if dbEvent.valid() {
+			// unique index is written atomically with PLog event
+			if batch, err = e.app.records.appendUniquesBatch(batch, &dbEvent.eventType); err != nil {
+				return nil, err
+			}
+			// view records are written atomically with PLog event too
+			for _, kv := range views {
+				item := istorage.BatchItem{TTL: kv.TTL}
+				if item.PKey, item.CCols, item.Value, err = e.app.veiwRecords.storeViewRecord(ev.Workspace(), kv.Key, kv.Value); err != nil {
+					return nil, err
+				}
+				batch = append(batch, item)
+			}
+		}
+		if err = e.app.config.storage.PutBatch(batch); err == nil {
 			event = &dbEvent
 		}
 	}
@@ -449,9 +470,58 @@ func (recs *appRecordsType) validEvent(ev *eventType) (err error) {
 		}
 	}
 
+	return recs.validUniques(ev)
+}
+
+// getUniqueID reads ID of record which owns specified unique key. Returns NullRecordID if key is not owned
+func (recs *appRecordsType) getUniqueID(key *uniqueKeyType) (id istructs.RecordID, err error) {
+	data := make([]byte, 0)
+	var ok bool
+	if ok, err = recs.app.config.storage.Get(key.pKey, key.cCols, &data); !ok || (err != nil) {
+		return istructs.NullRecordID, err
+	}
+	return istructs.RecordID(binary.BigEndian.Uint64(data)), nil
+}
+
+// validUniques returns error if event CUDs violate record uniques
+func (recs *appRecordsType) validUniques(ev *eventType) error {
+	changes, err := recs.app.config.Uniques.uniquesChanges(ev.ws, &ev.cud)
+	if err != nil {
+		return err
+	}
+	for s, key := range changes.claimed {
+		id, err := recs.getUniqueID(key)
+		if err != nil {
+			return err
+		}
+		if (id == istructs.NullRecordID) || (id == key.id) {
+			continue
+		}
+		if released, ok := changes.released[s]; ok && (released.id == id) {
+			continue // key is released by owner in the same event
+		}
+		return fmt.Errorf("record «%d» of «%v» has the same unique key values as existing record «%d»: %w", key.id, key.qName, id, ErrUniqueConstraintViolation)
+	}
 	return nil
 }
 
+// appendUniquesBatch appends to batch unique index changes made by event CUDs
+func (recs *appRecordsType) appendUniquesBatch(batch []istorage.BatchItem, ev *eventType) ([]istorage.BatchItem, error) {
+	changes, err := recs.app.config.Uniques.uniquesChanges(ev.ws, &ev.cud)
+	if err != nil {
+		return batch, err
+	}
+	for s, key := range changes.released {
+		if _, ok := changes.claimed[s]; !ok {
+			batch = append(batch, istorage.BatchItem{PKey: key.pKey, CCols: key.cCols, Value: utils.ToBytes(uint64(istructs.NullRecordID))})
+		}
+	}
+	for _, key := range changes.claimed {
+		batch = append(batch, istorage.BatchItem{PKey: key.pKey, CCols: key.cCols, Value: utils.ToBytes(uint64(key.id))})
+	}
+	return batch, nil
+}
+
 // istructs.IRecords.Apply
 func (recs *appRecordsType) Apply(event istructs.IPLogEvent) (err error) {
 	return recs.Apply2(event, func(_ istructs.IRecord) {})
@@ -535,3 +605,44 @@ func (recs *appRecordsType) GetSingleton(workspace istructs.WSID, qName appdef.Q
 	}
 	return recs.Get(workspace, true, id)
 }
+
+// istructs.IRecords.GetUniqueID
+func (recs *appRecordsType) GetUniqueID(workspace istructs.WSID, qName appdef.QName, values map[string]interface{}) (id istructs.RecordID, err error) {
+	fields := make([]string, 0, len(values))
+	for f := range values {
+		fields = append(fields, f)
+	}
+	unique := recs.app.config.Uniques.GetForKeySet(qName, fields)
+	if unique == nil {
+		return istructs.NullRecordID, fmt.Errorf("«%v» has no unique with fields %v: %w", qName, fields, ErrUniqueNotFound)
+	}
+
+	row := newRow(recs.app.config)
+	row.setQName(qName)
+	for f, v := range values {
+		switch value := v.(type) {
+		case float64:
+			row.PutNumber(f, value)
+		case string:
+			row.PutChars(f, value)
+		default:
+			row.putValue(f, dynobuffers.FieldTypeUnspecified, value)
+		}
+	}
+	if _, err = row.build(); err != nil {
+		return istructs.NullRecordID, err
+	}
+
+	num := 0
+	for i, u := range recs.app.config.Uniques.GetAll(qName) {
+		if u == unique {
+			num = i
+			break
+		}
+	}
+	key, err := newUniqueKey(workspace, &row, num, unique)
+	if err != nil {
+		return istructs.NullRecordID, err
+	}
+	return recs.getUniqueID(key)
+}
@@ -7,6 +7,7 @@ package istructsmem
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 
 	"github.com/stretchr/testify/require"
@@ -528,3 +529,103 @@ func Test_Provide(t *testing.T) {
 		require.Equal(istructs.AppQName_test1_app1, app.AppQName())
 	})
 }
+
+func Test_PutPlogViews(t *testing.T) {
+	require := require.New(t)
+	test := test()
+
+	docName := appdef.NewQName("test", "article")
+	viewName := appdef.NewQName("test", "articleEvents")
+	appDef := appdef.New()
+	appDef.AddStruct(docName, appdef.DefKind_CDoc).
+		AddField("number", appdef.DataKind_int32, true)
+	appDef.AddView(viewName).
+		AddPartField("pk", appdef.DataKind_int64).
+		AddClustColumn("offset", appdef.DataKind_int64).
+		AddValueField("number", appdef.DataKind_int32, true)
+
+	cfgs := AppConfigsType{}
+	cfg := cfgs.AddConfig(test.appName, appDef)
+	cfg.Uniques.Add(docName, []string{"number"})
+
+	asp := Provide(cfgs, iratesce.TestBucketsFactory, testTokensFactory(), simpleStorageProvder())
+	app, err := asp.AppStructs(test.appName)
+	require.NoError(err)
+
+	const ws = istructs.WSID(1)
+	nextID := istructs.FirstBaseRecordID
+	offset := istructs.Offset(100500)
+
+	viewKV := func(number int32) istructs.ViewKV {
+		kb := app.ViewRecords().KeyBuilder(viewName)
+		kb.PutInt64("pk", 1)
+		if number > 0 {
+			kb.PutInt64("offset", int64(offset))
+		}
+		vb := app.ViewRecords().NewValueBuilder(viewName)
+		vb.PutInt32("number", number)
+		return istructs.ViewKV{Key: kb, Value: vb}
+	}
+
+	putEvent := func(number int32, buildErr error) (err error) {
+		offset++
+		bld := app.Events().GetNewRawEventBuilder(
+			istructs.NewRawEventBuilderParams{
+				GenericRawEventBuilderParams: istructs.GenericRawEventBuilderParams{
+					HandlingPartition: 1,
+					PLogOffset:        offset,
+					Workspace:         ws,
+					WLogOffset:        offset,
+					QName:             istructs.QNameCommandCUD,
+					RegisteredAt:      istructs.UnixMilli(offset),
+				},
+			})
+		rec := bld.CUDBuilder().Create(docName)
+		rec.PutRecordID(appdef.SystemField_ID, 1)
+		rec.PutInt32("number", number)
+		rawEvent, err := bld.BuildRawEvent()
+		require.NoError(err)
+		pLogEvent, err := app.Events().PutPlog(rawEvent, buildErr,
+			func(istructs.RecordID, appdef.IDef) (istructs.RecordID, error) {
+				nextID++
+				return nextID, nil
+			},
+			viewKV(number))
+		if err != nil || buildErr != nil {
+			return err
+		}
+		return app.Records().Apply(pLogEvent)
+	}
+
+	viewNumbers := func() (numbers []int32) {
+		kb := app.ViewRecords().KeyBuilder(viewName)
+		kb.PutInt64("pk", 1)
+		require.NoError(app.ViewRecords().Read(context.Background(), ws, kb, func(_ istructs.IKey, value istructs.IValue) error {
+			numbers = append(numbers, value.AsInt32("number"))
+			return nil
+		}))
+		return numbers
+	}
+
+	t.Run("must be ok to write view records with the event", func(t *testing.T) {
+		require.NoError(putEvent(1, nil))
+		require.Equal([]int32{1}, viewNumbers())
+	})
+
+	t.Run("view records must not be written with the invalid event", func(t *testing.T) {
+		require.NoError(putEvent(2, errors.New("test error")))
+		require.Equal([]int32{1}, viewNumbers())
+	})
+
+	t.Run("event must not be written if view record is wrong", func(t *testing.T) {
+		require.Error(putEvent(0, nil))
+		require.Equal([]int32{1}, viewNumbers())
+
+		events := 0
+		require.NoError(app.Events().ReadPLog(context.Background(), 1, offset, 1, func(istructs.Offset, istructs.IPLogEvent) error {
+			events++
+			return nil
+		}))
+		require.Zero(events)
+	})
+}
@@ -14,4 +14,5 @@ const (
 	SysView_PLog                            // application PLog view
 	SysView_WLog                            // application WLog view
 	SysView_SingletonIDs                    // application singletons IDs view
+	SysView_Uniques                         // application records uniques view
 )
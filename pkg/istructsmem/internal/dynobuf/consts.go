@@ -11,18 +11,22 @@ import (
 )
 
 var dataKindToDynoFieldType = map[appdef.DataKind]dynobuffers.FieldType{
-	appdef.DataKind_null:     dynobuffers.FieldTypeUnspecified,
-	appdef.DataKind_int32:    dynobuffers.FieldTypeInt32,
-	appdef.DataKind_int64:    dynobuffers.FieldTypeInt64,
-	appdef.DataKind_float32:  dynobuffers.FieldTypeFloat32,
-	appdef.DataKind_float64:  dynobuffers.FieldTypeFloat64,
-	appdef.DataKind_bytes:    dynobuffers.FieldTypeByte,
-	appdef.DataKind_string:   dynobuffers.FieldTypeString,
-	appdef.DataKind_QName:    dynobuffers.FieldTypeByte, // two fixed bytes LittleEndian
-	appdef.DataKind_bool:     dynobuffers.FieldTypeBool,
-	appdef.DataKind_RecordID: dynobuffers.FieldTypeInt64,
-	appdef.DataKind_Record:   dynobuffers.FieldTypeByte,
-	appdef.DataKind_Event:    dynobuffers.FieldTypeByte,
+	appdef.DataKind_null:      dynobuffers.FieldTypeUnspecified,
+	appdef.DataKind_int32:     dynobuffers.FieldTypeInt32,
+	appdef.DataKind_int64:     dynobuffers.FieldTypeInt64,
+	appdef.DataKind_float32:   dynobuffers.FieldTypeFloat32,
+	appdef.DataKind_float64:   dynobuffers.FieldTypeFloat64,
+	appdef.DataKind_bytes:     dynobuffers.FieldTypeByte,
+	appdef.DataKind_string:    dynobuffers.FieldTypeString,
+	appdef.DataKind_QName:     dynobuffers.FieldTypeByte, // two fixed bytes LittleEndian
+	appdef.DataKind_bool:      dynobuffers.FieldTypeBool,
+	appdef.DataKind_RecordID:  dynobuffers.FieldTypeInt64,
+	appdef.DataKind_decimal:   dynobuffers.FieldTypeInt64, // unscaled value
+	appdef.DataKind_timestamp: dynobuffers.FieldTypeInt64, // UTC unix milliseconds
+	appdef.DataKind_date:      dynobuffers.FieldTypeInt32, // days from unix epoch
+	appdef.DataKind_UUID:      dynobuffers.FieldTypeByte,  // sixteen fixed bytes
+	appdef.DataKind_Record:    dynobuffers.FieldTypeByte,
+	appdef.DataKind_Event:     dynobuffers.FieldTypeByte,
 }
 
 var dynobufferFieldTypeToStr = map[dynobuffers.FieldType]string{
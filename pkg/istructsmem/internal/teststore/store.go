@@ -8,6 +8,7 @@ package teststore
 import (
 	"bytes"
 	"context"
+	"time"
 
 	"github.com/voedger/voedger/pkg/istorage"
 	"github.com/voedger/voedger/pkg/istorageimpl"
@@ -158,15 +159,68 @@ func (s *TestMemStorage) Put(pKey []byte, cCols []byte, value []byte) (err error
 	return s.storage.Put(pKey, cCols, value)
 }
 
+func (s *TestMemStorage) PutWithTTL(pKey []byte, cCols []byte, value []byte, ttl time.Duration) (err error) {
+	if s.put.err != nil {
+		if s.put.match(pKey, cCols) {
+			err = s.put.err
+			s.put.err = nil
+			return err
+		}
+	}
+	return s.storage.PutWithTTL(pKey, cCols, value, ttl)
+}
+
 func (s *TestMemStorage) PutBatch(items []istorage.BatchItem) (err error) {
 	for _, p := range items {
-		if err = s.Put(p.PKey, p.CCols, p.Value); err != nil {
+		if p.TTL > 0 {
+			err = s.PutWithTTL(p.PKey, p.CCols, p.Value, p.TTL)
+		} else {
+			err = s.Put(p.PKey, p.CCols, p.Value)
+		}
+		if err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+func (s *TestMemStorage) Delete(pKey []byte, cCols []byte) (err error) {
+	if s.put.err != nil {
+		if s.put.match(pKey, cCols) {
+			err = s.put.err
+			s.put.err = nil
+			return err
+		}
+	}
+	return s.storage.Delete(pKey, cCols)
+}
+
+func (s *TestMemStorage) DeleteRange(pKey []byte, startCCols, finishCCols []byte) (err error) {
+	return s.storage.DeleteRange(pKey, startCCols, finishCCols)
+}
+
+func (s *TestMemStorage) InsertIfNotExists(pKey []byte, cCols []byte, value []byte) (ok bool, err error) {
+	if s.put.err != nil {
+		if s.put.match(pKey, cCols) {
+			err = s.put.err
+			s.put.err = nil
+			return false, err
+		}
+	}
+	return s.storage.InsertIfNotExists(pKey, cCols, value)
+}
+
+func (s *TestMemStorage) CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte) (ok bool, err error) {
+	if s.put.err != nil {
+		if s.put.match(pKey, cCols) {
+			err = s.put.err
+			s.put.err = nil
+			return false, err
+		}
+	}
+	return s.storage.CompareAndSwap(pKey, cCols, oldValue, newValue)
+}
+
 func (s *TestMemStorage) Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
 	cbWrap := func(cCols []byte, data []byte) (err error) {
 		if s.get.err != nil {
@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package istructsmem
+
+import (
+	"fmt"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+type implISequence struct {
+	qName     appdef.QName
+	kind      appdef.DataKind
+	startWith int64
+	minValue  int64
+	maxValue  int64
+	increment int64
+}
+
+func (s *implISequence) QName() appdef.QName       { return s.qName }
+func (s *implISequence) DataKind() appdef.DataKind { return s.kind }
+func (s *implISequence) StartWith() int64          { return s.startWith }
+func (s *implISequence) MinValue() int64           { return s.minValue }
+func (s *implISequence) MaxValue() int64           { return s.maxValue }
+func (s *implISequence) Increment() int64          { return s.increment }
+
+type fieldSequenceKey struct {
+	def   appdef.QName
+	field string
+}
+
+func newSequences() *implISequences {
+	return &implISequences{
+		sequences: map[appdef.QName]*implISequence{},
+		fields:    map[fieldSequenceKey]appdef.QName{},
+	}
+}
+
+type implISequences struct {
+	sequences map[appdef.QName]*implISequence
+	// sequence names by record fields which defaults are next values of the sequences
+	fields map[fieldSequenceKey]appdef.QName
+}
+
+// Adds the sequence. Sequence is validated on application structures preparation
+func (s *implISequences) Add(name appdef.QName, kind appdef.DataKind, startWith, minValue, maxValue, increment int64) {
+	s.sequences[name] = &implISequence{
+		qName:     name,
+		kind:      kind,
+		startWith: startWith,
+		minValue:  minValue,
+		maxValue:  maxValue,
+		increment: increment,
+	}
+}
+
+// Makes the next value of the sequence the default of the record field
+func (s *implISequences) AddFieldDefault(def appdef.QName, field string, sequence appdef.QName) {
+	s.fields[fieldSequenceKey{def, field}] = sequence
+}
+
+func (s *implISequences) Sequence(name appdef.QName) istructs.ISequence {
+	if seq, ok := s.sequences[name]; ok {
+		return seq
+	}
+	return nil
+}
+
+func (s *implISequences) Sequences(cb func(istructs.ISequence)) {
+	for _, seq := range s.sequences {
+		cb(seq)
+	}
+}
+
+func (s *implISequences) FieldSequence(def appdef.QName, field string) istructs.ISequence {
+	if name, ok := s.fields[fieldSequenceKey{def, field}]; ok {
+		return s.Sequence(name)
+	}
+	return nil
+}
+
+func (s *implISequences) validate(cfg *AppConfigType) error {
+	for name, seq := range s.sequences {
+		if (seq.kind != appdef.DataKind_int32) && (seq.kind != appdef.DataKind_int64) {
+			return fmt.Errorf("sequence «%v» data kind is %v: %w", name, seq.kind, ErrWrongSequence)
+		}
+		if (seq.increment == 0) || (seq.minValue > seq.maxValue) || (seq.startWith < seq.minValue) || (seq.startWith > seq.maxValue) {
+			return fmt.Errorf("sequence «%v» has invalid range: start %d, min %d, max %d, increment %d: %w",
+				name, seq.startWith, seq.minValue, seq.maxValue, seq.increment, ErrWrongSequence)
+		}
+	}
+	for key, name := range s.fields {
+		seq, ok := s.sequences[name]
+		if !ok {
+			return fmt.Errorf("default of field «%s» of «%v» is unknown sequence «%v»: %w", key.field, key.def, name, ErrWrongSequence)
+		}
+		def := cfg.AppDef.DefByName(key.def)
+		if def == nil {
+			return fmt.Errorf("sequence «%v» default: %w: %v", name, ErrUnknownDefinitionQName, key.def)
+		}
+		if def.Kind() != appdef.DefKind_CDoc && def.Kind() != appdef.DefKind_WDoc && def.Kind() != appdef.DefKind_ODoc &&
+			def.Kind() != appdef.DefKind_CRecord && def.Kind() != appdef.DefKind_WRecord && def.Kind() != appdef.DefKind_ORecord {
+			return fmt.Errorf("sequence «%v» default: «%v» is %v, not a document or record: %w", name, key.def, def.Kind(), ErrWrongSequence)
+		}
+		fld := def.Field(key.field)
+		if fld == nil {
+			return fmt.Errorf("sequence «%v» default: «%v» has no field «%s»: %w", name, key.def, key.field, ErrWrongSequence)
+		}
+		if fld.DataKind() != seq.kind {
+			return fmt.Errorf("sequence «%v» default: field «%s» of «%v» is %v, sequence is %v: %w", name, key.field, key.def, fld.DataKind(), seq.kind, ErrWrongSequence)
+		}
+	}
+	return nil
+}
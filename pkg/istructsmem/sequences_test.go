@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package istructsmem
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+func TestBasicUsage_Sequences(t *testing.T) {
+	require := require.New(t)
+	test := test()
+
+	docName := appdef.NewQName("my", "doc")
+	seqName := appdef.NewQName("my", "numbers")
+	appDef := appdef.New()
+	appDef.AddStruct(docName, appdef.DefKind_CDoc).
+		AddField("number", appdef.DataKind_int32, true)
+
+	cfgs := AppConfigsType{}
+	cfg := cfgs.AddConfig(test.appName, appDef)
+	cfg.Sequences.Add(seqName, appdef.DataKind_int32, 1000, 1, 9999, 1)
+	cfg.Sequences.AddFieldDefault(docName, "number", seqName)
+
+	asp := Provide(cfgs, iratesce.TestBucketsFactory, testTokensFactory(), simpleStorageProvder())
+	as, err := asp.AppStructs(test.appName)
+	require.NoError(err)
+	seqs := as.Sequences()
+
+	seq := seqs.Sequence(seqName)
+	require.Equal(seqName, seq.QName())
+	require.Equal(appdef.DataKind_int32, seq.DataKind())
+	require.EqualValues(1000, seq.StartWith())
+	require.EqualValues(1, seq.MinValue())
+	require.EqualValues(9999, seq.MaxValue())
+	require.EqualValues(1, seq.Increment())
+	require.Nil(seqs.Sequence(appdef.NewQName("my", "unknown")))
+
+	cnt := 0
+	seqs.Sequences(func(istructs.ISequence) { cnt++ })
+	require.Equal(1, cnt)
+
+	require.Equal(seq, seqs.FieldSequence(docName, "number"))
+	require.Nil(seqs.FieldSequence(docName, "unknown"))
+}
+
+func Test_SequencesValidation(t *testing.T) {
+	require := require.New(t)
+	test := test()
+
+	docName := appdef.NewQName("my", "doc")
+	seqName := appdef.NewQName("my", "numbers")
+
+	cases := map[string]func(s *implISequences){
+		"wrong data kind":  func(s *implISequences) { s.Add(seqName, appdef.DataKind_string, 1, 1, 10, 1) },
+		"zero increment":   func(s *implISequences) { s.Add(seqName, appdef.DataKind_int32, 1, 1, 10, 0) },
+		"start out of min": func(s *implISequences) { s.Add(seqName, appdef.DataKind_int32, 0, 1, 10, 1) },
+		"min above max":    func(s *implISequences) { s.Add(seqName, appdef.DataKind_int32, 1, 10, 1, 1) },
+		"unknown sequence": func(s *implISequences) { s.AddFieldDefault(docName, "number", seqName) },
+		"unknown field": func(s *implISequences) {
+			s.Add(seqName, appdef.DataKind_int32, 1, 1, 10, 1)
+			s.AddFieldDefault(docName, "unknown", seqName)
+		},
+		"wrong field kind": func(s *implISequences) {
+			s.Add(seqName, appdef.DataKind_int64, 1, 1, 10, 1)
+			s.AddFieldDefault(docName, "number", seqName)
+		},
+	}
+	for name, add := range cases {
+		t.Run(name, func(t *testing.T) {
+			appDef := appdef.New()
+			appDef.AddStruct(docName, appdef.DefKind_CDoc).
+				AddField("number", appdef.DataKind_int32, true)
+			cfgs := AppConfigsType{}
+			cfg := cfgs.AddConfig(test.appName, appDef)
+			add(cfg.Sequences)
+
+			asp := Provide(cfgs, iratesce.TestBucketsFactory, testTokensFactory(), simpleStorageProvder())
+			_, err := asp.AppStructs(test.appName)
+			require.ErrorIs(err, ErrWrongSequence)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"time"
 
 	dynobuffers "github.com/untillpro/dynobuffers"
 	"github.com/voedger/voedger/pkg/appdef"
@@ -23,7 +24,8 @@ import (
 // If value type is not corresponding to kind then next conversions are available:
 //
 //	— float64 value can be converted to all numeric kinds (int32, int64, float32, float64, RecordID)
-//	— string value can be converted to QName and []byte kinds
+//	— string value can be converted to QName, []byte, date and UUID kinds
+//	— float64 value can be converted to timestamp kind as unix milliseconds
 //
 // QName values, record- and event- values returned as []byte
 func (row *rowType) dynoBufValue(value interface{}, kind appdef.DataKind) (interface{}, error) {
@@ -105,6 +107,35 @@ func (row *rowType) dynoBufValue(value interface{}, kind appdef.DataKind) (inter
 		case istructs.RecordID:
 			return int64(v), nil
 		}
+	case appdef.DataKind_timestamp:
+		switch v := value.(type) {
+		case float64:
+			return int64(v), nil
+		case time.Time:
+			return v.UnixMilli(), nil
+		}
+	case appdef.DataKind_date:
+		switch v := value.(type) {
+		case string:
+			t, err := time.Parse(istructs.DateLayout, v)
+			if err != nil {
+				return nil, err
+			}
+			return dateToDays(t), nil
+		case time.Time:
+			return dateToDays(v), nil
+		}
+	case appdef.DataKind_UUID:
+		switch v := value.(type) {
+		case string:
+			u, err := istructs.ParseUUID(v)
+			if err != nil {
+				return nil, err
+			}
+			return u[:], nil
+		case istructs.UUID:
+			return v[:], nil
+		}
 	case appdef.DataKind_Record:
 		switch v := value.(type) {
 		case *recordType:
@@ -11,6 +11,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/untillpro/dynobuffers"
 	"github.com/voedger/voedger/pkg/appdef"
@@ -350,6 +351,48 @@ func (row *rowType) setDef(value appdef.IDef) {
 	}
 }
 
+// Puts field default values into fields what have no values and rebuilds row if some defaults are put.
+//
+// Must be called for new rows after build
+func (row *rowType) setDefaults() (err error) {
+	row.def.Fields(
+		func(f appdef.IField) {
+			value := f.Default()
+			if (value == nil) || row.hasValue(f.Name()) {
+				return
+			}
+			switch f.DataKind() {
+			case appdef.DataKind_int32:
+				row.PutInt32(f.Name(), value.(int32))
+			case appdef.DataKind_int64:
+				row.PutInt64(f.Name(), value.(int64))
+			case appdef.DataKind_float32:
+				row.PutFloat32(f.Name(), value.(float32))
+			case appdef.DataKind_float64:
+				row.PutFloat64(f.Name(), value.(float64))
+			case appdef.DataKind_bytes:
+				row.PutBytes(f.Name(), value.([]byte))
+			case appdef.DataKind_string:
+				row.PutString(f.Name(), value.(string))
+			case appdef.DataKind_QName:
+				row.PutQName(f.Name(), value.(appdef.QName))
+			case appdef.DataKind_bool:
+				row.PutBool(f.Name(), value.(bool))
+			case appdef.DataKind_decimal, appdef.DataKind_UUID:
+				row.PutChars(f.Name(), value.(string))
+			case appdef.DataKind_timestamp:
+				row.PutTimestamp(f.Name(), value.(time.Time))
+			case appdef.DataKind_date:
+				row.PutDate(f.Name(), value.(time.Time))
+			}
+		})
+
+	if row.dyB.IsModified() {
+		_, err = row.build()
+	}
+	return err
+}
+
 // Stores row to bytes and returns error if occurs
 func (row *rowType) storeToBytes() (out []byte, err error) {
 	buf := new(bytes.Buffer)
@@ -522,6 +565,52 @@ func (row *rowType) AsRecordID(name string) istructs.RecordID {
 	return istructs.NullRecordID
 }
 
+// istructs.IRowReader.AsDecimal
+func (row *rowType) AsDecimal(name string) istructs.Decimal {
+	fld := row.def.Field(name)
+	if fld == nil {
+		panic(fmt.Errorf(errFieldNotFoundWrap, appdef.DataKind_decimal.ToString(), name, row.QName(), ErrNameNotFound))
+	}
+	if value, ok := row.dyB.GetInt64(name); ok {
+		return istructs.NewDecimal(value, fld.Scale())
+	}
+	return istructs.NewDecimal(0, fld.Scale())
+}
+
+// istructs.IRowReader.AsTimestamp
+func (row *rowType) AsTimestamp(name string) time.Time {
+	if value, ok := row.dyB.GetInt64(name); ok {
+		return time.UnixMilli(value).UTC()
+	}
+	if row.def.Field(name) == nil {
+		panic(fmt.Errorf(errFieldNotFoundWrap, appdef.DataKind_timestamp.ToString(), name, row.QName(), ErrNameNotFound))
+	}
+	return time.Time{}
+}
+
+// istructs.IRowReader.AsDate
+func (row *rowType) AsDate(name string) time.Time {
+	if value, ok := row.dyB.GetInt32(name); ok {
+		return daysToDate(value)
+	}
+	if row.def.Field(name) == nil {
+		panic(fmt.Errorf(errFieldNotFoundWrap, appdef.DataKind_date.ToString(), name, row.QName(), ErrNameNotFound))
+	}
+	return time.Time{}
+}
+
+// istructs.IRowReader.AsUUID
+func (row *rowType) AsUUID(name string) (value istructs.UUID) {
+	if b := row.dyB.GetByteArray(name); b != nil {
+		copy(value[:], b.Bytes())
+		return value
+	}
+	if row.def.Field(name) == nil {
+		panic(fmt.Errorf(errFieldNotFoundWrap, appdef.DataKind_UUID.ToString(), name, row.QName(), ErrNameNotFound))
+	}
+	return istructs.NullUUID
+}
+
 // IValue.AsRecord
 func (row *rowType) AsRecord(name string) istructs.IRecord {
 	if bytes := row.dyB.GetByteArray(name); bytes != nil {
@@ -638,6 +727,15 @@ func (row *rowType) PutNumber(name string, value float64) {
 		row.dyB.Set(name, value)
 	case appdef.DataKind_RecordID:
 		row.PutRecordID(name, istructs.RecordID(value))
+	case appdef.DataKind_decimal:
+		d, err := istructs.DecimalFromFloat64(value, fld.Scale())
+		if err != nil {
+			row.collectErrorf(errFieldConvertErrorWrap, name, value, appdef.DataKind_decimal.ToString(), err)
+			return
+		}
+		row.PutDecimal(name, d)
+	case appdef.DataKind_timestamp:
+		row.PutTimestamp(name, time.UnixMilli(int64(value)))
 	default:
 		row.collectErrorf(errFieldValueTypeMismatchWrap, appdef.DataKind_float64.ToString(), k, name, ErrWrongFieldType)
 	}
@@ -705,6 +803,34 @@ func (row *rowType) PutChars(name string, value string) {
 			return
 		}
 		row.PutQName(name, qName)
+	case appdef.DataKind_decimal:
+		d, err := istructs.ParseDecimal(value)
+		if err != nil {
+			row.collectErrorf(errFieldConvertErrorWrap, name, value, appdef.DataKind_decimal.ToString(), err)
+			return
+		}
+		row.PutDecimal(name, d)
+	case appdef.DataKind_timestamp:
+		t, err := time.Parse(istructs.TimestampLayout, value)
+		if err != nil {
+			row.collectErrorf(errFieldConvertErrorWrap, name, value, appdef.DataKind_timestamp.ToString(), err)
+			return
+		}
+		row.PutTimestamp(name, t)
+	case appdef.DataKind_date:
+		t, err := time.Parse(istructs.DateLayout, value)
+		if err != nil {
+			row.collectErrorf(errFieldConvertErrorWrap, name, value, appdef.DataKind_date.ToString(), err)
+			return
+		}
+		row.PutDate(name, t)
+	case appdef.DataKind_UUID:
+		u, err := istructs.ParseUUID(value)
+		if err != nil {
+			row.collectErrorf(errFieldConvertErrorWrap, name, value, appdef.DataKind_UUID.ToString(), err)
+			return
+		}
+		row.PutUUID(name, u)
 	default:
 		row.collectErrorf(errFieldValueTypeMismatchWrap, appdef.DataKind_string.ToString(), k, name, ErrWrongFieldType)
 	}
@@ -734,6 +860,45 @@ func (row *rowType) PutRecordID(name string, value istructs.RecordID) {
 	row.putValue(name, dynobuffers.FieldTypeInt64, int64(value))
 }
 
+// istructs.IRowWriter.PutDecimal
+func (row *rowType) PutDecimal(name string, value istructs.Decimal) {
+	fld := row.def.Field(name)
+	if fld == nil {
+		row.collectErrorf(errFieldNotFoundWrap, appdef.DataKind_decimal.ToString(), name, row.QName(), ErrNameNotFound)
+		return
+	}
+	if fld.DataKind() != appdef.DataKind_decimal {
+		row.collectErrorf(errFieldValueTypeMismatchWrap, appdef.DataKind_decimal.ToString(), fld.DataKind().ToString(), name, ErrWrongFieldType)
+		return
+	}
+
+	d, err := value.Rescale(fld.Scale())
+	if err == nil && d.Digits() > int(fld.Precision()) {
+		err = istructs.ErrDecimalOverflow
+	}
+	if err != nil {
+		row.collectErrorf(errDecimalOutOfRangeWrap, name, value, fld.Precision(), fld.Scale(), err)
+		return
+	}
+
+	row.putValue(name, dynobuffers.FieldTypeInt64, d.Value())
+}
+
+// istructs.IRowWriter.PutTimestamp
+func (row *rowType) PutTimestamp(name string, value time.Time) {
+	row.putValue(name, dynobuffers.FieldTypeInt64, value.UnixMilli())
+}
+
+// istructs.IRowWriter.PutDate
+func (row *rowType) PutDate(name string, value time.Time) {
+	row.putValue(name, dynobuffers.FieldTypeInt32, dateToDays(value))
+}
+
+// istructs.IRowWriter.PutUUID
+func (row *rowType) PutUUID(name string, value istructs.UUID) {
+	row.putValue(name, dynobuffers.FieldTypeByte, value[:])
+}
+
 // istructs.IValueBuilder.PutRecord
 func (row *rowType) PutRecord(name string, record istructs.IRecord) {
 	if rec, ok := record.(*recordType); ok {
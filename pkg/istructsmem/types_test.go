@@ -8,9 +8,11 @@ package istructsmem
 import (
 	"encoding/binary"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iratesce"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem/internal/qnames"
 )
@@ -271,6 +273,134 @@ func Test_rowType_PutAs_SimpleTypes(t *testing.T) {
 	})
 }
 
+func Test_rowType_PutAs_DecimalDateTimeUUID(t *testing.T) {
+	require := require.New(t)
+
+	docName := appdef.NewQName("test", "doc")
+
+	cfgs := make(AppConfigsType, 1)
+	cfg := func() *AppConfigType {
+		appDef := appdef.New()
+		appDef.AddStruct(docName, appdef.DefKind_CDoc).
+			AddDecimalField("amount", 10, 2, false).
+			AddField("qty", appdef.DataKind_decimal, false).
+			AddField("moment", appdef.DataKind_timestamp, false).
+			AddField("day", appdef.DataKind_date, false).
+			AddField("uuid", appdef.DataKind_UUID, false)
+		return cfgs.AddConfig(istructs.AppQName_test1_app1, appDef)
+	}()
+	_, err := Provide(cfgs, iratesce.TestBucketsFactory, testTokensFactory(), simpleStorageProvder()).AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	newDoc := func() *rowType {
+		row := newRow(cfg)
+		row.setQName(docName)
+		return &row
+	}
+
+	moment := time.Date(2023, time.April, 1, 12, 34, 56, 789_123_456, time.FixedZone("MSK", 3*60*60))
+	uuid := istructs.NewUUID()
+
+	t.Run("Put××× and As××× row methods", func(t *testing.T) {
+		row := newDoc()
+		row.PutDecimal("amount", istructs.NewDecimal(-1235, 2))
+		row.PutDecimal("qty", istructs.NewDecimal(700, 2)) // must be rescaled to field scale 0
+		row.PutTimestamp("moment", moment)
+		row.PutDate("day", moment)
+		row.PutUUID("uuid", uuid)
+		_, err := row.build()
+		require.NoError(err)
+
+		testRow := func(row *rowType) {
+			require.Equal(istructs.NewDecimal(-1235, 2), row.AsDecimal("amount"))
+			require.Equal("-12.35", row.AsDecimal("amount").String())
+			require.Equal(istructs.NewDecimal(7, 0), row.AsDecimal("qty"))
+			require.Equal(moment.Truncate(time.Millisecond).UTC(), row.AsTimestamp("moment"))
+			require.Equal(time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC), row.AsDate("day"))
+			require.Equal(uuid, row.AsUUID("uuid"))
+		}
+
+		testRow(row)
+
+		t.Run("must be ok to store and load row", func(t *testing.T) {
+			b, err := row.storeToBytes()
+			require.NoError(err)
+
+			row2 := newRow(cfg)
+			require.NoError(row2.loadFromBytes(b))
+			testRow(&row2)
+		})
+	})
+
+	t.Run("As××× row methods must return default values if not calls Put×××", func(t *testing.T) {
+		row := newDoc()
+		_, err := row.build()
+		require.NoError(err)
+
+		require.Equal(istructs.NewDecimal(0, 2), row.AsDecimal("amount"))
+		require.True(row.AsTimestamp("moment").IsZero())
+		require.True(row.AsDate("day").IsZero())
+		require.Equal(istructs.NullUUID, row.AsUUID("uuid"))
+
+		require.Panics(func() { row.AsDecimal("unknown") })
+		require.Panics(func() { row.AsTimestamp("unknown") })
+		require.Panics(func() { row.AsDate("unknown") })
+		require.Panics(func() { row.AsUUID("unknown") })
+	})
+
+	t.Run("PutNumber and PutChars must be available (json)", func(t *testing.T) {
+		row := newDoc()
+		row.PutNumber("amount", 12.345)
+		row.PutChars("qty", "42")
+		row.PutChars("moment", "2023-04-01T09:34:56.789Z")
+		row.PutChars("day", "2023-04-01")
+		row.PutChars("uuid", uuid.String())
+		_, err := row.build()
+		require.NoError(err)
+
+		require.Equal("12.35", row.AsDecimal("amount").String())
+		require.Equal(istructs.NewDecimal(42, 0), row.AsDecimal("qty"))
+		require.Equal(moment.Truncate(time.Millisecond).UTC(), row.AsTimestamp("moment"))
+		require.Equal(time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC), row.AsDate("day"))
+		require.Equal(uuid, row.AsUUID("uuid"))
+
+		row = newDoc()
+		row.PutNumber("moment", float64(moment.UnixMilli()))
+		row.PutChars("amount", "12.3")
+		_, err = row.build()
+		require.NoError(err)
+		require.Equal(moment.Truncate(time.Millisecond).UTC(), row.AsTimestamp("moment"))
+		require.Equal("12.30", row.AsDecimal("amount").String())
+	})
+
+	t.Run("Put××× errors", func(t *testing.T) {
+		testPut := func(put func(row *rowType), expected error) {
+			row := newDoc()
+			put(row)
+			_, err := row.build()
+			require.Error(err)
+			if expected != nil {
+				require.ErrorIs(err, expected)
+			}
+		}
+
+		testPut(func(row *rowType) { row.PutDecimal("amount", istructs.NewDecimal(123456789, 0)) }, istructs.ErrDecimalOverflow)
+		testPut(func(row *rowType) { row.PutDecimal("amount", istructs.NewDecimal(1, 3)) }, istructs.ErrInvalidDecimal)
+		testPut(func(row *rowType) { row.PutDecimal("moment", istructs.NewDecimal(1, 0)) }, ErrWrongFieldType)
+		testPut(func(row *rowType) { row.PutDecimal("unknown", istructs.NewDecimal(1, 0)) }, ErrNameNotFound)
+		testPut(func(row *rowType) { row.PutTimestamp("day", moment) }, ErrWrongFieldType)
+		testPut(func(row *rowType) { row.PutDate("moment", moment) }, ErrWrongFieldType)
+		testPut(func(row *rowType) { row.PutUUID("amount", uuid) }, ErrWrongFieldType)
+
+		testPut(func(row *rowType) { row.PutNumber("amount", 1e10) }, istructs.ErrDecimalOverflow)
+		testPut(func(row *rowType) { row.PutNumber("day", 1) }, ErrWrongFieldType)
+		testPut(func(row *rowType) { row.PutChars("amount", "1.2.3") }, istructs.ErrInvalidDecimal)
+		testPut(func(row *rowType) { row.PutChars("moment", "yesterday") }, nil)
+		testPut(func(row *rowType) { row.PutChars("day", "01.04.2023") }, nil)
+		testPut(func(row *rowType) { row.PutChars("uuid", "uuid") }, istructs.ErrInvalidUUID)
+	})
+}
+
 func Test_rowType_PutAs_ComplexTypes(t *testing.T) {
 	require := require.New(t)
 	test := test()
@@ -5,10 +5,15 @@
 package istructsmem
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem/internal/consts"
+	"github.com/voedger/voedger/pkg/istructsmem/internal/utils"
 )
 
 type implIUnique struct {
@@ -154,3 +159,158 @@ func uniqueError(qName appdef.QName, err error, name string) error {
 	}
 	return fmt.Errorf(mes, qName, err, name)
 }
+
+// uniqueKeyType is a key of unique index view record.
+//
+// Partition key contains record definition QNameID and unique number,
+// clustering columns contain values of unique key fields.
+// Value of view record is ID of record which owns the key or NullRecordID if key is released
+type uniqueKeyType struct {
+	pKey  []byte
+	cCols []byte
+	qName appdef.QName
+	id    istructs.RecordID
+}
+
+func (key *uniqueKeyType) String() string {
+	return string(key.pKey) + string(key.cCols)
+}
+
+// newUniqueKey returns unique index view key for specified row and unique
+func newUniqueKey(ws istructs.WSID, row *rowType, uniqueNum int, unique istructs.IUnique) (key *uniqueKeyType, err error) {
+	qNameID, err := row.qNameID()
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	for _, f := range unique.Fields() {
+		// only one variable size field is allowed in unique, so values are concatenated without delimiters
+		utils.SafeWriteBuf(buf, row.dyB.Get(f))
+	}
+	return &uniqueKeyType{
+		pKey:  utils.PrefixBytes(utils.ToBytes(uint16(qNameID), uint16(uniqueNum)), consts.SysView_Uniques, ws),
+		cCols: buf.Bytes(),
+		qName: row.QName(),
+		id:    row.ID(),
+	}, nil
+}
+
+// rowUniqueKeys calls cb for each unique key of specified row
+func (u *implIUniques) rowUniqueKeys(ws istructs.WSID, row *rowType, cb func(key *uniqueKeyType) error) error {
+	for i, unique := range u.uniques[row.QName()] {
+		key, err := newUniqueKey(ws, row, i, unique)
+		if err != nil {
+			return err
+		}
+		if err = cb(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uniquesChangesType contains unique index keys which should be released and claimed by event CUDs
+type uniquesChangesType struct {
+	released map[string]*uniqueKeyType
+	claimed  map[string]*uniqueKeyType
+}
+
+// uniquesChanges returns unique index keys released and claimed by specified CUDs.
+//
+// Returns error if the same key is claimed by different records
+func (u *implIUniques) uniquesChanges(ws istructs.WSID, cud *cudType) (changes uniquesChangesType, err error) {
+	changes = uniquesChangesType{
+		released: make(map[string]*uniqueKeyType),
+		claimed:  make(map[string]*uniqueKeyType),
+	}
+
+	claim := func(key *uniqueKeyType) error {
+		if exists, ok := changes.claimed[key.String()]; ok && (exists.id != key.id) {
+			return fmt.Errorf("records «%d» and «%d» of «%v» have the same unique key values: %w", exists.id, key.id, key.qName, ErrUniqueConstraintViolation)
+		}
+		changes.claimed[key.String()] = key
+		return nil
+	}
+
+	for _, rec := range cud.creates {
+		if !rec.IsActive() {
+			continue
+		}
+		if err = u.rowUniqueKeys(ws, &rec.rowType, claim); err != nil {
+			return changes, err
+		}
+	}
+
+	for _, upd := range cud.updates {
+		if len(u.uniques[upd.result.QName()]) == 0 {
+			continue
+		}
+		oldKeys := make(map[string]*uniqueKeyType)
+		if upd.originRec.IsActive() {
+			if err = u.rowUniqueKeys(ws, &upd.originRec.rowType, func(key *uniqueKeyType) error {
+				oldKeys[key.String()] = key
+				return nil
+			}); err != nil {
+				return changes, err
+			}
+		}
+		newKeys := make(map[string]*uniqueKeyType)
+		if upd.result.IsActive() {
+			if err = u.rowUniqueKeys(ws, &upd.result.rowType, func(key *uniqueKeyType) error {
+				newKeys[key.String()] = key
+				return nil
+			}); err != nil {
+				return changes, err
+			}
+		}
+		for s, key := range oldKeys {
+			if _, ok := newKeys[s]; !ok {
+				changes.released[s] = key
+			}
+		}
+		for s, key := range newKeys {
+			if _, ok := oldKeys[s]; !ok {
+				if err = claim(key); err != nil {
+					return changes, err
+				}
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// Adds sys.GetUniqueID query function to look up active record ID by unique key values.
+//
+// Query parameters are record QName and JSON object with values of all unique key fields,
+// result object contains record ID or NullRecordID if record is not found
+func (cfg *AppConfigType) AddUniqueIDQuery() {
+	cfg.appDefBuilder.AddStruct(QNameQueryGetUniqueIDParams, appdef.DefKind_Object).
+		AddField(Field_GetUniqueID_DocQName, appdef.DataKind_string, true).
+		AddField(Field_GetUniqueID_Values, appdef.DataKind_string, true)
+	cfg.appDefBuilder.AddStruct(QNameQueryGetUniqueIDResult, appdef.DefKind_Object).
+		AddField(Field_GetUniqueID_ID, appdef.DataKind_RecordID, true)
+
+	cfg.Resources.Add(NewQueryFunction(QNameQueryGetUniqueID, QNameQueryGetUniqueIDParams, QNameQueryGetUniqueIDResult,
+		func(_ context.Context, _ istructs.IQueryFunction, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) error {
+			qName, err := appdef.ParseQName(args.ArgumentObject.AsString(Field_GetUniqueID_DocQName))
+			if err != nil {
+				return err
+			}
+			values := make(map[string]interface{})
+			if err := json.Unmarshal([]byte(args.ArgumentObject.AsString(Field_GetUniqueID_Values)), &values); err != nil {
+				return err
+			}
+			id, err := cfg.app.records.GetUniqueID(args.Workspace, qName, values)
+			if err != nil {
+				return err
+			}
+			res := NewIObjectBuilder(cfg, QNameQueryGetUniqueIDResult)
+			res.PutRecordID(Field_GetUniqueID_ID, id)
+			obj, err := res.Build()
+			if err != nil {
+				return err
+			}
+			return callback(obj)
+		}))
+}
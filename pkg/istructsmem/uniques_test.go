@@ -5,11 +5,13 @@
 package istructsmem
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/istructs"
 )
 
 func TestBasicUsage_Uniques(t *testing.T) {
@@ -73,3 +75,184 @@ func TestBasicUsage_Uniques(t *testing.T) {
 		require.Panics(func() { iu.GetForKeySet(qName, []string{"a", "a"}) })
 	})
 }
+
+func Test_UniquesEnforcement(t *testing.T) {
+	require := require.New(t)
+	test := test()
+
+	docName := appdef.NewQName("test", "article")
+	appDef := appdef.New()
+	appDef.AddStruct(docName, appdef.DefKind_CDoc).
+		AddField("number", appdef.DataKind_int32, true).
+		AddField("name", appdef.DataKind_string, true)
+
+	cfgs := AppConfigsType{}
+	cfg := cfgs.AddConfig(test.appName, appDef)
+	cfg.Uniques.Add(docName, []string{"number"})
+	cfg.AddUniqueIDQuery()
+
+	asp := Provide(cfgs, iratesce.TestBucketsFactory, testTokensFactory(), simpleStorageProvder())
+	app, err := asp.AppStructs(test.appName)
+	require.NoError(err)
+
+	const ws = istructs.WSID(1)
+	nextID := istructs.FirstBaseRecordID
+	offset := istructs.Offset(100500)
+
+	putEvent := func(cuds func(cud istructs.ICUD)) (newIDs map[istructs.RecordID]istructs.RecordID, err error) {
+		offset++
+		bld := app.Events().GetNewRawEventBuilder(
+			istructs.NewRawEventBuilderParams{
+				GenericRawEventBuilderParams: istructs.GenericRawEventBuilderParams{
+					HandlingPartition: 1,
+					PLogOffset:        offset,
+					Workspace:         ws,
+					WLogOffset:        offset,
+					QName:             istructs.QNameCommandCUD,
+					RegisteredAt:      istructs.UnixMilli(offset),
+				},
+			})
+		cuds(bld.CUDBuilder())
+		rawEvent, buildErr := bld.BuildRawEvent()
+		if buildErr != nil {
+			return nil, buildErr
+		}
+		newIDs = make(map[istructs.RecordID]istructs.RecordID)
+		pLogEvent, err := app.Events().PutPlog(rawEvent, nil,
+			func(rawID istructs.RecordID, _ appdef.IDef) (istructs.RecordID, error) {
+				nextID++
+				newIDs[rawID] = nextID
+				return nextID, nil
+			})
+		require.NoError(err)
+		require.NoError(app.Records().Apply(pLogEvent))
+		return newIDs, nil
+	}
+
+	create := func(rawID istructs.RecordID, number int32) func(cud istructs.ICUD) {
+		return func(cud istructs.ICUD) {
+			rec := cud.Create(docName)
+			rec.PutRecordID(appdef.SystemField_ID, rawID)
+			rec.PutInt32("number", number)
+			rec.PutString("name", "article")
+		}
+	}
+
+	update := func(id istructs.RecordID, cb func(rec istructs.IRowWriter)) func(cud istructs.ICUD) {
+		return func(cud istructs.ICUD) {
+			rec, err := app.Records().Get(ws, true, id)
+			require.NoError(err)
+			cb(cud.Update(rec))
+		}
+	}
+
+	uniqueID := func(number int32) istructs.RecordID {
+		id, err := app.Records().GetUniqueID(ws, docName, map[string]interface{}{"number": float64(number)})
+		require.NoError(err)
+		return id
+	}
+
+	var id1, id2 istructs.RecordID
+
+	t.Run("must be ok to create records with different unique values", func(t *testing.T) {
+		ids, err := putEvent(create(1, 1))
+		require.NoError(err)
+		id1 = ids[1]
+
+		ids, err = putEvent(create(1, 2))
+		require.NoError(err)
+		id2 = ids[1]
+
+		require.Equal(id1, uniqueID(1))
+		require.Equal(id2, uniqueID(2))
+		require.Equal(istructs.NullRecordID, uniqueID(3))
+	})
+
+	t.Run("must fail to create record with existing unique values", func(t *testing.T) {
+		_, err := putEvent(create(1, 1))
+		require.ErrorIs(err, ErrUniqueConstraintViolation)
+	})
+
+	t.Run("must fail to create records with the same unique values in one event", func(t *testing.T) {
+		_, err := putEvent(func(cud istructs.ICUD) {
+			create(1, 3)(cud)
+			create(2, 3)(cud)
+		})
+		require.ErrorIs(err, ErrUniqueConstraintViolation)
+		require.Equal(istructs.NullRecordID, uniqueID(3))
+	})
+
+	t.Run("must fail to update record to existing unique values", func(t *testing.T) {
+		_, err := putEvent(update(id2, func(rec istructs.IRowWriter) { rec.PutInt32("number", 1) }))
+		require.ErrorIs(err, ErrUniqueConstraintViolation)
+	})
+
+	t.Run("must be ok to update record not key fields", func(t *testing.T) {
+		_, err := putEvent(update(id1, func(rec istructs.IRowWriter) { rec.PutString("name", "renamed") }))
+		require.NoError(err)
+		require.Equal(id1, uniqueID(1))
+	})
+
+	t.Run("must be ok to update record unique values and release old ones", func(t *testing.T) {
+		_, err := putEvent(update(id1, func(rec istructs.IRowWriter) { rec.PutInt32("number", 3) }))
+		require.NoError(err)
+		require.Equal(istructs.NullRecordID, uniqueID(1))
+		require.Equal(id1, uniqueID(3))
+
+		_, err = putEvent(update(id2, func(rec istructs.IRowWriter) { rec.PutInt32("number", 1) }))
+		require.NoError(err)
+		require.Equal(id2, uniqueID(1))
+		require.Equal(istructs.NullRecordID, uniqueID(2))
+	})
+
+	t.Run("must be ok to swap unique values in one event", func(t *testing.T) {
+		_, err := putEvent(func(cud istructs.ICUD) {
+			update(id1, func(rec istructs.IRowWriter) { rec.PutInt32("number", 1) })(cud)
+			update(id2, func(rec istructs.IRowWriter) { rec.PutInt32("number", 3) })(cud)
+		})
+		require.NoError(err)
+		require.Equal(id1, uniqueID(1))
+		require.Equal(id2, uniqueID(3))
+	})
+
+	t.Run("deactivation must release unique values", func(t *testing.T) {
+		_, err := putEvent(update(id1, func(rec istructs.IRowWriter) { rec.PutBool(appdef.SystemField_IsActive, false) }))
+		require.NoError(err)
+		require.Equal(istructs.NullRecordID, uniqueID(1))
+
+		ids, err := putEvent(create(1, 1))
+		require.NoError(err)
+		require.Equal(ids[1], uniqueID(1))
+
+		t.Run("must fail to activate record with occupied unique values", func(t *testing.T) {
+			_, err := putEvent(update(id1, func(rec istructs.IRowWriter) { rec.PutBool(appdef.SystemField_IsActive, true) }))
+			require.ErrorIs(err, ErrUniqueConstraintViolation)
+		})
+	})
+
+	t.Run("must fail to get unique ID by unknown key fields", func(t *testing.T) {
+		id, err := app.Records().GetUniqueID(ws, docName, map[string]interface{}{"name": "article"})
+		require.ErrorIs(err, ErrUniqueNotFound)
+		require.Equal(istructs.NullRecordID, id)
+	})
+
+	t.Run("must be ok to get unique ID by sys.GetUniqueID query", func(t *testing.T) {
+		query := app.Resources().QueryResource(QNameQueryGetUniqueID).(istructs.IQueryFunction)
+
+		args := NewIObjectBuilder(cfg, QNameQueryGetUniqueIDParams)
+		args.PutString(Field_GetUniqueID_DocQName, docName.String())
+		args.PutString(Field_GetUniqueID_Values, `{"number":3}`)
+		argsObj, err := args.Build()
+		require.NoError(err)
+
+		var result istructs.RecordID
+		err = query.Exec(context.Background(),
+			istructs.ExecQueryArgs{PrepareArgs: istructs.PrepareArgs{ArgumentObject: argsObj, Workspace: ws}},
+			func(obj istructs.IObject) error {
+				result = obj.AsRecordID(Field_GetUniqueID_ID)
+				return nil
+			})
+		require.NoError(err)
+		require.Equal(id2, result)
+	})
+}
@@ -8,6 +8,7 @@ package istructsmem
 import (
 	"encoding/binary"
 	"fmt"
+	"time"
 
 	"github.com/untillpro/dynobuffers"
 	"github.com/voedger/voedger/pkg/appdef"
@@ -80,6 +81,18 @@ func calcLogOffset(pk, cc []byte) istructs.Offset {
 	return uncrackLogOffset(hi, low)
 }
 
+// dateToDays returns number of days from unix epoch to date of specified time. Time part is truncated
+func dateToDays(t time.Time) int32 {
+	const secondsPerDay = 24 * 60 * 60
+	y, m, d := t.Date()
+	return int32(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / secondsPerDay)
+}
+
+// daysToDate returns UTC midnight of date specified by number of days from unix epoch
+func daysToDate(days int32) time.Time {
+	return time.Unix(0, 0).UTC().AddDate(0, 0, int(days))
+}
+
 // used in tests only
 func IBucketsFromIAppStructs(as istructs.IAppStructs) irates.IBuckets {
 	// appStructs implementation has method Buckets()
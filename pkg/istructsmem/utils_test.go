@@ -6,9 +6,11 @@
 package istructsmem
 
 import (
+	"encoding/json"
 	"reflect"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/voedger/voedger/pkg/appdef"
@@ -208,6 +210,41 @@ func TestElementFillAndGet(t *testing.T) {
 		require.Equal(1, count)
 	})
 
+	t.Run("decimal, timestamp, date and UUID", func(t *testing.T) {
+		docName := appdef.NewQName("test", "doc")
+		cfgs := make(AppConfigsType, 1)
+		cfg := func() *AppConfigType {
+			appDef := appdef.New()
+			appDef.AddStruct(docName, appdef.DefKind_CDoc).
+				AddDecimalField("amount", 10, 2, false).
+				AddField("moment", appdef.DataKind_timestamp, false).
+				AddField("day", appdef.DataKind_date, false).
+				AddField("uuid", appdef.DataKind_UUID, false)
+			return cfgs.AddConfig(istructs.AppQName_test1_app1, appDef)
+		}()
+		_, err := Provide(cfgs, iratesce.TestBucketsFactory, testTokensFactory(), simpleStorageProvder()).AppStructs(istructs.AppQName_test1_app1)
+		require.NoError(err)
+
+		data := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(`{
+			"sys.ID": 1,
+			"amount": 12.5,
+			"moment": "2023-04-01T10:00:00.123Z",
+			"day": "2023-04-01",
+			"uuid": "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+		}`), &data))
+
+		builder := NewIObjectBuilder(cfg, docName)
+		require.NoError(FillElementFromJSON(data, cfg.AppDef.Def(docName), builder))
+		o, err := builder.Build()
+		require.NoError(err)
+
+		require.Equal("12.50", o.AsDecimal("amount").String())
+		require.Equal(time.Date(2023, time.April, 1, 10, 0, 0, 123_000_000, time.UTC), o.AsTimestamp("moment"))
+		require.Equal(time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC), o.AsDate("day"))
+		require.Equal("6ba7b810-9dad-11d1-80b4-00c04fd430c8", o.AsUUID("uuid").String())
+	})
+
 	t.Run("type errors", func(t *testing.T) {
 		cases := map[string]interface{}{
 			"int32":   "str",
@@ -5,7 +5,11 @@
 
 package istructsmem
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
 
 // validate error codes, see ValidateError.Code()
 const (
@@ -27,6 +31,8 @@ const (
 	ECode_InvalidElementName
 	ECode_InvalidOccursMin
 	ECode_InvalidOccursMax
+
+	ECode_DataConstraintViolation
 )
 
 type validateErrorType struct {
@@ -53,3 +59,28 @@ func validateError(code int, err error) ValidateError {
 func validateErrorf(code int, format string, a ...interface{}) ValidateError {
 	return validateError(code, fmt.Errorf(format, a...))
 }
+
+// Describes violation of field value constraint.
+//
+// Can be extracted from validate error by errors.As()
+type DataConstraintError struct {
+	// Definition name of validated row
+	Def appdef.QName
+
+	// Field name
+	Field string
+
+	// Violated constraint
+	Constraint appdef.IConstraint
+
+	// Field value
+	Value interface{}
+}
+
+func (e *DataConstraintError) Error() string {
+	return fmt.Sprintf("field «%s» of «%v» value «%v» violates constraint «%v»: %v", e.Field, e.Def, e.Value, e.Constraint, ErrDataConstraintViolation)
+}
+
+func (e *DataConstraintError) Unwrap() error {
+	return ErrDataConstraintViolation
+}
@@ -8,6 +8,8 @@ package istructsmem
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"unicode/utf8"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
@@ -171,17 +173,102 @@ func (v *validator) validRecord(rec *recordType, rawIDexpected bool) (err error)
 func (v *validator) validRow(row *rowType) (err error) {
 	v.def.Fields(
 		func(f appdef.IField) {
-			if f.Required() {
-				if !row.hasValue(f.Name()) {
+			if !row.hasValue(f.Name()) {
+				if f.Required() {
 					err = errors.Join(err,
 						validateErrorf(ECode_EmptyData, "%s misses field «%s» required by definition «%v»: %w", v.entName(row), f.Name(), v.def.QName(), ErrNameNotFound))
 				}
+				return
 			}
+			err = errors.Join(err,
+				v.validRowFieldConstraints(row, f))
 		})
 
 	return err
 }
 
+// Validates row field value by field constraints
+func (v *validator) validRowFieldConstraints(row *rowType, f appdef.IField) (err error) {
+	f.Constraints(
+		func(c appdef.IConstraint) {
+			if value, ok := checkConstraint(row, f, c); !ok {
+				err = errors.Join(err,
+					validateErrorf(ECode_DataConstraintViolation, "%s has not valid data: %w", v.entName(row), &DataConstraintError{Def: v.def.QName(), Field: f.Name(), Constraint: c, Value: value}))
+			}
+		})
+	return err
+}
+
+// Checks row field value by constraint. Returns checked value and is constraint satisfied
+func checkConstraint(row *rowType, f appdef.IField, c appdef.IConstraint) (value interface{}, ok bool) {
+	name := f.Name()
+
+	switch c.Kind() {
+	case appdef.ConstraintKind_MinLen, appdef.ConstraintKind_MaxLen:
+		var l int
+		if f.DataKind() == appdef.DataKind_bytes {
+			b := row.AsBytes(name)
+			value, l = b, len(b)
+		} else {
+			s := row.AsString(name)
+			value, l = s, utf8.RuneCountInString(s)
+		}
+		if c.Kind() == appdef.ConstraintKind_MinLen {
+			return value, l >= int(c.Value().(uint16))
+		}
+		return value, l <= int(c.Value().(uint16))
+
+	case appdef.ConstraintKind_Pattern:
+		s := row.AsString(name)
+		return s, c.Value().(*regexp.Regexp).MatchString(s)
+
+	case appdef.ConstraintKind_MinIncl, appdef.ConstraintKind_MinExcl, appdef.ConstraintKind_MaxIncl, appdef.ConstraintKind_MaxExcl:
+		var n float64
+		switch f.DataKind() {
+		case appdef.DataKind_int32:
+			value, n = row.AsInt32(name), float64(row.AsInt32(name))
+		case appdef.DataKind_int64:
+			value, n = row.AsInt64(name), float64(row.AsInt64(name))
+		case appdef.DataKind_float32:
+			value, n = row.AsFloat32(name), float64(row.AsFloat32(name))
+		case appdef.DataKind_float64:
+			value, n = row.AsFloat64(name), row.AsFloat64(name)
+		case appdef.DataKind_decimal:
+			d := row.AsDecimal(name)
+			value, n = d, d.Float64()
+		}
+		bound := c.Value().(float64)
+		switch c.Kind() {
+		case appdef.ConstraintKind_MinIncl:
+			return value, n >= bound
+		case appdef.ConstraintKind_MinExcl:
+			return value, n > bound
+		case appdef.ConstraintKind_MaxIncl:
+			return value, n <= bound
+		default:
+			return value, n < bound
+		}
+
+	case appdef.ConstraintKind_Enum:
+		switch f.DataKind() {
+		case appdef.DataKind_int32:
+			value = row.AsInt32(name)
+		case appdef.DataKind_int64:
+			value = row.AsInt64(name)
+		case appdef.DataKind_string:
+			value = row.AsString(name)
+		}
+		for _, e := range c.Value().([]interface{}) {
+			if e == value {
+				return value, true
+			}
+		}
+		return value, false
+	}
+
+	return nil, true
+}
+
 // Validate specified object
 func (v *validator) validObject(obj *elementType) error {
 	return v.validElement(obj, false)
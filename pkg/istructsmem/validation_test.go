@@ -1163,3 +1163,140 @@ func Test_ValidateErrors(t *testing.T) {
 		require.Equal(ECode_InvalidOccursMax, validateErr.Code())
 	})
 }
+
+func Test_FieldConstraintsAndDefaults(t *testing.T) {
+	require := require.New(t)
+
+	docName := appdef.NewQName("test", "document")
+	objName := appdef.NewQName("test", "object")
+
+	appDef := appdef.New()
+
+	t.Run("must be ok to build test application definition", func(t *testing.T) {
+		docDef := appDef.AddStruct(docName, appdef.DefKind_CDoc)
+		docDef.
+			AddField("code", appdef.DataKind_string, true).
+			AddField("data", appdef.DataKind_bytes, false).
+			AddField("kind", appdef.DataKind_int32, false).
+			AddField("qty", appdef.DataKind_int64, false).
+			AddDecimalField("price", 10, 2, false).
+			AddField("comment", appdef.DataKind_string, false).
+			SetFieldConstraints("code", appdef.MinLen(2), appdef.MaxLen(4), appdef.Pattern(`^[A-Z]+$`)).
+			SetFieldConstraints("data", appdef.MaxLen(2)).
+			SetFieldConstraints("kind", appdef.Enum(1, 2, 3)).
+			SetFieldConstraints("qty", appdef.MinExcl(0), appdef.MaxIncl(100)).
+			SetFieldConstraints("price", appdef.MinIncl(0), appdef.MaxExcl(1000)).
+			SetFieldDefault("kind", 1).
+			SetFieldDefault("qty", 1).
+			SetFieldDefault("price", "9.99").
+			SetFieldDefault("comment", "no comments")
+
+		objDef := appDef.AddStruct(objName, appdef.DefKind_Object)
+		objDef.
+			AddField("name", appdef.DataKind_string, false).
+			AddField("moment", appdef.DataKind_timestamp, false).
+			SetFieldConstraints("name", appdef.Enum("a", "b")).
+			SetFieldDefault("name", "a").
+			SetFieldDefault("moment", "2023-06-17T10:20:30Z")
+	})
+
+	cfgs := make(AppConfigsType, 1)
+	cfg := cfgs.AddConfig(istructs.AppQName_test1_app1, appDef)
+
+	storage, err := simpleStorageProvder().AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	err = cfg.prepare(iratesce.TestBucketsFactory(), storage)
+	require.NoError(err)
+
+	t.Run("must be ok if constraints are satisfied and defaults are used", func(t *testing.T) {
+		cud := newCUD(cfg)
+		c := cud.Create(docName)
+		c.PutRecordID(appdef.SystemField_ID, 1)
+		c.PutString("code", "ABC")
+		c.PutBytes("data", []byte{1, 2})
+		c.PutInt32("kind", 3)
+
+		require.NoError(cud.build())
+		require.NoError(cfg.validators.validCUD(&cud, false))
+
+		rec := cud.creates[0]
+		require.EqualValues(3, rec.AsInt32("kind"))
+		require.EqualValues(1, rec.AsInt64("qty"))
+		require.Equal("9.99", rec.AsDecimal("price").String())
+		require.Equal("no comments", rec.AsString("comment"))
+	})
+
+	t.Run("must be defaults in object", func(t *testing.T) {
+		o := newObject(cfg, objName)
+		obj, err := o.Build()
+		require.NoError(err)
+		require.Equal("a", obj.AsString("name"))
+		require.Equal(time.Date(2023, time.June, 17, 10, 20, 30, 0, time.UTC), obj.AsTimestamp("moment"))
+	})
+
+	t.Run("must error if constraints are violated", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			put        func(w istructs.IRowWriter)
+			field      string
+			constraint appdef.ConstraintKind
+		}{
+			{"too short string", func(w istructs.IRowWriter) { w.PutString("code", "A") }, "code", appdef.ConstraintKind_MinLen},
+			{"too long string", func(w istructs.IRowWriter) { w.PutString("code", "ABCDE") }, "code", appdef.ConstraintKind_MaxLen},
+			{"pattern mismatch", func(w istructs.IRowWriter) { w.PutString("code", "abc") }, "code", appdef.ConstraintKind_Pattern},
+			{"too long bytes", func(w istructs.IRowWriter) { w.PutBytes("data", []byte{1, 2, 3}) }, "data", appdef.ConstraintKind_MaxLen},
+			{"not in enum", func(w istructs.IRowWriter) { w.PutInt32("kind", 4) }, "kind", appdef.ConstraintKind_Enum},
+			{"min exclusive", func(w istructs.IRowWriter) { w.PutInt64("qty", 0) }, "qty", appdef.ConstraintKind_MinExcl},
+			{"max inclusive", func(w istructs.IRowWriter) { w.PutInt64("qty", 101) }, "qty", appdef.ConstraintKind_MaxIncl},
+			{"min inclusive", func(w istructs.IRowWriter) { w.PutDecimal("price", istructs.NewDecimal(-1, 2)) }, "price", appdef.ConstraintKind_MinIncl},
+			{"max exclusive", func(w istructs.IRowWriter) { w.PutDecimal("price", istructs.NewDecimal(1000, 0)) }, "price", appdef.ConstraintKind_MaxExcl},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				cud := newCUD(cfg)
+				c := cud.Create(docName)
+				c.PutRecordID(appdef.SystemField_ID, 1)
+				c.PutString("code", "ABC")
+				tt.put(c)
+
+				require.NoError(cud.build())
+				err := cfg.validators.validCUD(&cud, false)
+				require.ErrorIs(err, ErrDataConstraintViolation)
+
+				validateErr := validateErrorf(0, "")
+				require.ErrorAs(err, &validateErr)
+				require.Equal(ECode_DataConstraintViolation, validateErr.Code())
+
+				var e *DataConstraintError
+				require.ErrorAs(err, &e)
+				require.Equal(docName, e.Def)
+				require.Equal(tt.field, e.Field)
+				require.Equal(tt.constraint, e.Constraint.Kind())
+			})
+		}
+	})
+
+	t.Run("must error if constraints are violated by updated record", func(t *testing.T) {
+		cud := newCUD(cfg)
+		c := cud.Create(docName)
+		c.PutRecordID(appdef.SystemField_ID, 1)
+		c.PutString("code", "ABC")
+		require.NoError(cud.build())
+
+		upd := newCUD(cfg)
+		u := upd.Update(cud.creates[0])
+		u.PutString("code", "abc")
+		require.NoError(upd.build())
+
+		err := cfg.validators.validCUD(&upd, false)
+		require.ErrorIs(err, ErrDataConstraintViolation)
+		require.ErrorContains(err, "abc")
+	})
+
+	t.Run("must error if constraints are violated in object", func(t *testing.T) {
+		o := newObject(cfg, objName)
+		o.PutString("name", "c")
+		_, err := o.Build()
+		require.ErrorIs(err, ErrDataConstraintViolation)
+	})
+}
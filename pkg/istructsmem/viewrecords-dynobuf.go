@@ -196,6 +196,24 @@ func loadFixedLenCellFromBuffer_00(row *rowType, field appdef.IField, appCfg *Ap
 			return err
 		}
 		row.PutRecordID(field.Name(), istructs.RecordID(v))
+	case appdef.DataKind_decimal, appdef.DataKind_timestamp:
+		v := int64(0)
+		if err := binary.Read(buf, binary.BigEndian, &v); err != nil {
+			return err
+		}
+		row.PutInt64(field.Name(), v) // unscaled decimal or unix milliseconds
+	case appdef.DataKind_date:
+		v := int32(0)
+		if err := binary.Read(buf, binary.BigEndian, &v); err != nil {
+			return err
+		}
+		row.PutInt32(field.Name(), v) // days from unix epoch
+	case appdef.DataKind_UUID:
+		v := istructs.NullUUID
+		if _, err := io.ReadFull(buf, v[:]); err != nil {
+			return err
+		}
+		row.PutUUID(field.Name(), v)
 	default:
 		return fmt.Errorf("field «%s» in row «%v» has variable length or unsupported field type «%v»: %w", field.Name(), row.QName(), field.DataKind(), ErrWrongFieldType)
 	}
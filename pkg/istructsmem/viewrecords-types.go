@@ -153,6 +153,7 @@ func (vr *appViewRecords) PutBatch(workspace istructs.WSID, viewrecs []istructs.
 	batch := make([]istorage.BatchItem, len(viewrecs))
 
 	for i, kv := range viewrecs {
+		batch[i].TTL = kv.TTL
 		if batch[i].PKey, batch[i].CCols, batch[i].Value, err = vr.storeViewRecord(workspace, kv.Key, kv.Value); err != nil {
 			return err
 		}
@@ -195,6 +196,20 @@ func (vr *appViewRecords) Read(ctx context.Context, workspace istructs.WSID, key
 	return vr.app.config.storage.Read(ctx, pk, cKey, utils.SuccBytes(cKey), readRecord)
 }
 
+// istructs.IViewRecords.DeletePartition
+func (vr *appViewRecords) DeletePartition(workspace istructs.WSID, key istructs.IKeyBuilder) (err error) {
+	k := key.(*keyType)
+	if err = k.build(); err != nil {
+		return err
+	}
+	if err = vr.app.config.validators.validKey(k, true); err != nil {
+		return err
+	}
+
+	pKey, _ := k.storeToBytes()
+	return vr.app.config.storage.DeleteRange(utils.PrefixBytes(pKey, k.viewID, workspace), nil, nil)
+}
+
 // keyType is complex key from two parts (partition key and clustering key)
 //   - interfaces:
 //     — IKeyBuilder
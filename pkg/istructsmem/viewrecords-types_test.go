@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/voedger/voedger/pkg/appdef"
@@ -836,6 +837,79 @@ func Test_ViewRecords_ClustColumnsQName(t *testing.T) {
 	})
 }
 
+func Test_ViewRecords_ClustColumnsDecimalDateTimeUUID(t *testing.T) {
+	require := require.New(t)
+	ws := istructs.WSID(1234)
+	viewName := appdef.NewQName("test", "viewPayments")
+
+	appConfigs := func() AppConfigsType {
+		appDef := appdef.New()
+		view := appDef.AddView(viewName)
+		view.
+			AddPartField("day", appdef.DataKind_date).
+			AddPartField("terminal", appdef.DataKind_UUID).
+			AddClustColumn("moment", appdef.DataKind_timestamp)
+		view.ClustColsDef().AddDecimalField("amount", 10, 2, false)
+		view.AddValueField("total", appdef.DataKind_decimal, true)
+
+		cfgs := make(AppConfigsType, 1)
+		_ = cfgs.AddConfig(istructs.AppQName_test1_app1, appDef)
+		return cfgs
+	}
+
+	p := Provide(appConfigs(), iratesce.TestBucketsFactory, testTokensFactory(), simpleStorageProvder())
+	as, err := p.AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	viewRecords := as.ViewRecords()
+
+	day := time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)
+	terminal := istructs.NewUUID()
+	moment := day.Add(10*time.Hour + 123*time.Millisecond)
+
+	for i := int64(1); i <= 3; i++ {
+		kb := viewRecords.KeyBuilder(viewName)
+		kb.PutDate("day", day)
+		kb.PutUUID("terminal", terminal)
+		kb.PutTimestamp("moment", moment)
+		kb.PutDecimal("amount", istructs.NewDecimal(i*100, 2))
+		vb := viewRecords.NewValueBuilder(viewName)
+		vb.PutDecimal("total", istructs.NewDecimal(i, 0))
+		require.NoError(viewRecords.Put(ws, kb, vb))
+	}
+
+	t.Run("must be ok to read records by partition key and clustering columns prefix", func(t *testing.T) {
+		kb := viewRecords.KeyBuilder(viewName)
+		kb.PutDate("day", day)
+		kb.PutUUID("terminal", terminal)
+		kb.PutTimestamp("moment", moment)
+
+		cnt := int64(0)
+		err := viewRecords.Read(context.Background(), ws, kb, func(key istructs.IKey, value istructs.IValue) (err error) {
+			cnt++
+			require.Equal(day, key.AsDate("day"))
+			require.Equal(terminal, key.AsUUID("terminal"))
+			require.Equal(moment, key.AsTimestamp("moment"))
+			require.Equal(istructs.NewDecimal(cnt*100, 2), key.AsDecimal("amount"))
+			require.Equal(istructs.NewDecimal(cnt, 0), value.AsDecimal("total"))
+			return nil
+		})
+		require.NoError(err)
+		require.EqualValues(3, cnt)
+	})
+
+	t.Run("must be ok to get record by full key", func(t *testing.T) {
+		kb := viewRecords.KeyBuilder(viewName)
+		kb.PutDate("day", day)
+		kb.PutUUID("terminal", terminal)
+		kb.PutTimestamp("moment", moment)
+		kb.PutDecimal("amount", istructs.NewDecimal(2, 0))
+
+		value, err := viewRecords.Get(ws, kb)
+		require.NoError(err)
+		require.Equal("2", value.AsDecimal("total").String())
+	})
+}
+
 func Test_ViewRecord_GetBatch(t *testing.T) {
 	require := require.New(t)
 
@@ -1103,3 +1177,97 @@ func Test_ViewRecord_GetBatch(t *testing.T) {
 		require.False(k1.Equals(k4), "KeyBuilder must not be equals if different QNames")
 	})
 }
+
+func Test_ViewRecords_DeletePartition(t *testing.T) {
+	require := require.New(t)
+	ws := istructs.WSID(1234)
+	viewName := appdef.NewQName("test", "viewDrinks")
+
+	appDef := appdef.New()
+	appDef.AddView(viewName).
+		AddPartField("partitionKey1", appdef.DataKind_int64).
+		AddClustColumn("clusteringColumn1", appdef.DataKind_int32).
+		AddValueField("name", appdef.DataKind_string, true)
+	cfgs := make(AppConfigsType, 1)
+	_ = cfgs.AddConfig(istructs.AppQName_test1_app1, appDef)
+
+	p := Provide(cfgs, iratesce.TestBucketsFactory, testTokensFactory(), simpleStorageProvder())
+	as, err := p.AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	viewRecords := as.ViewRecords()
+
+	key := func(pk int64, cc int32) istructs.IKeyBuilder {
+		kb := viewRecords.KeyBuilder(viewName)
+		kb.PutInt64("partitionKey1", pk)
+		kb.PutInt32("clusteringColumn1", cc)
+		return kb
+	}
+	put := func(pk int64, cc int32) {
+		vb := viewRecords.NewValueBuilder(viewName)
+		vb.PutString("name", "Coca-cola")
+		require.NoError(viewRecords.Put(ws, key(pk, cc), vb))
+	}
+	count := func(pk int64) (cnt int) {
+		kb := viewRecords.KeyBuilder(viewName)
+		kb.PutInt64("partitionKey1", pk)
+		require.NoError(viewRecords.Read(context.Background(), ws, kb, func(istructs.IKey, istructs.IValue) error {
+			cnt++
+			return nil
+		}))
+		return cnt
+	}
+
+	put(1, 1)
+	put(1, 2)
+	put(2, 1)
+
+	t.Run("must delete all records of the partition", func(t *testing.T) {
+		require.NoError(viewRecords.DeletePartition(ws, key(1, 1)))
+		require.Zero(count(1))
+	})
+
+	t.Run("must keep records of other partitions", func(t *testing.T) {
+		require.Equal(1, count(2))
+		_, err := viewRecords.Get(istructs.WSID(1), key(2, 1))
+		require.ErrorIs(err, ErrRecordNotFound)
+	})
+}
+
+func Test_ViewRecords_PutBatchTTL(t *testing.T) {
+	require := require.New(t)
+	ws := istructs.WSID(1234)
+	viewName := appdef.NewQName("test", "viewDrinks")
+
+	appDef := appdef.New()
+	appDef.AddView(viewName).
+		AddPartField("partitionKey1", appdef.DataKind_int64).
+		AddClustColumn("clusteringColumn1", appdef.DataKind_int32).
+		AddValueField("name", appdef.DataKind_string, true)
+	cfgs := make(AppConfigsType, 1)
+	_ = cfgs.AddConfig(istructs.AppQName_test1_app1, appDef)
+
+	p := Provide(cfgs, iratesce.TestBucketsFactory, testTokensFactory(), simpleStorageProvder())
+	as, err := p.AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	viewRecords := as.ViewRecords()
+
+	kv := func(cc int32, ttl time.Duration) istructs.ViewKV {
+		kb := viewRecords.KeyBuilder(viewName)
+		kb.PutInt64("partitionKey1", 1)
+		kb.PutInt32("clusteringColumn1", cc)
+		vb := viewRecords.NewValueBuilder(viewName)
+		vb.PutString("name", "Coca-cola")
+		return istructs.ViewKV{Key: kb, Value: vb, TTL: ttl}
+	}
+	require.NoError(viewRecords.PutBatch(ws, []istructs.ViewKV{kv(1, 50*time.Millisecond), kv(2, 0)}))
+
+	_, err = viewRecords.Get(ws, kv(1, 0).Key)
+	require.NoError(err)
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = viewRecords.Get(ws, kv(1, 0).Key)
+	require.ErrorIs(err, ErrRecordNotFound, "record put with TTL must expire")
+	_, err = viewRecords.Get(ws, kv(2, 0).Key)
+	require.NoError(err, "record put without TTL must never expire")
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package jobs
+
+import (
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+var (
+	// View with the run history of the jobs of the workspace
+	ViewQNameJobRuns = appdef.NewQName(appdef.SysPackage, "JobRuns")
+)
+
+// sys.JobRuns fields
+const (
+	Field_Command    = "Command"    // job command, partition key
+	Field_StartedAt  = "StartedAt"  // run start time, unix milliseconds
+	Field_Job        = "Job"        // job name
+	Field_FinishedAt = "FinishedAt" // run finish time, unix milliseconds
+	Field_StatusCode = "StatusCode" // HTTP status code of the command response, 0 if there is no response
+	Field_Error      = "Error"      // error message if the command is failed
+	Field_WLogOffset = "WLogOffset" // WLog offset of the command event if the command is succeeded
+)
+
+const (
+	// amount of the jobs executed simultaneously
+	controllerRoutines = 10
+	// timeout of the job command response
+	commandTimeout = 10 * time.Second
+)
@@ -0,0 +1,9 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package jobs
+
+import "errors"
+
+var ErrInvalidJob = errors.New("invalid job")
@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aptible/supercronic/cronexpr"
+	ibus "github.com/untillpro/airs-ibus"
+	"github.com/untillpro/goutils/logger"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/ctrlloop"
+	"github.com/voedger/voedger/pkg/istructs"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+// pipeline.IService.Prepare
+func (r *runner) Prepare(interface{}) error {
+	names := map[appdef.QName]bool{}
+	for _, job := range r.jobs {
+		if job.Name == appdef.NullQName {
+			return fmt.Errorf("%w: job name is empty", ErrInvalidJob)
+		}
+		if names[job.Name] {
+			return fmt.Errorf("%w: job «%v» is defined more than once", ErrInvalidJob, job.Name)
+		}
+		names[job.Name] = true
+		if _, err := cronexpr.Parse(job.CronSchedule); err != nil {
+			return fmt.Errorf("%w: job «%v» cron schedule «%s»: %s", ErrInvalidJob, job.Name, job.CronSchedule, err)
+		}
+		if job.Command == appdef.NullQName {
+			return fmt.Errorf("%w: job «%v» command is empty", ErrInvalidJob, job.Name)
+		}
+		if len(job.Workspaces) == 0 {
+			return fmt.Errorf("%w: job «%v» has no workspaces", ErrInvalidJob, job.Name)
+		}
+	}
+	return nil
+}
+
+// pipeline.IService.Run
+//
+// Every job in every workspace is scheduled by the control loop, the loop is closed when ctx is done
+func (r *runner) Run(ctx context.Context) {
+	r.ctx = ctx
+	in := make(chan ctrlloop.OriginalMessage[jobKey, Job])
+	wait := ctrlloop.New(r.controller, r.reporter, controllerRoutines, in, r.now)
+	for _, job := range r.jobs {
+		for _, ws := range job.Workspaces {
+			in <- ctrlloop.OriginalMessage[jobKey, Job]{
+				Key:          jobKey{job: job.Name, ws: ws},
+				SP:           job,
+				CronSchedule: job.CronSchedule,
+			}
+		}
+	}
+	<-ctx.Done()
+	close(in)
+	wait()
+}
+
+// pipeline.IService.Stop
+func (r *runner) Stop() {}
+
+// controller executes the job command in the workspace and schedules the next run.
+// Jobs are neither executed nor scheduled after the service context is done
+func (r *runner) controller(key jobKey, job Job, _ struct{}) (newState *struct{}, pv *jobRun, startTime *time.Time) {
+	if r.ctx.Err() != nil {
+		return nil, nil, nil
+	}
+	run := r.execute(key.ws, job)
+	if r.ctx.Err() == nil {
+		next := cronexpr.MustParse(job.CronSchedule).Next(r.now())
+		startTime = &next
+	}
+	return nil, &run, startTime
+}
+
+// execute sends the job command with the system principal token and waits for the response
+func (r *runner) execute(ws istructs.WSID, job Job) (run jobRun) {
+	run.command = job.Command
+	run.startedAt = r.now()
+	defer func() { run.finishedAt = r.now() }()
+
+	as, err := r.asp.AppStructs(r.appQName)
+	if err != nil {
+		run.err = err.Error()
+		return run
+	}
+	token, err := payloads.GetSystemPrincipalTokenApp(as.AppTokens())
+	if err != nil {
+		run.err = err.Error()
+		return run
+	}
+	body := job.Body
+	if len(body) == 0 {
+		body = "{}"
+	}
+	req := ibus.Request{
+		Method:   ibus.HTTPMethodPOST,
+		WSID:     int64(ws),
+		AppQName: r.appQName.String(),
+		Resource: "c." + job.Command.String(),
+		Body:     []byte(body),
+		Header:   map[string][]string{coreutils.Authorization: {coreutils.BearerPrefix + token}},
+	}
+	resp, _, _, err := r.bus.SendRequest2(r.ctx, req, commandTimeout)
+	if err != nil {
+		run.err = err.Error()
+		return run
+	}
+	run.statusCode = resp.StatusCode
+	res := commandResponse{}
+	if err := json.Unmarshal(resp.Data, &res); err != nil {
+		run.err = fmt.Sprintf("failed to unmarshal response: %s", err)
+		return run
+	}
+	if resp.StatusCode == http.StatusOK {
+		run.wLogOffset = res.CurrentWLogOffset
+	} else {
+		run.err = res.SysError.Message
+	}
+	return run
+}
+
+// reporter records the job run in the run history of the workspace.
+// Error is returned to the control loop which retries the reporting
+func (r *runner) reporter(key jobKey, run *jobRun) error {
+	command := run.command
+	if len(run.err) > 0 {
+		logger.Error(fmt.Sprintf(`app "%s" workspace %d: job %v failed: %s`, r.appQName, key.ws, key.job, run.err))
+	}
+	as, err := r.asp.AppStructs(r.appQName)
+	if err != nil {
+		return err
+	}
+	if as.AppDef().DefByName(ViewQNameJobRuns) == nil {
+		return nil
+	}
+	kb := as.ViewRecords().KeyBuilder(ViewQNameJobRuns)
+	kb.PutQName(Field_Command, command)
+	kb.PutInt64(Field_StartedAt, run.startedAt.UnixMilli())
+	kb.PutString(Field_Job, key.job.String())
+	vb := as.ViewRecords().NewValueBuilder(ViewQNameJobRuns)
+	vb.PutInt64(Field_FinishedAt, run.finishedAt.UnixMilli())
+	vb.PutInt32(Field_StatusCode, int32(run.statusCode))
+	vb.PutString(Field_Error, run.err)
+	vb.PutInt64(Field_WLogOffset, int64(run.wLogOffset))
+	return as.ViewRecords().Put(key.ws, kb, vb)
+}
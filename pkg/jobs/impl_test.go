@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package jobs
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	ibus "github.com/untillpro/airs-ibus"
+	"github.com/untillpro/ibusmem"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorageimpl"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+
+	adb := appdef.New()
+	ProvideViewDef(adb)
+	cfgs := make(istructsmem.AppConfigsType)
+	cfg := cfgs.AddConfig(istructs.AppQName_test1_app1, adb)
+	cfg.Resources.Add(istructsmem.NewCommandFunction(appdef.NewQName("test", "Close"), appdef.NullQName, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec))
+	cfg.Resources.Add(istructsmem.NewCommandFunction(appdef.NewQName("test", "Remind"), appdef.NullQName, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec))
+	asp := istructsmem.Provide(cfgs, iratesce.TestBucketsFactory, payloads.ProvideIAppTokensFactory(itokensjwt.TestTokensJWT()), istorageimpl.Provide(istorage.ProvideMem()))
+
+	// bus replies as the command processor: c.test.Close succeeds, c.test.Remind fails
+	var bus ibus.IBus
+	bus = ibusmem.Provide(func(_ context.Context, sender interface{}, request ibus.Request) {
+		require.Equal(istructs.AppQName_test1_app1.String(), request.AppQName)
+		require.True(strings.HasPrefix(request.Header[coreutils.Authorization][0], coreutils.BearerPrefix))
+
+		if request.Resource == "c.test.Close" {
+			require.Equal(`{"args":{"Reason":"nightly"}}`, string(request.Body))
+			bus.SendResponse(sender, ibus.Response{ContentType: coreutils.ApplicationJSON, StatusCode: http.StatusOK, Data: []byte(`{"CurrentWLogOffset":42}`)})
+			return
+		}
+		require.Equal(`{}`, string(request.Body))
+		bus.SendResponse(sender, ibus.Response{ContentType: coreutils.ApplicationJSON, StatusCode: http.StatusBadRequest, Data: []byte(`{"sys.Error":{"HTTPStatus":400,"Message":"wrong reminder"}}`)})
+	})
+
+	jobs := []Job{
+		{
+			Name:         appdef.NewQName("test", "nightlyClosing"),
+			CronSchedule: "* * * * * * *", // every second
+			Command:      appdef.NewQName("test", "Close"),
+			Body:         `{"args":{"Reason":"nightly"}}`,
+			Workspaces:   []istructs.WSID{1, 2},
+		},
+		{
+			Name:         appdef.NewQName("test", "reminder"),
+			CronSchedule: "* * * * * * *",
+			Command:      appdef.NewQName("test", "Remind"),
+			Workspaces:   []istructs.WSID{1},
+		},
+	}
+	service := ProvideService(istructs.AppQName_test1_app1, jobs, bus, asp, time.Now)
+	require.NoError(service.Prepare(nil))
+
+	as, err := asp.AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	// readRuns returns the run history of the job in the workspace, runs interrupted by the service stop are skipped
+	readRuns := func(ws istructs.WSID, command, job string) (runs []istructs.IValue) {
+		kb := as.ViewRecords().KeyBuilder(ViewQNameJobRuns)
+		kb.PutQName(Field_Command, appdef.NewQName("test", command))
+		require.NoError(as.ViewRecords().Read(context.Background(), ws, kb, func(key istructs.IKey, value istructs.IValue) error {
+			require.Equal(job, key.AsString(Field_Job))
+			require.LessOrEqual(key.AsInt64(Field_StartedAt), value.AsInt64(Field_FinishedAt))
+			if value.AsInt32(Field_StatusCode) == 0 {
+				require.NotEmpty(value.AsString(Field_Error))
+				return nil
+			}
+			runs = append(runs, value)
+			return nil
+		}))
+		return runs
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	finished := make(chan struct{})
+	go func() {
+		service.Run(ctx)
+		close(finished)
+	}()
+	for deadline := time.Now().Add(10 * time.Second); ; time.Sleep(100 * time.Millisecond) {
+		if len(readRuns(1, "Close", "test.nightlyClosing")) >= 2 && len(readRuns(2, "Close", "test.nightlyClosing")) >= 2 &&
+			len(readRuns(1, "Remind", "test.reminder")) >= 2 {
+			break
+		}
+		require.True(time.Now().Before(deadline), "jobs are not executed")
+	}
+	cancel()
+	<-finished
+	service.Stop()
+
+	t.Run("Should record succeeded runs", func(t *testing.T) {
+		for _, ws := range []istructs.WSID{1, 2} {
+			runs := readRuns(ws, "Close", "test.nightlyClosing")
+			require.GreaterOrEqual(len(runs), 2)
+			for _, run := range runs {
+				require.Equal(int32(http.StatusOK), run.AsInt32(Field_StatusCode))
+				require.Empty(run.AsString(Field_Error))
+				require.Equal(int64(42), run.AsInt64(Field_WLogOffset))
+			}
+		}
+	})
+
+	t.Run("Should record failed runs", func(t *testing.T) {
+		runs := readRuns(1, "Remind", "test.reminder")
+		require.GreaterOrEqual(len(runs), 2)
+		for _, run := range runs {
+			require.Equal(int32(http.StatusBadRequest), run.AsInt32(Field_StatusCode))
+			require.Equal("wrong reminder", run.AsString(Field_Error))
+		}
+		require.Empty(readRuns(2, "Remind", "test.reminder"), "job must be executed in its workspaces only")
+	})
+}
+
+func TestPrepare(t *testing.T) {
+	require := require.New(t)
+
+	job := func(change func(job *Job)) []Job {
+		j := Job{
+			Name:         appdef.NewQName("test", "job"),
+			CronSchedule: "0 3 * * *",
+			Command:      appdef.NewQName("test", "Cmd"),
+			Workspaces:   []istructs.WSID{1},
+		}
+		change(&j)
+		return []Job{j}
+	}
+	cases := map[string][]Job{
+		"empty name":       job(func(j *Job) { j.Name = appdef.NullQName }),
+		"wrong schedule":   job(func(j *Job) { j.CronSchedule = "QWERTY" }),
+		"empty command":    job(func(j *Job) { j.Command = appdef.NullQName }),
+		"no workspaces":    job(func(j *Job) { j.Workspaces = nil }),
+		"duplicated names": append(job(func(*Job) {}), job(func(*Job) {})...),
+	}
+	for name, jobs := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ProvideService(istructs.AppQName_test1_app1, jobs, nil, nil, time.Now).Prepare(nil)
+			require.ErrorIs(err, ErrInvalidJob)
+		})
+	}
+	require.NoError(ProvideService(istructs.AppQName_test1_app1, job(func(*Job) {}), nil, nil, time.Now).Prepare(nil))
+}
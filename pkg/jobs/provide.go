@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package jobs
+
+import (
+	"time"
+
+	ibus "github.com/untillpro/airs-ibus"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/pipeline"
+)
+
+// ProvideService returns the service which runs the jobs of the application by their schedules.
+//
+// Jobs are checked on Prepare(), Run() blocks until the context is done
+func ProvideService(appQName istructs.AppQName, jobs []Job, bus ibus.IBus, asp istructs.IAppStructsProvider, now func() time.Time) pipeline.IService {
+	return &runner{
+		appQName: appQName,
+		jobs:     jobs,
+		bus:      bus,
+		asp:      asp,
+		now:      now,
+	}
+}
+
+// ProvideViewDef adds the view with the run history of the jobs, runs are recorded only if application defines it.
+// Runs are partitioned by the job command, job names are not application QNames
+func ProvideViewDef(appDef appdef.IAppDefBuilder) {
+	appDef.AddView(ViewQNameJobRuns).
+		AddPartField(Field_Command, appdef.DataKind_QName).
+		AddClustColumn(Field_StartedAt, appdef.DataKind_int64).
+		AddClustColumn(Field_Job, appdef.DataKind_string).
+		AddValueField(Field_FinishedAt, appdef.DataKind_int64, true).
+		AddValueField(Field_StatusCode, appdef.DataKind_int32, true).
+		AddValueField(Field_Error, appdef.DataKind_string, false).
+		AddValueField(Field_WLogOffset, appdef.DataKind_int64, false)
+}
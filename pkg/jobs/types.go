@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package jobs
+
+import (
+	"context"
+	"time"
+
+	ibus "github.com/untillpro/airs-ibus"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Job is the command executed in the workspaces by the cron schedule
+type Job struct {
+	// Job name, unique in the application
+	Name appdef.QName
+
+	// Cron expression, e.g. `0 3 * * *` means every day at 03:00
+	CronSchedule string
+
+	// Command and its request body, e.g. `{"args":{"Reason":"nightly"}}`. Empty body means `{}`
+	Command appdef.QName
+	Body    string
+
+	Workspaces []istructs.WSID
+}
+
+// jobKey is the control loop key: the job in the workspace
+type jobKey struct {
+	job appdef.QName
+	ws  istructs.WSID
+}
+
+// commandResponse is the part of the command processor response which is recorded in the run history
+type commandResponse struct {
+	CurrentWLogOffset istructs.Offset
+	SysError          struct {
+		Message string
+	} `json:"sys.Error"`
+}
+
+// jobRun is the control loop process variable: the result of the job run
+type jobRun struct {
+	command    appdef.QName
+	startedAt  time.Time
+	finishedAt time.Time
+	statusCode int
+	err        string
+	wLogOffset istructs.Offset
+}
+
+type runner struct {
+	appQName istructs.AppQName
+	jobs     []Job
+	bus      ibus.IBus
+	asp      istructs.IAppStructsProvider
+	now      func() time.Time
+
+	// context of the running service, commands are sent with it
+	ctx context.Context
+}
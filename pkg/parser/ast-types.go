@@ -0,0 +1,260 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package parser
+
+import (
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// Parsed schema file
+type FileSchemaAST struct {
+	FileName string
+	Ast      *SchemaAST
+}
+
+// Parsed schema package. All files of the package are merged into single AST
+type PackageSchemaAST struct {
+	// Qualified package name, e.g. "github.com/untillpro/airs-bp3/packages/air"
+	QualifiedPackageName string
+	Ast                  *SchemaAST
+}
+
+type SchemaAST struct {
+	Package    string       `parser:"'SCHEMA' @Ident ';'?"`
+	Imports    []ImportStmt `parser:"@@*"`
+	Statements []Statement  `parser:"@@*"`
+}
+
+type ImportStmt struct {
+	Pos   lexer.Position
+	Name  string  `parser:"'IMPORT' 'SCHEMA' @String"`
+	Alias *string `parser:"('AS' @Ident)? ';'?"`
+}
+
+type Statement struct {
+	Workspace *WorkspaceStmt `parser:"( @@"`
+	Role      *RoleStmt      `parser:"| @@"`
+	Tag       *TagStmt       `parser:"| @@"`
+	Sequence  *SequenceStmt  `parser:"| @@"`
+	Rate      *RateStmt      `parser:"| @@"`
+	Type      *TypeStmt      `parser:"| @@"`
+	Table     *TableStmt     `parser:"| @@"`
+	View      *ViewStmt      `parser:"| @@"`
+	Command   *CommandStmt   `parser:"| @@"`
+	Query     *QueryStmt     `parser:"| @@"`
+	Projector *ProjectorStmt `parser:"| @@"`
+	Grant     *GrantStmt     `parser:"| @@ ) ';'?"`
+}
+
+type WorkspaceStmt struct {
+	Pos        lexer.Position
+	Name       string      `parser:"'WORKSPACE' @Ident '('"`
+	Statements []Statement `parser:"@@* ')'"`
+	With       []WithItem  `parser:"('WITH' @@ (('AND' | ',') @@)*)?"`
+}
+
+type RoleStmt struct {
+	Pos  lexer.Position
+	Name string     `parser:"'ROLE' @Ident"`
+	With []WithItem `parser:"('WITH' @@ (('AND' | ',') @@)*)?"`
+}
+
+type TagStmt struct {
+	Pos  lexer.Position
+	Name string     `parser:"'TAG' @Ident"`
+	With []WithItem `parser:"('WITH' @@ (('AND' | ',') @@)*)?"`
+}
+
+type SequenceStmt struct {
+	Pos     lexer.Position
+	Name    string      `parser:"'SEQUENCE' @Ident 'AS'?"`
+	Type    DefQName    `parser:"@@"`
+	Options []SeqOption `parser:"@@*"`
+	With    []WithItem  `parser:"('WITH' @@ (('AND' | ',') @@)*)?"`
+}
+
+type SeqOption struct {
+	Pos       lexer.Position
+	StartWith *int64 `parser:"( 'START' 'WITH' @Int"`
+	MinValue  *int64 `parser:"| 'MINVALUE' @Int"`
+	MaxValue  *int64 `parser:"| 'MAXVALUE' @Int"`
+	Increment *int64 `parser:"| 'INCREMENT' 'BY' @Int )"`
+}
+
+type RateStmt struct {
+	Pos    lexer.Position
+	Name   string     `parser:"'RATE' @Ident"`
+	Amount uint32     `parser:"@Int"`
+	Period string     `parser:"'PER' @('SECOND' | 'MINUTE' | 'HOUR' | 'DAY' | 'YEAR')"`
+	Scope  *string    `parser:"('PER' @('APP' | 'WORKSPACE' | 'IP'))?"`
+	With   []WithItem `parser:"('WITH' @@ (('AND' | ',') @@)*)?"`
+}
+
+type TypeStmt struct {
+	Pos    lexer.Position
+	Name   string      `parser:"'TYPE' @Ident 'AS'? '('"`
+	Fields []FieldExpr `parser:"(@@ ','?)* ')'"`
+	With   []WithItem  `parser:"('WITH' @@ (('AND' | ',') @@)*)?"`
+}
+
+type TableStmt struct {
+	Pos      lexer.Position
+	Name     string      `parser:"'TABLE' @Ident"`
+	Inherits []DefQName  `parser:"('OF' @@ (',' @@)*)? '('"`
+	Items    []TableItem `parser:"(@@ ','?)* ')'"`
+	With     []WithItem  `parser:"('WITH' @@ (('AND' | ',') @@)*)?"`
+}
+
+type TableItem struct {
+	Table  *TableStmt  `parser:"( @@"`
+	Unique *UniqueExpr `parser:"| @@"`
+	Field  *FieldExpr  `parser:"| @@ )"`
+}
+
+type UniqueExpr struct {
+	Pos    lexer.Position
+	Fields []string `parser:"'UNIQUE' '(' @Ident (',' @Ident)* ')'"`
+}
+
+type FieldExpr struct {
+	Pos        lexer.Position
+	Name       string       `parser:"@Ident"`
+	Type       DefQName     `parser:"@@"`
+	Precision  *Precision   `parser:"@@?"`
+	NotNull    bool         `parser:"@('NOT' 'NULL')?"`
+	Verifiable bool         `parser:"@'VERIFIABLE'?"`
+	Default    *DefaultExpr `parser:"('DEFAULT' @@)?"`
+	References *DefQName    `parser:"('REFERENCES' @@)?"`
+	Check      *CheckExpr   `parser:"('CHECK' '(' @@ ')')?"`
+}
+
+type DefaultExpr struct {
+	Pos     lexer.Position
+	NextVal *string  `parser:"( 'NEXTVAL' '(' @String ')'"`
+	String  *string  `parser:"| @String"`
+	Float   *float64 `parser:"| @Float"`
+	Int     *int64   `parser:"| @Int"`
+	Bool    *string  `parser:"| @('true' | 'false' | 'TRUE' | 'FALSE') )"`
+}
+
+type CheckExpr struct {
+	Pos    lexer.Position
+	Regexp *string  `parser:"( @String"`
+	Expr   []string `parser:"| @(Ident | Int | Float | Op | '.')+ )"`
+}
+
+type ViewStmt struct {
+	Pos      lexer.Position
+	Name     string     `parser:"'VIEW' @Ident '('"`
+	Items    []ViewItem `parser:"@@ (',' @@)* ','? ')'"`
+	ResultOf *DefQName  `parser:"('AS' 'RESULT' 'OF' @@)?"`
+	With     []WithItem `parser:"('WITH' @@ (('AND' | ',') @@)*)?"`
+}
+
+type ViewItem struct {
+	PrimaryKey *PrimaryKeyExpr `parser:"( 'PRIMARY' 'KEY' '(' @@ ')'"`
+	Field      *ViewField      `parser:"| @@ )"`
+}
+
+type PrimaryKeyExpr struct {
+	Pos          lexer.Position
+	PartKey      []string `parser:"( '(' @Ident (',' @Ident)* ')' | @Ident )"`
+	ClustColumns []string `parser:"(',' @Ident)*"`
+}
+
+type ViewField struct {
+	Pos       lexer.Position
+	Name      string     `parser:"@Ident"`
+	Type      DefQName   `parser:"@@"`
+	Precision *Precision `parser:"@@?"`
+	NotNull   bool       `parser:"@('NOT' 'NULL')?"`
+}
+
+// Decimal precision and optional scale, e.g. DECIMAL(10, 2)
+type Precision struct {
+	Pos       lexer.Position
+	Precision uint8  `parser:"'(' @Int"`
+	Scale     *uint8 `parser:"(',' @Int)? ')'"`
+}
+
+func (p Precision) scale() uint8 {
+	if p.Scale == nil {
+		return 0
+	}
+	return *p.Scale
+}
+
+type CommandStmt struct {
+	Pos     lexer.Position
+	Name    string     `parser:"'COMMAND' @Ident"`
+	Args    []ArgExpr  `parser:"('(' (@@ (',' @@)*)? ')')?"`
+	Returns *DefQName  `parser:"('RETURNS' @@)?"`
+	Engine  *string    `parser:"('ENGINE' @('WASM' | 'BUILTIN'))?"`
+	With    []WithItem `parser:"('WITH' @@ (('AND' | ',') @@)*)?"`
+}
+
+type QueryStmt struct {
+	Pos     lexer.Position
+	Name    string     `parser:"'QUERY' @Ident"`
+	Args    []ArgExpr  `parser:"('(' (@@ (',' @@)*)? ')')?"`
+	Returns DefQName   `parser:"'RETURNS' @@"`
+	Engine  *string    `parser:"('ENGINE' @('WASM' | 'BUILTIN'))?"`
+	With    []WithItem `parser:"('WITH' @@ (('AND' | ',') @@)*)?"`
+}
+
+// Function argument.
+//
+// If only one name is specified then it is argument type, else the first name is argument name and the second is type
+type ArgExpr struct {
+	Pos      lexer.Position
+	Unlogged bool      `parser:"@'UNLOGGED'?"`
+	First    DefQName  `parser:"@@"`
+	Second   *DefQName `parser:"@@?"`
+	NotNull  bool      `parser:"@('NOT' 'NULL')?"`
+}
+
+type ProjectorStmt struct {
+	Pos      lexer.Position
+	Name     string     `parser:"'PROJECTOR' @Ident 'ON' 'COMMAND'"`
+	Argument bool       `parser:"@'ARGUMENT'?"`
+	Targets  []DefQName `parser:"( 'IN' '(' @@ (',' @@)* ')' | @@ )"`
+	Engine   *string    `parser:"('ENGINE' @('WASM' | 'BUILTIN'))?"`
+	With     []WithItem `parser:"('WITH' @@ (('AND' | ',') @@)*)?"`
+}
+
+type GrantStmt struct {
+	Pos       lexer.Position
+	All       bool      `parser:"'GRANT' ( @'ALL'"`
+	Ops       []string  `parser:"| @('SELECT' | 'INSERT' | 'UPDATE' | 'EXECUTE') (',' @('SELECT' | 'INSERT' | 'UPDATE' | 'EXECUTE'))* ) 'ON'"`
+	AllOfKind *string   `parser:"( 'ALL' @('TABLES' | 'COMMANDS' | 'QUERIES' | 'VIEWS') 'WITH' 'TAG'"`
+	Tag       *DefQName `parser:"@@"`
+	OfKind    *string   `parser:"| @('TABLE' | 'COMMAND' | 'QUERY' | 'VIEW')"`
+	On        *DefQName `parser:"@@ )"`
+	To        DefQName  `parser:"'TO' @@"`
+}
+
+// Option in WITH clause: Comment='text', Tags=[tag1, tag2], Rate=RateName, HandleErrors=true
+type WithItem struct {
+	Pos    lexer.Position
+	Name   string     `parser:"@Ident '='"`
+	String *string    `parser:"( @String"`
+	Int    *int64     `parser:"| @Int"`
+	List   []DefQName `parser:"| '[' @@ (',' @@)* ']'"`
+	QName  *DefQName  `parser:"| @@ )"`
+}
+
+// Qualified or unqualified name in schema
+type DefQName struct {
+	Pos     lexer.Position
+	Package string `parser:"(@Ident '.')?"`
+	Name    string `parser:"@Ident"`
+}
+
+func (q DefQName) String() string {
+	if q.Package == "" {
+		return q.Name
+	}
+	return q.Package + "." + q.Name
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package parser
+
+import (
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Schema file extension
+const SchemaFileExt = ".sql"
+
+const (
+	engineBuiltin = "BUILTIN"
+	engineWASM    = "WASM"
+)
+
+const (
+	withComment      = "Comment"
+	withDescription  = "Description"
+	withTags         = "Tags"
+	withRate         = "Rate"
+	withHandleErrors = "HandleErrors"
+)
+
+// Data types. Names are case insensitive, see dataKindByName()
+var dataTypes = map[string]appdef.DataKind{
+	"ID":      appdef.DataKind_RecordID,
+	"OFFSET":  appdef.DataKind_int64,
+	"INT":     appdef.DataKind_int32,
+	"INT32":   appdef.DataKind_int32,
+	"INT64":   appdef.DataKind_int64,
+	"FLOAT":   appdef.DataKind_float32,
+	"FLOAT32": appdef.DataKind_float32,
+	"FLOAT64": appdef.DataKind_float64,
+	"QNAME":   appdef.DataKind_QName,
+	"TEXT":    appdef.DataKind_string,
+	"BYTES":   appdef.DataKind_bytes,
+	"BOOL":    appdef.DataKind_bool,
+	"BOOLEAN": appdef.DataKind_bool,
+
+	"DECIMAL":   appdef.DataKind_decimal,
+	"NUMERIC":   appdef.DataKind_decimal,
+	"MONEY":     appdef.DataKind_decimal,
+	"TIMESTAMP": appdef.DataKind_timestamp,
+	"DATE":      appdef.DataKind_date,
+	"UUID":      appdef.DataKind_UUID,
+}
+
+// Table kinds to be specified in TABLE ... OF clause. Names are case insensitive
+var tableKinds = map[string]appdef.DefKind{
+	"GDOC":      appdef.DefKind_GDoc,
+	"CDOC":      appdef.DefKind_CDoc,
+	"ODOC":      appdef.DefKind_ODoc,
+	"WDOC":      appdef.DefKind_WDoc,
+	"SINGLETON": appdef.DefKind_CDoc,
+}
+
+const singletonTableKind = "SINGLETON"
+
+// Kinds of nested tables records by root document kind
+var nestedTableKinds = map[appdef.DefKind]appdef.DefKind{
+	appdef.DefKind_GDoc: appdef.DefKind_GRecord,
+	appdef.DefKind_CDoc: appdef.DefKind_CRecord,
+	appdef.DefKind_ODoc: appdef.DefKind_ORecord,
+	appdef.DefKind_WDoc: appdef.DefKind_WRecord,
+}
+
+var ratePeriods = map[string]time.Duration{
+	"SECOND": time.Second,
+	"MINUTE": time.Minute,
+	"HOUR":   time.Hour,
+	"DAY":    24 * time.Hour,
+	"YEAR":   365 * 24 * time.Hour,
+}
+
+var rateScopes = map[string]istructs.RateLimitKind{
+	"APP":       istructs.RateLimitKind_byApp,
+	"WORKSPACE": istructs.RateLimitKind_byWorkspace,
+}
+
+// Suffix of the definition name generated for function arguments declared by name
+const argsDefSuffix = "Params"
+
+// Suffix of the definition name generated for unlogged function arguments declared by name
+const unloggedArgsDefSuffix = "UnloggedParams"
@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+var ErrSchemaNameMismatch = errors.New("schema name mismatch")
+
+var ErrNoSchemaFiles = errors.New("no schema files")
+
+var ErrPackageNotFound = errors.New("package not found")
+
+var ErrUndefined = errors.New("undefined")
+
+var ErrRedefined = errors.New("redefined")
+
+var ErrStatementNotAllowed = errors.New("statement not allowed here")
+
+var ErrInvalidTableKind = errors.New("invalid table kind")
+
+var ErrInvalidDataType = errors.New("invalid data type")
+
+var ErrInvalidOption = errors.New("invalid option")
+
+var ErrInvalidArgument = errors.New("invalid argument")
+
+var ErrInvalidPrimaryKey = errors.New("invalid primary key")
+
+var ErrInvalidGrant = errors.New("invalid grant")
+
+var ErrUnsupported = errors.New("not supported")
+
+// Returns error with schema position prefix, e.g. "file.sql:10:5: undefined: air.Bill"
+func errorAt(pos lexer.Position, err error, format string, args ...interface{}) error {
+	return fmt.Errorf("%s: %w: %s", pos, err, fmt.Sprintf(format, args...))
+}
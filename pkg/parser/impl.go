@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+var schemaLexer = lexer.MustSimple([]lexer.SimpleRule{
+	{Name: "Comment", Pattern: `--[^\n]*`},
+	{Name: "Whitespace", Pattern: `[ \r\t\n]+`},
+	{Name: "String", Pattern: `'(?:\\.|[^'])*'|"(?:\\.|[^"])*"`},
+	{Name: "Float", Pattern: `-?[0-9]+\.[0-9]+`},
+	{Name: "Int", Pattern: `-?[0-9]+`},
+	{Name: "Ident", Pattern: `[a-zA-Z_][a-zA-Z0-9_]*`},
+	{Name: "Op", Pattern: `<>|!=|<=|>=|[-+*/%=<>!]`},
+	{Name: "Punct", Pattern: `[;,.()\[\]]`},
+})
+
+var schemaParser = participle.MustBuild[SchemaAST](
+	participle.Lexer(schemaLexer),
+	participle.Elide("Whitespace", "Comment"),
+	participle.Unquote("String"),
+	participle.UseLookahead(2),
+)
+
+func parseFile(fileName, content string) (*FileSchemaAST, error) {
+	ast, err := schemaParser.ParseString(fileName, content)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSchemaAST{FileName: fileName, Ast: ast}, nil
+}
+
+func parsePackageDir(qualifiedPackageName string, fs IReadFS, dir string) (*PackageSchemaAST, error) {
+	entries, err := fs.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	asts := make([]*FileSchemaAST, 0)
+	var errs error
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(path.Ext(entry.Name()), SchemaFileExt) {
+			continue
+		}
+		fileName := path.Join(dir, entry.Name())
+		content, err := fs.ReadFile(fileName)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		ast, err := parseFile(fileName, string(content))
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		asts = append(asts, ast)
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	return mergeFileSchemaASTs(qualifiedPackageName, asts)
+}
+
+func mergeFileSchemaASTs(qualifiedPackageName string, asts []*FileSchemaAST) (*PackageSchemaAST, error) {
+	if len(asts) == 0 {
+		return nil, fmt.Errorf("package «%s»: %w", qualifiedPackageName, ErrNoSchemaFiles)
+	}
+
+	head := asts[0]
+	merged := &SchemaAST{
+		Package:    head.Ast.Package,
+		Imports:    make([]ImportStmt, 0),
+		Statements: make([]Statement, 0),
+	}
+
+	var errs error
+	for _, f := range asts {
+		if f.Ast.Package != merged.Package {
+			errs = errors.Join(errs,
+				fmt.Errorf("%s: %w: «%s», expected «%s» as declared in %s", f.FileName, ErrSchemaNameMismatch, f.Ast.Package, merged.Package, head.FileName))
+			continue
+		}
+		merged.Imports = append(merged.Imports, f.Ast.Imports...)
+		merged.Statements = append(merged.Statements, f.Ast.Statements...)
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	return &PackageSchemaAST{
+		QualifiedPackageName: qualifiedPackageName,
+		Ast:                  merged,
+	}, nil
+}
@@ -0,0 +1,1081 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/alecthomas/participle/v2/lexer"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/iextengine"
+	"github.com/voedger/voedger/pkg/istructs"
+	"golang.org/x/exp/slices"
+)
+
+type symbolKind uint8
+
+const (
+	symbol_null symbolKind = iota
+	symbol_Workspace
+	symbol_Role
+	symbol_Tag
+	symbol_Sequence
+	symbol_Rate
+	symbol_Type
+	symbol_Table
+	symbol_View
+	symbol_Command
+	symbol_Query
+	symbol_Projector
+)
+
+var symbolKindNames = map[symbolKind]string{
+	symbol_Workspace: "workspace",
+	symbol_Role:      "role",
+	symbol_Tag:       "tag",
+	symbol_Sequence:  "sequence",
+	symbol_Rate:      "rate",
+	symbol_Type:      "type",
+	symbol_Table:     "table",
+	symbol_View:      "view",
+	symbol_Command:   "command",
+	symbol_Query:     "query",
+	symbol_Projector: "projector",
+}
+
+func (k symbolKind) String() string { return symbolKindNames[k] }
+
+// Declared schema entity
+type symbol struct {
+	kind symbolKind
+	name appdef.QName
+	pos  lexer.Position
+	pkg  *packageContext
+	stmt interface{}
+
+	// root document kind for tables
+	tableKind appdef.DefKind
+	// tags applied by WITH Tags=[…]
+	tags []appdef.QName
+}
+
+type packageContext struct {
+	ast *PackageSchemaAST
+	// local package name, declared by SCHEMA statement
+	name string
+	// imported packages local names by aliases
+	imports map[string]string
+}
+
+type buildContext struct {
+	builder  appdef.IAppDefBuilder
+	schema   *AppSchema
+	packages []*packageContext
+	symbols  map[appdef.QName]*symbol
+	ordered  []*symbol
+	rates    map[appdef.QName]RateLimitDecl
+	// views declared as result of projectors
+	projViews map[appdef.QName][]appdef.QName
+	errs      []error
+}
+
+func buildAppDefs(packages []*PackageSchemaAST, builder appdef.IAppDefBuilder) (*AppSchema, error) {
+	c := buildContext{
+		builder:   builder,
+		schema:    &AppSchema{},
+		symbols:   make(map[appdef.QName]*symbol),
+		rates:     make(map[appdef.QName]RateLimitDecl),
+		projViews: make(map[appdef.QName][]appdef.QName),
+	}
+
+	steps := []func(){
+		func() { c.preparePackages(packages) },
+		c.collectSymbols,
+		c.buildRates,
+		c.buildTypes,
+		c.buildTables,
+		c.buildViews,
+		c.buildFunctions,
+		c.buildProjectors,
+		c.buildGrants,
+		c.buildOthers,
+	}
+	for _, step := range steps {
+		step()
+		if len(c.errs) > 0 {
+			return nil, errors.Join(c.errs...)
+		}
+	}
+
+	return c.schema, nil
+}
+
+func (c *buildContext) err(e error) {
+	c.errs = append(c.errs, e)
+}
+
+// Calls f and converts builder panic into error at specified position
+func (c *buildContext) safe(pos lexer.Position, f func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(error)
+			if !ok {
+				err = fmt.Errorf("%v", r)
+			}
+			c.err(fmt.Errorf("%s: %w", pos, err))
+		}
+	}()
+	f()
+}
+
+func (c *buildContext) preparePackages(packages []*PackageSchemaAST) {
+	byQualified := make(map[string]*packageContext)
+	byName := make(map[string]*packageContext)
+
+	for _, p := range packages {
+		pkg := &packageContext{ast: p, name: p.Ast.Package, imports: make(map[string]string)}
+		if pkg.name == appdef.SysPackage {
+			c.err(fmt.Errorf("package «%s»: %w: schema name «%s» is reserved", p.QualifiedPackageName, ErrRedefined, pkg.name))
+			continue
+		}
+		if prev, ok := byName[pkg.name]; ok {
+			c.err(fmt.Errorf("package «%s»: %w: schema name «%s» already used by package «%s»", p.QualifiedPackageName, ErrRedefined, pkg.name, prev.ast.QualifiedPackageName))
+			continue
+		}
+		byName[pkg.name] = pkg
+		byQualified[p.QualifiedPackageName] = pkg
+		c.packages = append(c.packages, pkg)
+	}
+
+	for _, pkg := range c.packages {
+		for _, imp := range pkg.ast.Ast.Imports {
+			imported, ok := byQualified[imp.Name]
+			if !ok {
+				c.err(errorAt(imp.Pos, ErrPackageNotFound, "«%s»", imp.Name))
+				continue
+			}
+			alias := imported.name
+			if imp.Alias != nil {
+				alias = *imp.Alias
+			}
+			if (alias == pkg.name) || (alias == appdef.SysPackage) {
+				c.err(errorAt(imp.Pos, ErrRedefined, "import alias «%s» conflicts with package name", alias))
+				continue
+			}
+			if _, ok := pkg.imports[alias]; ok {
+				c.err(errorAt(imp.Pos, ErrRedefined, "import alias «%s»", alias))
+				continue
+			}
+			pkg.imports[alias] = imported.name
+		}
+	}
+}
+
+// Enumerates all statements of all packages. Workspace is NullQName for statements declared out of workspace
+func (c *buildContext) iterate(cb func(pkg *packageContext, ws appdef.QName, stmt *Statement)) {
+	var iter func(pkg *packageContext, ws appdef.QName, stmts []Statement)
+	iter = func(pkg *packageContext, ws appdef.QName, stmts []Statement) {
+		for i := range stmts {
+			stmt := &stmts[i]
+			cb(pkg, ws, stmt)
+			if stmt.Workspace != nil {
+				iter(pkg, appdef.NewQName(pkg.name, stmt.Workspace.Name), stmt.Workspace.Statements)
+			}
+		}
+	}
+	for _, pkg := range c.packages {
+		iter(pkg, appdef.NullQName, pkg.ast.Ast.Statements)
+	}
+}
+
+func (c *buildContext) addSymbol(pkg *packageContext, kind symbolKind, name string, pos lexer.Position, stmt interface{}) *symbol {
+	if ok, err := appdef.ValidIdent(name); !ok {
+		c.err(errorAt(pos, err, "«%s»", name))
+		return nil
+	}
+	q := appdef.NewQName(pkg.name, name)
+	if prev, ok := c.symbols[q]; ok {
+		c.err(errorAt(pos, ErrRedefined, "«%v» already declared as %v at %s", q, prev.kind, prev.pos))
+		return nil
+	}
+	s := &symbol{kind: kind, name: q, pos: pos, pkg: pkg, stmt: stmt}
+	c.symbols[q] = s
+	c.ordered = append(c.ordered, s)
+	return s
+}
+
+func (c *buildContext) collectSymbols() {
+	workspaces := make(map[appdef.QName]int)
+
+	c.iterate(func(pkg *packageContext, ws appdef.QName, stmt *Statement) {
+		var (
+			kind   symbolKind
+			name   string
+			pos    lexer.Position
+			target interface{}
+			wsOnly bool
+		)
+		switch {
+		case stmt.Workspace != nil:
+			kind, name, pos, target = symbol_Workspace, stmt.Workspace.Name, stmt.Workspace.Pos, stmt.Workspace
+		case stmt.Role != nil:
+			kind, name, pos, target = symbol_Role, stmt.Role.Name, stmt.Role.Pos, stmt.Role
+		case stmt.Tag != nil:
+			kind, name, pos, target = symbol_Tag, stmt.Tag.Name, stmt.Tag.Pos, stmt.Tag
+		case stmt.Sequence != nil:
+			kind, name, pos, target = symbol_Sequence, stmt.Sequence.Name, stmt.Sequence.Pos, stmt.Sequence
+		case stmt.Rate != nil:
+			kind, name, pos, target, wsOnly = symbol_Rate, stmt.Rate.Name, stmt.Rate.Pos, stmt.Rate, true
+		case stmt.Type != nil:
+			kind, name, pos, target = symbol_Type, stmt.Type.Name, stmt.Type.Pos, stmt.Type
+		case stmt.Table != nil:
+			kind, name, pos, target = symbol_Table, stmt.Table.Name, stmt.Table.Pos, stmt.Table
+		case stmt.View != nil:
+			kind, name, pos, target, wsOnly = symbol_View, stmt.View.Name, stmt.View.Pos, stmt.View, true
+		case stmt.Command != nil:
+			kind, name, pos, target, wsOnly = symbol_Command, stmt.Command.Name, stmt.Command.Pos, stmt.Command, true
+		case stmt.Query != nil:
+			kind, name, pos, target, wsOnly = symbol_Query, stmt.Query.Name, stmt.Query.Pos, stmt.Query, true
+		case stmt.Projector != nil:
+			kind, name, pos, target, wsOnly = symbol_Projector, stmt.Projector.Name, stmt.Projector.Pos, stmt.Projector, true
+		case stmt.Grant != nil:
+			if ws == appdef.NullQName {
+				c.err(errorAt(stmt.Grant.Pos, ErrStatementNotAllowed, "GRANT must be declared in workspace"))
+			}
+			return
+		}
+
+		if wsOnly && (ws == appdef.NullQName) {
+			c.err(errorAt(pos, ErrStatementNotAllowed, "%v «%s» must be declared in workspace", kind, name))
+			return
+		}
+
+		sym := c.addSymbol(pkg, kind, name, pos, target)
+		if sym == nil {
+			return
+		}
+
+		if ws != appdef.NullQName {
+			idx := workspaces[ws]
+			c.schema.Workspaces[idx].Members = append(c.schema.Workspaces[idx].Members, sym.name)
+		}
+
+		switch kind {
+		case symbol_Workspace:
+			workspaces[sym.name] = len(c.schema.Workspaces)
+			c.schema.Workspaces = append(c.schema.Workspaces, WorkspaceDecl{QName: sym.name})
+		case symbol_Table:
+			c.collectNestedTables(pkg, stmt.Table)
+		}
+	})
+}
+
+func (c *buildContext) collectNestedTables(pkg *packageContext, table *TableStmt) {
+	for _, item := range table.Items {
+		if item.Table != nil {
+			if sym := c.addSymbol(pkg, symbol_Table, item.Table.Name, item.Table.Pos, item.Table); sym != nil {
+				c.collectNestedTables(pkg, item.Table)
+			}
+		}
+	}
+}
+
+// Resolves name from schema into qualified name
+func (c *buildContext) qName(pkg *packageContext, name DefQName) (appdef.QName, error) {
+	switch name.Package {
+	case "", pkg.name:
+		return appdef.NewQName(pkg.name, name.Name), nil
+	case appdef.SysPackage:
+		return appdef.NewQName(appdef.SysPackage, name.Name), nil
+	}
+	if local, ok := pkg.imports[name.Package]; ok {
+		return appdef.NewQName(local, name.Name), nil
+	}
+	return appdef.NullQName, errorAt(name.Pos, ErrUndefined, "package «%s» is not imported", name.Package)
+}
+
+// Finds declared symbol by name and checks it kind.
+//
+// Names from sys package are not declared in schemas, so nil symbol and no error is returned for them
+func (c *buildContext) lookup(pkg *packageContext, name DefQName, kinds ...symbolKind) (appdef.QName, *symbol, error) {
+	q, err := c.qName(pkg, name)
+	if err != nil {
+		return q, nil, err
+	}
+	sym, ok := c.symbols[q]
+	if !ok {
+		if q.Pkg() == appdef.SysPackage {
+			return q, nil, nil
+		}
+		return q, nil, errorAt(name.Pos, ErrUndefined, "«%v»", name)
+	}
+	if !slices.Contains(kinds, sym.kind) {
+		expected := make([]string, 0, len(kinds))
+		for _, k := range kinds {
+			expected = append(expected, k.String())
+		}
+		return q, nil, errorAt(name.Pos, ErrUndefined, "«%v» is %v, expected %s", name, sym.kind, strings.Join(expected, " or "))
+	}
+	return q, sym, nil
+}
+
+// Returns data kind if name is data type name
+func dataKind(name DefQName) (appdef.DataKind, bool) {
+	if name.Package != "" {
+		return appdef.DataKind_null, false
+	}
+	k, ok := dataTypes[strings.ToUpper(name.Name)]
+	return k, ok
+}
+
+func (c *buildContext) mustDataKind(name DefQName) (appdef.DataKind, bool) {
+	k, ok := dataKind(name)
+	if !ok {
+		c.err(errorAt(name.Pos, ErrInvalidDataType, "«%v» is not a data type", name))
+	}
+	return k, ok
+}
+
+// Checks what precision is specified for decimal data kind only
+func (c *buildContext) checkPrecision(kind appdef.DataKind, p *Precision) bool {
+	if (p != nil) && (kind != appdef.DataKind_decimal) {
+		c.err(errorAt(p.Pos, ErrInvalidDataType, "precision can be specified for decimal fields only, not for «%v»", kind.ToString()))
+		return false
+	}
+	return true
+}
+
+// Returns is name refers to sys.Json arguments or result
+func isJSON(name DefQName) bool {
+	return (name.Package == appdef.SysPackage) && strings.EqualFold(name.Name, istructs.QNameJSON.Entity())
+}
+
+type withOptions struct {
+	comment      string
+	tags         []appdef.QName
+	rate         appdef.QName
+	handleErrors bool
+}
+
+// Parses WITH clause options. Only allowed options can be specified
+func (c *buildContext) with(pkg *packageContext, items []WithItem, allowed ...string) (opts withOptions) {
+	for _, item := range items {
+		if !slices.Contains(allowed, item.Name) {
+			c.err(errorAt(item.Pos, ErrInvalidOption, "«%s» is not allowed here, expected one of %v", item.Name, allowed))
+			continue
+		}
+		switch item.Name {
+		case withComment, withDescription:
+			if item.String == nil {
+				c.err(errorAt(item.Pos, ErrInvalidOption, "%s must be a string", item.Name))
+				continue
+			}
+			opts.comment = *item.String
+		case withTags:
+			list := item.List
+			if item.QName != nil {
+				list = []DefQName{*item.QName}
+			}
+			if len(list) == 0 {
+				c.err(errorAt(item.Pos, ErrInvalidOption, "%s must be a list of tags", item.Name))
+				continue
+			}
+			for _, t := range list {
+				q, _, err := c.lookup(pkg, t, symbol_Tag)
+				if err != nil {
+					c.err(err)
+					continue
+				}
+				opts.tags = append(opts.tags, q)
+			}
+		case withRate:
+			if item.QName == nil {
+				c.err(errorAt(item.Pos, ErrInvalidOption, "%s must be a rate name", item.Name))
+				continue
+			}
+			q, _, err := c.lookup(pkg, *item.QName, symbol_Rate)
+			if err != nil {
+				c.err(err)
+				continue
+			}
+			opts.rate = q
+		case withHandleErrors:
+			if (item.QName == nil) || (item.QName.Package != "") || !slices.Contains([]string{"true", "false"}, strings.ToLower(item.QName.Name)) {
+				c.err(errorAt(item.Pos, ErrInvalidOption, "%s must be true or false", item.Name))
+				continue
+			}
+			opts.handleErrors = strings.EqualFold(item.QName.Name, "true")
+		}
+	}
+	return opts
+}
+
+func engineKind(engine *string) int {
+	if (engine != nil) && (*engine == engineWASM) {
+		return iextengine.ExtEngineKind_WASM
+	}
+	return iextengine.ExtEngineKind_BuiltIn
+}
+
+func (c *buildContext) buildRates() {
+	c.iterate(func(pkg *packageContext, _ appdef.QName, stmt *Statement) {
+		rate := stmt.Rate
+		if rate == nil {
+			return
+		}
+		c.with(pkg, rate.With, withComment, withDescription)
+
+		if rate.Amount == 0 {
+			c.err(errorAt(rate.Pos, ErrInvalidOption, "rate amount must be positive"))
+			return
+		}
+		kind := istructs.RateLimitKind_byApp
+		if rate.Scope != nil {
+			k, ok := rateScopes[*rate.Scope]
+			if !ok {
+				c.err(errorAt(rate.Pos, ErrUnsupported, "rate scope «PER %s»", *rate.Scope))
+				return
+			}
+			kind = k
+		}
+		name := appdef.NewQName(pkg.name, rate.Name)
+		c.rates[name] = RateLimitDecl{
+			Name: name,
+			Kind: kind,
+			Limit: istructs.RateLimit{
+				Period:                ratePeriods[rate.Period],
+				MaxAllowedPerDuration: rate.Amount,
+			},
+		}
+	})
+}
+
+// Adds fields to definition. Only data types are allowed
+func (c *buildContext) addFields(pkg *packageContext, def appdef.IDefBuilder, fields []FieldExpr) {
+	for i := range fields {
+		f := &fields[i]
+		kind, ok := c.mustDataKind(f.Type)
+		if !ok {
+			continue
+		}
+		if def.Field(f.Name) != nil {
+			c.err(errorAt(f.Pos, ErrRedefined, "field «%s» in «%v»", f.Name, def.QName()))
+			continue
+		}
+		if f.References != nil {
+			if _, _, err := c.lookup(pkg, *f.References, symbol_Table); err != nil {
+				c.err(err)
+				continue
+			}
+			if kind != appdef.DataKind_RecordID {
+				c.err(errorAt(f.Pos, ErrInvalidDataType, "referencing field «%s» must be ID, not «%v»", f.Name, f.Type))
+				continue
+			}
+		}
+		var sequence appdef.QName
+		if (f.Default != nil) && (f.Default.NextVal != nil) {
+			seq, err := ParseDefQName(f.Default.Pos, *f.Default.NextVal)
+			var sym *symbol
+			if err == nil {
+				sequence, sym, err = c.lookup(pkg, seq, symbol_Sequence)
+			}
+			if err != nil {
+				c.err(err)
+				continue
+			}
+			if seqKind, _ := dataKind(sym.stmt.(*SequenceStmt).Type); seqKind != kind {
+				c.err(errorAt(f.Default.Pos, ErrInvalidDataType, "field «%s» must be «%v» as sequence «%v»", f.Name, sym.stmt.(*SequenceStmt).Type, sequence))
+				continue
+			}
+		}
+		if !c.checkPrecision(kind, f.Precision) {
+			continue
+		}
+		c.safe(f.Pos, func() {
+			switch {
+			case f.Verifiable:
+				def.AddVerifiedField(f.Name, kind, f.NotNull, appdef.VerificationKind_Any...)
+			case f.Precision != nil:
+				def.AddDecimalField(f.Name, f.Precision.Precision, f.Precision.scale(), f.NotNull)
+			default:
+				def.AddField(f.Name, kind, f.NotNull)
+			}
+		})
+		if (f.Default != nil) && (f.Default.NextVal == nil) {
+			c.safe(f.Default.Pos, func() {
+				def.SetFieldDefault(f.Name, f.Default.value())
+			})
+		}
+		if sequence != appdef.NullQName {
+			c.schema.SequenceDefaults = append(c.schema.SequenceDefaults, SequenceDefaultDecl{Def: def.QName(), Field: f.Name, Sequence: sequence})
+		}
+		if f.Check != nil {
+			c.safe(f.Check.Pos, func() {
+				cons, err := f.Check.constraints(f.Name)
+				if err != nil {
+					c.err(err)
+					return
+				}
+				def.SetFieldConstraints(f.Name, cons...)
+			})
+		}
+	}
+}
+
+func (c *buildContext) buildTypes() {
+	c.iterate(func(pkg *packageContext, _ appdef.QName, stmt *Statement) {
+		t := stmt.Type
+		if t == nil {
+			return
+		}
+		opts := c.with(pkg, t.With, withComment, withDescription, withTags)
+		c.symbols[appdef.NewQName(pkg.name, t.Name)].tags = opts.tags
+		c.safe(t.Pos, func() {
+			def := c.builder.AddStruct(appdef.NewQName(pkg.name, t.Name), appdef.DefKind_Object)
+			c.addFields(pkg, def, t.Fields)
+		})
+	})
+}
+
+func (c *buildContext) buildTables() {
+	c.iterate(func(pkg *packageContext, _ appdef.QName, stmt *Statement) {
+		if stmt.Table != nil {
+			c.buildTable(pkg, stmt.Table, nil)
+		}
+	})
+}
+
+// Builds table definition. Parent is nil for root tables
+func (c *buildContext) buildTable(pkg *packageContext, table *TableStmt, parent *symbol) {
+	name := appdef.NewQName(pkg.name, table.Name)
+	sym := c.symbols[name]
+
+	kind := appdef.DefKind_null
+	singleton := false
+	mixins := make([]*symbol, 0)
+	for _, inh := range table.Inherits {
+		if (inh.Package == "") || (inh.Package == appdef.SysPackage) {
+			if k, ok := tableKinds[strings.ToUpper(inh.Name)]; ok {
+				if parent != nil {
+					c.err(errorAt(inh.Pos, ErrInvalidTableKind, "nested table «%s» can not override table kind", table.Name))
+					return
+				}
+				if kind != appdef.DefKind_null {
+					c.err(errorAt(inh.Pos, ErrInvalidTableKind, "table «%s» kind is already specified", table.Name))
+					return
+				}
+				kind = k
+				singleton = strings.EqualFold(inh.Name, singletonTableKind)
+				continue
+			}
+		}
+		_, mixin, err := c.lookup(pkg, inh, symbol_Type)
+		if err != nil {
+			c.err(err)
+			continue
+		}
+		if mixin == nil {
+			c.err(errorAt(inh.Pos, ErrUndefined, "«%v»", inh))
+			continue
+		}
+		mixins = append(mixins, mixin)
+	}
+
+	if parent == nil {
+		if kind == appdef.DefKind_null {
+			c.err(errorAt(table.Pos, ErrInvalidTableKind, "table «%s» kind is missed, expected OF CDOC, WDOC, ODOC, GDOC or SINGLETON", table.Name))
+			return
+		}
+		sym.tableKind = kind
+	} else {
+		sym.tableKind = parent.tableKind
+		kind = nestedTableKinds[parent.tableKind]
+	}
+
+	opts := c.with(pkg, table.With, withComment, withDescription, withTags)
+	sym.tags = opts.tags
+
+	var def appdef.IDefBuilder
+	c.safe(table.Pos, func() {
+		def = c.builder.AddStruct(name, kind)
+		if singleton {
+			def.SetSingleton()
+		}
+	})
+	if def == nil {
+		return
+	}
+
+	for _, mixin := range mixins {
+		c.addFields(mixin.pkg, def, mixin.stmt.(*TypeStmt).Fields)
+	}
+
+	uniques := make([]*UniqueExpr, 0)
+	for _, item := range table.Items {
+		switch {
+		case item.Field != nil:
+			c.addFields(pkg, def, []FieldExpr{*item.Field})
+		case item.Unique != nil:
+			uniques = append(uniques, item.Unique)
+		case item.Table != nil:
+			c.buildTable(pkg, item.Table, sym)
+			c.safe(item.Table.Pos, func() {
+				def.AddContainer(item.Table.Name, appdef.NewQName(pkg.name, item.Table.Name), 0, appdef.Occurs_Unbounded)
+			})
+		}
+	}
+
+	for _, u := range uniques {
+		ok := true
+		for _, f := range u.Fields {
+			if def.Field(f) == nil {
+				c.err(errorAt(u.Pos, ErrUndefined, "unique field «%s» in table «%s»", f, table.Name))
+				ok = false
+			}
+		}
+		if ok {
+			c.schema.Uniques = append(c.schema.Uniques, UniqueDecl{QName: name, Fields: u.Fields})
+		}
+	}
+}
+
+func (c *buildContext) buildViews() {
+	c.iterate(func(pkg *packageContext, _ appdef.QName, stmt *Statement) {
+		if stmt.View != nil {
+			c.buildView(pkg, stmt.View)
+		}
+	})
+}
+
+func (c *buildContext) buildView(pkg *packageContext, view *ViewStmt) {
+	name := appdef.NewQName(pkg.name, view.Name)
+	opts := c.with(pkg, view.With, withComment, withDescription, withTags)
+	c.symbols[name].tags = opts.tags
+
+	var pk *PrimaryKeyExpr
+	fields := make(map[string]*ViewField)
+	ordered := make([]*ViewField, 0)
+	for _, item := range view.Items {
+		switch {
+		case item.PrimaryKey != nil:
+			if pk != nil {
+				c.err(errorAt(item.PrimaryKey.Pos, ErrInvalidPrimaryKey, "view «%s» primary key is already declared", view.Name))
+				return
+			}
+			pk = item.PrimaryKey
+		case item.Field != nil:
+			f := item.Field
+			if _, ok := fields[f.Name]; ok {
+				c.err(errorAt(f.Pos, ErrRedefined, "view «%s» field «%s»", view.Name, f.Name))
+				return
+			}
+			if _, ok := c.mustDataKind(f.Type); !ok {
+				return
+			}
+			fields[f.Name] = f
+			ordered = append(ordered, f)
+		}
+	}
+
+	if pk == nil {
+		c.err(errorAt(view.Pos, ErrInvalidPrimaryKey, "view «%s» primary key is missed", view.Name))
+		return
+	}
+	if len(pk.ClustColumns) == 0 {
+		c.err(errorAt(pk.Pos, ErrInvalidPrimaryKey, "view «%s» clustering columns are missed", view.Name))
+		return
+	}
+
+	keys := make(map[string]bool)
+	for _, k := range append(append([]string{}, pk.PartKey...), pk.ClustColumns...) {
+		if _, ok := fields[k]; !ok {
+			c.err(errorAt(pk.Pos, ErrUndefined, "view «%s» primary key field «%s»", view.Name, k))
+			return
+		}
+		if keys[k] {
+			c.err(errorAt(pk.Pos, ErrInvalidPrimaryKey, "view «%s» primary key field «%s» is used more than once", view.Name, k))
+			return
+		}
+		keys[k] = true
+	}
+
+	if view.ResultOf != nil {
+		proj, _, err := c.lookup(pkg, *view.ResultOf, symbol_Projector)
+		if err != nil {
+			c.err(err)
+			return
+		}
+		c.projViews[proj] = append(c.projViews[proj], name)
+	}
+
+	for _, f := range ordered {
+		kind, _ := dataKind(f.Type)
+		if !c.checkPrecision(kind, f.Precision) {
+			return
+		}
+	}
+
+	c.safe(view.Pos, func() {
+		v := c.builder.AddView(name)
+		for _, k := range pk.PartKey {
+			if p := fields[k].Precision; p != nil {
+				v.PartKeyDef().AddDecimalField(k, p.Precision, p.scale(), true)
+				continue
+			}
+			kind, _ := dataKind(fields[k].Type)
+			v.AddPartField(k, kind)
+		}
+		for _, k := range pk.ClustColumns {
+			if p := fields[k].Precision; p != nil {
+				v.ClustColsDef().AddDecimalField(k, p.Precision, p.scale(), false)
+				continue
+			}
+			kind, _ := dataKind(fields[k].Type)
+			v.AddClustColumn(k, kind)
+		}
+		for _, f := range ordered {
+			if !keys[f.Name] {
+				if p := f.Precision; p != nil {
+					v.ValueDef().AddDecimalField(f.Name, p.Precision, p.scale(), f.NotNull)
+					continue
+				}
+				kind, _ := dataKind(f.Type)
+				v.AddValueField(f.Name, kind, f.NotNull)
+			}
+		}
+	})
+}
+
+func (c *buildContext) buildFunctions() {
+	c.iterate(func(pkg *packageContext, _ appdef.QName, stmt *Statement) {
+		switch {
+		case stmt.Command != nil:
+			cmd := stmt.Command
+			decl := c.buildFunction(pkg, symbol_Command, cmd.Name, cmd.Args, cmd.Returns, cmd.Engine, cmd.With)
+			c.schema.Commands = append(c.schema.Commands, decl)
+		case stmt.Query != nil:
+			qry := stmt.Query
+			decl := c.buildFunction(pkg, symbol_Query, qry.Name, qry.Args, &qry.Returns, qry.Engine, qry.With)
+			c.schema.Queries = append(c.schema.Queries, decl)
+		}
+	})
+}
+
+func (c *buildContext) buildFunction(pkg *packageContext, kind symbolKind, name string, args []ArgExpr, returns *DefQName, engine *string, with []WithItem) FunctionDecl {
+	decl := FunctionDecl{
+		QName:  appdef.NewQName(pkg.name, name),
+		Engine: engineKind(engine),
+	}
+
+	decl.Params, decl.UnloggedParams = c.buildFunctionArgs(pkg, kind, name, args)
+
+	if returns != nil {
+		decl.Result = c.functionArgType(pkg, *returns)
+	}
+
+	opts := c.with(pkg, with, withComment, withDescription, withTags, withRate)
+	decl.Comment = opts.comment
+	decl.Tags = opts.tags
+	c.symbols[decl.QName].tags = opts.tags
+
+	if opts.rate != appdef.NullQName {
+		rate := c.rates[opts.rate]
+		rate.Function = decl.QName
+		c.schema.RateLimits = append(c.schema.RateLimits, rate)
+	}
+
+	return decl
+}
+
+// Returns definition name for function argument or result type. Data types are not allowed
+func (c *buildContext) functionArgType(pkg *packageContext, name DefQName) appdef.QName {
+	if isJSON(name) {
+		return istructs.QNameJSON
+	}
+	if _, ok := dataKind(name); ok {
+		c.err(errorAt(name.Pos, ErrInvalidArgument, "data type «%v» can not be used as function argument or result type without name", name))
+		return appdef.NullQName
+	}
+	q, _, err := c.lookup(pkg, name, symbol_Type, symbol_Table)
+	if err != nil {
+		c.err(err)
+		return appdef.NullQName
+	}
+	return q
+}
+
+func (c *buildContext) buildFunctionArgs(pkg *packageContext, kind symbolKind, name string, args []ArgExpr) (params, unlogged appdef.QName) {
+	named, unnamed := make([]*ArgExpr, 0), make([]*ArgExpr, 0)
+	for i := range args {
+		arg := &args[i]
+		if arg.Unlogged && (kind != symbol_Command) {
+			c.err(errorAt(arg.Pos, ErrInvalidArgument, "only commands can have unlogged arguments"))
+			return
+		}
+		if arg.Second != nil {
+			named = append(named, arg)
+			continue
+		}
+		if arg.NotNull {
+			c.err(errorAt(arg.Pos, ErrInvalidArgument, "NOT NULL can be specified for named arguments only"))
+			return
+		}
+		unnamed = append(unnamed, arg)
+	}
+
+	if (len(named) > 0) && (len(unnamed) > 0) {
+		c.err(errorAt(args[0].Pos, ErrInvalidArgument, "function «%s» arguments must be all named or single type", name))
+		return
+	}
+
+	if len(unnamed) > 0 {
+		for _, arg := range unnamed {
+			q := c.functionArgType(pkg, arg.First)
+			if arg.Unlogged {
+				if unlogged != appdef.NullQName {
+					c.err(errorAt(arg.Pos, ErrInvalidArgument, "function «%s» has more than one unlogged argument type", name))
+					return
+				}
+				unlogged = q
+			} else {
+				if params != appdef.NullQName {
+					c.err(errorAt(arg.Pos, ErrInvalidArgument, "function «%s» has more than one argument type", name))
+					return
+				}
+				params = q
+			}
+		}
+		return params, unlogged
+	}
+
+	addParamsDef := func(suffix string, unl bool) (q appdef.QName) {
+		q = appdef.NewQName(pkg.name, name+suffix)
+		var def appdef.IDefBuilder
+		for _, arg := range named {
+			if arg.Unlogged != unl {
+				continue
+			}
+			if def == nil {
+				if prev, ok := c.symbols[q]; ok {
+					c.err(errorAt(arg.Pos, ErrRedefined, "arguments definition «%v» already declared as %v at %s", q, prev.kind, prev.pos))
+					return appdef.NullQName
+				}
+				c.safe(arg.Pos, func() { def = c.builder.AddStruct(q, appdef.DefKind_Object) })
+				if def == nil {
+					return appdef.NullQName
+				}
+			}
+			if arg.First.Package != "" {
+				c.err(errorAt(arg.First.Pos, ErrInvalidArgument, "invalid argument name «%v»", arg.First))
+				continue
+			}
+			c.addFields(pkg, def, []FieldExpr{{Pos: arg.Pos, Name: arg.First.Name, Type: *arg.Second, NotNull: arg.NotNull}})
+		}
+		if def == nil {
+			return appdef.NullQName
+		}
+		return q
+	}
+
+	params = addParamsDef(argsDefSuffix, false)
+	unlogged = addParamsDef(unloggedArgsDefSuffix, true)
+	return params, unlogged
+}
+
+func (c *buildContext) buildProjectors() {
+	c.iterate(func(pkg *packageContext, _ appdef.QName, stmt *Statement) {
+		proj := stmt.Projector
+		if proj == nil {
+			return
+		}
+		decl := ProjectorDecl{
+			QName:  appdef.NewQName(pkg.name, proj.Name),
+			Engine: engineKind(proj.Engine),
+		}
+		for _, target := range proj.Targets {
+			if proj.Argument {
+				if q := c.functionArgType(pkg, target); q != appdef.NullQName {
+					decl.EventsArgsFilter = append(decl.EventsArgsFilter, q)
+				}
+				continue
+			}
+			q, _, err := c.lookup(pkg, target, symbol_Command)
+			if err != nil {
+				c.err(err)
+				continue
+			}
+			decl.EventsFilter = append(decl.EventsFilter, q)
+		}
+		opts := c.with(pkg, proj.With, withComment, withDescription, withHandleErrors)
+		decl.HandleErrors = opts.handleErrors
+		decl.Views = c.projViews[decl.QName]
+		c.schema.Projectors = append(c.schema.Projectors, decl)
+	})
+}
+
+var grantOps = map[string]iauthnz.OperationKindType{
+	"SELECT":  iauthnz.OperationKind_SELECT,
+	"INSERT":  iauthnz.OperationKind_INSERT,
+	"UPDATE":  iauthnz.OperationKind_UPDATE,
+	"EXECUTE": iauthnz.OperationKind_EXECUTE,
+}
+
+// Allowed grant operations by resource kind. First is used for GRANT ALL
+var grantKindOps = map[symbolKind][]iauthnz.OperationKindType{
+	symbol_Table:   {iauthnz.OperationKind_SELECT, iauthnz.OperationKind_INSERT, iauthnz.OperationKind_UPDATE},
+	symbol_View:    {iauthnz.OperationKind_SELECT},
+	symbol_Command: {iauthnz.OperationKind_EXECUTE},
+	symbol_Query:   {iauthnz.OperationKind_EXECUTE},
+}
+
+var grantTargetKinds = map[string]symbolKind{
+	"TABLE":    symbol_Table,
+	"TABLES":   symbol_Table,
+	"VIEW":     symbol_View,
+	"VIEWS":    symbol_View,
+	"COMMAND":  symbol_Command,
+	"COMMANDS": symbol_Command,
+	"QUERY":    symbol_Query,
+	"QUERIES":  symbol_Query,
+}
+
+func (c *buildContext) buildGrants() {
+	c.iterate(func(pkg *packageContext, _ appdef.QName, stmt *Statement) {
+		grant := stmt.Grant
+		if grant == nil {
+			return
+		}
+
+		decl := GrantDecl{}
+
+		var kind symbolKind
+		switch {
+		case grant.OfKind != nil:
+			kind = grantTargetKinds[*grant.OfKind]
+			q, _, err := c.lookup(pkg, *grant.On, kind)
+			if err != nil {
+				c.err(err)
+				return
+			}
+			decl.Resources = []appdef.QName{q}
+		case grant.AllOfKind != nil:
+			kind = grantTargetKinds[*grant.AllOfKind]
+			tag, _, err := c.lookup(pkg, *grant.Tag, symbol_Tag)
+			if err != nil {
+				c.err(err)
+				return
+			}
+			for _, sym := range c.ordered {
+				if (sym.kind == kind) && slices.Contains(sym.tags, tag) {
+					decl.Resources = append(decl.Resources, sym.name)
+				}
+			}
+		}
+
+		if grant.All {
+			decl.Ops = grantKindOps[kind]
+		} else {
+			for _, op := range grant.Ops {
+				o := grantOps[op]
+				if !slices.Contains(grantKindOps[kind], o) {
+					c.err(errorAt(grant.Pos, ErrInvalidGrant, "%s can not be granted on %v", op, kind))
+					return
+				}
+				if !slices.Contains(decl.Ops, o) {
+					decl.Ops = append(decl.Ops, o)
+				}
+			}
+		}
+
+		role, sym, err := c.lookup(pkg, grant.To, symbol_Role)
+		if err != nil {
+			c.err(err)
+			return
+		}
+		if (sym == nil) && !iauthnz.IsSystemRole(role) {
+			c.err(errorAt(grant.To.Pos, ErrUndefined, "system role «%v»", role))
+			return
+		}
+		decl.Role = role
+
+		c.schema.ACL = append(c.schema.ACL, decl)
+	})
+}
+
+// Builds roles, tags and sequences
+func (c *buildContext) buildOthers() {
+	c.iterate(func(pkg *packageContext, _ appdef.QName, stmt *Statement) {
+		switch {
+		case stmt.Workspace != nil:
+			c.with(pkg, stmt.Workspace.With, withComment, withDescription)
+		case stmt.Role != nil:
+			c.with(pkg, stmt.Role.With, withComment, withDescription)
+			c.schema.Roles = append(c.schema.Roles, appdef.NewQName(pkg.name, stmt.Role.Name))
+		case stmt.Tag != nil:
+			c.with(pkg, stmt.Tag.With, withComment, withDescription)
+			c.schema.Tags = append(c.schema.Tags, appdef.NewQName(pkg.name, stmt.Tag.Name))
+		case stmt.Sequence != nil:
+			c.buildSequence(pkg, stmt.Sequence)
+		}
+	})
+}
+
+func (c *buildContext) buildSequence(pkg *packageContext, seq *SequenceStmt) {
+	c.with(pkg, seq.With, withComment, withDescription)
+
+	kind, ok := c.mustDataKind(seq.Type)
+	if !ok {
+		return
+	}
+	decl := SequenceDecl{
+		QName:     appdef.NewQName(pkg.name, seq.Name),
+		DataKind:  kind,
+		Increment: 1,
+		MinValue:  1,
+	}
+	switch kind {
+	case appdef.DataKind_int32:
+		decl.MaxValue = math.MaxInt32
+	case appdef.DataKind_int64:
+		decl.MaxValue = math.MaxInt64
+	default:
+		c.err(errorAt(seq.Type.Pos, ErrInvalidDataType, "sequence «%s» must be integer, not «%v»", seq.Name, seq.Type))
+		return
+	}
+
+	var start *int64
+	for _, opt := range seq.Options {
+		switch {
+		case opt.StartWith != nil:
+			start = opt.StartWith
+		case opt.MinValue != nil:
+			decl.MinValue = *opt.MinValue
+		case opt.MaxValue != nil:
+			decl.MaxValue = *opt.MaxValue
+		case opt.Increment != nil:
+			decl.Increment = *opt.Increment
+		}
+	}
+
+	switch {
+	case start != nil:
+		decl.StartWith = *start
+	case decl.Increment < 0:
+		decl.StartWith = decl.MaxValue
+	default:
+		decl.StartWith = decl.MinValue
+	}
+
+	if (decl.Increment == 0) || (decl.MinValue > decl.MaxValue) || (decl.StartWith < decl.MinValue) || (decl.StartWith > decl.MaxValue) {
+		c.err(errorAt(seq.Pos, ErrInvalidOption, "sequence «%s» has invalid range: start %d, min %d, max %d, increment %d", seq.Name, decl.StartWith, decl.MinValue, decl.MaxValue, decl.Increment))
+		return
+	}
+
+	c.schema.Sequences = append(c.schema.Sequences, decl)
+}
@@ -0,0 +1,357 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package parser
+
+import (
+	"embed"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/iextengine"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+//go:embed sql_example_app/*/*.sql
+var exampleFS embed.FS
+
+func Test_BasicUsage(t *testing.T) {
+	require := require.New(t)
+
+	mainPkg, err := ParsePackageDir("github.com/untillpro/main", exampleFS, "sql_example_app/pmain")
+	require.NoError(err)
+	untillPkg, err := ParsePackageDir("github.com/untillpro/untill", exampleFS, "sql_example_app/untill")
+	require.NoError(err)
+
+	builder := appdef.New()
+	schema, err := BuildAppDefs([]*PackageSchemaAST{mainPkg, untillPkg}, builder)
+	require.NoError(err)
+
+	app, err := builder.Build()
+	require.NoError(err)
+
+	t.Run("tables", func(t *testing.T) {
+
+		bill := app.DefByName(appdef.NewQName("untill", "bill"))
+		require.NotNil(bill)
+		require.Equal(appdef.DefKind_CDoc, bill.Kind())
+		require.True(bill.Field("name").Required())
+		require.Equal(appdef.DataKind_int32, bill.Field("number").DataKind())
+		require.Equal(appdef.DataKind_string, bill.Field("working_day").DataKind())
+
+		articles := app.DefByName(appdef.NewQName("main", "articles"))
+		require.NotNil(articles)
+		require.Equal(appdef.DefKind_CDoc, articles.Kind())
+		require.NotNil(articles.Field("name"))
+		require.Equal(appdef.DataKind_RecordID, articles.Field("id_bill").DataKind())
+		require.Equal(appdef.DefKind_CRecord, articles.ContainerDef("article_prices").Kind())
+		require.Equal(appdef.Occurs_Unbounded, articles.Container("article_prices").MaxOccurs())
+
+		prices := app.DefByName(appdef.NewQName("main", "article_prices"))
+		require.Equal(appdef.DataKind_float32, prices.Field("price").DataKind())
+		require.Equal(float32(1), prices.Field("price").Default())
+
+		require.EqualValues(0, articles.Field("article_number").Constraint(appdef.ConstraintKind_MinExcl).Value())
+		require.NotNil(articles.Field("ean13barcode").Constraint(appdef.ConstraintKind_Pattern))
+
+		pbill := app.DefByName(appdef.NewQName("main", "pbill"))
+		require.Equal(appdef.DefKind_ODoc, pbill.Kind())
+		require.Equal(appdef.DefKind_ORecord, pbill.ContainerDef("pbill_item").Kind())
+		require.Equal(appdef.DataKind_UUID, pbill.Field("pbill_uid").DataKind())
+		require.Equal(appdef.DataKind_date, pbill.Field("working_date").DataKind())
+		require.Equal(appdef.DataKind_timestamp, pbill.Field("created").DataKind())
+		total := pbill.Field("total")
+		require.Equal(appdef.DataKind_decimal, total.DataKind())
+		require.EqualValues(14, total.Precision())
+		require.EqualValues(2, total.Scale())
+		quantity := pbill.ContainerDef("pbill_item").Field("quantity")
+		require.EqualValues(0, quantity.Constraint(appdef.ConstraintKind_MinExcl).Value())
+		require.EqualValues(1000, quantity.Constraint(appdef.ConstraintKind_MaxIncl).Value())
+		price := pbill.ContainerDef("pbill_item").Field("price")
+		require.Equal(appdef.DataKind_decimal, price.DataKind())
+		require.EqualValues(10, price.Precision())
+		require.EqualValues(4, price.Scale())
+
+		rest := app.DefByName(appdef.NewQName("main", "Restaurant"))
+		require.True(rest.Singleton())
+	})
+
+	t.Run("types and function arguments", func(t *testing.T) {
+
+		res := app.DefByName(appdef.NewQName("main", "QueryResellerInfoResult"))
+		require.Equal(appdef.DefKind_Object, res.Kind())
+		require.Equal(appdef.DataKind_string, res.Field("reseller_phone").DataKind())
+		require.Equal(appdef.DataKind_string, res.Field("reseller_company").DataKind())
+
+		params := app.DefByName(appdef.NewQName("main", "UpdateProfileParams"))
+		require.NotNil(params)
+		require.True(params.Field("name").Required())
+		require.False(params.Field("age").Required())
+		unlogged := app.DefByName(appdef.NewQName("main", "UpdateProfileUnloggedParams"))
+		require.NotNil(unlogged)
+		require.NotNil(unlogged.Field("password"))
+	})
+
+	t.Run("views", func(t *testing.T) {
+
+		view := app.DefByName(appdef.NewQName("main", "XZReports"))
+		require.Equal(appdef.DefKind_ViewRecord, view.Kind())
+		pk := view.ContainerDef(appdef.SystemContainer_ViewPartitionKey)
+		require.Equal(1, pk.FieldCount())
+		require.NotNil(pk.Field("Year"))
+		cc := view.ContainerDef(appdef.SystemContainer_ViewClusteringCols)
+		require.Equal(4, cc.FieldCount())
+		val := view.ContainerDef(appdef.SystemContainer_ViewValue)
+		require.True(val.Field("XZReportWDocID").Required())
+	})
+
+	t.Run("functions", func(t *testing.T) {
+
+		require.Len(schema.Commands, 3)
+		orders := schema.Commands[0]
+		require.Equal(appdef.NewQName("main", "Orders"), orders.QName)
+		require.Equal(appdef.NewQName("untill", "bill"), orders.Params)
+		require.Equal(iextengine.ExtEngineKind_BuiltIn, orders.Engine)
+
+		pbill := schema.Commands[1]
+		require.Equal(appdef.NewQName("main", "pbill"), pbill.Params)
+		require.Equal(appdef.NewQName("untill", "HasNameAndNumber"), pbill.UnloggedParams)
+		require.Equal(appdef.NewQName("untill", "HasNameAndNumber"), pbill.Result)
+		require.Equal(iextengine.ExtEngineKind_WASM, pbill.Engine)
+		require.Equal([]appdef.QName{appdef.NewQName("main", "Pos")}, pbill.Tags)
+
+		require.Len(schema.Queries, 2)
+		info := schema.Queries[0]
+		require.Equal(appdef.NewQName("main", "QueryResellerInfoParams"), info.Params)
+		require.Equal(appdef.NewQName("main", "QueryResellerInfoResult"), info.Result)
+		require.Equal("Reseller info", info.Comment)
+
+		history := schema.Queries[1]
+		require.Equal(istructs.QNameJSON, history.Params)
+		require.Equal(istructs.QNameJSON, history.Result)
+	})
+
+	t.Run("rate limits", func(t *testing.T) {
+		require.Equal([]RateLimitDecl{{
+			Name:     appdef.NewQName("main", "BackofficeFuncRate"),
+			Function: appdef.NewQName("main", "QueryResellerInfo"),
+			Kind:     istructs.RateLimitKind_byWorkspace,
+			Limit:    istructs.RateLimit{Period: time.Minute, MaxAllowedPerDuration: 100},
+		}}, schema.RateLimits)
+	})
+
+	t.Run("projectors", func(t *testing.T) {
+		require.Len(schema.Projectors, 2)
+
+		dash := schema.Projectors[0]
+		require.Equal([]appdef.QName{appdef.NewQName("main", "Orders"), appdef.NewQName("main", "Pbill")}, dash.EventsFilter)
+		require.True(dash.HandleErrors)
+		require.Equal([]appdef.QName{appdef.NewQName("main", "XZReports")}, dash.Views)
+
+		fill := schema.Projectors[1]
+		require.Equal([]appdef.QName{appdef.NewQName("untill", "bill")}, fill.EventsArgsFilter)
+		require.Equal(iextengine.ExtEngineKind_WASM, fill.Engine)
+	})
+
+	t.Run("ACL", func(t *testing.T) {
+		manager, user := appdef.NewQName("main", "LocationManager"), appdef.NewQName("main", "LocationUser")
+
+		require.Equal([]GrantDecl{
+			{
+				Ops:       []iauthnz.OperationKindType{iauthnz.OperationKind_SELECT, iauthnz.OperationKind_INSERT, iauthnz.OperationKind_UPDATE},
+				Resources: []appdef.QName{appdef.NewQName("main", "articles"), appdef.NewQName("untill", "bill")},
+				Role:      manager,
+			},
+			{
+				Ops:       []iauthnz.OperationKindType{iauthnz.OperationKind_INSERT, iauthnz.OperationKind_UPDATE},
+				Resources: []appdef.QName{appdef.NewQName("main", "pbill")},
+				Role:      user,
+			},
+			{
+				Ops:       []iauthnz.OperationKindType{iauthnz.OperationKind_SELECT},
+				Resources: []appdef.QName{appdef.NewQName("untill", "bill")},
+				Role:      user,
+			},
+			{
+				Ops:       []iauthnz.OperationKindType{iauthnz.OperationKind_EXECUTE},
+				Resources: []appdef.QName{appdef.NewQName("main", "Orders")},
+				Role:      user,
+			},
+			{
+				Ops:       []iauthnz.OperationKindType{iauthnz.OperationKind_EXECUTE},
+				Resources: []appdef.QName{appdef.NewQName("main", "QueryResellerInfo")},
+				Role:      user,
+			},
+			{
+				Ops:       []iauthnz.OperationKindType{iauthnz.OperationKind_SELECT},
+				Resources: []appdef.QName{appdef.NewQName("main", "XZReports")},
+				Role:      iauthnz.QNameRoleWorkspaceOwner,
+			},
+		}, schema.ACL)
+	})
+
+	t.Run("others", func(t *testing.T) {
+
+		require.Equal([]UniqueDecl{
+			{QName: appdef.NewQName("main", "article_prices"), Fields: []string{"id_prices"}},
+			{QName: appdef.NewQName("main", "articles"), Fields: []string{"article_number"}},
+		}, schema.Uniques)
+
+		require.Equal([]SequenceDecl{{
+			QName:     appdef.NewQName("main", "article_numbers"),
+			DataKind:  appdef.DataKind_int32,
+			StartWith: 1000,
+			MinValue:  1,
+			MaxValue:  2147483647,
+			Increment: 1,
+		}}, schema.Sequences)
+
+		require.Equal([]SequenceDefaultDecl{{
+			Def:      appdef.NewQName("main", "articles"),
+			Field:    "article_number",
+			Sequence: appdef.NewQName("main", "article_numbers"),
+		}}, schema.SequenceDefaults)
+
+		require.Len(schema.Workspaces, 2)
+		require.Equal(appdef.NewQName("main", "MyWorkspace"), schema.Workspaces[0].QName)
+		require.Contains(schema.Workspaces[0].Members, appdef.NewQName("main", "XZReports"))
+		require.Contains(schema.Workspaces[0].Members, appdef.NewQName("main", "Child"))
+		require.Equal([]appdef.QName{appdef.NewQName("main", "ChildAdmin")}, schema.Workspaces[1].Members)
+
+		require.ElementsMatch([]appdef.QName{
+			appdef.NewQName("main", "LocationManager"),
+			appdef.NewQName("main", "LocationUser"),
+			appdef.NewQName("main", "ChildAdmin"),
+		}, schema.Roles)
+	})
+}
+
+func Test_SyntaxErrors(t *testing.T) {
+	require := require.New(t)
+
+	_, err := ParseFile("file1.sql", `SCHEMA test;
+	TABLE t1 OF CDOC (
+		f1 int NOT NOT NULL
+	);`)
+	require.ErrorContains(err, "file1.sql:3:18: unexpected token \"NULL\"")
+
+	_, err = ParseFile("file2.sql", `TABLE t1 OF CDOC ();`)
+	require.ErrorContains(err, "file2.sql:1:1:")
+}
+
+func Test_MergeErrors(t *testing.T) {
+	require := require.New(t)
+
+	_, err := MergeFileSchemaASTs("test", nil)
+	require.ErrorIs(err, ErrNoSchemaFiles)
+
+	f1, err := ParseFile("file1.sql", `SCHEMA test1;`)
+	require.NoError(err)
+	f2, err := ParseFile("file2.sql", `SCHEMA test2;`)
+	require.NoError(err)
+	_, err = MergeFileSchemaASTs("test", []*FileSchemaAST{f1, f2})
+	require.ErrorIs(err, ErrSchemaNameMismatch)
+	require.ErrorContains(err, "file2.sql")
+}
+
+func Test_BuildErrors(t *testing.T) {
+	build := func(sql string) error {
+		f, err := ParseFile("test.sql", sql)
+		require.NoError(t, err)
+		pkg, err := MergeFileSchemaASTs("github.com/test", []*FileSchemaAST{f})
+		require.NoError(t, err)
+		_, err = BuildAppDefs([]*PackageSchemaAST{pkg}, appdef.New())
+		return err
+	}
+
+	tests := []struct {
+		name   string
+		sql    string
+		err    error
+		errPos string
+	}{
+		{"unknown import", `SCHEMA test; IMPORT SCHEMA "github.com/unknown";`, ErrPackageNotFound, "test.sql:1:14:"},
+		{"redefined", `SCHEMA test;
+			ROLE r1;
+			TAG r1;`, ErrRedefined, "test.sql:3:4:"},
+		{"command out of workspace", `SCHEMA test;
+			COMMAND c1();`, ErrStatementNotAllowed, "test.sql:2:4:"},
+		{"table kind missed", `SCHEMA test;
+			TABLE t1 (f1 int);`, ErrInvalidTableKind, "test.sql:2:4:"},
+		{"nested table kind", `SCHEMA test;
+			TABLE t1 OF CDOC (TABLE t2 OF CDOC (f1 int));`, ErrInvalidTableKind, "test.sql:2:34:"},
+		{"unknown data type", `SCHEMA test;
+			TABLE t1 OF CDOC (f1 varchar);`, ErrInvalidDataType, "test.sql:2:25:"},
+		{"type field in type", `SCHEMA test;
+			TYPE t1 (f1 int);
+			TYPE t2 (f1 t1);`, ErrInvalidDataType, "test.sql:3:16:"},
+		{"precision for not decimal", `SCHEMA test;
+			TABLE t1 OF CDOC (f1 int(10));`, ErrInvalidDataType, "test.sql:2:28:"},
+		{"invalid decimal precision", `SCHEMA test;
+			TABLE t1 OF CDOC (f1 decimal(10, 12));`, appdef.ErrInvalidDecimalPrecision, "test.sql:2:22:"},
+		{"invalid default", `SCHEMA test;
+			TABLE t1 OF CDOC (f1 int DEFAULT 'one');`, appdef.ErrIncompatibleValue, "test.sql:2:37:"},
+		{"unsupported check", `SCHEMA test;
+			TABLE t1 OF CDOC (f1 int CHECK(f1 <> 0));`, ErrUnsupported, "test.sql:2:35:"},
+		{"invalid check pattern", `SCHEMA test;
+			TABLE t1 OF CDOC (f1 text CHECK('[0-9'));`, appdef.ErrInvalidConstraint, "test.sql:2:36:"},
+		{"check pattern for not text", `SCHEMA test;
+			TABLE t1 OF CDOC (f1 int CHECK('[0-9]+'));`, appdef.ErrInvalidConstraint, "test.sql:2:35:"},
+		{"unknown package", `SCHEMA test;
+			TABLE t1 OF CDOC (f1 id REFERENCES air.bill);`, ErrUndefined, "test.sql:2:39:"},
+		{"reference not ID", `SCHEMA test;
+			TABLE t1 OF CDOC (f1 int REFERENCES t1);`, ErrInvalidDataType, "test.sql:2:22:"},
+		{"unknown sequence", `SCHEMA test;
+			TABLE t1 OF CDOC (f1 int DEFAULT NEXTVAL('seq'));`, ErrUndefined, "test.sql:2:37:"},
+		{"sequence of other data type", `SCHEMA test;
+			SEQUENCE seq AS int64;
+			TABLE t1 OF CDOC (f1 int DEFAULT NEXTVAL('seq'));`, ErrInvalidDataType, "test.sql:3:37:"},
+		{"unknown unique field", `SCHEMA test;
+			TABLE t1 OF CDOC (f1 int, UNIQUE(f2));`, ErrUndefined, "test.sql:2:30:"},
+		{"view primary key missed", `SCHEMA test;
+			WORKSPACE w (VIEW v1(f1 int););`, ErrInvalidPrimaryKey, "test.sql:2:17:"},
+		{"view clustering columns missed", `SCHEMA test;
+			WORKSPACE w (VIEW v1(f1 int, PRIMARY KEY((f1))););`, ErrInvalidPrimaryKey, "test.sql:2:45:"},
+		{"view unknown key field", `SCHEMA test;
+			WORKSPACE w (VIEW v1(f1 int, PRIMARY KEY((f1), f2)););`, ErrUndefined, "test.sql:2:45:"},
+		{"view result of not projector", `SCHEMA test;
+			WORKSPACE w (VIEW v1(f1 int, f2 int, PRIMARY KEY((f1), f2)) AS RESULT OF w;);`, ErrUndefined, "test.sql:2:77:"},
+		{"argument data type without name", `SCHEMA test;
+			WORKSPACE w (COMMAND c1(int););`, ErrInvalidArgument, "test.sql:2:28:"},
+		{"mixed arguments", `SCHEMA test;
+			TYPE t1 (f1 int);
+			WORKSPACE w (COMMAND c1(t1, f1 int););`, ErrInvalidArgument, "test.sql:3:28:"},
+		{"unlogged query arguments", `SCHEMA test;
+			TYPE t1 (f1 int);
+			WORKSPACE w (QUERY q1(UNLOGGED t1) RETURNS t1;);`, ErrInvalidArgument, "test.sql:3:26:"},
+		{"unknown option", `SCHEMA test;
+			TAG t1 WITH Rate=r1;`, ErrInvalidOption, "test.sql:2:16:"},
+		{"unsupported rate scope", `SCHEMA test;
+			WORKSPACE w (RATE r1 10 PER HOUR PER IP;);`, ErrUnsupported, "test.sql:2:17:"},
+		{"invalid grant", `SCHEMA test;
+			WORKSPACE w (
+				ROLE r1;
+				VIEW v1(f1 int, f2 int, PRIMARY KEY((f1), f2));
+				GRANT INSERT ON VIEW v1 TO r1;
+			);`, ErrInvalidGrant, "test.sql:5:5:"},
+		{"grant to unknown role", `SCHEMA test;
+			WORKSPACE w (
+				TABLE t1 OF CDOC (f1 int);
+				GRANT SELECT ON TABLE t1 TO sys.Unknown;
+			);`, ErrUndefined, "test.sql:4:33:"},
+		{"invalid sequence", `SCHEMA test;
+			SEQUENCE s1 int START WITH 0;`, ErrInvalidOption, "test.sql:2:4:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := build(tt.sql)
+			require.ErrorIs(t, err, tt.err)
+			require.ErrorContains(t, err, tt.errPos)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package parser
+
+import "io/fs"
+
+// File system to read schema files from, e.g. embed.FS
+type IReadFS interface {
+	fs.ReadDirFS
+	fs.ReadFileFS
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package parser
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+// Parses schema file content.
+//
+// Returned error contains «file:line:col» position of the syntax error
+func ParseFile(fileName, content string) (*FileSchemaAST, error) {
+	return parseFile(fileName, content)
+}
+
+// Parses all schema files (*.sql) from specified directory of file system and merges them into package schema.
+//
+// All files must declare the same SCHEMA name
+func ParsePackageDir(qualifiedPackageName string, fs IReadFS, dir string) (*PackageSchemaAST, error) {
+	return parsePackageDir(qualifiedPackageName, fs, dir)
+}
+
+// Merges parsed schema files of the same package into package schema.
+//
+// All files must declare the same SCHEMA name
+func MergeFileSchemaASTs(qualifiedPackageName string, asts []*FileSchemaAST) (*PackageSchemaAST, error) {
+	return mergeFileSchemaASTs(qualifiedPackageName, asts)
+}
+
+// Builds application definitions from specified package schemas into builder.
+//
+// All packages imported by IMPORT SCHEMA must be in packages list.
+// Returns declarations which are not definitions: functions, projectors, rate limits, ACL, uniques, sequences etc.
+// Returned error joins all found errors, each one contains «file:line:col» position
+func BuildAppDefs(packages []*PackageSchemaAST, builder appdef.IAppDefBuilder) (*AppSchema, error) {
+	return buildAppDefs(packages, builder)
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package parser
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Application schema declarations which are not application definitions.
+//
+// Returned by BuildAppDefs() together with populated appdef.IAppDefBuilder
+type AppSchema struct {
+	Workspaces []WorkspaceDecl
+	Roles      []appdef.QName
+	Tags       []appdef.QName
+	Commands   []FunctionDecl
+	Queries    []FunctionDecl
+	Projectors []ProjectorDecl
+	RateLimits []RateLimitDecl
+	ACL        []GrantDecl
+	Uniques    []UniqueDecl
+	Sequences  []SequenceDecl
+
+	SequenceDefaults []SequenceDefaultDecl
+}
+
+// Workspace declaration.
+type WorkspaceDecl struct {
+	QName appdef.QName
+
+	// Names of definitions, functions, projectors and nested workspaces declared in workspace
+	Members []appdef.QName
+}
+
+// Command or query function declaration
+type FunctionDecl struct {
+	QName appdef.QName
+
+	// Arguments definition name. NullQName if function has no arguments, istructs.QNameJSON if arguments are raw JSON
+	Params appdef.QName
+
+	// Unlogged arguments definition name. Always NullQName for queries
+	UnloggedParams appdef.QName
+
+	// Result definition name. NullQName if function has no result
+	Result appdef.QName
+
+	// Extension engine kind, ref. iextengine.ExtEngineKind_* constants
+	Engine int
+
+	Comment string
+	Tags    []appdef.QName
+}
+
+// Projector declaration
+type ProjectorDecl struct {
+	QName appdef.QName
+
+	// Extension engine kind, ref. iextengine.ExtEngineKind_* constants
+	Engine int
+
+	// Commands to feed projector. Ref. istructs.Projector.EventsFilter
+	EventsFilter []appdef.QName
+
+	// Command arguments to feed projector. Ref. istructs.Projector.EventsArgsFilter
+	EventsArgsFilter []appdef.QName
+
+	HandleErrors bool
+
+	// Views declared as result of projector, ref. VIEW ... AS RESULT OF
+	Views []appdef.QName
+}
+
+// Function rate limit declaration
+type RateLimitDecl struct {
+	// Rate name from RATE statement
+	Name     appdef.QName
+	Function appdef.QName
+	Kind     istructs.RateLimitKind
+	Limit    istructs.RateLimit
+}
+
+// GRANT statement declaration. All tags are resolved to resources names
+type GrantDecl struct {
+	Ops       []iauthnz.OperationKindType
+	Resources []appdef.QName
+	Role      appdef.QName
+}
+
+// UNIQUE table constraint declaration
+type UniqueDecl struct {
+	QName  appdef.QName
+	Fields []string
+}
+
+// SEQUENCE declaration
+type SequenceDecl struct {
+	QName     appdef.QName
+	DataKind  appdef.DataKind
+	StartWith int64
+	MinValue  int64
+	MaxValue  int64
+	Increment int64
+}
+
+// Field which default is the next value of the sequence, DEFAULT NEXTVAL('sequence')
+type SequenceDefaultDecl struct {
+	Def      appdef.QName
+	Field    string
+	Sequence appdef.QName
+}
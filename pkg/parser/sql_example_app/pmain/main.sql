@@ -0,0 +1,47 @@
+-- Copyright (c) 2023-present unTill Pro, Ltd.
+
+SCHEMA main;
+
+IMPORT SCHEMA "github.com/untillpro/untill" AS untill;
+
+ROLE LocationManager;
+ROLE LocationUser;
+
+TAG Pos;
+
+SEQUENCE article_numbers AS int START WITH 1000;
+
+TYPE QueryResellerInfoResult (
+    reseller_phone   text,
+    reseller_company text
+) WITH Comment='Contains information about Reseller';
+
+TABLE articles OF CDOC, untill.HasNameAndNumber (
+    article_number  int NOT NULL DEFAULT NEXTVAL('article_numbers') CHECK(article_number > 0),
+    barcode         text NOT NULL,
+    ean13barcode    text CHECK('^[0-9]{13}$'),
+    id_bill         id REFERENCES untill.bill,
+    UNIQUE(article_number),
+    TABLE article_prices (
+        id_prices   int64,
+        price       float32 DEFAULT 1.00,
+        UNIQUE(id_prices)
+    )
+) WITH Comment='Information about article', Tags=[untill.Backoffice];
+
+TABLE pbill OF ODOC (
+    tableno int NOT NULL,
+    pbill_uid UUID,
+    working_date DATE,
+    created TIMESTAMP,
+    total DECIMAL(14, 2),
+    TABLE pbill_item (
+        quantity int NOT NULL CHECK(quantity > 0 AND quantity <= 1000),
+        price money(10, 4)
+    )
+) WITH Tags=[Pos];
+
+TABLE Restaurant OF SINGLETON (
+    DisplayName text,
+    Country     text
+);
@@ -0,0 +1,41 @@
+-- Copyright (c) 2023-present unTill Pro, Ltd.
+
+SCHEMA main;
+
+WORKSPACE MyWorkspace (
+    RATE BackofficeFuncRate 100 PER MINUTE PER WORKSPACE;
+
+    COMMAND Orders(untill.bill);
+    COMMAND Pbill(pbill, UNLOGGED untill.HasNameAndNumber) RETURNS untill.HasNameAndNumber ENGINE WASM WITH Tags=[Pos];
+    COMMAND UpdateProfile(name text NOT NULL, age int32, UNLOGGED password text);
+
+    QUERY QueryResellerInfo(reseller_id text NOT NULL) RETURNS QueryResellerInfoResult ENGINE WASM
+        WITH Rate=BackofficeFuncRate,
+        Comment='Reseller info',
+        Tags=[Pos];
+    QUERY TransactionHistory(sys.Json) RETURNS sys.Json;
+
+    PROJECTOR UpdateDashboard ON COMMAND IN (Orders, Pbill) WITH HandleErrors=true;
+    PROJECTOR FillProfile ON COMMAND ARGUMENT untill.bill ENGINE WASM;
+
+    VIEW XZReports(
+        Year int32,
+        Month int32,
+        Day int32,
+        Kind int32,
+        Number int32,
+        XZReportWDocID id NOT NULL,
+        PRIMARY KEY((Year), Month, Day, Kind, Number)
+    ) AS RESULT OF UpdateDashboard;
+
+    GRANT ALL ON ALL TABLES WITH TAG untill.Backoffice TO LocationManager;
+    GRANT INSERT, UPDATE ON ALL TABLES WITH TAG Pos TO LocationUser;
+    GRANT SELECT ON TABLE untill.bill TO LocationUser;
+    GRANT EXECUTE ON COMMAND Orders TO LocationUser;
+    GRANT EXECUTE ON ALL QUERIES WITH TAG Pos TO LocationUser;
+    GRANT SELECT ON VIEW XZReports TO sys.RoleWorkspaceOwner;
+
+    WORKSPACE Child (
+        ROLE ChildAdmin;
+    );
+);
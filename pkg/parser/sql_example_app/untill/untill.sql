@@ -0,0 +1,15 @@
+-- Copyright (c) 2023-present unTill Pro, Ltd.
+
+SCHEMA untill;
+
+TAG Backoffice;
+
+TYPE HasNameAndNumber (
+    name    text NOT NULL,
+    number  int
+);
+
+TABLE bill OF CDOC, HasNameAndNumber (
+    tableno     int NOT NULL,
+    working_day text NOT NULL CHECK('^[0-9]{8}$')
+) WITH Tags=[Backoffice];
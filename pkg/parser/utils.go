@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package parser
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/participle/v2/lexer"
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+// Parses qualified or unqualified name from string, e.g. from NEXTVAL('pkg.sequence')
+func ParseDefQName(pos lexer.Position, s string) (DefQName, error) {
+	parts := strings.Split(s, appdef.QNameQualifierChar)
+	switch len(parts) {
+	case 1:
+		return DefQName{Pos: pos, Name: parts[0]}, nil
+	case 2:
+		return DefQName{Pos: pos, Package: parts[0], Name: parts[1]}, nil
+	}
+	return DefQName{}, errorAt(pos, appdef.ErrInvalidQNameStringRepresentation, "«%s»", s)
+}
+
+// Returns default value as string, float64, int64 or bool
+func (d DefaultExpr) value() interface{} {
+	switch {
+	case d.String != nil:
+		return *d.String
+	case d.Float != nil:
+		return *d.Float
+	case d.Int != nil:
+		return *d.Int
+	case d.Bool != nil:
+		return strings.EqualFold(*d.Bool, "true")
+	}
+	return nil
+}
+
+// Returns field constraints from CHECK expression.
+//
+// Supported expressions are regular expression string and field comparisons with numbers joined by AND,
+// e.g. CHECK(price > 0 AND price <= 1000)
+func (c CheckExpr) constraints(field string) ([]appdef.IConstraint, error) {
+	if c.Regexp != nil {
+		return []appdef.IConstraint{appdef.Pattern(*c.Regexp)}, nil
+	}
+
+	unsupported := func() error {
+		return errorAt(c.Pos, ErrUnsupported, "check expression «%s»", strings.Join(c.Expr, " "))
+	}
+
+	type cmp struct{ less, incl bool }
+	ops := map[string]cmp{"<": {true, false}, "<=": {true, true}, ">": {false, false}, ">=": {false, true}}
+	reversed := map[string]string{"<": ">", "<=": ">=", ">": "<", ">=": "<="}
+
+	cons := make([]appdef.IConstraint, 0)
+	for _, cond := range splitTokens(c.Expr, "AND") {
+		if len(cond) != 3 {
+			return nil, unsupported()
+		}
+		name, op, num := cond[0], cond[1], cond[2]
+		if name != field {
+			name, num, op = num, name, reversed[op]
+		}
+		o, ok := ops[op]
+		if !ok || (name != field) {
+			return nil, unsupported()
+		}
+		v, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return nil, unsupported()
+		}
+		switch o {
+		case cmp{true, true}:
+			cons = append(cons, appdef.MaxIncl(v))
+		case cmp{true, false}:
+			cons = append(cons, appdef.MaxExcl(v))
+		case cmp{false, true}:
+			cons = append(cons, appdef.MinIncl(v))
+		default:
+			cons = append(cons, appdef.MinExcl(v))
+		}
+	}
+	return cons, nil
+}
+
+// Splits tokens by separator keyword (case insensitive)
+func splitTokens(tokens []string, sep string) (parts [][]string) {
+	part := make([]string, 0)
+	for _, t := range tokens {
+		if strings.EqualFold(t, sep) {
+			parts = append(parts, part)
+			part = make([]string, 0)
+			continue
+		}
+		part = append(part, t)
+	}
+	return append(parts, part)
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package commandprocessor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+// resolves the commands of the c.sys.Batch request, checks their rate limits and authorizes their execution
+// the commands without unlogged arguments are the arguments of the batch event
+func (cmdProc *cmdProc) parseBatch(_ context.Context, work interface{}) (err error) {
+	cmd := work.(*cmdWorkpiece)
+	if cmd.cmdFunc.QName() != QNameCommandBatch {
+		return nil
+	}
+	commands, _, err := cmd.requestData.AsObjects("commands")
+	if err != nil {
+		return err
+	}
+	if len(commands) == 0 {
+		return errors.New(`"commands" missing`)
+	}
+	if len(commands) > maxBatchCommands {
+		return fmt.Errorf("batch of %d commands exceeds the limit of %d commands", len(commands), maxBatchCommands)
+	}
+	loggedCommands := make([]map[string]interface{}, 0, len(commands))
+	for commandNumber, commandIntf := range commands {
+		xPath := xPath("commands[" + strconv.Itoa(commandNumber) + "]")
+		commandData, ok := commandIntf.(map[string]interface{})
+		if !ok {
+			return xPath.Errorf("not an object")
+		}
+		bc := &batchCommand{requestData: commandData, xPath: xPath}
+		qNameStr, ok, err := bc.requestData.AsString("command")
+		if err != nil {
+			return xPath.Error(err)
+		}
+		if !ok {
+			return xPath.Errorf(`"command" missing`)
+		}
+		qName, err := appdef.ParseQName(qNameStr)
+		if err != nil {
+			return xPath.Error(err)
+		}
+		if bc.cmdFunc, ok = cmd.appStructs.Resources().QueryResource(qName).(istructs.ICommandFunction); !ok || qName == QNameCommandBatch {
+			return xPath.Errorf("unknown command %s", qName)
+		}
+		if projector, ok := filteredProjector(cmd.appStructs, cmd.cmdMes.PartitionID(), bc.cmdFunc); ok {
+			return xPath.Errorf("%s can not be executed in batch: projector %s is fed by the command events only", qName, projector)
+		}
+		if cmd.appStructs.IsFunctionRateLimitsExceeded(qName, cmd.cmdMes.WSID()) {
+			return coreutils.NewHTTPError(http.StatusTooManyRequests, xPath.Errorf("%s call rate exceeded", qName))
+		}
+		req := iauthnz.AuthzRequest{
+			OperationKind: iauthnz.OperationKind_EXECUTE,
+			Resource:      qName,
+		}
+		ok, err = cmdProc.authorizer.Authorize(cmd.appStructs, cmd.principals, req)
+		if err != nil {
+			return xPath.Error(err)
+		}
+		if !ok {
+			return coreutils.NewHTTPError(http.StatusForbidden, xPath.Errorf("%s execution forbidden", qName))
+		}
+		cmd.batch = append(cmd.batch, bc)
+
+		loggedCommand := map[string]interface{}{"command": qNameStr}
+		for _, field := range []string{"args", "cuds"} {
+			if value, ok := commandData[field]; ok {
+				loggedCommand[field] = value
+			}
+		}
+		loggedCommands = append(loggedCommands, loggedCommand)
+	}
+	loggedCommandsJSON, err := json.Marshal(loggedCommands)
+	if err != nil {
+		// notest
+		return err
+	}
+	cmd.requestData["args"] = map[string]interface{}{
+		Field_BatchParams_Commands: string(loggedCommandsJSON),
+	}
+	return nil
+}
+
+// returns the projector which is fed by the events of the command or by the events with the command arguments only,
+// such projector is not fed by the batch event
+func filteredProjector(appStructs istructs.IAppStructs, partition istructs.PartitionID, cmdFunc istructs.ICommandFunction) (projector appdef.QName, ok bool) {
+	for _, factories := range [][]istructs.ProjectorFactory{appStructs.SyncProjectors(), appStructs.AsyncProjectors()} {
+		for _, factory := range factories {
+			p := factory(partition)
+			for _, qName := range p.EventsFilter {
+				if qName == cmdFunc.QName() {
+					return p.Name, true
+				}
+			}
+			for _, qName := range p.EventsArgsFilter {
+				if qName == cmdFunc.ParamsDef() {
+					return p.Name, true
+				}
+			}
+		}
+	}
+	return appdef.NullQName, false
+}
+
+// builds the arguments of the batch commands and parses their CUDs, CUDs of all commands are written to the batch event
+func parseBatchCommands(_ context.Context, work interface{}) (err error) {
+	cmd := work.(*cmdWorkpiece)
+	for _, bc := range cmd.batch {
+		if err = bc.buildArgs(cmd); err != nil {
+			return err
+		}
+		parsedBefore := len(cmd.parsedCUDs)
+		if err = parseRequestCUDs(cmd, bc.requestData, bc.cmdFunc.QName(), bc.xPath+"."); err != nil {
+			return err
+		}
+		for _, parsedCUD := range cmd.parsedCUDs[parsedBefore:] {
+			if parsedCUD.opKind == iauthnz.OperationKind_INSERT {
+				bc.rawIDs = append(bc.rawIDs, istructs.RecordID(parsedCUD.id))
+			}
+		}
+	}
+	return nil
+}
+
+// arguments are built by the raw event builder of the command, the event itself is never built
+// "args" of the command with istructs.QNameJSON params are passed as the JSON body
+func (bc *batchCommand) buildArgs(cmd *cmdWorkpiece) (err error) {
+	reb := cmd.appStructs.Events().GetNewRawEventBuilder(istructs.NewRawEventBuilderParams{
+		GenericRawEventBuilderParams: istructs.GenericRawEventBuilderParams{
+			HandlingPartition: cmd.cmdMes.PartitionID(),
+			Workspace:         cmd.cmdMes.WSID(),
+			QName:             bc.cmdFunc.QName(),
+		},
+	})
+	appDef := cmd.appStructs.AppDef()
+	if bc.cmdFunc.ParamsDef() == istructs.QNameJSON {
+		body, err := json.Marshal(bc.requestData["args"])
+		if err != nil {
+			// notest
+			return err
+		}
+		bc.requestData["args"] = map[string]interface{}{Field_JSONDef_Body: string(body)}
+	}
+	if bc.cmdFunc.ParamsDef() != appdef.NullQName {
+		aob := reb.ArgumentObjectBuilder()
+		if err = fillArgsObject(bc.requestData, "args", appDef.Def(bc.cmdFunc.ParamsDef()), aob); err != nil {
+			return bc.xPath.Error(err)
+		}
+		if bc.argsObject, err = aob.Build(); err != nil {
+			return bc.xPath.Errorf("argument object build failed: %w", err)
+		}
+		if err = istructsmem.CheckRefIntegrity(bc.argsObject, cmd.appStructs, cmd.cmdMes.WSID()); err != nil {
+			return bc.xPath.SysError(err)
+		}
+	}
+	if bc.cmdFunc.UnloggedParamsDef() != appdef.NullQName {
+		auob := reb.ArgumentUnloggedObjectBuilder()
+		if err = fillArgsObject(bc.requestData, "unloggedArgs", appDef.Def(bc.cmdFunc.UnloggedParamsDef()), auob); err != nil {
+			return bc.xPath.Error(err)
+		}
+		if bc.unloggedArgsObject, err = auob.Build(); err != nil {
+			return bc.xPath.Errorf("unlogged argument object build failed: %w", err)
+		}
+		if err = istructsmem.CheckRefIntegrity(bc.unloggedArgsObject, cmd.appStructs, cmd.cmdMes.WSID()); err != nil {
+			return bc.xPath.SysError(err)
+		}
+	}
+	return nil
+}
+
+// executes the batch commands one by one, the first failed command fails the batch
+func execBatch(cmd *cmdWorkpiece) error {
+	for _, bc := range cmd.batch {
+		eca := cmd.eca
+		eca.ArgumentObject = bc.argsObject
+		eca.ArgumentUnloggedObject = bc.unloggedArgsObject
+		cmd.cud.command = bc.cmdFunc.QName()
+		if err := bc.cmdFunc.Exec(eca); err != nil {
+			return bc.xPath.SysError(err)
+		}
+	}
+	return nil
+}
+
+// result of the batch command is the command QName and storage IDs of the records created by the command CUDs
+func (bc *batchCommand) result(generatedIDs map[istructs.RecordID]istructs.RecordID) string {
+	newIDs := make([]string, 0, len(bc.rawIDs))
+	for _, rawID := range bc.rawIDs {
+		if generatedID, ok := generatedIDs[rawID]; ok {
+			newIDs = append(newIDs, fmt.Sprintf(`"%d":%d`, rawID, generatedID))
+		}
+	}
+	return fmt.Sprintf(`{"Command":"%s","NewIDs":{%s}}`, bc.cmdFunc.QName(), strings.Join(newIDs, ","))
+}
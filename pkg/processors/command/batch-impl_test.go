@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package commandprocessor
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/state"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+func TestBatch(t *testing.T) {
+	require := require.New(t)
+
+	testCmd := appdef.NewQName("test", "Cmd")
+	testCmdParams := appdef.NewQName("test", "CmdParams")
+	testCmdUnloggedParams := appdef.NewQName("test", "CmdUnloggedParams")
+	testFailCmd := appdef.NewQName("test", "FailCmd")
+	testCreateCmd := appdef.NewQName("test", "CreateCmd")
+	testProjectedCmd := appdef.NewQName("test", "ProjectedCmd")
+	testProjectedArgsCmd := appdef.NewQName("test", "ProjectedArgsCmd")
+	testProjectedParams := appdef.NewQName("test", "ProjectedParams")
+	executed := []string{}
+	validated := []string{}
+
+	var adb appdef.IAppDefBuilder
+	app := setUp(t, func(appDef appdef.IAppDefBuilder) {
+		adb = appDef
+		_ = appDef.AddStruct(testCRecord, appdef.DefKind_CRecord)
+		_ = appDef.AddStruct(testCDoc, appdef.DefKind_CDoc).AddContainer("TestCRecord", testCRecord, 0, 1)
+		_ = appDef.AddStruct(testWDoc, appdef.DefKind_WDoc)
+		appDef.AddStruct(testCmdParams, appdef.DefKind_Object).AddField("Name", appdef.DataKind_string, true)
+		appDef.AddStruct(testCmdUnloggedParams, appdef.DefKind_Object).AddField("Secret", appdef.DataKind_string, true)
+		appDef.AddStruct(testProjectedParams, appdef.DefKind_Object).AddField("Name", appdef.DataKind_string, false)
+	}, func(cfg *istructsmem.AppConfigType) {
+		ProvideBatchCommand(cfg, adb)
+		cfg.Resources.Add(istructsmem.NewCommandFunction(istructs.QNameCommandCUD, appdef.NullQName, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec))
+		cfg.Resources.Add(istructsmem.NewCommandFunction(testCmd, testCmdParams, testCmdUnloggedParams, appdef.NullQName,
+			func(_ istructs.ICommandFunction, args istructs.ExecCommandArgs) error {
+				executed = append(executed, args.ArgumentObject.AsString("Name")+":"+args.ArgumentUnloggedObject.AsString("Secret"))
+				return nil
+			}))
+		cfg.Resources.Add(istructsmem.NewCommandFunction(testFailCmd, appdef.NullQName, appdef.NullQName, appdef.NullQName,
+			func(_ istructs.ICommandFunction, _ istructs.ExecCommandArgs) error {
+				return coreutils.NewHTTPErrorf(http.StatusUnprocessableEntity, "failed")
+			}))
+		cfg.Resources.Add(istructsmem.NewCommandFunction(testCreateCmd, appdef.NullQName, appdef.NullQName, appdef.NullQName,
+			func(_ istructs.ICommandFunction, args istructs.ExecCommandArgs) error {
+				kb, err := args.State.KeyBuilder(state.RecordsStorage, testWDoc)
+				if err != nil {
+					return err
+				}
+				vb, err := args.Intents.NewValue(kb)
+				if err != nil {
+					return err
+				}
+				vb.PutRecordID(appdef.SystemField_ID, 10)
+				return nil
+			}))
+		cfg.Resources.Add(istructsmem.NewCommandFunction(testProjectedCmd, appdef.NullQName, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec))
+		cfg.Resources.Add(istructsmem.NewCommandFunction(testProjectedArgsCmd, testProjectedParams, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec))
+		cfg.AddSyncProjectors(func(istructs.PartitionID) istructs.Projector {
+			return istructs.Projector{Name: appdef.NewQName("test", "SyncProjector"), EventsFilter: []appdef.QName{testProjectedCmd}}
+		})
+		cfg.AddAsyncProjectors(func(istructs.PartitionID) istructs.Projector {
+			return istructs.Projector{Name: appdef.NewQName("test", "AsyncProjector"), EventsArgsFilter: []appdef.QName{testProjectedParams}}
+		})
+		cfg.AddCUDValidators(istructs.CUDValidator{
+			MatchFunc: func(appdef.QName) bool { return true },
+			Validate: func(_ context.Context, _ istructs.IAppStructs, cudRow istructs.ICUDRow, _ istructs.WSID, cmdQName appdef.QName) error {
+				validated = append(validated, cudRow.QName().String()+":"+cmdQName.String())
+				return nil
+			},
+		})
+	})
+	defer tearDown(app)
+
+	send := func(body string) (statusCode int, respData map[string]interface{}) {
+		return sendRequest(t, app, 1, "c.sys.Batch", body, nil)
+	}
+
+	t.Run("Should execute commands in one event", func(t *testing.T) {
+		statusCode, respData := send(`{"commands":[
+			{"command":"sys.CUD","cuds":[{"fields":{"sys.ID":1,"sys.QName":"test.TestCDoc"}}]},
+			{"command":"test.Cmd","args":{"Name":"first"},"unloggedArgs":{"Secret":"secret"},"cuds":[
+				{"fields":{"sys.ID":2,"sys.QName":"test.TestWDoc"}},
+				{"fields":{"sys.ID":3,"sys.QName":"test.TestCRecord","sys.ParentID":1,"sys.Container":"TestCRecord"}}
+			]}
+		]}`)
+		require.Equal(http.StatusOK, statusCode, respData)
+		require.Equal(1, int(respData["CurrentWLogOffset"].(float64)))
+		require.Len(respData["NewIDs"], 3)
+		require.Equal([]string{"first:secret"}, executed)
+
+		results := respData["Results"].([]interface{})
+		require.Len(results, 2)
+		require.Equal("sys.CUD", results[0].(map[string]interface{})["Command"])
+		require.Equal(map[string]interface{}{"1": respData["NewIDs"].(map[string]interface{})["1"]}, results[0].(map[string]interface{})["NewIDs"])
+		require.Equal("test.Cmd", results[1].(map[string]interface{})["Command"])
+		require.Len(results[1].(map[string]interface{})["NewIDs"], 2)
+
+		as, err := app.asp.AppStructs(istructs.AppQName_untill_airs_bp)
+		require.NoError(err)
+		events := 0
+		require.NoError(as.Events().ReadPLog(context.Background(), 1, istructs.FirstOffset, istructs.ReadToTheEnd,
+			func(_ istructs.Offset, event istructs.IPLogEvent) error {
+				events++
+				require.Equal(QNameCommandBatch, event.QName())
+				commands := event.ArgumentObject().AsString(Field_BatchParams_Commands)
+				require.Contains(commands, `"first"`)
+				require.NotContains(commands, "secret", "unlogged arguments must not be logged")
+				cuds := 0
+				require.NoError(event.CUDs(func(rec istructs.ICUDRow) error {
+					cuds++
+					return nil
+				}))
+				require.Equal(3, cuds)
+				return nil
+			}))
+		require.Equal(1, events)
+	})
+
+	t.Run("Should fail all commands if one fails", func(t *testing.T) {
+		executed = executed[:0]
+		statusCode, respData := send(`{"commands":[
+			{"command":"test.Cmd","args":{"Name":"second"},"unloggedArgs":{"Secret":"secret"}},
+			{"command":"test.FailCmd","cuds":[{"fields":{"sys.ID":1,"sys.QName":"test.TestCDoc"}}]}
+		]}`)
+		require.Equal(http.StatusUnprocessableEntity, statusCode)
+		require.Equal("commands[1]: failed", respData["sys.Error"].(map[string]interface{})["Message"])
+		require.Equal([]string{"second:secret"}, executed)
+
+		statusCode, respData = send(`{"commands":[{"command":"sys.CUD","cuds":[{"fields":{"sys.ID":1,"sys.QName":"test.TestCDoc"}}]}]}`)
+		require.Equal(http.StatusOK, statusCode)
+		require.Equal(2, int(respData["CurrentWLogOffset"].(float64)), "failed batch must not be written")
+	})
+
+	t.Run("Should validate CUDs by the commands the CUDs are made by", func(t *testing.T) {
+		validated = validated[:0]
+		statusCode, respData := send(`{"commands":[
+			{"command":"test.CreateCmd"},
+			{"command":"sys.CUD","cuds":[{"fields":{"sys.ID":1,"sys.QName":"test.TestCDoc"}}]},
+			{"command":"test.Cmd","args":{"Name":"third"},"unloggedArgs":{"Secret":"secret"},"cuds":[{"fields":{"sys.ID":2,"sys.QName":"test.TestWDoc"}}]}
+		]}`)
+		require.Equal(http.StatusOK, statusCode, respData)
+		require.ElementsMatch([]string{"test.TestCDoc:sys.CUD", "test.TestWDoc:test.Cmd", "test.TestWDoc:test.CreateCmd"}, validated)
+	})
+
+	t.Run("400 on wrong batch", func(t *testing.T) {
+		cases := map[string]string{
+			`{}`:                                     `"commands" missing`,
+			`{"commands":[1]}`:                       "commands[0]: not an object",
+			`{"commands":[{}]}`:                      `commands[0]: "command" missing`,
+			`{"commands":[{"command":"x.Y"}]}`:       "commands[0]: unknown command x.Y",
+			`{"commands":[{"command":"sys.Batch"}]}`: "commands[0]: unknown command sys.Batch",
+			`{"commands":[{"command":"test.Cmd","args":{}}]}`:             "commands[0]: argument object build failed",
+			`{"commands":[{"command":"sys.CUD","cuds":[{"fields":{}}]}]}`: "commands[0].cuds[0]",
+			`{"commands":[{"command":"test.ProjectedCmd"}]}`:              "commands[0]: test.ProjectedCmd can not be executed in batch: projector test.SyncProjector",
+			`{"commands":[{"command":"test.ProjectedArgsCmd"}]}`:          "commands[0]: test.ProjectedArgsCmd can not be executed in batch: projector test.AsyncProjector",
+		}
+		for body, expectedMessage := range cases {
+			statusCode, respData := send(body)
+			require.Equal(http.StatusBadRequest, statusCode, body)
+			require.Contains(respData["sys.Error"].(map[string]interface{})["Message"], expectedMessage, body)
+		}
+	})
+}
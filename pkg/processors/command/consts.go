@@ -5,6 +5,7 @@
 package commandprocessor
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/voedger/voedger/pkg/appdef"
@@ -16,10 +17,62 @@ const (
 	intentsLimit       = 128
 )
 
+// command processor checkpoints view fields
+const (
+	checkpointPartitionFld             = "Partition"
+	checkpointWSIDFld                  = "Workspace" // NullWSID for partition row
+	checkpointNextPLogOffsetFld        = "NextPLogOffset"
+	checkpointNextWLogOffsetFld        = "NextWLogOffset"
+	checkpointNextBaseIDFld            = "NextBaseID"
+	checkpointNextCDocCRecordBaseIDFld = "NextCDocCRecordBaseID"
+)
+
+// c.sys.Batch
+const (
+	Field_BatchParams_Commands = "Commands"
+	maxBatchCommands           = 100
+)
+
+// command processor record versions view fields
+const (
+	recordVersionQNameFld      = "QName"
+	recordVersionIDFld         = "ID"
+	recordVersionWLogOffsetFld = "WLogOffset"
+)
+
+// the field of the update CUD with the expected version of the record, i.e. WLog offset of the last record change
+const Field_CUD_Version = "sys.Version"
+
+// command processor idempotency keys view fields
+const (
+	idempotencyWSIDFld         = "Workspace"
+	idempotencyKeyFld          = "Key"
+	idempotencyCommandFld      = "Command"
+	idempotencyRegisteredAtFld = "RegisteredAt"
+	idempotencyResponseFld     = "Response"
+	idempotencyPLogOffsetFld   = "PLogOffset"
+	idempotencyBodyHashFld     = "BodyHash"
+)
+
+const (
+	// HTTP header the client puts the idempotency key of the command request to
+	IdempotencyKeyHeader    = "Idempotency-Key"
+	maxIdempotencyKeyLength = 128
+)
+
+// checkpoint is written each checkpointInterval successfully processed commands
+// var to make it possible to decrease it in tests
+var checkpointInterval = 1000
+
 var (
 	ViewQNamePLogKnownOffsets = appdef.NewQName(appdef.SysPackage, "PLogKnownOffsets")
 	ViewQNameWLogKnownOffsets = appdef.NewQName(appdef.SysPackage, "WLogKnownOffsets")
+	ViewQNameCheckpoints      = appdef.NewQName(appdef.SysPackage, "CommandProcessorCheckpoints")
+	ViewQNameIdempotencyKeys  = appdef.NewQName(appdef.SysPackage, "CommandIdempotencyKeys")
+	ViewQNameRecordVersions   = appdef.NewQName(appdef.SysPackage, "RecordVersions")
 	errWSNotInited            = coreutils.NewHTTPErrorf(http.StatusForbidden, "workspace is not initialized")
+	// returned by the pipeline operator if the response for the idempotency key is replayed, the rest of the pipeline is skipped
+	errCommandReplayed = errors.New("command response is replayed by idempotency key")
 )
 
 // TODO: should be in a separate package
@@ -37,4 +90,6 @@ var (
 	QNameWDocBLOB                 = appdef.NewQName(appdef.SysPackage, "BLOB")
 	QNameCommandInit              = appdef.NewQName(appdef.SysPackage, "Init")
 	QNameCommandImport            = appdef.NewQName(appdef.SysPackage, "Import")
+	QNameCommandBatch             = appdef.NewQName(appdef.SysPackage, "Batch")
+	QNameBatchParams              = appdef.NewQName(appdef.SysPackage, "BatchParams")
 )
@@ -7,6 +7,7 @@ package commandprocessor
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,6 +19,7 @@ import (
 	"github.com/untillpro/goutils/logger"
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/idgen"
 	"github.com/voedger/voedger/pkg/in10n"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
@@ -37,19 +39,21 @@ func (cm *implICommandMessage) RequestCtx() context.Context       { return cm.re
 func (cm *implICommandMessage) Resource() istructs.IResource      { return cm.resource }
 func (cm *implICommandMessage) Token() string                     { return cm.token }
 func (cm *implICommandMessage) Host() string                      { return cm.host }
+func (cm *implICommandMessage) IdempotencyKey() string            { return cm.idempotencyKey }
 
 func NewCommandMessage(requestCtx context.Context, body []byte, appQName istructs.AppQName, wsid istructs.WSID, sender interface{},
-	partitionID istructs.PartitionID, resource istructs.IResource, token string, host string) ICommandMessage {
+	partitionID istructs.PartitionID, resource istructs.IResource, token string, host string, idempotencyKey string) ICommandMessage {
 	return &implICommandMessage{
-		body:        body,
-		appQName:    appQName,
-		wsid:        wsid,
-		sender:      sender,
-		partitionID: partitionID,
-		requestCtx:  requestCtx,
-		resource:    resource,
-		token:       token,
-		host:        host,
+		body:           body,
+		appQName:       appQName,
+		wsid:           wsid,
+		sender:         sender,
+		partitionID:    partitionID,
+		requestCtx:     requestCtx,
+		resource:       resource,
+		token:          token,
+		host:           host,
+		idempotencyKey: idempotencyKey,
 	}
 }
 
@@ -69,8 +73,12 @@ func (c *cmdWorkpiece) Context() context.Context {
 }
 
 // need for collection.ProvideSyncActualizer()
+// the event knows the principal login, need for the record history projector
 func (c *cmdWorkpiece) Event() istructs.IPLogEvent {
-	return c.pLogEvent
+	if c.pLogEvent == nil {
+		return nil
+	}
+	return cmdEvent{IPLogEvent: c.pLogEvent, login: c.principalPayload.Login}
 }
 
 // used by ProvideSyncActualizerFactory
@@ -83,15 +91,22 @@ func (c *cmdWorkpiece) GetPrincipalPayload() payloads.PrincipalPayload {
 	return c.principalPayload
 }
 
-func (ws *workspace) nextRecordID(def appdef.IDef) (res istructs.RecordID) {
+// updates workspace counters by the ID of the new record, counters are written to checkpoints and sync the ID generator on recovery
+func (ws *workspace) syncRecordID(def appdef.IDef, id istructs.RecordID) {
 	if def.Kind() == appdef.DefKind_CDoc || def.Kind() == appdef.DefKind_CRecord {
-		res = istructs.NewCDocCRecordID(ws.NextCDocCRecordBaseID)
-		ws.NextCDocCRecordBaseID++
+		ws.NextCDocCRecordBaseID = id.BaseRecordID() + 1
 	} else {
-		res = istructs.NewRecordID(ws.NextBaseID)
-		ws.NextBaseID++
+		ws.NextBaseID = id.BaseRecordID() + 1
+	}
+}
+
+func newAppPartition(appStructs istructs.IAppStructs) *appPartition {
+	return &appPartition{
+		idGen:             idgen.Provide(appStructs, idgen.DefaultBlockSize),
+		workspaces:        map[istructs.WSID]*workspace{},
+		nextPLogOffset:    istructs.FirstOffset,
+		changedWorkspaces: map[istructs.WSID]*workspace{},
 	}
-	return
 }
 
 func (ap *appPartition) getWorkspace(wsid istructs.WSID) *workspace {
@@ -107,6 +122,60 @@ func (ap *appPartition) getWorkspace(wsid istructs.WSID) *workspace {
 	return ws
 }
 
+func checkpointsEnabled(appDef appdef.IAppDef) bool {
+	return appDef.DefByName(ViewQNameCheckpoints) != nil
+}
+
+// reads the last checkpoint, returns PLog offset to continue recovery from
+func (ap *appPartition) readCheckpoint(ctx context.Context, appStructs istructs.IAppStructs, partition istructs.PartitionID) (istructs.Offset, error) {
+	kb := appStructs.ViewRecords().KeyBuilder(ViewQNameCheckpoints)
+	kb.PutInt32(checkpointPartitionFld, int32(partition))
+	err := appStructs.ViewRecords().Read(ctx, istructs.NullWSID, kb, func(key istructs.IKey, value istructs.IValue) (err error) {
+		wsid := istructs.WSID(key.AsInt64(checkpointWSIDFld))
+		if wsid == istructs.NullWSID {
+			ap.nextPLogOffset = istructs.Offset(value.AsInt64(checkpointNextPLogOffsetFld))
+			return nil
+		}
+		ws := ap.getWorkspace(wsid)
+		ws.NextWLogOffset = istructs.Offset(value.AsInt64(checkpointNextWLogOffsetFld))
+		ws.NextBaseID = istructs.RecordID(value.AsInt64(checkpointNextBaseIDFld))
+		ws.NextCDocCRecordBaseID = istructs.RecordID(value.AsInt64(checkpointNextCDocCRecordBaseIDFld))
+		return nil
+	})
+	return ap.nextPLogOffset, err
+}
+
+// writes partition counters and counters of workspaces changed since last checkpoint in one batch
+func (ap *appPartition) writeCheckpoint(appStructs istructs.IAppStructs, partition istructs.PartitionID) error {
+	vr := appStructs.ViewRecords()
+	batch := make([]istructs.ViewKV, 0, len(ap.changedWorkspaces)+1)
+	put := func(wsid istructs.WSID, fill func(value istructs.IValueBuilder)) {
+		kb := vr.KeyBuilder(ViewQNameCheckpoints)
+		kb.PutInt32(checkpointPartitionFld, int32(partition))
+		kb.PutInt64(checkpointWSIDFld, int64(wsid))
+		vb := vr.NewValueBuilder(ViewQNameCheckpoints)
+		fill(vb)
+		batch = append(batch, istructs.ViewKV{Key: kb, Value: vb})
+	}
+	for wsid, ws := range ap.changedWorkspaces {
+		ws := ws
+		put(wsid, func(vb istructs.IValueBuilder) {
+			vb.PutInt64(checkpointNextWLogOffsetFld, int64(ws.NextWLogOffset))
+			vb.PutInt64(checkpointNextBaseIDFld, int64(ws.NextBaseID))
+			vb.PutInt64(checkpointNextCDocCRecordBaseIDFld, int64(ws.NextCDocCRecordBaseID))
+		})
+	}
+	put(istructs.NullWSID, func(vb istructs.IValueBuilder) {
+		vb.PutInt64(checkpointNextPLogOffsetFld, int64(ap.nextPLogOffset))
+	})
+	if err := vr.PutBatch(istructs.NullWSID, batch); err != nil {
+		return err
+	}
+	ap.changedWorkspaces = map[istructs.WSID]*workspace{}
+	ap.sinceCheckpoint = 0
+	return nil
+}
+
 func (cmdProc *cmdProc) getAppPartition(ctx context.Context, work interface{}) (err error) {
 	cmd := work.(*cmdWorkpiece)
 	cmd.cmdMes.AppQName()
@@ -123,7 +192,7 @@ func (cmdProc *cmdProc) getAppPartition(ctx context.Context, work interface{}) (
 
 func (cmdProc *cmdProc) buildCommandArgs(_ context.Context, work interface{}) (err error) {
 	cmd := work.(*cmdWorkpiece)
-	hs := cmd.hostStateProvider.get(cmd.appStructs, cmd.cmdMes.WSID(), cmd.reb.CUDBuilder(), cmd.principals, cmd.cmdMes.Token())
+	hs := cmd.hostStateProvider.get(cmd.appStructs, cmd.cmdMes.WSID(), cmd.cud, cmd.principals, cmd.cmdMes.Token())
 	hs.ClearIntents()
 	cmd.eca = istructs.ExecCommandArgs{
 		CommandPrepareArgs: istructs.CommandPrepareArgs{
@@ -141,20 +210,23 @@ func (cmdProc *cmdProc) buildCommandArgs(_ context.Context, work interface{}) (e
 }
 
 func (cmdProc *cmdProc) recovery(ctx context.Context, cmd *cmdWorkpiece) (*appPartition, error) {
-	ap := &appPartition{
-		workspaces:     map[istructs.WSID]*workspace{},
-		nextPLogOffset: istructs.FirstOffset,
+	start := time.Now()
+	ap := newAppPartition(cmd.appStructs)
+	readFrom := istructs.FirstOffset
+	if checkpointsEnabled(cmd.AppDef()) {
+		var err error
+		if readFrom, err = ap.readCheckpoint(ctx, cmd.appStructs, cmdProc.pNumber); err != nil {
+			return nil, err
+		}
 	}
+	replayed := 0
 	cb := func(plogOffset istructs.Offset, event istructs.IPLogEvent) (err error) {
+		replayed++
 		ws := ap.getWorkspace(event.Workspace())
+		ap.changedWorkspaces[event.Workspace()] = ws
 		_ = event.CUDs(func(rec istructs.ICUDRow) error { // no errors to return
 			if rec.IsNew() {
-				def := cmd.AppDef().Def(rec.QName())
-				if def.Kind() == appdef.DefKind_CDoc || def.Kind() == appdef.DefKind_CRecord {
-					ws.NextCDocCRecordBaseID = rec.ID().BaseRecordID() + 1
-				} else {
-					ws.NextBaseID = rec.ID().BaseRecordID() + 1
-				}
+				ws.syncRecordID(cmd.AppDef().Def(rec.QName()), rec.ID())
 			}
 			return nil
 		})
@@ -163,28 +235,183 @@ func (cmdProc *cmdProc) recovery(ctx context.Context, cmd *cmdWorkpiece) (*appPa
 		return nil
 	}
 
-	if err := cmd.appStructs.Events().ReadPLog(ctx, cmdProc.pNumber, istructs.FirstOffset, istructs.ReadToTheEnd, cb); err != nil {
+	if err := cmd.appStructs.Events().ReadPLog(ctx, cmdProc.pNumber, readFrom, istructs.ReadToTheEnd, cb); err != nil {
 		return nil, err
 	}
+	for wsid, ws := range ap.workspaces {
+		if ws.NextBaseID > istructs.FirstBaseRecordID {
+			ap.idGen.UpdateOnSync(wsid, istructs.NewRecordID(ws.NextBaseID-1))
+		}
+		if ws.NextCDocCRecordBaseID > istructs.FirstBaseRecordID {
+			ap.idGen.UpdateOnSync(wsid, istructs.NewCDocCRecordID(ws.NextCDocCRecordBaseID-1))
+		}
+	}
+	cmd.metrics.increase(RecoverySeconds, time.Since(start).Seconds())
+	cmd.metrics.increase(RecoveryEvents, float64(replayed))
 	worskapcesJSON, err := json.Marshal(ap.workspaces)
 	if err != nil {
 		// error impossible
 		// notest
 		return nil, err
 	}
-	logger.Info(fmt.Sprintf(`app "%s" partition %d recovered: nextPLogOffset %d, workspaces: %s`, cmd.cmdMes.AppQName(), cmdProc.pNumber, ap.nextPLogOffset, string(worskapcesJSON)))
+	logger.Info(fmt.Sprintf(`app "%s" partition %d recovered from PLog offset %d, %d events replayed: nextPLogOffset %d, workspaces: %s`,
+		cmd.cmdMes.AppQName(), cmdProc.pNumber, readFrom, replayed, ap.nextPLogOffset, string(worskapcesJSON)))
 	return ap, nil
 }
 
+// writes checkpoint each checkpointInterval commands
+// checkpoint is the recovery shortcut only, the command event is already stored in PLog and WLog: if the checkpoint batch
+// is not written then the changed workspaces are kept and the checkpoint is written by the next command,
+// recovery before that replays more PLog events from the previous checkpoint
+func (cmdProc *cmdProc) checkpoint(_ context.Context, work interface{}) (err error) {
+	cmd := work.(*cmdWorkpiece)
+	ap := cmdProc.appPartition
+	ap.sinceCheckpoint++
+	if ap.sinceCheckpoint < checkpointInterval || !checkpointsEnabled(cmd.AppDef()) {
+		return nil
+	}
+	if err := ap.writeCheckpoint(cmd.appStructs, cmdProc.pNumber); err != nil {
+		logger.Error(fmt.Sprintf(`app "%s" partition %d: failed to write checkpoint: %s`, cmd.cmdMes.AppQName(), cmdProc.pNumber, err))
+		return nil
+	}
+	cmd.metrics.increase(CheckpointsTotal, 1.0)
+	return nil
+}
+
+func (cmdProc *cmdProc) idempotencyEnabled(cmd *cmdWorkpiece) bool {
+	return len(cmd.cmdMes.IdempotencyKey()) > 0 && cmdProc.idempotencyWindow > 0 && cmd.AppDef().DefByName(ViewQNameIdempotencyKeys) != nil
+}
+
+func idempotencyKeyBuilder(cmd *cmdWorkpiece) istructs.IKeyBuilder {
+	kb := cmd.appStructs.ViewRecords().KeyBuilder(ViewQNameIdempotencyKeys)
+	kb.PutInt64(idempotencyWSIDFld, int64(cmd.cmdMes.WSID()))
+	kb.PutString(idempotencyKeyFld, cmd.cmdMes.IdempotencyKey())
+	return kb
+}
+
+// the response stored for the idempotency key is replayed if it is stored not earlier than idempotency window ago
+// the key used by another command or with another request body is the conflict
+func (cmdProc *cmdProc) replayByIdempotencyKey(_ context.Context, work interface{}) (err error) {
+	cmd := work.(*cmdWorkpiece)
+	if !cmdProc.idempotencyEnabled(cmd) {
+		return nil
+	}
+	if len(cmd.cmdMes.IdempotencyKey()) > maxIdempotencyKeyLength {
+		return coreutils.NewHTTPErrorf(http.StatusBadRequest, IdempotencyKeyHeader, " is longer than ", maxIdempotencyKeyLength)
+	}
+	value, err := cmd.appStructs.ViewRecords().Get(cmd.cmdMes.WSID(), idempotencyKeyBuilder(cmd))
+	if err != nil {
+		if errors.Is(err, istructsmem.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	registeredAt := time.UnixMilli(value.AsInt64(idempotencyRegisteredAtFld))
+	if cmdProc.now().Sub(registeredAt) > cmdProc.idempotencyWindow {
+		return nil
+	}
+	if command := value.AsQName(idempotencyCommandFld); command != cmd.cmdFunc.QName() {
+		return coreutils.NewHTTPErrorf(http.StatusConflict, IdempotencyKeyHeader, " is already used by ", command)
+	}
+	if !bytes.Equal(value.AsBytes(idempotencyBodyHashFld), requestBodyHash(cmd)) {
+		return coreutils.NewHTTPErrorf(http.StatusConflict, IdempotencyKeyHeader, " is already used with another request body")
+	}
+	cmd.replayedResponse = value.AsString(idempotencyResponseFld)
+	return errCommandReplayed
+}
+
+// the key is written in the same storage batch as the PLog event, so the command is never applied without its key.
+// The key expires after the idempotency window, so keys are not kept forever
+func (cmdProc *cmdProc) buildIdempotencyKey(_ context.Context, work interface{}) (err error) {
+	cmd := work.(*cmdWorkpiece)
+	if !cmdProc.idempotencyEnabled(cmd) {
+		return nil
+	}
+	vb := cmd.appStructs.ViewRecords().NewValueBuilder(ViewQNameIdempotencyKeys)
+	vb.PutQName(idempotencyCommandFld, cmd.cmdFunc.QName())
+	vb.PutInt64(idempotencyRegisteredAtFld, cmdProc.now().UnixMilli())
+	vb.PutString(idempotencyResponseFld, cmd.responseBody())
+	vb.PutInt64(idempotencyPLogOffsetFld, int64(cmd.rawEvent.PLogOffset()))
+	vb.PutBytes(idempotencyBodyHashFld, requestBodyHash(cmd))
+	cmd.eventViews = append(cmd.eventViews, istructs.ViewKV{Key: idempotencyKeyBuilder(cmd), Value: vb, TTL: cmdProc.idempotencyWindow})
+	return nil
+}
+
+func requestBodyHash(cmd *cmdWorkpiece) []byte {
+	hash := sha256.Sum256(cmd.cmdMes.Body())
+	return hash[:]
+}
+
+// storage IDs are reserved for all raw IDs of the event before the event is written,
+// so the command fails and nothing is written to PLog if some ID could not be generated
+func (cmdProc *cmdProc) reserveIDs(_ context.Context, work interface{}) (err error) {
+	cmd := work.(*cmdWorkpiece)
+	reserve := func(rawID istructs.RecordID, def appdef.IDef) error {
+		if !rawID.IsRaw() || def.Singleton() {
+			return nil
+		}
+		if _, ok := cmd.generatedIDs[rawID]; ok {
+			return nil
+		}
+		storageID, err := cmdProc.appPartition.idGen.NextID(cmd.cmdMes.WSID(), def)
+		if err != nil {
+			return err
+		}
+		cmd.workspace.syncRecordID(def, storageID)
+		cmd.generatedIDs[rawID] = storageID
+		return nil
+	}
+	var reserveElementIDs func(el istructs.IElement) error
+	reserveElementIDs = func(el istructs.IElement) (err error) {
+		def := cmd.AppDef().Def(el.QName())
+		if def.Kind().HasSystemField(appdef.SystemField_ID) {
+			if err = reserve(el.AsRecordID(appdef.SystemField_ID), def); err != nil {
+				return err
+			}
+		}
+		el.Containers(func(container string) {
+			el.Elements(container, func(el istructs.IElement) {
+				if err == nil {
+					err = reserveElementIDs(el)
+				}
+			})
+		})
+		return err
+	}
+	if args := cmd.rawEvent.ArgumentObject(); args.QName() != appdef.NullQName && isDocument(cmd.AppDef().Def(args.QName())) {
+		if err = reserveElementIDs(args); err != nil {
+			return err
+		}
+	}
+	return cmd.rawEvent.CUDs(func(rec istructs.ICUDRow) error {
+		if !rec.IsNew() {
+			return nil
+		}
+		return reserve(rec.ID(), cmd.AppDef().Def(rec.QName()))
+	})
+}
+
+// IDs of the argument object elements are generated only if the argument is the document
+func isDocument(def appdef.IDef) bool {
+	switch def.Kind() {
+	case appdef.DefKind_GDoc, appdef.DefKind_CDoc, appdef.DefKind_ODoc, appdef.DefKind_WDoc:
+		return true
+	}
+	return false
+}
+
 func (cmdProc *cmdProc) putPLog(_ context.Context, work interface{}) (err error) {
 	cmd := work.(*cmdWorkpiece)
 	cmd.pLogEvent, err = cmd.appStructs.Events().PutPlog(cmd.rawEvent, nil,
-		// FIXME: implement the right id generator
-		func(tempId istructs.RecordID, def appdef.IDef) (storageID istructs.RecordID, err error) {
-			storageID = cmd.workspace.nextRecordID(def)
-			cmd.generatedIDs[tempId] = storageID
-			return
+		func(tempId istructs.RecordID, _ appdef.IDef) (storageID istructs.RecordID, err error) {
+			storageID, ok := cmd.generatedIDs[tempId]
+			if !ok {
+				// all raw IDs are reserved by reserveIDs
+				return istructs.NullRecordID, fmt.Errorf("storage ID is not reserved for raw ID %d", tempId)
+			}
+			return storageID, nil
 		},
+		cmd.eventViews...,
 	)
 	cmdProc.appPartition.nextPLogOffset++
 	return
@@ -289,6 +516,8 @@ func unmarshalRequestBody(_ context.Context, work interface{}) (err error) {
 func (cmdProc *cmdProc) getWorkspace(_ context.Context, work interface{}) (err error) {
 	cmd := work.(*cmdWorkpiece)
 	cmd.workspace = cmdProc.appPartition.getWorkspace(cmd.cmdMes.WSID())
+	// counters could be changed even if command fails after PLog is written
+	cmdProc.appPartition.changedWorkspaces[cmd.cmdMes.WSID()] = cmd.workspace
 	return nil
 }
 
@@ -319,24 +548,57 @@ func (cmdProc *cmdProc) getRawEventBuilder(_ context.Context, work interface{})
 			},
 		)
 	}
+	cmd.cud = newCommandCUD(cmd.reb.CUDBuilder(), cmd.cmdFunc.QName())
 	return nil
 }
 
+func newCommandCUD(cud istructs.ICUD, command appdef.QName) *commandCUD {
+	return &commandCUD{
+		ICUD:    cud,
+		command: command,
+		updates: map[istructs.RecordID]appdef.QName{},
+	}
+}
+
+// istructs.ICUD.Create
+func (c *commandCUD) Create(qName appdef.QName) istructs.IRowWriter {
+	c.creates = append(c.creates, c.command)
+	row := &createdRow{IRowWriter: c.ICUD.Create(qName), qName: qName, fields: map[string]bool{}}
+	c.created = append(c.created, row)
+	return row
+}
+
+// istructs.ICUD.Update
+func (c *commandCUD) Update(record istructs.IRecord) istructs.IRowWriter {
+	if _, ok := c.updates[record.ID()]; !ok {
+		c.updates[record.ID()] = c.command
+	}
+	return c.ICUD.Update(record)
+}
+
+// returns commands of the event CUDs in order the event enumerates the CUDs: created records in order of creation, then updated records
+func (c *commandCUD) commands(event istructs.IRawEvent) (commands []appdef.QName) {
+	created := 0
+	_ = event.CUDs(func(rec istructs.ICUDRow) error { // no errors to return
+		if rec.IsNew() {
+			commands = append(commands, c.creates[created])
+			created++
+		} else {
+			commands = append(commands, c.updates[rec.ID()])
+		}
+		return nil
+	})
+	return commands
+}
+
 func getArgsObject(_ context.Context, work interface{}) (err error) {
 	cmd := work.(*cmdWorkpiece)
 	if cmd.cmdFunc.ParamsDef() == appdef.NullQName {
 		return nil
 	}
 	aob := cmd.reb.ArgumentObjectBuilder()
-	if argsIntf, exists := cmd.requestData["args"]; exists {
-		args, ok := argsIntf.(map[string]interface{})
-		if !ok {
-			return errors.New(`"args" field must be an object`)
-		}
-		parsDef := cmd.appStructs.AppDef().Def(cmd.cmdFunc.ParamsDef())
-		if err = istructsmem.FillElementFromJSON(args, parsDef, aob); err != nil {
-			return err
-		}
+	if err = fillArgsObject(cmd.requestData, "args", cmd.appStructs.AppDef().Def(cmd.cmdFunc.ParamsDef()), aob); err != nil {
+		return err
 	}
 	if cmd.argsObject, err = aob.Build(); err != nil {
 		err = fmt.Errorf("argument object build failed: %w", err)
@@ -350,15 +612,8 @@ func getUnloggedArgsObject(_ context.Context, work interface{}) (err error) {
 		return nil
 	}
 	auob := cmd.reb.ArgumentUnloggedObjectBuilder()
-	if unloggedArgsIntf, exists := cmd.requestData["unloggedArgs"]; exists {
-		unloggedArgs, ok := unloggedArgsIntf.(map[string]interface{})
-		if !ok {
-			return errors.New(`"unloggedArgs" field must be an object`)
-		}
-		unloggedParsDef := cmd.appStructs.AppDef().Def(cmd.cmdFunc.UnloggedParamsDef())
-		if err = istructsmem.FillElementFromJSON(unloggedArgs, unloggedParsDef, auob); err != nil {
-			return err
-		}
+	if err = fillArgsObject(cmd.requestData, "unloggedArgs", cmd.appStructs.AppDef().Def(cmd.cmdFunc.UnloggedParamsDef()), auob); err != nil {
+		return err
 	}
 	if cmd.unloggedArgsObject, err = auob.Build(); err != nil {
 		err = fmt.Errorf("unlogged argument object build failed: %w", err)
@@ -366,6 +621,19 @@ func getUnloggedArgsObject(_ context.Context, work interface{}) (err error) {
 	return
 }
 
+// fills the builder from the object of the request data field if the field exists
+func fillArgsObject(requestData coreutils.MapObject, field string, paramsDef appdef.IDef, builder istructs.IObjectBuilder) error {
+	argsIntf, exists := requestData[field]
+	if !exists {
+		return nil
+	}
+	args, ok := argsIntf.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf(`"%s" field must be an object`, field)
+	}
+	return istructsmem.FillElementFromJSON(args, paramsDef, builder)
+}
+
 func (xp xPath) Errorf(mes string, args ...interface{}) error {
 	return fmt.Errorf(string(xp)+": "+mes, args...)
 }
@@ -374,10 +642,25 @@ func (xp xPath) Error(err error) error {
 	return xp.Errorf("%w", err)
 }
 
+// the same as Error but keeps HTTP status of the SysError
+func (xp xPath) SysError(err error) error {
+	var sysErr coreutils.SysError
+	if errors.As(err, &sysErr) {
+		sysErr.Message = string(xp) + ": " + sysErr.Message
+		return sysErr
+	}
+	return xp.Error(err)
+}
+
 func execCommand(_ context.Context, work interface{}) (err error) {
-	begin := time.Now()
-	defer work.(*cmdWorkpiece).metrics.increase(ExecSeconds, time.Since(begin).Seconds())
 	cmd := work.(*cmdWorkpiece)
+	begin := time.Now()
+	defer func() {
+		cmd.metrics.increase(ExecSeconds, time.Since(begin).Seconds())
+	}()
+	if cmd.cmdFunc.QName() == QNameCommandBatch {
+		return execBatch(cmd)
+	}
 	return cmd.cmdFunc.Exec(cmd.eca)
 }
 
@@ -397,11 +680,16 @@ func (cmdProc *cmdProc) validate(ctx context.Context, work interface{}) (err err
 			return
 		}
 	}
+	// CUD of the batch event is validated in the context of the batch command the CUD is made by
+	cudCommands := cmd.cud.commands(cmd.rawEvent)
 	for _, appCUDValidator := range cmd.appStructs.CUDValidators() {
+		cudNumber := 0
 		err = cmd.rawEvent.CUDs(func(rec istructs.ICUDRow) error {
+			command := cudCommands[cudNumber]
+			cudNumber++
 			recQName := rec.AsQName(appdef.SystemField_QName)
 			if istructs.ValidatorMatchByQName(appCUDValidator, recQName) {
-				if err := appCUDValidator.Validate(ctx, cmd.appStructs, rec, cmd.cmdMes.WSID(), cmd.cmdFunc.QName()); err != nil {
+				if err := appCUDValidator.Validate(ctx, cmd.appStructs, rec, cmd.cmdMes.WSID(), command); err != nil {
 					return err
 				}
 			}
@@ -416,12 +704,17 @@ func (cmdProc *cmdProc) validate(ctx context.Context, work interface{}) (err err
 
 func parseCUDs(_ context.Context, work interface{}) (err error) {
 	cmd := work.(*cmdWorkpiece)
-	cuds, _, err := cmd.requestData.AsObjects("cuds")
+	return parseRequestCUDs(cmd, cmd.requestData, cmd.cmdFunc.QName(), "")
+}
+
+// parses "cuds" of the request data, xPathPrefix is prepended to the xPath of each CUD
+func parseRequestCUDs(cmd *cmdWorkpiece, requestData coreutils.MapObject, command appdef.QName, xPathPrefix xPath) (err error) {
+	cuds, _, err := requestData.AsObjects("cuds")
 	if err != nil {
 		return err
 	}
 	for cudNumber, cudIntf := range cuds {
-		xPath := xPath("cuds[" + strconv.Itoa(cudNumber) + "]")
+		xPath := xPathPrefix + xPath("cuds["+strconv.Itoa(cudNumber)+"]")
 		cudDataMap, ok := cudIntf.(map[string]interface{})
 		if !ok {
 			return xPath.Errorf("not an object")
@@ -429,7 +722,8 @@ func parseCUDs(_ context.Context, work interface{}) (err error) {
 		cudData := coreutils.MapObject(cudDataMap)
 
 		parsedCUD := parsedCUD{
-			xPath: xPath,
+			xPath:   xPath,
+			command: command,
 		}
 
 		parsedCUD.fields, ok, err = cudData.AsObject("fields")
@@ -467,6 +761,11 @@ func parseCUDs(_ context.Context, work interface{}) (err error) {
 			if parsedCUD.qName = parsedCUD.existingRecord.QName(); parsedCUD.qName == appdef.NullQName {
 				return coreutils.NewHTTPError(http.StatusNotFound, xPath.Errorf("record with queried id %d does not exist", parsedCUD.id))
 			}
+			var version int64
+			if version, parsedCUD.hasVersion, err = cudData.AsInt64(Field_CUD_Version); err != nil {
+				return xPath.Error(err)
+			}
+			parsedCUD.version = istructs.Offset(version)
 		}
 		cmd.parsedCUDs = append(cmd.parsedCUDs, parsedCUD)
 	}
@@ -526,11 +825,12 @@ func (cmdProc *cmdProc) writeCUDs(_ context.Context, work interface{}) (err erro
 	cmd := work.(*cmdWorkpiece)
 	for _, parsedCUD := range cmd.parsedCUDs {
 		var rowWriter istructs.IRowWriter
+		cmd.cud.command = parsedCUD.command
 		if parsedCUD.opKind == iauthnz.OperationKind_INSERT {
-			rowWriter = cmd.reb.CUDBuilder().Create(parsedCUD.qName)
+			rowWriter = cmd.cud.Create(parsedCUD.qName)
 			rowWriter.PutRecordID(appdef.SystemField_ID, istructs.RecordID(parsedCUD.id))
 		} else {
-			rowWriter = cmd.reb.CUDBuilder().Update(parsedCUD.existingRecord)
+			rowWriter = cmd.cud.Update(parsedCUD.existingRecord)
 		}
 		if err := coreutils.Marshal(rowWriter, parsedCUD.fields); err != nil {
 			return parsedCUD.xPath.Error(err)
@@ -540,6 +840,9 @@ func (cmdProc *cmdProc) writeCUDs(_ context.Context, work interface{}) (err erro
 }
 
 func (osp *wrongArgsCatcher) OnErr(err error, _ interface{}, _ pipeline.IWorkpieceContext) (newErr error) {
+	if errors.Is(err, errCommandReplayed) {
+		return err
+	}
 	return coreutils.WrapSysError(err, http.StatusBadRequest)
 }
 
@@ -594,6 +897,11 @@ type opSendResponse struct {
 
 func (sr *opSendResponse) DoSync(_ context.Context, work interface{}) (err error) {
 	cmd := work.(*cmdWorkpiece)
+	if len(cmd.replayedResponse) > 0 {
+		cmd.metrics.increase(ReplayedTotal, 1.0)
+		coreutils.ReplyJSON(sr.bus, cmd.cmdMes.Sender(), http.StatusOK, cmd.replayedResponse)
+		return
+	}
 	if cmd.err != nil {
 		cmd.metrics.increase(ErrorsTotal, 1.0)
 		//if error occurred somewhere in syncProjectors we have to measure elapsed time
@@ -601,24 +909,38 @@ func (sr *opSendResponse) DoSync(_ context.Context, work interface{}) (err error
 			cmd.metrics.increase(ProjectorsSeconds, time.Since(cmd.syncProjectorsStart).Seconds())
 		}
 		logger.Error(cmd.err)
-		if errors.Is(cmd.err, istructsmem.ErrRecordIDUniqueViolation) {
+		if errors.Is(cmd.err, istructsmem.ErrRecordIDUniqueViolation) || errors.Is(cmd.err, istructsmem.ErrUniqueConstraintViolation) {
 			cmd.err = coreutils.NewHTTPError(http.StatusConflict, cmd.err)
 		}
 		coreutils.ReplyErr(sr.bus, cmd.cmdMes.Sender(), cmd.err)
 		return
 	}
-	body := bytes.NewBufferString(fmt.Sprintf(`{"CurrentWLogOffset":%d`, cmd.Event().WLogOffset()))
-	if len(cmd.generatedIDs) > 0 {
+	coreutils.ReplyJSON(sr.bus, cmd.cmdMes.Sender(), http.StatusOK, cmd.responseBody())
+	return
+}
+
+func (c *cmdWorkpiece) responseBody() string {
+	body := bytes.NewBufferString(fmt.Sprintf(`{"CurrentWLogOffset":%d`, c.rawEvent.WLogOffset()))
+	if len(c.generatedIDs) > 0 {
 		body.WriteString(`,"NewIDs":{`)
-		for rawID, generatedID := range cmd.generatedIDs {
+		for rawID, generatedID := range c.generatedIDs {
 			body.WriteString(fmt.Sprintf(`"%d":%d,`, rawID, generatedID))
 		}
 		body.Truncate(body.Len() - 1)
 		body.WriteString("}")
 	}
+	if len(c.batch) > 0 {
+		body.WriteString(`,"Results":[`)
+		for i, bc := range c.batch {
+			if i > 0 {
+				body.WriteString(",")
+			}
+			body.WriteString(bc.result(c.generatedIDs))
+		}
+		body.WriteString("]")
+	}
 	body.WriteString("}")
-	coreutils.ReplyJSON(sr.bus, cmd.cmdMes.Sender(), http.StatusOK, body.String())
-	return
+	return body.String()
 }
 
 // nolint (result is always nil)
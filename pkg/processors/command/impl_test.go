@@ -25,6 +25,7 @@ import (
 	ibus "github.com/untillpro/airs-ibus"
 	"github.com/untillpro/ibusmem"
 	"github.com/voedger/voedger/pkg/iauthnzimpl"
+	"github.com/voedger/voedger/pkg/idgen"
 	"github.com/voedger/voedger/pkg/iratesce"
 	"github.com/voedger/voedger/pkg/isecretsimpl"
 	"github.com/voedger/voedger/pkg/istorage"
@@ -42,6 +43,10 @@ var (
 	testCDoc    = appdef.NewQName("test", "TestCDoc")
 	testWDoc    = appdef.NewQName("test", "TestWDoc")
 	testTimeout = ibus.DefaultTimeout
+	// shifts the time of the command processor, need to test idempotency window expiration
+	testTimeShift time.Duration
+	// idempotency window of the command processor, need to test idempotency keys expiration
+	testIdempotencyWindow = time.Hour
 )
 
 func TestBasicUsage(t *testing.T) {
@@ -222,6 +227,198 @@ func TestRecovery(t *testing.T) {
 	<-app.done
 }
 
+func TestRecoveryFromCheckpoint(t *testing.T) {
+	require := require.New(t)
+
+	defer func(i int) { checkpointInterval = i }(checkpointInterval)
+	checkpointInterval = 2
+
+	app := setUp(t, func(appDef appdef.IAppDefBuilder) {
+		_ = appDef.AddStruct(testCRecord, appdef.DefKind_CRecord)
+		_ = appDef.AddStruct(testCDoc, appdef.DefKind_CDoc).AddContainer("TestCRecord", testCRecord, 0, 1)
+		_ = appDef.AddStruct(testWDoc, appdef.DefKind_WDoc)
+		ProvideCheckpointsDef(appDef)
+	})
+	defer tearDown(app)
+
+	cudQName := appdef.NewQName(appdef.SysPackage, "CUD")
+	cmdCUD := istructsmem.NewCommandFunction(cudQName, appdef.NullQName, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec)
+	app.cfg.Resources.Add(cmdCUD)
+
+	sendCUD(t, 1, app)
+	sendCUD(t, 1, app) // checkpoint is written here
+	sendCUD(t, 2, app) // PLog tail after checkpoint
+
+	as, err := app.asp.AppStructs(istructs.AppQName_untill_airs_bp)
+	require.NoError(err)
+
+	t.Run("Checkpoint should contain counters of partition and changed workspaces", func(t *testing.T) {
+		ap := newAppPartition(as)
+		readFrom, err := ap.readCheckpoint(context.Background(), as, 1)
+		require.NoError(err)
+		require.Equal(istructs.FirstOffset+2, readFrom)
+		require.Len(ap.workspaces, 1)
+		ws := ap.workspaces[1]
+		require.Equal(istructs.FirstOffset+2, ws.NextWLogOffset)
+		require.Equal(istructs.FirstBaseRecordID+2, ws.NextBaseID)
+		require.Equal(istructs.FirstBaseRecordID+4, ws.NextCDocCRecordBaseID)
+	})
+
+	t.Run("Recovery should replay PLog tail only", func(t *testing.T) {
+		cmdProc := &cmdProc{pNumber: 1}
+		cmd := &cmdWorkpiece{
+			appStructs: as,
+			cmdMes:     NewCommandMessage(context.Background(), nil, istructs.AppQName_untill_airs_bp, 1, nil, 1, nil, "", "", ""),
+			metrics:    commandProcessorMetrics{metrics: imetrics.Provide(), app: istructs.AppQName_untill_airs_bp},
+		}
+		ap, err := cmdProc.recovery(context.Background(), cmd)
+		require.NoError(err)
+		require.Equal(istructs.FirstOffset+3, ap.nextPLogOffset)
+		require.Len(ap.workspaces, 2)
+		require.Equal(istructs.FirstOffset+1, ap.workspaces[2].NextWLogOffset)
+		require.Len(ap.changedWorkspaces, 1, "only workspaces from PLog tail are changed since checkpoint")
+	})
+
+	restartCmdProc(&app)
+
+	respData := sendCUD(t, 1, app)
+	require.Equal(3, int(respData["CurrentWLogOffset"].(float64)))
+	require.Equal(istructs.NewCDocCRecordID(istructs.FirstBaseRecordID)+4, istructs.RecordID(respData["NewIDs"].(map[string]interface{})["1"].(float64)))
+	require.Equal(istructs.NewRecordID(istructs.FirstBaseRecordID)+2, istructs.RecordID(respData["NewIDs"].(map[string]interface{})["2"].(float64)))
+
+	respData = sendCUD(t, 2, app)
+	require.Equal(2, int(respData["CurrentWLogOffset"].(float64)))
+	require.Equal(istructs.NewCDocCRecordID(istructs.FirstBaseRecordID)+2, istructs.RecordID(respData["NewIDs"].(map[string]interface{})["1"].(float64)))
+	require.Equal(istructs.NewRecordID(istructs.FirstBaseRecordID)+1, istructs.RecordID(respData["NewIDs"].(map[string]interface{})["2"].(float64)))
+}
+
+func TestIdempotency(t *testing.T) {
+	require := require.New(t)
+
+	app := setUp(t, func(appDef appdef.IAppDefBuilder) {
+		_ = appDef.AddStruct(testCRecord, appdef.DefKind_CRecord)
+		_ = appDef.AddStruct(testCDoc, appdef.DefKind_CDoc).AddContainer("TestCRecord", testCRecord, 0, 1)
+		_ = appDef.AddStruct(testWDoc, appdef.DefKind_WDoc)
+		ProvideIdempotencyDef(appDef)
+	})
+	defer tearDown(app)
+
+	cudQName := appdef.NewQName(appdef.SysPackage, "CUD")
+	app.cfg.Resources.Add(istructsmem.NewCommandFunction(cudQName, appdef.NullQName, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec))
+	testCmdQName := appdef.NewQName(appdef.SysPackage, "Test")
+	app.cfg.Resources.Add(istructsmem.NewCommandFunction(testCmdQName, appdef.NullQName, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec))
+
+	send := func(wsid istructs.WSID, resource string, key string) (statusCode int, respData map[string]interface{}) {
+		var header map[string]string
+		if len(key) > 0 {
+			header = map[string]string{IdempotencyKeyHeader: key}
+		}
+		return sendRequest(t, app, wsid, resource, `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"test.TestCDoc"}}]}`, header)
+	}
+
+	statusCode, first := send(1, "c.sys.CUD", "key1")
+	require.Equal(http.StatusOK, statusCode)
+	require.Equal(1, int(first["CurrentWLogOffset"].(float64)))
+
+	t.Run("Should store the key with PLog offset of the command event", func(t *testing.T) {
+		as, err := app.asp.AppStructs(istructs.AppQName_untill_airs_bp)
+		require.NoError(err)
+		kb := as.ViewRecords().KeyBuilder(ViewQNameIdempotencyKeys)
+		kb.PutInt64(idempotencyWSIDFld, 1)
+		kb.PutString(idempotencyKeyFld, "key1")
+		value, err := as.ViewRecords().Get(1, kb)
+		require.NoError(err)
+		require.Equal(cudQName, value.AsQName(idempotencyCommandFld))
+
+		pLogOffset := istructs.Offset(value.AsInt64(idempotencyPLogOffsetFld))
+		events := 0
+		require.NoError(as.Events().ReadPLog(context.Background(), 1, pLogOffset, 1, func(_ istructs.Offset, event istructs.IPLogEvent) error {
+			events++
+			require.Equal(cudQName, event.QName())
+			require.Equal(istructs.WSID(1), event.Workspace())
+			require.Equal(istructs.Offset(1), event.WLogOffset())
+			return nil
+		}))
+		require.Equal(1, events)
+	})
+
+	t.Run("Should replay response of the command with the same key", func(t *testing.T) {
+		statusCode, replayed := send(1, "c.sys.CUD", "key1")
+		require.Equal(http.StatusOK, statusCode)
+		require.Equal(first, replayed)
+	})
+
+	t.Run("Should execute command without key or with another key", func(t *testing.T) {
+		_, respData := send(1, "c.sys.CUD", "")
+		require.Equal(2, int(respData["CurrentWLogOffset"].(float64)))
+		_, respData = send(1, "c.sys.CUD", "key2")
+		require.Equal(3, int(respData["CurrentWLogOffset"].(float64)))
+	})
+
+	t.Run("Should keep keys per workspace", func(t *testing.T) {
+		_, respData := send(2, "c.sys.CUD", "key1")
+		require.Equal(1, int(respData["CurrentWLogOffset"].(float64)))
+	})
+
+	t.Run("409 on the key used by another command", func(t *testing.T) {
+		statusCode, respData := send(1, "c.sys.Test", "key1")
+		require.Equal(http.StatusConflict, statusCode)
+		require.Contains(respData["sys.Error"].(map[string]interface{})["Message"], "sys.CUD")
+	})
+
+	t.Run("409 on the key used with another request body", func(t *testing.T) {
+		statusCode, respData := sendRequest(t, app, 1, "c.sys.CUD", `{"cuds":[{"fields":{"sys.ID":2,"sys.QName":"test.TestCDoc"}}]}`,
+			map[string]string{IdempotencyKeyHeader: "key1"})
+		require.Equal(http.StatusConflict, statusCode)
+		require.Contains(respData["sys.Error"].(map[string]interface{})["Message"], "another request body")
+	})
+
+	t.Run("400 on too long key", func(t *testing.T) {
+		statusCode, _ := send(1, "c.sys.CUD", strings.Repeat("k", maxIdempotencyKeyLength+1))
+		require.Equal(http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("Should replay after restart", func(t *testing.T) {
+		restartCmdProc(&app)
+		_, replayed := send(1, "c.sys.CUD", "key1")
+		require.Equal(first, replayed)
+	})
+
+	t.Run("Should execute command again if idempotency window is expired", func(t *testing.T) {
+		defer func() { testTimeShift = 0 }()
+		testTimeShift = time.Hour + time.Minute
+		_, respData := send(1, "c.sys.CUD", "key1")
+		require.Equal(4, int(respData["CurrentWLogOffset"].(float64)))
+		_, replayed := send(1, "c.sys.CUD", "key1")
+		require.Equal(respData, replayed)
+	})
+}
+
+// sendRequest sends the request to the command processor of the test app with the system principal token.
+// Values of header are added to the request header. Returns the response status code and data
+func sendRequest(t *testing.T, app testApp, wsid istructs.WSID, resource string, body string, header map[string]string) (statusCode int, respData map[string]interface{}) {
+	require := require.New(t)
+	reqHeader := map[string][]string{}
+	for k, v := range app.sysAuthHeader {
+		reqHeader[k] = v
+	}
+	for k, v := range header {
+		reqHeader[k] = []string{v}
+	}
+	req := ibus.Request{
+		WSID:     int64(wsid),
+		AppQName: istructs.AppQName_untill_airs_bp.String(),
+		Resource: resource,
+		Body:     []byte(body),
+		Header:   reqHeader,
+	}
+	resp, _, _, err := app.bus.SendRequest2(app.ctx, req, testTimeout)
+	require.NoError(err)
+	respData = map[string]interface{}{}
+	require.NoError(json.Unmarshal(resp.Data, &respData), string(resp.Data))
+	return resp.StatusCode, respData
+}
+
 func restartCmdProc(app *testApp) {
 	app.cancel()
 	<-app.done
@@ -284,6 +481,55 @@ func TestCUDUpdate(t *testing.T) {
 		require.Equal(http.StatusNotFound, resp.StatusCode)
 		require.Equal(coreutils.ApplicationJSON, resp.ContentType)
 	})
+
+	t.Run("400 on version if record versions are not stored", func(t *testing.T) {
+		id := int64(m["NewIDs"].(map[string]interface{})["1"].(float64))
+		req.Body = []byte(fmt.Sprintf(`{"cuds":[{"sys.ID":%d,"sys.Version":1,"fields":{"IntFld": 42}}]}`, id))
+		resp, sections, secErr, err = app.bus.SendRequest2(app.ctx, req, testTimeout)
+		require.Nil(err, err)
+		require.Nil(secErr, secErr)
+		require.Nil(sections)
+		require.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func Test409OnUniqueViolation(t *testing.T) {
+	require := require.New(t)
+
+	testQName := appdef.NewQName("test", "test")
+
+	app := setUp(t, func(appDef appdef.IAppDefBuilder) {
+		_ = appDef.AddStruct(testQName, appdef.DefKind_CDoc).AddField("IntFld", appdef.DataKind_int32, true)
+	}, func(cfg *istructsmem.AppConfigType) {
+		cfg.Uniques.Add(testQName, []string{"IntFld"})
+	})
+	defer tearDown(app)
+
+	cudQName := appdef.NewQName(appdef.SysPackage, "CUD")
+	cmdCUD := istructsmem.NewCommandFunction(cudQName, appdef.NullQName, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec)
+	app.cfg.Resources.Add(cmdCUD)
+
+	req := ibus.Request{
+		WSID:     1,
+		AppQName: istructs.AppQName_untill_airs_bp.String(),
+		Resource: "c.sys.CUD",
+		Body:     []byte(`{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"test.test","IntFld":42}}]}`),
+		Header:   app.sysAuthHeader,
+	}
+	resp, sections, secErr, err := app.bus.SendRequest2(app.ctx, req, testTimeout)
+	require.Nil(err, err)
+	require.Nil(secErr, secErr)
+	require.Nil(sections)
+	require.Equal(http.StatusOK, resp.StatusCode)
+
+	t.Run("409 conflict on insert duplicate unique values", func(t *testing.T) {
+		resp, sections, secErr, err = app.bus.SendRequest2(app.ctx, req, testTimeout)
+		require.Nil(err, err)
+		require.Nil(secErr, secErr)
+		require.Nil(sections)
+		require.Equal(http.StatusConflict, resp.StatusCode)
+		require.Contains(string(resp.Data), jsonEscape(istructsmem.ErrUniqueConstraintViolation.Error()))
+	})
 }
 
 func Test400BadRequestOnCUDErrors(t *testing.T) {
@@ -577,6 +823,7 @@ func TestRateLimit(t *testing.T) {
 type testApp struct {
 	ctx            context.Context
 	cfg            *istructsmem.AppConfigType
+	asp            istructs.IAppStructsProvider
 	bus            ibus.IBus
 	cancel         context.CancelFunc
 	done           chan struct{}
@@ -655,7 +902,11 @@ func setUp(t *testing.T, prepareAppDef func(appDef appdef.IAppDefBuilder), cfgFu
 		if authHeaders, ok := request.Header[coreutils.Authorization]; ok {
 			token = strings.TrimPrefix(authHeaders[0], "Bearer ")
 		}
-		icm := NewCommandMessage(ctx, request.Body, appQName, istructs.WSID(request.WSID), sender, 1, resource, token, "")
+		idempotencyKey := ""
+		if keyHeaders, ok := request.Header[IdempotencyKeyHeader]; ok {
+			idempotencyKey = keyHeaders[0]
+		}
+		icm := NewCommandMessage(ctx, request.Body, appQName, istructs.WSID(request.WSID), sender, 1, resource, token, "", idempotencyKey)
 		serviceChannel <- icm
 	})
 	n10nBroker := in10nmem.Provide(in10n.Quotas{
@@ -669,9 +920,11 @@ func setUp(t *testing.T, prepareAppDef func(appDef appdef.IAppDefBuilder), cfgFu
 	appTokens := payloads.ProvideIAppTokensFactory(tokens).New(istructs.AppQName_untill_airs_bp)
 	systemToken, err := payloads.GetSystemPrincipalTokenApp(appTokens)
 	require.NoError(t, err)
-	cmdProcessorFactory := ProvideServiceFactory(bus, appStructsProvider, time.Now, func(ctx context.Context, partitionID istructs.PartitionID) pipeline.ISyncOperator {
+	now := func() time.Time { return time.Now().Add(testTimeShift) }
+	cmdProcessorFactory := ProvideServiceFactory(bus, appStructsProvider, now, func(ctx context.Context, partitionID istructs.PartitionID) pipeline.ISyncOperator {
 		return &pipeline.NOOP{}
-	}, n10nBroker, imetrics.Provide(), "hvm", iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter), iauthnzimpl.NewDefaultAuthorizer(), isecretsimpl.ProvideSecretReader())
+	}, n10nBroker, imetrics.Provide(), "hvm", iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter), iauthnzimpl.NewDefaultAuthorizer(), isecretsimpl.ProvideSecretReader(),
+		IdempotencyWindow(testIdempotencyWindow))
 	cmdProcService := cmdProcessorFactory(serviceChannel, 1)
 
 	go func() {
@@ -685,6 +938,7 @@ func setUp(t *testing.T, prepareAppDef func(appDef appdef.IAppDefBuilder), cfgFu
 
 	return testApp{
 		cfg:            cfg,
+		asp:            appStructsProvider,
 		bus:            bus,
 		cancel:         cancel,
 		ctx:            ctx,
@@ -705,3 +959,133 @@ func jsonEscape(i string) string {
 	s := string(b)
 	return s[1 : len(s)-1]
 }
+
+func TestIdempotencyKeyExpiration(t *testing.T) {
+	require := require.New(t)
+	defer func() { testIdempotencyWindow = time.Hour }()
+	testIdempotencyWindow = 100 * time.Millisecond
+
+	app := setUp(t, func(appDef appdef.IAppDefBuilder) {
+		_ = appDef.AddStruct(testCDoc, appdef.DefKind_CDoc)
+		ProvideIdempotencyDef(appDef)
+	})
+	defer tearDown(app)
+	app.cfg.Resources.Add(istructsmem.NewCommandFunction(istructs.QNameCommandCUD, appdef.NullQName, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec))
+
+	statusCode, _ := sendRequest(t, app, 1, "c.sys.CUD", `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"test.TestCDoc"}}]}`,
+		map[string]string{IdempotencyKeyHeader: "key1"})
+	require.Equal(http.StatusOK, statusCode)
+
+	as, err := app.asp.AppStructs(istructs.AppQName_untill_airs_bp)
+	require.NoError(err)
+	kb := as.ViewRecords().KeyBuilder(ViewQNameIdempotencyKeys)
+	kb.PutInt64(idempotencyWSIDFld, 1)
+	kb.PutString(idempotencyKeyFld, "key1")
+	_, err = as.ViewRecords().Get(1, kb)
+	require.NoError(err)
+
+	time.Sleep(2 * testIdempotencyWindow)
+
+	_, err = as.ViewRecords().Get(1, kb)
+	require.ErrorIs(err, istructsmem.ErrRecordNotFound, "expired key must be removed from storage")
+}
+
+func TestReserveIDs(t *testing.T) {
+	require := require.New(t)
+
+	app := setUp(t, func(appDef appdef.IAppDefBuilder) {
+		idgen.ProvideViewDef(appDef)
+		_ = appDef.AddStruct(testCDoc, appdef.DefKind_CDoc)
+	}, func(cfg *istructsmem.AppConfigType) {
+		cfg.Resources.Add(istructsmem.NewCommandFunction(istructs.QNameCommandCUD, appdef.NullQName, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec))
+	})
+	defer tearDown(app)
+
+	as, err := app.asp.AppStructs(istructs.AppQName_untill_airs_bp)
+	require.NoError(err)
+	const (
+		partition = istructs.PartitionID(2) // the command processor of the test app handles partition 1
+		wsid      = istructs.WSID(1)
+	)
+
+	// cmd returns the workpiece of the command which creates the record with raw ID 1
+	cmd := func(cmdProc *cmdProc) *cmdWorkpiece {
+		reb := as.Events().GetNewRawEventBuilder(istructs.NewRawEventBuilderParams{
+			GenericRawEventBuilderParams: istructs.GenericRawEventBuilderParams{
+				HandlingPartition: partition,
+				Workspace:         wsid,
+				QName:             istructs.QNameCommandCUD,
+				PLogOffset:        cmdProc.appPartition.nextPLogOffset,
+				WLogOffset:        istructs.FirstOffset,
+			},
+		})
+		reb.CUDBuilder().Create(testCDoc).PutRecordID(appdef.SystemField_ID, 1)
+		rawEvent, err := reb.BuildRawEvent()
+		require.NoError(err)
+		cmd := &cmdWorkpiece{
+			appStructs:   as,
+			cmdMes:       NewCommandMessage(context.Background(), nil, istructs.AppQName_untill_airs_bp, wsid, nil, partition, nil, "", "", ""),
+			rawEvent:     rawEvent,
+			generatedIDs: map[istructs.RecordID]istructs.RecordID{},
+		}
+		cmd.workspace = cmdProc.appPartition.getWorkspace(wsid)
+		return cmd
+	}
+	reserved := func() int64 {
+		kb := as.ViewRecords().KeyBuilder(idgen.ViewQNameIDSequences)
+		kb.PutInt64(idgen.Field_WSID, int64(wsid))
+		kb.PutString(idgen.Field_Sequence, idgen.QNameCDocCRecordIDs.String())
+		value, err := as.ViewRecords().Get(wsid, kb)
+		if errors.Is(err, istructsmem.ErrRecordNotFound) {
+			return 0
+		}
+		require.NoError(err)
+		return value.AsInt64(idgen.Field_Reserved)
+	}
+	pLogEvents := func() (count int) {
+		require.NoError(as.Events().ReadPLog(context.Background(), partition, istructs.FirstOffset, istructs.ReadToTheEnd,
+			func(istructs.Offset, istructs.IPLogEvent) error {
+				count++
+				return nil
+			}))
+		return count
+	}
+
+	proc := &cmdProc{appPartition: newAppPartition(as)}
+	var firstID istructs.RecordID
+
+	t.Run("Reserved block must be persisted before the event is put to PLog", func(t *testing.T) {
+		work := cmd(proc)
+		require.NoError(proc.reserveIDs(context.Background(), work))
+		require.EqualValues(idgen.DefaultBlockSize, reserved())
+		require.Zero(pLogEvents())
+
+		require.NoError(proc.putPLog(context.Background(), work))
+		require.Equal(1, pLogEvents())
+		firstID = work.generatedIDs[1]
+		require.Equal(istructs.NewCDocCRecordID(istructs.FirstBaseRecordID), firstID)
+		require.NoError(work.pLogEvent.CUDs(func(rec istructs.ICUDRow) error {
+			require.Equal(firstID, rec.ID())
+			return nil
+		}))
+	})
+
+	t.Run("IDs must not be reused after restart", func(t *testing.T) {
+		// restart without recovery: IDs are skipped by the persisted reserved block
+		restarted := &cmdProc{appPartition: newAppPartition(as)}
+		restarted.appPartition.nextPLogOffset = proc.appPartition.nextPLogOffset
+		work := cmd(restarted)
+		require.NoError(restarted.reserveIDs(context.Background(), work))
+		require.Equal(istructs.NewCDocCRecordID(istructs.FirstBaseRecordID+istructs.RecordID(idgen.DefaultBlockSize)), work.generatedIDs[1])
+		require.Greater(work.generatedIDs[1], firstID)
+		require.EqualValues(2*idgen.DefaultBlockSize, reserved())
+	})
+
+	t.Run("Event must be put to PLog as error event if the ID is not reserved", func(t *testing.T) {
+		work := cmd(proc)
+		require.NoError(proc.putPLog(context.Background(), work))
+		require.False(work.pLogEvent.Error().ValidEvent())
+		require.Contains(work.pLogEvent.Error().ErrStr(), "storage ID is not reserved for raw ID 1")
+		require.Equal(2, pLogEvents())
+	})
+}
@@ -10,4 +10,8 @@ const (
 	ErrorsTotal       = "heeus_cp_errors_total"
 	ExecSeconds       = "heeus_cp_exec_seconds"
 	ProjectorsSeconds = "heeus_cp_projectors_seconds"
+	RecoverySeconds   = "heeus_cp_recovery_seconds"
+	RecoveryEvents    = "heeus_cp_recovery_events_total"
+	CheckpointsTotal  = "heeus_cp_checkpoints_total"
+	ReplayedTotal     = "heeus_cp_replayed_total"
 )
@@ -15,6 +15,7 @@ import (
 	"github.com/voedger/voedger/pkg/in10n"
 	"github.com/voedger/voedger/pkg/isecrets"
 	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
 	imetrics "github.com/voedger/voedger/pkg/metrics"
 	"github.com/voedger/voedger/pkg/pipeline"
 	coreutils "github.com/voedger/voedger/pkg/utils"
@@ -34,11 +35,19 @@ type cmdProc struct {
 	now           func() time.Time
 	authenticator iauthnz.IAuthenticator
 	authorizer    iauthnz.IAuthorizer
+	// idempotency keys are stored and replayed only if application defines ViewQNameIdempotencyKeys
+	idempotencyWindow time.Duration
 }
 
 type appPartition struct {
+	idGen          istructs.IIDGenerator
 	workspaces     map[istructs.WSID]*workspace
 	nextPLogOffset istructs.Offset
+
+	// workspaces changed since last checkpoint
+	changedWorkspaces map[istructs.WSID]*workspace
+	// commands processed since last checkpoint
+	sinceCheckpoint int
 }
 
 func ProvideJSONFuncParamsDef(appDef appdef.IAppDefBuilder) {
@@ -46,18 +55,64 @@ func ProvideJSONFuncParamsDef(appDef appdef.IAppDefBuilder) {
 		AddField(Field_JSONDef_Body, appdef.DataKind_string, true)
 }
 
+// Checkpoints of partition and workspaces counters are written only if application defines the view.
+// Recovery then reads the PLog from the last checkpoint instead of the PLog start
+func ProvideCheckpointsDef(appDef appdef.IAppDefBuilder) {
+	def := appDef.AddView(ViewQNameCheckpoints)
+	def.AddPartField(checkpointPartitionFld, appdef.DataKind_int32)
+	def.AddClustColumn(checkpointWSIDFld, appdef.DataKind_int64)
+	def.AddValueField(checkpointNextPLogOffsetFld, appdef.DataKind_int64, false)
+	def.AddValueField(checkpointNextWLogOffsetFld, appdef.DataKind_int64, false)
+	def.AddValueField(checkpointNextBaseIDFld, appdef.DataKind_int64, false)
+	def.AddValueField(checkpointNextCDocCRecordBaseIDFld, appdef.DataKind_int64, false)
+}
+
+// Idempotency keys of the commands are stored and the responses are replayed only if application defines the view.
+// Key is the pair of the workspace and the IdempotencyKeyHeader value, the key is stored atomically with the PLog event of the command
+// and expires after the idempotency window
+func ProvideIdempotencyDef(appDef appdef.IAppDefBuilder) {
+	def := appDef.AddView(ViewQNameIdempotencyKeys)
+	def.AddPartField(idempotencyWSIDFld, appdef.DataKind_int64)
+	def.AddClustColumn(idempotencyKeyFld, appdef.DataKind_string)
+	def.AddValueField(idempotencyCommandFld, appdef.DataKind_QName, true)
+	def.AddValueField(idempotencyRegisteredAtFld, appdef.DataKind_int64, true)
+	def.AddValueField(idempotencyResponseFld, appdef.DataKind_string, true)
+	def.AddValueField(idempotencyPLogOffsetFld, appdef.DataKind_int64, true)
+	def.AddValueField(idempotencyBodyHashFld, appdef.DataKind_bytes, true)
+}
+
+// Versions of the records are stored and checked only if application defines the view.
+// Version of the record is WLog offset of the event the record was created or updated last time by, records which are
+// not changed since the view is defined have zero version
+func ProvideRecordVersionsDef(appDef appdef.IAppDefBuilder) {
+	def := appDef.AddView(ViewQNameRecordVersions)
+	def.AddPartField(recordVersionQNameFld, appdef.DataKind_QName)
+	def.AddClustColumn(recordVersionIDFld, appdef.DataKind_RecordID)
+	def.AddValueField(recordVersionWLogOffsetFld, appdef.DataKind_int64, true)
+}
+
+// Commands of the c.sys.Batch are executed one by one in the request workspace, CUDs of all commands are written to the one sys.Batch event,
+// so all commands are applied or none. The event arguments are the commands of the batch without unlogged arguments.
+// Commands which feed projectors by the command QName or by the arguments QName are rejected, the batch event does not feed them
+func ProvideBatchCommand(cfg *istructsmem.AppConfigType, appDef appdef.IAppDefBuilder) {
+	appDef.AddStruct(QNameBatchParams, appdef.DefKind_Object).
+		AddField(Field_BatchParams_Commands, appdef.DataKind_string, true)
+	cfg.Resources.Add(istructsmem.NewCommandFunction(QNameCommandBatch, QNameBatchParams, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec))
+}
+
 // syncActualizerFactory - это фабрика(разделИД), которая возвращает свитч, в бранчах которого по синхронному актуализатору на каждое приложение, внутри каждого - проекторы на каждое приложение
 func ProvideServiceFactory(bus ibus.IBus, asp istructs.IAppStructsProvider, now func() time.Time, syncActualizerFactory SyncActualizerFactory,
 	n10nBroker in10n.IN10nBroker, metrics imetrics.IMetrics, hvm HVMName, authenticator iauthnz.IAuthenticator, authorizer iauthnz.IAuthorizer,
-	secretReader isecrets.ISecretReader) ServiceFactory {
+	secretReader isecrets.ISecretReader, idempotencyWindow IdempotencyWindow) ServiceFactory {
 	return func(commandsChannel CommandChannel, partitionID istructs.PartitionID) pipeline.IService {
 		cmdProc := &cmdProc{
-			pNumber:       partitionID,
-			appPartitions: map[istructs.AppQName]*appPartition{},
-			n10nBroker:    n10nBroker,
-			now:           now,
-			authenticator: authenticator,
-			authorizer:    authorizer,
+			pNumber:           partitionID,
+			appPartitions:     map[istructs.AppQName]*appPartition{},
+			n10nBroker:        n10nBroker,
+			now:               now,
+			authenticator:     authenticator,
+			authorizer:        authorizer,
+			idempotencyWindow: time.Duration(idempotencyWindow),
 		}
 		return pipeline.NewService(func(hvmCtx context.Context) {
 			hsp := newHostStateProvider(hvmCtx, partitionID, secretReader)
@@ -70,21 +125,29 @@ func ProvideServiceFactory(bus ibus.IBus, asp istructs.IAppStructsProvider, now
 				pipeline.WireFunc("getFunction", getFunction),
 				pipeline.WireFunc("authenticate", cmdProc.authenticate),
 				pipeline.WireFunc("authorizeRequest", cmdProc.authorizeRequest),
+				pipeline.WireFunc("replayByIdempotencyKey", cmdProc.replayByIdempotencyKey),
 				pipeline.WireFunc("unmarshalRequestBody", unmarshalRequestBody),
+				pipeline.WireFunc("parseBatch", cmdProc.parseBatch),
 				pipeline.WireFunc("getWorkspace", cmdProc.getWorkspace),
 				pipeline.WireFunc("getRawEventBuilderBuilders", cmdProc.getRawEventBuilder),
 				pipeline.WireFunc("getArgsObject", getArgsObject),
 				pipeline.WireFunc("getUnloggedArgsObject", getUnloggedArgsObject),
 				pipeline.WireFunc("checkArgsRefIntegrity", checkArgsRefIntegrity),
 				pipeline.WireFunc("parseCUDs", parseCUDs),
+				pipeline.WireFunc("parseBatchCommands", parseBatchCommands),
+				pipeline.WireFunc("checkRecordVersions", checkRecordVersions),
 				pipeline.WireSyncOperator("wrongArgsCatcher", &wrongArgsCatcher{}), // any error before -> wrap error into bad request http error
 				pipeline.WireFunc("checkWSDescUpdating", checkWorkspaceDescriptorUpdating),
 				pipeline.WireFunc("authorizeCUDs", cmdProc.authorizeCUDs),
 				pipeline.WireFunc("writeCUDs", cmdProc.writeCUDs),
 				pipeline.WireFunc("buildCommandArgs", cmdProc.buildCommandArgs),
 				pipeline.WireFunc("execCommand", execCommand),
+				pipeline.WireFunc("putSequenceDefaults", cmdProc.putSequenceDefaults),
 				pipeline.WireFunc("build raw event", buildRawEvent),
 				pipeline.WireFunc("validate", cmdProc.validate),
+				pipeline.WireFunc("reserveIDs", cmdProc.reserveIDs),
+				pipeline.WireFunc("buildRecordVersions", buildRecordVersions),
+				pipeline.WireFunc("buildIdempotencyKey", cmdProc.buildIdempotencyKey),
 				pipeline.WireFunc("putPLog", cmdProc.putPLog),
 				pipeline.WireFunc("applyPLogEvent", applyPLogEvent),
 				pipeline.WireFunc("syncProjectorsStart", syncProjectorsBegin),
@@ -92,6 +155,7 @@ func ProvideServiceFactory(bus ibus.IBus, asp istructs.IAppStructsProvider, now
 				pipeline.WireFunc("syncProjectorsEnd", syncProjectorsEnd),
 				pipeline.WireFunc("n10n", cmdProc.n10n),
 				pipeline.WireFunc("putWLog", putWLog),
+				pipeline.WireFunc("checkpoint", cmdProc.checkpoint),
 				pipeline.WireSyncOperator("sendResponse", &opSendResponse{bus: bus}), // ICatch
 			)
 			// TODO: сделать потом plogOffset свой по каждому разделу, wlogoffset - свой для каждого wsid
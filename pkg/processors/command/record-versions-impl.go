@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package commandprocessor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+func recordVersionsEnabled(cmd *cmdWorkpiece) bool {
+	return cmd.AppDef().DefByName(ViewQNameRecordVersions) != nil
+}
+
+func recordVersionKeyBuilder(cmd *cmdWorkpiece, qName appdef.QName, id istructs.RecordID) istructs.IKeyBuilder {
+	kb := cmd.appStructs.ViewRecords().KeyBuilder(ViewQNameRecordVersions)
+	kb.PutQName(recordVersionQNameFld, qName)
+	kb.PutRecordID(recordVersionIDFld, id)
+	return kb
+}
+
+// returns zero version if the record is not changed since the view is defined
+func recordVersion(cmd *cmdWorkpiece, qName appdef.QName, id istructs.RecordID) (istructs.Offset, error) {
+	value, err := cmd.appStructs.ViewRecords().Get(cmd.cmdMes.WSID(), recordVersionKeyBuilder(cmd, qName, id))
+	if err != nil {
+		if errors.Is(err, istructsmem.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return istructs.Offset(value.AsInt64(recordVersionWLogOffsetFld)), nil
+}
+
+// stale update is the conflict, the error data is the current version and the current values of the record fields
+func checkRecordVersions(_ context.Context, work interface{}) (err error) {
+	cmd := work.(*cmdWorkpiece)
+	for _, parsedCUD := range cmd.parsedCUDs {
+		if !parsedCUD.hasVersion {
+			continue
+		}
+		if !recordVersionsEnabled(cmd) {
+			return parsedCUD.xPath.Errorf(`"%s" is specified but record versions are not stored by the application`, Field_CUD_Version)
+		}
+		current, err := recordVersion(cmd, parsedCUD.qName, istructs.RecordID(parsedCUD.id))
+		if err != nil {
+			return parsedCUD.xPath.Error(err)
+		}
+		if current == parsedCUD.version {
+			continue
+		}
+		data, err := json.Marshal(map[string]interface{}{
+			Field_CUD_Version: current,
+			"fields":          coreutils.FieldsToMap(parsedCUD.existingRecord, cmd.AppDef()),
+		})
+		if err != nil {
+			// notest
+			return err
+		}
+		return coreutils.SysError{
+			HTTPStatus: http.StatusConflict,
+			QName:      parsedCUD.qName,
+			Message:    parsedCUD.xPath.Errorf("record %d is changed: version %d expected, current version is %d", parsedCUD.id, parsedCUD.version, current).Error(),
+			Data:       string(data),
+		}
+	}
+	return nil
+}
+
+// version of the records created or updated by the event is the event WLog offset
+// versions are written in the same storage batch as the PLog event, so the record is never changed without its version
+func buildRecordVersions(_ context.Context, work interface{}) (err error) {
+	cmd := work.(*cmdWorkpiece)
+	if !recordVersionsEnabled(cmd) {
+		return nil
+	}
+	vr := cmd.appStructs.ViewRecords()
+	return cmd.rawEvent.CUDs(func(rec istructs.ICUDRow) error {
+		id, err := storageID(cmd, rec)
+		if err != nil {
+			return err
+		}
+		vb := vr.NewValueBuilder(ViewQNameRecordVersions)
+		vb.PutInt64(recordVersionWLogOffsetFld, int64(cmd.rawEvent.WLogOffset()))
+		cmd.eventViews = append(cmd.eventViews, istructs.ViewKV{Key: recordVersionKeyBuilder(cmd, rec.QName(), id), Value: vb})
+		return nil
+	})
+}
+
+// storage ID of the new record is reserved by reserveIDs, the singleton has the predefined one
+func storageID(cmd *cmdWorkpiece, rec istructs.ICUDRow) (istructs.RecordID, error) {
+	id := rec.ID()
+	if !rec.IsNew() || !id.IsRaw() {
+		return id, nil
+	}
+	if storageID, ok := cmd.generatedIDs[id]; ok {
+		return storageID, nil
+	}
+	// new singleton is not stored yet, so NullRecord with the singleton ID is returned
+	singleton, err := cmd.appStructs.Records().GetSingleton(cmd.cmdMes.WSID(), rec.QName())
+	return singleton.ID(), err
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package commandprocessor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+)
+
+func TestRecordVersions(t *testing.T) {
+	require := require.New(t)
+
+	testQName := appdef.NewQName("test", "test")
+	singletonQName := appdef.NewQName("test", "singleton")
+	app := setUp(t, func(appDef appdef.IAppDefBuilder) {
+		_ = appDef.AddStruct(testQName, appdef.DefKind_CDoc).AddField("IntFld", appdef.DataKind_int32, false)
+		appDef.AddStruct(singletonQName, appdef.DefKind_CDoc).AddField("IntFld", appdef.DataKind_int32, false).SetSingleton()
+		ProvideRecordVersionsDef(appDef)
+	})
+	defer tearDown(app)
+
+	app.cfg.Resources.Add(istructsmem.NewCommandFunction(istructs.QNameCommandCUD, appdef.NullQName, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec))
+
+	send := func(body string) (statusCode int, respData map[string]interface{}) {
+		return sendRequest(t, app, 1, "c.sys.CUD", body, nil)
+	}
+
+	_, respData := send(`{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"test.test"}}]}`)
+	id := int64(respData["NewIDs"].(map[string]interface{})["1"].(float64))
+	update := func(version string, value int) (statusCode int, respData map[string]interface{}) {
+		return send(fmt.Sprintf(`{"cuds":[{"sys.ID":%d,%s"fields":{"IntFld":%d}}]}`, id, version, value))
+	}
+
+	t.Run("Should update the record of the expected version", func(t *testing.T) {
+		statusCode, respData := update(`"sys.Version":1,`, 42)
+		require.Equal(http.StatusOK, statusCode)
+		require.Equal(2, int(respData["CurrentWLogOffset"].(float64)))
+	})
+
+	t.Run("409 on stale update", func(t *testing.T) {
+		statusCode, respData := update(`"sys.Version":1,`, 43)
+		require.Equal(http.StatusConflict, statusCode)
+		sysErr := respData["sys.Error"].(map[string]interface{})
+		require.Equal("cuds[0]: record "+fmt.Sprint(id)+" is changed: version 1 expected, current version is 2", sysErr["Message"])
+		require.Equal("test.test", sysErr["QName"])
+		current := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(sysErr["Data"].(string)), &current))
+		require.Equal(float64(2), current[Field_CUD_Version])
+		require.Equal(float64(42), current["fields"].(map[string]interface{})["IntFld"])
+	})
+
+	t.Run("Should update without version check", func(t *testing.T) {
+		statusCode, respData := update("", 44)
+		require.Equal(http.StatusOK, statusCode)
+		require.Equal(3, int(respData["CurrentWLogOffset"].(float64)))
+		statusCode, _ = update(`"sys.Version":3,`, 45)
+		require.Equal(http.StatusOK, statusCode)
+	})
+
+	t.Run("Should store version of the new singleton", func(t *testing.T) {
+		statusCode, respData := send(`{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"test.singleton"}}]}`)
+		require.Equal(http.StatusOK, statusCode)
+		version := int(respData["CurrentWLogOffset"].(float64))
+
+		as, err := app.asp.AppStructs(istructs.AppQName_untill_airs_bp)
+		require.NoError(err)
+		singleton, err := as.Records().GetSingleton(1, singletonQName)
+		require.NoError(err)
+		statusCode, _ = send(fmt.Sprintf(`{"cuds":[{"sys.ID":%d,"sys.Version":%d,"fields":{"IntFld":1}}]}`, singleton.ID(), version))
+		require.Equal(http.StatusOK, statusCode)
+	})
+
+	t.Run("400 on wrong version", func(t *testing.T) {
+		statusCode, _ := update(`"sys.Version":"3",`, 46)
+		require.Equal(http.StatusBadRequest, statusCode)
+	})
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package commandprocessor
+
+import (
+	"context"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// puts the next values of the sequences to the fields of the records created by the request CUDs and by the command intents
+// which defaults are DEFAULT NEXTVAL('sequence'), fields which values are provided are not changed
+func (cmdProc *cmdProc) putSequenceDefaults(_ context.Context, work interface{}) (err error) {
+	cmd := work.(*cmdWorkpiece)
+	sequences := cmd.appStructs.Sequences()
+	for _, row := range cmd.cud.created {
+		cmd.AppDef().Def(row.qName).Fields(func(field appdef.IField) {
+			if err != nil {
+				return
+			}
+			seq := sequences.FieldSequence(row.qName, field.Name())
+			if seq == nil || row.fields[field.Name()] {
+				return
+			}
+			var val int64
+			if val, err = cmdProc.appPartition.idGen.NextVal(cmd.cmdMes.WSID(), seq.QName()); err != nil {
+				return
+			}
+			if seq.DataKind() == appdef.DataKind_int32 {
+				row.PutInt32(field.Name(), int32(val))
+			} else {
+				row.PutInt64(field.Name(), val)
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *createdRow) put(name string) { r.fields[name] = true }
+
+// istructs.IRowWriter.PutInt32
+func (r *createdRow) PutInt32(name string, value int32) {
+	r.put(name)
+	r.IRowWriter.PutInt32(name, value)
+}
+
+// istructs.IRowWriter.PutInt64
+func (r *createdRow) PutInt64(name string, value int64) {
+	r.put(name)
+	r.IRowWriter.PutInt64(name, value)
+}
+
+// istructs.IRowWriter.PutFloat32
+func (r *createdRow) PutFloat32(name string, value float32) {
+	r.put(name)
+	r.IRowWriter.PutFloat32(name, value)
+}
+
+// istructs.IRowWriter.PutFloat64
+func (r *createdRow) PutFloat64(name string, value float64) {
+	r.put(name)
+	r.IRowWriter.PutFloat64(name, value)
+}
+
+// istructs.IRowWriter.PutBytes
+func (r *createdRow) PutBytes(name string, value []byte) {
+	r.put(name)
+	r.IRowWriter.PutBytes(name, value)
+}
+
+// istructs.IRowWriter.PutString
+func (r *createdRow) PutString(name, value string) {
+	r.put(name)
+	r.IRowWriter.PutString(name, value)
+}
+
+// istructs.IRowWriter.PutQName
+func (r *createdRow) PutQName(name string, value appdef.QName) {
+	r.put(name)
+	r.IRowWriter.PutQName(name, value)
+}
+
+// istructs.IRowWriter.PutBool
+func (r *createdRow) PutBool(name string, value bool) {
+	r.put(name)
+	r.IRowWriter.PutBool(name, value)
+}
+
+// istructs.IRowWriter.PutRecordID
+func (r *createdRow) PutRecordID(name string, value istructs.RecordID) {
+	r.put(name)
+	r.IRowWriter.PutRecordID(name, value)
+}
+
+// istructs.IRowWriter.PutDecimal
+func (r *createdRow) PutDecimal(name string, value istructs.Decimal) {
+	r.put(name)
+	r.IRowWriter.PutDecimal(name, value)
+}
+
+// istructs.IRowWriter.PutTimestamp
+func (r *createdRow) PutTimestamp(name string, value time.Time) {
+	r.put(name)
+	r.IRowWriter.PutTimestamp(name, value)
+}
+
+// istructs.IRowWriter.PutDate
+func (r *createdRow) PutDate(name string, value time.Time) {
+	r.put(name)
+	r.IRowWriter.PutDate(name, value)
+}
+
+// istructs.IRowWriter.PutUUID
+func (r *createdRow) PutUUID(name string, value istructs.UUID) {
+	r.put(name)
+	r.IRowWriter.PutUUID(name, value)
+}
+
+// istructs.IRowWriter.PutNumber
+func (r *createdRow) PutNumber(name string, value float64) {
+	r.put(name)
+	r.IRowWriter.PutNumber(name, value)
+}
+
+// istructs.IRowWriter.PutChars
+func (r *createdRow) PutChars(name string, value string) {
+	r.put(name)
+	r.IRowWriter.PutChars(name, value)
+}
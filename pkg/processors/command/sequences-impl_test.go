@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package commandprocessor
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/idgen"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/state"
+)
+
+func TestSequenceDefaults(t *testing.T) {
+	require := require.New(t)
+
+	testNumbers := appdef.NewQName("test", "Numbers")
+	testCreateCmd := appdef.NewQName("test", "Create")
+	app := setUp(t, func(appDef appdef.IAppDefBuilder) {
+		idgen.ProvideViewDef(appDef)
+		appDef.AddStruct(testCDoc, appdef.DefKind_CDoc).AddField("Number", appdef.DataKind_int32, true)
+	}, func(cfg *istructsmem.AppConfigType) {
+		cfg.Resources.Add(istructsmem.NewCommandFunction(istructs.QNameCommandCUD, appdef.NullQName, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec))
+		cfg.Resources.Add(istructsmem.NewCommandFunction(testCreateCmd, appdef.NullQName, appdef.NullQName, appdef.NullQName,
+			func(_ istructs.ICommandFunction, args istructs.ExecCommandArgs) error {
+				kb, err := args.State.KeyBuilder(state.RecordsStorage, testCDoc)
+				if err != nil {
+					return err
+				}
+				vb, err := args.Intents.NewValue(kb)
+				if err != nil {
+					return err
+				}
+				vb.PutRecordID(appdef.SystemField_ID, 1)
+				return nil
+			}))
+		cfg.Sequences.Add(testNumbers, appdef.DataKind_int32, 1000, 1, 9999, 1)
+		cfg.Sequences.AddFieldDefault(testCDoc, "Number", testNumbers)
+	})
+	defer tearDown(app)
+
+	// create returns the ID and the Number of the new record
+	create := func(fields string) (istructs.RecordID, int32) {
+		statusCode, respData := sendRequest(t, app, 1, "c.sys.CUD", `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"test.TestCDoc"`+fields+`}}]}`, nil)
+		require.Equal(http.StatusOK, statusCode, respData)
+		id := istructs.RecordID(respData["NewIDs"].(map[string]interface{})["1"].(float64))
+
+		as, err := app.asp.AppStructs(istructs.AppQName_untill_airs_bp)
+		require.NoError(err)
+		rec, err := as.Records().Get(1, true, id)
+		require.NoError(err)
+		return id, rec.AsInt32("Number")
+	}
+
+	t.Run("Should put next value of the sequence to the field", func(t *testing.T) {
+		id, number := create("")
+		require.Equal(istructs.NewCDocCRecordID(istructs.FirstBaseRecordID), id)
+		require.EqualValues(1000, number)
+
+		_, number = create("")
+		require.EqualValues(1001, number)
+	})
+
+	t.Run("Should not change value provided by request", func(t *testing.T) {
+		_, number := create(`,"Number":42`)
+		require.EqualValues(42, number)
+	})
+
+	t.Run("Should put next value of the sequence to the field of the record created by the command", func(t *testing.T) {
+		statusCode, respData := sendRequest(t, app, 1, "c.test.Create", `{}`, nil)
+		require.Equal(http.StatusOK, statusCode, respData)
+		id := istructs.RecordID(respData["NewIDs"].(map[string]interface{})["1"].(float64))
+
+		as, err := app.asp.AppStructs(istructs.AppQName_untill_airs_bp)
+		require.NoError(err)
+		rec, err := as.Records().Get(1, true, id)
+		require.NoError(err)
+		require.EqualValues(1002, rec.AsInt32("Number"))
+	})
+
+	t.Run("Should not repeat values and IDs after restart", func(t *testing.T) {
+		restartCmdProc(&app)
+		id, number := create("")
+		require.Equal(istructs.NewCDocCRecordID(istructs.FirstBaseRecordID+istructs.RecordID(idgen.DefaultBlockSize)), id)
+		require.EqualValues(1000+idgen.DefaultBlockSize, number)
+	})
+}
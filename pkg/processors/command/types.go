@@ -26,6 +26,9 @@ type OperatorSyncActualizer pipeline.ISyncOperator
 type SyncActualizerFactory func(hvmCtx context.Context, partitionID istructs.PartitionID) pipeline.ISyncOperator
 type HVMName string
 
+// IdempotencyWindow is the time the response of the command with the idempotency key is replayed for. Zero disables replays
+type IdempotencyWindow time.Duration
+
 type ValidateFunc func(ctx context.Context, appStructs istructs.IAppStructs, cudRow istructs.ICUDRow, wsid istructs.WSID) (err error)
 
 type ICommandMessage interface {
@@ -38,6 +41,7 @@ type ICommandMessage interface {
 	Resource() istructs.IResource
 	Token() string
 	Host() string
+	IdempotencyKey() string // empty if the request has no IdempotencyKeyHeader
 }
 
 type xPath string
@@ -76,8 +80,45 @@ type cmdWorkpiece struct {
 	wsDesc              istructs.IRecord
 	checkWSDescUpdating bool
 	hostStateProvider   *hostStateProvider
+	replayedResponse    string
+	batch               []*batchCommand
+	eventViews          []istructs.ViewKV // written atomically with the PLog event
+	cud                 *commandCUD
+}
+
+// CUD builder of the raw event which remembers the command each CUD is made by, CUDs of the batch event are made by the batch commands
+type commandCUD struct {
+	istructs.ICUD
+	command appdef.QName                       // command the CUDs are made by now
+	creates []appdef.QName                     // commands of the created records in order of creation
+	created []*createdRow                      // created records in order of creation
+	updates map[istructs.RecordID]appdef.QName // commands of the updated records, the first one if the record is updated by several commands
+}
+
+// row writer of the created record which remembers the fields put to the record
+type createdRow struct {
+	istructs.IRowWriter
+	qName  appdef.QName
+	fields map[string]bool
 }
 
+// command of the c.sys.Batch request
+type batchCommand struct {
+	cmdFunc            istructs.ICommandFunction
+	requestData        coreutils.MapObject
+	argsObject         istructs.IObject
+	unloggedArgsObject istructs.IObject
+	rawIDs             []istructs.RecordID // raw IDs of the records created by the command CUDs
+	xPath              xPath
+}
+
+type cmdEvent struct {
+	istructs.IPLogEvent
+	login string
+}
+
+func (e cmdEvent) PrincipalLogin() string { return e.login }
+
 type parsedCUD struct {
 	opKind         iauthnz.OperationKindType
 	existingRecord istructs.IRecord // create -> nil
@@ -85,18 +126,23 @@ type parsedCUD struct {
 	qName          appdef.QName
 	fields         coreutils.MapObject
 	xPath          xPath
+	// expected version of the updated record, checked if hasVersion
+	version    istructs.Offset
+	hasVersion bool
+	command    appdef.QName // the batch command the CUD is requested for or the command of the request
 }
 
 type implICommandMessage struct {
-	body        []byte
-	appQName    istructs.AppQName // need to determine where to send c.sys.Init request on create a new workspace
-	wsid        istructs.WSID
-	sender      interface{}
-	partitionID istructs.PartitionID
-	requestCtx  context.Context
-	resource    istructs.IResource
-	token       string
-	host        string
+	body           []byte
+	appQName       istructs.AppQName // need to determine where to send c.sys.Init request on create a new workspace
+	wsid           istructs.WSID
+	sender         interface{}
+	partitionID    istructs.PartitionID
+	requestCtx     context.Context
+	resource       istructs.IResource
+	token          string
+	host           string
+	idempotencyKey string
 }
 
 type wrongArgsCatcher struct {
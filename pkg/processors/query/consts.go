@@ -7,12 +7,50 @@ package queryprocessor
 import "github.com/voedger/voedger/pkg/appdef"
 
 const (
-	filterKind_Eq    = "eq"
-	filterKind_NotEq = "notEq"
-	filterKind_Gt    = "gt"
-	filterKind_Lt    = "lt"
-	filterKind_And   = "and"
-	filterKind_Or    = "or"
+	filterKind_Eq         = "eq"
+	filterKind_NotEq      = "notEq"
+	filterKind_Gt         = "gt"
+	filterKind_Lt         = "lt"
+	filterKind_And        = "and"
+	filterKind_Or         = "or"
+	filterKind_In         = "in"
+	filterKind_Between    = "between"
+	filterKind_StartsWith = "startsWith"
+	filterKind_Contains   = "contains"
+	filterKind_IsNull     = "isNull"
+	filterKind_Not        = "not"
+)
+
+// Count of rows which the order operator keeps in memory, sorted rows are spilled to temp files then
+const maxOrderRowsInMemory = 10000
+
+const spillFilePattern = "voedger-qp-order-*"
+
+// q.sys.ReadView query function to read any view, see ProvideReadViewQuery
+var (
+	QNameQueryReadView       = appdef.NewQName(appdef.SysPackage, "ReadView")
+	QNameQueryReadViewParams = appdef.NewQName(appdef.SysPackage, "ReadViewParams")
+)
+
+// q.sys.ReadView query function parameters.
+// Key and range values of decimal fields are JSON numbers or strings, bytes are base64 strings,
+// timestamps, dates and UUIDs are strings in istructs.TimestampLayout, istructs.DateLayout and canonical UUID formats
+const (
+	Field_ReadView_View  = "View"  // qualified name of the view
+	Field_ReadView_Key   = "Key"   // JSON object with values of all partition key fields and of the clustering columns prefix
+	Field_ReadView_Range = "Range" // JSON object with {"from": value, "till": value} inclusive ranges of the clustering columns
+	Field_ReadView_Limit = "Limit" // max count of rows, zero means no limit
+)
+
+// Type of the response section which contains the continuation token
+const cursorSection = "cursor"
+
+const (
+	aggregateFunc_Sum   = "sum"
+	aggregateFunc_Count = "count"
+	aggregateFunc_Min   = "min"
+	aggregateFunc_Max   = "max"
+	aggregateFunc_Avg   = "avg"
 )
 
 const (
@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type cursor struct {
+	OrderBy []cursorOrderBy `json:"orderBy,omitempty"`
+	KeyData []interface{}   `json:"key,omitempty"`
+	SkipNum int64           `json:"skip,omitempty"`
+}
+
+type cursorOrderBy struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc,omitempty"`
+}
+
+func (c cursor) Fields() []string {
+	fields := make([]string, len(c.OrderBy))
+	for i, o := range c.OrderBy {
+		fields[i] = o.Field
+	}
+	return fields
+}
+func (c cursor) Key() []interface{} { return c.KeyData }
+func (c cursor) Skip() int64        { return c.SkipNum }
+
+// NewCursor decodes the continuation token
+func NewCursor(token string) (ICursor, error) {
+	bb, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("cursor: %v: %w", err, ErrWrongType)
+	}
+	c := cursor{}
+	decoder := json.NewDecoder(bytes.NewReader(bb))
+	decoder.UseNumber()
+	if err = decoder.Decode(&c); err != nil {
+		return nil, fmt.Errorf("cursor: %v: %w", err, ErrWrongType)
+	}
+	if len(c.KeyData) != len(c.OrderBy) || c.SkipNum < 0 {
+		return nil, fmt.Errorf("cursor: is malformed: %w", ErrWrongType)
+	}
+	return c, nil
+}
+
+// encodeCursor returns the continuation token of the last sent row key
+func encodeCursor(orderBys []IOrderBy, key []interface{}, skip int64) (string, error) {
+	c := cursor{
+		KeyData: key,
+		SkipNum: skip,
+	}
+	for _, o := range orderBys {
+		c.OrderBy = append(c.OrderBy, cursorOrderBy{Field: o.Field(), Desc: o.IsDesc()})
+	}
+	bb, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bb), nil
+}
+
+// validateCursor checks that cursor is issued for the same orderBy
+func validateCursor(c ICursor, orderBys []IOrderBy) error {
+	cursorOrderBys := c.(cursor).OrderBy
+	if len(cursorOrderBys) != len(orderBys) {
+		return fmt.Errorf("cursor is issued for another orderBy: %w", ErrUnexpected)
+	}
+	for i, o := range orderBys {
+		if cursorOrderBys[i].Field != o.Field() || cursorOrderBys[i].Desc != o.IsDesc() {
+			return fmt.Errorf("cursor is issued for another orderBy: %w", ErrUnexpected)
+		}
+	}
+	return nil
+}
+
+// rowKey returns values of orderBy fields of the row
+func rowKey(row IOutputRow, orderBys []IOrderBy) []interface{} {
+	rootRow := row.Value(rootDocument).([]IOutputRow)[0]
+	key := make([]interface{}, len(orderBys))
+	for i, o := range orderBys {
+		key[i] = rootRow.Value(o.Field())
+	}
+	return key
+}
+
+// compareKey compares the row key with the cursor key according to orderBy:
+// negative result means that the row precedes the cursor
+func compareKey(key, cursorKey []interface{}, orderBys []IOrderBy) (res int, err error) {
+	for i, o := range orderBys {
+		if res, err = compareValue(key[i], cursorKey[i]); err != nil {
+			return 0, fmt.Errorf("cursor: field '%s': %w", o.Field(), err)
+		}
+		if o.IsDesc() {
+			res = -res
+		}
+		if res != 0 {
+			return res, nil
+		}
+	}
+	return 0, nil
+}
+
+func compareValue(value, cursorValue interface{}) (int, error) {
+	switch v := value.(type) {
+	case string:
+		s, ok := cursorValue.(string)
+		if !ok {
+			return 0, ErrWrongType
+		}
+		return strings.Compare(v, s), nil
+	case int32, int64:
+		n, ok := cursorValue.(json.Number)
+		if !ok {
+			return 0, ErrWrongType
+		}
+		c, err := n.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("%v: %w", err, ErrWrongType)
+		}
+		i := int64(0)
+		if v32, ok := v.(int32); ok {
+			i = int64(v32)
+		} else {
+			i = v.(int64)
+		}
+		return compareOrdered(i, c), nil
+	case float32, float64:
+		n, ok := cursorValue.(json.Number)
+		if !ok {
+			return 0, ErrWrongType
+		}
+		c, err := n.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("%v: %w", err, ErrWrongType)
+		}
+		if v32, ok := v.(float32); ok {
+			// compare in float32 precision since cursor contains float32 value converted to float64
+			return compareOrdered(v32, float32(c)), nil
+		}
+		return compareOrdered(v.(float64), c), nil
+	default:
+		return 0, ErrWrongType
+	}
+}
+
+func compareOrdered[T int64 | float32 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
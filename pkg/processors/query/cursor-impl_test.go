@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	orderBys := []IOrderBy{orderBy{field: "id"}, orderBy{field: "weight", desc: true}, orderBy{field: "name"}}
+	t.Run("Should encode and decode cursor", func(t *testing.T) {
+		require := require.New(t)
+
+		token, err := encodeCursor(orderBys, []interface{}{int64(math.MaxInt64), float32(1.1), "Cola"}, 2)
+		require.NoError(err)
+		cursor, err := NewCursor(token)
+		require.NoError(err)
+
+		require.Equal([]interface{}{json.Number("9223372036854775807"), json.Number("1.1"), "Cola"}, cursor.Key())
+		require.Equal([]string{"id", "weight", "name"}, cursor.Fields())
+		require.Equal(int64(2), cursor.Skip())
+		require.NoError(validateCursor(cursor, orderBys))
+		require.ErrorIs(validateCursor(cursor, orderBys[:1]), ErrUnexpected)
+		require.ErrorIs(validateCursor(cursor, []IOrderBy{orderBy{field: "id"}, orderBy{field: "weight"}, orderBy{field: "name"}}), ErrUnexpected)
+	})
+	t.Run("Should compare key with cursor key", func(t *testing.T) {
+		require := require.New(t)
+		token, err := encodeCursor(orderBys, []interface{}{int64(math.MaxInt64), float32(1.1), "Cola"}, 0)
+		require.NoError(err)
+		cursor, err := NewCursor(token)
+		require.NoError(err)
+		compare := func(key ...interface{}) int {
+			res, err := compareKey(key, cursor.Key(), orderBys)
+			require.NoError(err)
+			return res
+		}
+
+		require.Equal(0, compare(int64(math.MaxInt64), float32(1.1), "Cola"))
+		require.Equal(-1, compare(int64(math.MaxInt64-1), float32(1.1), "Cola"))
+		require.Equal(-1, compare(int64(math.MaxInt64), float32(1.2), "Cola"))
+		require.Equal(1, compare(int64(math.MaxInt64), float32(1.0), "Cola"))
+		require.Equal(1, compare(int64(math.MaxInt64), float32(1.1), "Pepsi"))
+	})
+	t.Run("Should return error on malformed token", func(t *testing.T) {
+		for _, token := range []string{"#", "bm90IGpzb24", "eyJrZXkiOlsxXX0"} {
+			_, err := NewCursor(token)
+			require.ErrorIs(t, err, ErrWrongType, token)
+		}
+	})
+	t.Run("Should provide decoded cursor to query functions by workpiece", func(t *testing.T) {
+		require := require.New(t)
+		token, err := encodeCursor(orderBys, []interface{}{int64(1), float32(1.5), "Cola"}, 1)
+		require.NoError(err)
+		cursor, err := NewCursor(token)
+		require.NoError(err)
+		var work interface{} = &queryWork{queryParams: queryParams{orderBy: orderBys, cursor: cursor}}
+
+		qw, ok := work.(IQueryWorkpiece)
+
+		require.True(ok)
+		require.Equal([]string{"id", "weight", "name"}, qw.GetCursor().Fields())
+		require.Equal([]interface{}{json.Number("1"), json.Number("1.5"), "Cola"}, qw.GetCursor().Key())
+		require.Equal(int64(1), qw.GetCursor().Skip())
+	})
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"fmt"
+
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+func NewAggregate(data coreutils.MapObject) (IAggregate, error) {
+	a := aggregate{}
+	groupBy, _, err := data.AsObjects("groupBy")
+	if err != nil {
+		return nil, fmt.Errorf("aggregate: %w", err)
+	}
+	for _, fieldIntf := range groupBy {
+		field, ok := fieldIntf.(string)
+		if !ok {
+			return nil, fmt.Errorf("aggregate: groupBy: each member must be a string: %w", ErrWrongType)
+		}
+		a.groupBy = append(a.groupBy, field)
+	}
+	functions, _, err := data.AsObjects("functions")
+	if err != nil {
+		return nil, fmt.Errorf("aggregate: %w", err)
+	}
+	for _, functionIntf := range functions {
+		functionData, ok := functionIntf.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("aggregate: functions: each member must be an object: %w", ErrWrongType)
+		}
+		function, err := newAggregateFunction(functionData)
+		if err != nil {
+			return nil, fmt.Errorf("aggregate: functions: %w", err)
+		}
+		a.functions = append(a.functions, function)
+	}
+	if len(a.groupBy) == 0 && len(a.functions) == 0 {
+		return nil, fmt.Errorf("aggregate: groupBy or functions must be present: %w", ErrNotFound)
+	}
+	return a, nil
+}
+
+func newAggregateFunction(data coreutils.MapObject) (IAggregateFunction, error) {
+	fn, err := data.AsStringRequired("func")
+	if err != nil {
+		return nil, err
+	}
+	field, _, err := data.AsString("field")
+	if err != nil {
+		return nil, err
+	}
+	switch fn {
+	case aggregateFunc_Count:
+	case aggregateFunc_Sum, aggregateFunc_Min, aggregateFunc_Max, aggregateFunc_Avg:
+		if field == "" {
+			return nil, fmt.Errorf("'%s' function: field 'field' must be present: %w", fn, ErrNotFound)
+		}
+	default:
+		return nil, fmt.Errorf("function '%s' is unknown: %w", fn, ErrWrongType)
+	}
+	alias, ok, err := data.AsString("alias")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		alias = fn
+		if field != "" {
+			alias = fn + "_" + field
+		}
+	}
+	return aggregateFunction{
+		fn:    fn,
+		field: field,
+		alias: alias,
+	}, nil
+}
+
+type aggregate struct {
+	groupBy   []string
+	functions []IAggregateFunction
+}
+
+func (a aggregate) GroupBy() []string               { return a.groupBy }
+func (a aggregate) Functions() []IAggregateFunction { return a.functions }
+
+type aggregateFunction struct {
+	fn    string
+	field string
+	alias string
+}
+
+func (f aggregateFunction) Func() string  { return f.fn }
+func (f aggregateFunction) Field() string { return f.field }
+func (f aggregateFunction) Alias() string { return f.alias }
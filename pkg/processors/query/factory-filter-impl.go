@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/voedger/voedger/pkg/appdef"
 	coreutils "github.com/voedger/voedger/pkg/utils"
 )
 
@@ -33,6 +34,18 @@ func NewFilter(data coreutils.MapObject) (IFilter, error) {
 		return newAndFilter(args)
 	case filterKind_Or:
 		return newOrFilter(args)
+	case filterKind_In:
+		return newInFilter(args)
+	case filterKind_Between:
+		return newBetweenFilter(args)
+	case filterKind_StartsWith:
+		return newStartsWithFilter(args)
+	case filterKind_Contains:
+		return newContainsFilter(args)
+	case filterKind_IsNull:
+		return newIsNullFilter(args)
+	case filterKind_Not:
+		return newNotFilter(args)
 	default:
 		return nil, fmt.Errorf("filter: expr: filter '%s' is unknown: %w", expr, ErrWrongType)
 	}
@@ -132,6 +145,116 @@ func newOrFilter(args interface{}) (IFilter, error) {
 	return orFilter, nil
 }
 
+func newInFilter(args interface{}) (IFilter, error) {
+	data, ok := args.(map[string]interface{})
+	if !ok {
+		return nil, filterErr(filterKind_In, fmt.Errorf("field 'args' must be an object: %w", ErrWrongType))
+	}
+	field, err := coreutils.MapObject(data).AsStringRequired("field")
+	if err != nil {
+		return nil, filterErr(filterKind_In, err)
+	}
+	values, ok, err := coreutils.MapObject(data).AsObjects("values")
+	if err != nil {
+		return nil, filterErr(filterKind_In, err)
+	}
+	if !ok || len(values) == 0 {
+		return nil, filterErr(filterKind_In, fmt.Errorf("field 'values' must be a non-empty array: %w", ErrNotFound))
+	}
+	for _, value := range values {
+		if err = scalarArg("values", value); err != nil {
+			return nil, filterErr(filterKind_In, err)
+		}
+	}
+	epsilon, err := epsilon(args)
+	if err != nil {
+		return nil, filterErr(filterKind_In, err)
+	}
+	return &InFilter{
+		field:   field,
+		values:  values,
+		epsilon: epsilon,
+	}, nil
+}
+
+func newBetweenFilter(args interface{}) (IFilter, error) {
+	data, ok := args.(map[string]interface{})
+	if !ok {
+		return nil, filterErr(filterKind_Between, fmt.Errorf("field 'args' must be an object: %w", ErrWrongType))
+	}
+	field, err := coreutils.MapObject(data).AsStringRequired("field")
+	if err != nil {
+		return nil, filterErr(filterKind_Between, err)
+	}
+	bounds := make([]interface{}, 0, 2)
+	for _, name := range []string{"from", "till"} {
+		bound, ok := data[name]
+		if !ok {
+			return nil, filterErr(filterKind_Between, fmt.Errorf("field '%s' must be present: %w", name, ErrNotFound))
+		}
+		if _, isBool := bound.(bool); isBool {
+			return nil, filterErr(filterKind_Between, fmt.Errorf("field '%s' must be a number or a string: %w", name, ErrWrongType))
+		}
+		if err = scalarArg(name, bound); err != nil {
+			return nil, filterErr(filterKind_Between, err)
+		}
+		bounds = append(bounds, bound)
+	}
+	return &BetweenFilter{
+		field: field,
+		from:  bounds[0],
+		till:  bounds[1],
+	}, nil
+}
+
+func newStartsWithFilter(args interface{}) (IFilter, error) {
+	field, value, caseInsensitive, err := stringArgs(args)
+	if err != nil {
+		return nil, filterErr(filterKind_StartsWith, err)
+	}
+	return &StartsWithFilter{
+		field:           field,
+		value:           value,
+		caseInsensitive: caseInsensitive,
+	}, nil
+}
+
+func newContainsFilter(args interface{}) (IFilter, error) {
+	field, value, caseInsensitive, err := stringArgs(args)
+	if err != nil {
+		return nil, filterErr(filterKind_Contains, err)
+	}
+	return &ContainsFilter{
+		field:           field,
+		value:           value,
+		caseInsensitive: caseInsensitive,
+	}, nil
+}
+
+func newIsNullFilter(args interface{}) (IFilter, error) {
+	data, ok := args.(map[string]interface{})
+	if !ok {
+		return nil, filterErr(filterKind_IsNull, fmt.Errorf("field 'args' must be an object: %w", ErrWrongType))
+	}
+	field, err := coreutils.MapObject(data).AsStringRequired("field")
+	if err != nil {
+		return nil, filterErr(filterKind_IsNull, err)
+	}
+	return &IsNullFilter{field: field}, nil
+}
+
+func newNotFilter(args interface{}) (IFilter, error) {
+	operand, ok := args.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("'%s' filter: field 'args' must be an object: %w", filterKind_Not, ErrWrongType)
+	}
+	filter, err := NewFilter(operand)
+	if err != nil {
+		return nil, filterErr(filterKind_Not, err)
+	}
+	return &NotFilter{filter: filter}, nil
+}
+
 func filterErr(filterKind string, err error) error {
 	return fmt.Errorf("'%s' filter: %w", filterKind, err)
 }
@@ -153,6 +276,58 @@ func generalArgs(args interface{}) (string, interface{}, error) {
 	return field, value, nil
 }
 
+// scalarArg checks that JSON value is a number, a string or a boolean
+func scalarArg(name string, value interface{}) error {
+	switch value.(type) {
+	case float64, string, bool:
+		return nil
+	default:
+		return fmt.Errorf("field '%s' must contain numbers, strings or booleans: %w", name, ErrWrongType)
+	}
+}
+
+// filterArgOfKind checks the JSON type of the filter argument value suits the data kind of the filtered field.
+// Values for the other data kinds are not checked, the filter fails on such fields itself
+func filterArgOfKind(kind appdef.DataKind, name string, value interface{}) error {
+	ok := true
+	expected := ""
+	switch kind {
+	case appdef.DataKind_int32, appdef.DataKind_int64, appdef.DataKind_float32, appdef.DataKind_float64, appdef.DataKind_RecordID:
+		_, ok = value.(float64)
+		expected = "numbers"
+	case appdef.DataKind_string:
+		_, ok = value.(string)
+		expected = "strings"
+	case appdef.DataKind_bool:
+		_, ok = value.(bool)
+		expected = "booleans"
+	}
+	if !ok {
+		return fmt.Errorf("field '%s' must contain %s for %s field: %w", name, expected, kind, ErrWrongType)
+	}
+	return nil
+}
+
+func stringArgs(args interface{}) (field, value string, caseInsensitive bool, err error) {
+	data, ok := args.(map[string]interface{})
+	if !ok {
+		return "", "", false, fmt.Errorf("field 'args' must be an object: %w", ErrWrongType)
+	}
+	mapObject := coreutils.MapObject(data)
+	if field, err = mapObject.AsStringRequired("field"); err != nil {
+		return
+	}
+	if value, err = mapObject.AsStringRequired("value"); err != nil {
+		return
+	}
+	options, _, err := mapObject.AsObject("options")
+	if err != nil {
+		return
+	}
+	caseInsensitive, _, err = options.AsBoolean("caseInsensitive")
+	return
+}
+
 func epsilon(args interface{}) (float64, error) {
 	data := args.(map[string]interface{}) // type is already checked by generalArgs()
 	mapObject := coreutils.MapObject(data)
@@ -0,0 +1,41 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"fmt"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+// BetweenFilter matches values in the range including both bounds
+type BetweenFilter struct {
+	field string
+	from  interface{}
+	till  interface{}
+}
+
+func (f BetweenFilter) IsMatch(fd coreutils.FieldsDef, outputRow IOutputRow) (bool, error) {
+	switch fd[f.field] {
+	case appdef.DataKind_int32, appdef.DataKind_int64, appdef.DataKind_float32, appdef.DataKind_float64, appdef.DataKind_string:
+		if err := filterArgOfKind(fd[f.field], "from", f.from); err != nil {
+			return false, filterErr(filterKind_Between, err)
+		}
+		if err := filterArgOfKind(fd[f.field], "till", f.till); err != nil {
+			return false, filterErr(filterKind_Between, err)
+		}
+		less, err := LessFilter{field: f.field, value: f.from}.IsMatch(fd, outputRow)
+		if err != nil || less {
+			return false, err
+		}
+		greater, err := GreaterFilter{field: f.field, value: f.till}.IsMatch(fd, outputRow)
+		return !greater, err
+	case appdef.DataKind_null:
+		return false, nil
+	default:
+		return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_Between, f.field, ErrWrongType)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+func TestBetweenFilter_IsMatch(t *testing.T) {
+	match := func(match bool, err error) bool {
+		require.NoError(t, err)
+		return match
+	}
+	t.Run("Compare int64", func(t *testing.T) {
+		require := require.New(t)
+		row := func(age int64) IOutputRow {
+			r := &testOutputRow{fields: []string{"age"}}
+			r.Set("age", age)
+			return r
+		}
+		fd := coreutils.FieldsDef{"age": appdef.DataKind_int64}
+		filter := BetweenFilter{field: "age", from: float64(18), till: float64(65)}
+
+		require.True(match(filter.IsMatch(fd, row(18))))
+		require.True(match(filter.IsMatch(fd, row(42))))
+		require.True(match(filter.IsMatch(fd, row(65))))
+		require.False(match(filter.IsMatch(fd, row(17))))
+		require.False(match(filter.IsMatch(fd, row(66))))
+	})
+	t.Run("Compare float32", func(t *testing.T) {
+		require := require.New(t)
+		row := func(weight float32) IOutputRow {
+			r := &testOutputRow{fields: []string{"weight"}}
+			r.Set("weight", weight)
+			return r
+		}
+		fd := coreutils.FieldsDef{"weight": appdef.DataKind_float32}
+		filter := BetweenFilter{field: "weight", from: 1.5, till: 2.5}
+
+		require.True(match(filter.IsMatch(fd, row(2.0))))
+		require.False(match(filter.IsMatch(fd, row(1.0))))
+		require.False(match(filter.IsMatch(fd, row(3.0))))
+	})
+	t.Run("Compare string", func(t *testing.T) {
+		require := require.New(t)
+		row := func(name string) IOutputRow {
+			r := &testOutputRow{fields: []string{"name"}}
+			r.Set("name", name)
+			return r
+		}
+		fd := coreutils.FieldsDef{"name": appdef.DataKind_string}
+		filter := BetweenFilter{field: "name", from: "b", till: "d"}
+
+		require.True(match(filter.IsMatch(fd, row("b"))))
+		require.True(match(filter.IsMatch(fd, row("cola"))))
+		require.False(match(filter.IsMatch(fd, row("apple"))))
+		require.False(match(filter.IsMatch(fd, row("dog"))))
+	})
+	t.Run("Null", func(t *testing.T) {
+		require.False(t, match(BetweenFilter{field: "null"}.IsMatch(coreutils.FieldsDef{"null": appdef.DataKind_null}, nil)))
+	})
+	t.Run("Should return error on wrong field data kind", func(t *testing.T) {
+		_, err := BetweenFilter{field: "flag"}.IsMatch(coreutils.FieldsDef{"flag": appdef.DataKind_bool}, nil)
+		require.ErrorIs(t, err, ErrWrongType)
+	})
+	t.Run("Should return error on bound type mismatched the field data kind", func(t *testing.T) {
+		require := require.New(t)
+		fd := coreutils.FieldsDef{"age": appdef.DataKind_int32, "name": appdef.DataKind_string}
+		_, err := BetweenFilter{field: "age", from: "1", till: float64(2)}.IsMatch(fd, nil)
+		require.ErrorIs(err, ErrWrongType)
+		_, err = BetweenFilter{field: "age", from: float64(1), till: nil}.IsMatch(fd, nil)
+		require.ErrorIs(err, ErrWrongType)
+		_, err = BetweenFilter{field: "name", from: "a", till: float64(2)}.IsMatch(fd, nil)
+		require.ErrorIs(err, ErrWrongType)
+	})
+}
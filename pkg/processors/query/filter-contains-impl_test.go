@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+func TestContainsFilter_IsMatch(t *testing.T) {
+	match := func(match bool, err error) bool {
+		require.NoError(t, err)
+		return match
+	}
+	row := func(name string) IOutputRow {
+		r := &testOutputRow{fields: []string{"name"}}
+		r.Set("name", name)
+		return r
+	}
+	fd := coreutils.FieldsDef{"name": appdef.DataKind_string}
+	t.Run("Should match", func(t *testing.T) {
+		require := require.New(t)
+		require.True(match(ContainsFilter{field: "name", value: "a-C"}.IsMatch(fd, row("Coca-Cola"))))
+		require.True(match(ContainsFilter{field: "name", value: "A-c", caseInsensitive: true}.IsMatch(fd, row("Coca-Cola"))))
+	})
+	t.Run("Should not match", func(t *testing.T) {
+		require := require.New(t)
+		require.False(match(ContainsFilter{field: "name", value: "A-c"}.IsMatch(fd, row("Coca-Cola"))))
+		require.False(match(ContainsFilter{field: "name", value: "Pepsi", caseInsensitive: true}.IsMatch(fd, row("Coca-Cola"))))
+	})
+	t.Run("Should return error on wrong field data kind", func(t *testing.T) {
+		_, err := ContainsFilter{field: "age", value: "4"}.IsMatch(coreutils.FieldsDef{"age": appdef.DataKind_int32}, nil)
+		require.ErrorIs(t, err, ErrWrongType)
+	})
+}
@@ -0,0 +1,29 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import coreutils "github.com/voedger/voedger/pkg/utils"
+
+type InFilter struct {
+	field   string
+	values  []interface{}
+	epsilon float64
+}
+
+func (f InFilter) IsMatch(fd coreutils.FieldsDef, outputRow IOutputRow) (bool, error) {
+	for _, value := range f.values {
+		if err := filterArgOfKind(fd[f.field], "values", value); err != nil {
+			return false, filterErr(filterKind_In, err)
+		}
+		match, err := EqualsFilter{field: f.field, value: value, epsilon: f.epsilon}.IsMatch(fd, outputRow)
+		if err != nil {
+			return false, filterErr(filterKind_In, err)
+		}
+		if match {
+			return true, nil
+		}
+	}
+	return false, nil
+}
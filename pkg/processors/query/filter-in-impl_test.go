@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+func TestInFilter_IsMatch(t *testing.T) {
+	match := func(match bool, err error) bool {
+		require.NoError(t, err)
+		return match
+	}
+	row := func(name string, age int32, weight float64, id istructs.RecordID) IOutputRow {
+		r := &testOutputRow{fields: []string{"name", "age", "weight", "id"}}
+		r.Set("name", name)
+		r.Set("age", age)
+		r.Set("weight", weight)
+		r.Set("id", id)
+		return r
+	}
+	fd := coreutils.FieldsDef{
+		"name":   appdef.DataKind_string,
+		"age":    appdef.DataKind_int32,
+		"weight": appdef.DataKind_float64,
+		"id":     appdef.DataKind_RecordID,
+		"null":   appdef.DataKind_null,
+	}
+	t.Run("Should match", func(t *testing.T) {
+		require := require.New(t)
+		require.True(match(InFilter{field: "name", values: []interface{}{"Cola", "Pepsi"}}.IsMatch(fd, row("Pepsi", 42, 1.5, 7))))
+		require.True(match(InFilter{field: "age", values: []interface{}{float64(41), float64(42)}}.IsMatch(fd, row("Pepsi", 42, 1.5, 7))))
+		require.True(match(InFilter{field: "weight", values: []interface{}{1.5000001}, epsilon: 0.00001}.IsMatch(fd, row("Pepsi", 42, 1.5, 7))))
+		require.True(match(InFilter{field: "id", values: []interface{}{float64(7)}}.IsMatch(fd, row("Pepsi", 42, 1.5, 7))))
+	})
+	t.Run("Should not match", func(t *testing.T) {
+		require := require.New(t)
+		require.False(match(InFilter{field: "name", values: []interface{}{"Cola", "Sprite"}}.IsMatch(fd, row("Pepsi", 42, 1.5, 7))))
+		require.False(match(InFilter{field: "age", values: []interface{}{float64(43)}}.IsMatch(fd, row("Pepsi", 42, 1.5, 7))))
+		require.False(match(InFilter{field: "null", values: []interface{}{float64(43)}}.IsMatch(fd, row("Pepsi", 42, 1.5, 7))))
+	})
+	t.Run("Should return error on wrong field data kind", func(t *testing.T) {
+		_, err := InFilter{field: "bytes", values: []interface{}{"a"}}.IsMatch(coreutils.FieldsDef{"bytes": appdef.DataKind_bytes}, nil)
+		require.ErrorIs(t, err, ErrWrongType)
+	})
+	t.Run("Should return error on value type mismatched the field data kind", func(t *testing.T) {
+		require := require.New(t)
+		for field, value := range map[string]interface{}{"name": float64(1), "age": "x", "weight": true, "id": "7"} {
+			_, err := InFilter{field: field, values: []interface{}{value}}.IsMatch(fd, row("Pepsi", 42, 1.5, 7))
+			require.ErrorIs(err, ErrWrongType, field)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+// IsNullFilter matches empty values: fields that are not set are read as zero values,
+// so 0, false, "" and other zero values of the field kind count as null too
+type IsNullFilter struct {
+	field string
+}
+
+func (f IsNullFilter) IsMatch(fd coreutils.FieldsDef, outputRow IOutputRow) (bool, error) {
+	value := outputRow.Value(f.field)
+	if value == nil {
+		return true, nil
+	}
+	switch fd[f.field] {
+	case appdef.DataKind_int32:
+		return value.(int32) == 0, nil
+	case appdef.DataKind_int64:
+		return value.(int64) == 0, nil
+	case appdef.DataKind_float32:
+		return value.(float32) == 0, nil
+	case appdef.DataKind_float64:
+		return value.(float64) == 0, nil
+	case appdef.DataKind_string:
+		return value.(string) == "", nil
+	case appdef.DataKind_bytes:
+		return len(value.([]byte)) == 0, nil
+	case appdef.DataKind_bool:
+		return !value.(bool), nil
+	case appdef.DataKind_RecordID:
+		return value.(istructs.RecordID) == istructs.NullRecordID, nil
+	case appdef.DataKind_QName:
+		return value == appdef.NullQName.String(), nil
+	case appdef.DataKind_decimal:
+		return value.(istructs.Decimal).Value() == 0, nil
+	case appdef.DataKind_timestamp:
+		return value.(time.Time).IsZero(), nil
+	case appdef.DataKind_date:
+		return value == time.Time{}.Format(istructs.DateLayout), nil
+	case appdef.DataKind_UUID:
+		return value.(istructs.UUID) == istructs.NullUUID, nil
+	case appdef.DataKind_null:
+		return true, nil
+	default:
+		return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_IsNull, f.field, ErrWrongType)
+	}
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+func TestIsNullFilter_IsMatch(t *testing.T) {
+	match := func(match bool, err error) bool {
+		require.NoError(t, err)
+		return match
+	}
+	fd := coreutils.FieldsDef{
+		"name":   appdef.DataKind_string,
+		"age":    appdef.DataKind_int32,
+		"weight": appdef.DataKind_float64,
+		"id":     appdef.DataKind_RecordID,
+		"active": appdef.DataKind_bool,
+		"price":  appdef.DataKind_decimal,
+		"sold":   appdef.DataKind_timestamp,
+		"day":    appdef.DataKind_date,
+		"uid":    appdef.DataKind_UUID,
+	}
+	row := func(name string, age int32, weight float64, id istructs.RecordID, active bool,
+		price istructs.Decimal, sold time.Time, day time.Time, uid istructs.UUID) IOutputRow {
+		r := &testOutputRow{fields: []string{"name", "age", "weight", "id", "active", "price", "sold", "day", "uid"}}
+		r.Set("name", name)
+		r.Set("age", age)
+		r.Set("weight", weight)
+		r.Set("id", id)
+		r.Set("active", active)
+		r.Set("price", price)
+		r.Set("sold", sold)
+		r.Set("day", day.Format(istructs.DateLayout))
+		r.Set("uid", uid)
+		return r
+	}
+	t.Run("Should match empty values", func(t *testing.T) {
+		require := require.New(t)
+		empty := row("", 0, 0, istructs.NullRecordID, false, istructs.NewDecimal(0, 2), time.Time{}, time.Time{}, istructs.NullUUID)
+		for field := range fd {
+			require.True(match(IsNullFilter{field: field}.IsMatch(fd, empty)), field)
+		}
+	})
+	t.Run("Should not match non-empty values", func(t *testing.T) {
+		require := require.New(t)
+		filled := row("Cola", 42, 1.5, 7, true, istructs.NewDecimal(150, 2), time.UnixMilli(0).UTC(), time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC), istructs.NewUUID())
+		for field := range fd {
+			require.False(match(IsNullFilter{field: field}.IsMatch(fd, filled)), field)
+		}
+	})
+}
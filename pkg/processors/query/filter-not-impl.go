@@ -0,0 +1,16 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import coreutils "github.com/voedger/voedger/pkg/utils"
+
+type NotFilter struct {
+	filter IFilter
+}
+
+func (f NotFilter) IsMatch(fd coreutils.FieldsDef, outputRow IOutputRow) (bool, error) {
+	match, err := f.filter.IsMatch(fd, outputRow)
+	return !match && err == nil, err
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+func TestNotFilter_IsMatch(t *testing.T) {
+	row := &testOutputRow{fields: []string{"name"}}
+	row.Set("name", "Cola")
+	fd := coreutils.FieldsDef{"name": appdef.DataKind_string}
+	t.Run("Should negate match", func(t *testing.T) {
+		require := require.New(t)
+
+		match, err := NotFilter{filter: &EqualsFilter{field: "name", value: "Pepsi"}}.IsMatch(fd, row)
+		require.NoError(err)
+		require.True(match)
+
+		match, err = NotFilter{filter: &EqualsFilter{field: "name", value: "Cola"}}.IsMatch(fd, row)
+		require.NoError(err)
+		require.False(match)
+	})
+	t.Run("Should not match on error", func(t *testing.T) {
+		match, err := NotFilter{filter: &StartsWithFilter{field: "age"}}.IsMatch(coreutils.FieldsDef{"age": appdef.DataKind_int32}, row)
+		require.ErrorIs(t, err, ErrWrongType)
+		require.False(t, match)
+	})
+}
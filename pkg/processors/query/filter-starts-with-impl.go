@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+type StartsWithFilter struct {
+	field           string
+	value           string
+	caseInsensitive bool
+}
+
+func (f StartsWithFilter) IsMatch(fd coreutils.FieldsDef, outputRow IOutputRow) (bool, error) {
+	switch fd[f.field] {
+	case appdef.DataKind_string:
+		value := outputRow.Value(f.field).(string)
+		if f.caseInsensitive {
+			return strings.HasPrefix(strings.ToLower(value), strings.ToLower(f.value)), nil
+		}
+		return strings.HasPrefix(value, f.value), nil
+	case appdef.DataKind_null:
+		return false, nil
+	default:
+		return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_StartsWith, f.field, ErrWrongType)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"runtime/debug"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
@@ -27,12 +28,19 @@ import (
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
 	imetrics "github.com/voedger/voedger/pkg/metrics"
 	"github.com/voedger/voedger/pkg/pipeline"
+	"github.com/voedger/voedger/pkg/projectors"
 	"github.com/voedger/voedger/pkg/state"
 	coreutils "github.com/voedger/voedger/pkg/utils"
 )
 
 func implRowsProcessorFactory(ctx context.Context, appDef appdef.IAppDef, state istructs.IState, params IQueryParams,
 	resultMeta appdef.IDef, rs IResultSenderClosable, metrics IMetrics) pipeline.IAsyncPipeline {
+	return newRowsProcessor(ctx, appDef, state, params, resultMeta, rs, metrics, nil)
+}
+
+// newRowsProcessor builds the rows processor, viewOrdered returns true if the query function sends rows already ordered
+func newRowsProcessor(ctx context.Context, appDef appdef.IAppDef, state istructs.IState, params IQueryParams,
+	resultMeta appdef.IDef, rs IResultSenderClosable, metrics IMetrics, viewOrdered func() bool) pipeline.IAsyncPipeline {
 	operators := make([]*pipeline.WiredOperator, 0)
 	if resultMeta.QName() == istructs.QNameJSON {
 		operators = append(operators, pipeline.WireAsyncOperator("Raw result", &RawResultOperator{
@@ -63,13 +71,24 @@ func implRowsProcessorFactory(ctx context.Context, appDef appdef.IAppDef, state
 				metrics:    metrics,
 			}))
 		}
+		if params.Aggregate() != nil {
+			operators = append(operators, pipeline.WireAsyncOperator("Aggregate", newAggregateOperator(params.Elements(), rootFields, params.Aggregate(), metrics)))
+		}
 		if len(params.OrderBy()) != 0 {
-			operators = append(operators, pipeline.WireAsyncOperator("Order", newOrderOperator(params.OrderBy(), metrics)))
+			limit := 0
+			if params.Count() != 0 && params.Cursor() == nil {
+				// one more row lets counter know that there are more rows
+				limit = int(params.StartFrom() + params.Count() + 1)
+			}
+			operators = append(operators, pipeline.WireAsyncOperator("Order",
+				newBoundedOrderOperator(params.OrderBy(), viewOrdered, limit, maxOrderRowsInMemory, metrics)))
 		}
-		if params.StartFrom() != 0 || params.Count() != 0 {
+		if params.StartFrom() != 0 || params.Count() != 0 || params.Cursor() != nil {
 			operators = append(operators, pipeline.WireAsyncOperator("Counter", newCounterOperator(
 				params.StartFrom(),
 				params.Count(),
+				params.OrderBy(),
+				params.Cursor(),
 				metrics)))
 		}
 	}
@@ -82,7 +101,7 @@ func implRowsProcessorFactory(ctx context.Context, appDef appdef.IAppDef, state
 
 func implServiceFactory(serviceChannel iprocbus.ServiceChannel, resultSenderClosableFactory ResultSenderClosableFactory,
 	appStructsProvider istructs.IAppStructsProvider, maxPrepareQueries int, metrics imetrics.IMetrics, hvm string,
-	authn iauthnz.IAuthenticator, authz iauthnz.IAuthorizer, appCfgs istructsmem.AppConfigsType) pipeline.IService {
+	authn iauthnz.IAuthenticator, authz iauthnz.IAuthorizer, appCfgs istructsmem.AppConfigsType, rawActualizers projectors.IRawActualizers) pipeline.IService {
 	secretReader := isecretsimpl.ProvideSecretReader()
 	return pipeline.NewService(func(ctx context.Context) {
 		var p pipeline.ISyncPipeline
@@ -99,9 +118,9 @@ func implServiceFactory(serviceChannel iprocbus.ServiceChannel, resultSenderClos
 				qpm.Increase(queriesTotal, 1.0)
 				rs := resultSenderClosableFactory(msg.RequestCtx(), msg.Sender())
 				rs = &resultSenderClosableOnlyOnce{IResultSenderClosable: rs}
-				qwork := newQueryWork(msg, rs, appStructsProvider, maxPrepareQueries, qpm, secretReader)
+				qwork := newQueryWork(msg, rs, appStructsProvider, maxPrepareQueries, qpm, secretReader, authz)
 				if p == nil {
-					p = newQueryProcessorPipeline(ctx, authn, authz, appCfgs)
+					p = newQueryProcessorPipeline(ctx, authn, authz, appCfgs, rawActualizers)
 				}
 				err := p.SendSync(&qwork)
 				if err != nil {
@@ -121,7 +140,7 @@ func implServiceFactory(serviceChannel iprocbus.ServiceChannel, resultSenderClos
 }
 
 func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthenticator, authz iauthnz.IAuthorizer,
-	appCfgs istructsmem.AppConfigsType) pipeline.ISyncPipeline {
+	appCfgs istructsmem.AppConfigsType, rawActualizers projectors.IRawActualizers) pipeline.ISyncPipeline {
 	ops := []*pipeline.WiredOperator{
 		operator("get app structs", func(ctx context.Context, qw *queryWork) (err error) {
 			qw.appStructs, err = qw.appStructsProvider.AppStructs(qw.msg.AppQName())
@@ -177,6 +196,12 @@ func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthen
 			return coreutils.WrapSysError(err, http.StatusBadRequest)
 		}),
 		operator("create state", func(ctx context.Context, qw *queryWork) (err error) {
+			opts := make([]state.QueryProcessorStateOptFunc, 0, 1)
+			if rawActualizers != nil {
+				opts = append(opts, state.WithLazyViewActivator(func(view appdef.QName, wsid istructs.WSID) error {
+					return rawActualizers.Activate(qw.msg.RequestCtx(), qw.msg.AppQName(), qw.msg.Partition(), wsid, view)
+				}))
+			}
 			qw.state = state.ProvideQueryProcessorStateFactory()(
 				qw.msg.RequestCtx(),
 				qw.appStructs,
@@ -184,7 +209,8 @@ func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthen
 				state.SimpleWSIDFunc(qw.msg.WSID()),
 				qw.secretReader,
 				func() []iauthnz.Principal { return qw.principals },
-				func() string { return qw.msg.Token() })
+				func() string { return qw.msg.Token() },
+				opts...)
 			qw.execQueryArgs.State = qw.state
 			return
 		}),
@@ -197,7 +223,7 @@ func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthen
 			return coreutils.WrapSysError(err, http.StatusBadRequest)
 		}),
 		operator("validate: get query params", func(ctx context.Context, qw *queryWork) (err error) {
-			qw.queryParams, err = newQueryParams(qw.requestData, NewElement, NewFilter, NewOrderBy, coreutils.NewFieldsDef(qw.resultDef))
+			qw.queryParams, err = newQueryParams(qw.requestData, NewElement, NewFilter, NewOrderBy, NewAggregate, coreutils.NewFieldsDef(qw.resultDef))
 			return coreutils.WrapSysError(err, http.StatusBadRequest)
 		}),
 		operator("authorize result", func(ctx context.Context, qw *queryWork) (err error) {
@@ -227,8 +253,9 @@ func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthen
 			defer func() {
 				qw.metrics.Increase(buildSeconds, time.Since(now).Seconds())
 			}()
-			qw.rowsProcessor = ProvideRowsProcessorFactory()(qw.msg.RequestCtx(), qw.appStructs.AppDef(),
-				qw.state, qw.queryParams, qw.resultDef, qw.rs, qw.metrics)
+			qw.sender = &failFastSender{IResultSenderClosable: qw.rs}
+			qw.rowsProcessor = newRowsProcessor(qw.msg.RequestCtx(), qw.appStructs.AppDef(),
+				qw.state, qw.queryParams, qw.resultDef, qw.sender, qw.metrics, qw.viewOrdered.Load)
 			return nil
 		}),
 		operator("exec function", func(ctx context.Context, qw *queryWork) (err error) {
@@ -242,6 +269,10 @@ func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthen
 				qw.metrics.Increase(execSeconds, time.Since(now).Seconds())
 			}()
 			err = qw.queryFunction.Exec(ctx, qw.execQueryArgs, func(object istructs.IObject) error {
+				if err := qw.sender.Err(); err != nil {
+					// rows can't be sent, the query function should stop
+					return err
+				}
 				pathToIdx := make(map[string]int)
 				if qw.resultDef.QName() == istructs.QNameJSON {
 					pathToIdx[Field_JSONDef_Body] = 0
@@ -259,6 +290,9 @@ func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthen
 					enrichedRootFields: make(map[string]appdef.DataKind),
 				})
 			})
+			if errors.Is(err, projectors.ErrLazyProjectionNotActive) {
+				return coreutils.WrapSysError(err, http.StatusServiceUnavailable)
+			}
 			return coreutils.WrapSysError(err, http.StatusInternalServerError)
 		}),
 	}
@@ -280,15 +314,18 @@ type queryWork struct {
 	execQueryArgs     istructs.ExecQueryArgs
 	maxPrepareQueries int
 	rowsProcessor     pipeline.IAsyncPipeline
+	sender            *failFastSender
 	metrics           IMetrics
 	principals        []iauthnz.Principal
 	principalPayload  payloads.PrincipalPayload
 	secretReader      isecrets.ISecretReader
 	appCfg            *istructsmem.AppConfigType
+	viewOrdered       atomic.Bool
+	authz             iauthnz.IAuthorizer
 }
 
 func newQueryWork(msg IQueryMessage, rs IResultSenderClosable, appStructsProvider istructs.IAppStructsProvider,
-	maxPrepareQueries int, metrics *queryProcessorMetrics, secretReader isecrets.ISecretReader) queryWork {
+	maxPrepareQueries int, metrics *queryProcessorMetrics, secretReader isecrets.ISecretReader, authz iauthnz.IAuthorizer) queryWork {
 	return queryWork{
 		msg:                msg,
 		rs:                 rs,
@@ -297,6 +334,7 @@ func newQueryWork(msg IQueryMessage, rs IResultSenderClosable, appStructsProvide
 		maxPrepareQueries:  maxPrepareQueries,
 		metrics:            metrics,
 		secretReader:       secretReader,
+		authz:              authz,
 	}
 }
 
@@ -312,6 +350,40 @@ func (qw *queryWork) GetPrincipals() []iauthnz.Principal {
 	return qw.principals
 }
 
+// IQueryWorkpiece.GetViewPushDown
+// need for query functions which read views to apply filters and orderBy to the view read.
+// The query function declares that it sends rows of the single view read in the clustering columns order by calling it
+func (qw *queryWork) GetViewPushDown(view appdef.QName) IViewPushDown {
+	pushDown := newViewPushDown(qw.appStructs.AppDef(), view, qw.queryParams, coreutils.NewFieldsDef(qw.resultDef))
+	if pushDown.Ordered() {
+		qw.viewOrdered.Store(true)
+	}
+	return pushDown
+}
+
+// need for q.sys.ReadView to check that principals are allowed to read the view fields
+func (qw *queryWork) AuthorizeSelect(resource appdef.QName, fields []string) error {
+	req := iauthnz.AuthzRequest{
+		OperationKind: iauthnz.OperationKind_SELECT,
+		Resource:      resource,
+		Fields:        fields,
+	}
+	ok, err := qw.authz.Authorize(qw.appStructs, qw.principals, req)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return coreutils.NewSysError(http.StatusForbidden)
+	}
+	return nil
+}
+
+// IQueryWorkpiece.GetCursor
+// need for query functions which read views to seek to the cursor key instead of reading rows sent on the previous pages
+func (qw *queryWork) GetCursor() ICursor {
+	return qw.queryParams.Cursor()
+}
+
 func operator(name string, doSync func(ctx context.Context, qw *queryWork) (err error)) *pipeline.WiredOperator {
 	return pipeline.WireFunc(name, func(ctx context.Context, work interface{}) (err error) {
 		return doSync(ctx, work.(*queryWork))
@@ -371,6 +443,8 @@ type workpiece struct {
 	object             istructs.IObject
 	outputRow          IOutputRow
 	enrichedRootFields coreutils.FieldsDef
+	// continuation token, workpiece contains no row if present
+	cursor string
 }
 
 func (w workpiece) Object() istructs.IObject                { return w.object }
@@ -494,6 +568,42 @@ func (s *resultSenderClosableOnlyOnce) Close(err error) {
 	})
 }
 
+// failFastSender keeps the first error of the rows processor to stop the query function as soon as rows can't be sent
+type failFastSender struct {
+	IResultSenderClosable
+	lock sync.Mutex
+	err  error
+}
+
+func (s *failFastSender) SendElement(name string, element interface{}) (err error) {
+	return s.fail(s.IResultSenderClosable.SendElement(name, element))
+}
+
+func (s *failFastSender) ObjectSection(sectionType string, path []string, element interface{}) (err error) {
+	return s.fail(s.IResultSenderClosable.ObjectSection(sectionType, path, element))
+}
+
+func (s *failFastSender) Close(err error) {
+	s.IResultSenderClosable.Close(s.fail(err))
+}
+
+func (s *failFastSender) fail(err error) error {
+	if err != nil {
+		s.lock.Lock()
+		if s.err == nil {
+			s.err = err
+		}
+		s.lock.Unlock()
+	}
+	return err
+}
+
+func (s *failFastSender) Err() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.err
+}
+
 type queryProcessorMetrics struct {
 	hvm     string
 	app     istructs.AppQName
@@ -287,7 +287,7 @@ func TestBasicUsage_ServiceFactory(t *testing.T) {
 	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter)
 	authz := iauthnzimpl.NewDefaultAuthorizer()
 	queryProcessor := ProvideServiceFactory()(serviceChannel, func(ctx context.Context, sender interface{}) IResultSenderClosable { return rs },
-		appStructsProvider, 3, metrics, "hvm", authn, authz, cfgs)
+		appStructsProvider, 3, metrics, "hvm", authn, authz, cfgs, nil)
 	go queryProcessor.Run(context.Background())
 	funcResource := as.Resources().QueryResource(qNameFunction)
 	systemToken := getSystemToken(appTokens)
@@ -1025,7 +1025,7 @@ func TestRateLimiter(t *testing.T) {
 	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter)
 	authz := iauthnzimpl.NewDefaultAuthorizer()
 	queryProcessor := ProvideServiceFactory()(serviceChannel, func(ctx context.Context, sender interface{}) IResultSenderClosable { return rs },
-		appStructsProvider, 3, metrics, "hvm", authn, authz, cfgs)
+		appStructsProvider, 3, metrics, "hvm", authn, authz, cfgs, nil)
 	go queryProcessor.Run(context.Background())
 
 	systemToken := getSystemToken(appTokens)
@@ -1072,7 +1072,7 @@ func TestAuthnz(t *testing.T) {
 	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter)
 	authz := iauthnzimpl.NewDefaultAuthorizer()
 	queryProcessor := ProvideServiceFactory()(serviceChannel, func(ctx context.Context, sender interface{}) IResultSenderClosable { return rs },
-		appStructsProvider, 3, metrics, "hvm", authn, authz, cfgs)
+		appStructsProvider, 3, metrics, "hvm", authn, authz, cfgs, nil)
 	go queryProcessor.Run(context.Background())
 	funcResource := as.Resources().QueryResource(qNameFunction)
 
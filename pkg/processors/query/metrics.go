@@ -13,6 +13,7 @@ const (
 	execFieldsSeconds = "heeus_qp_exec_fields_seconds"
 	execEnrichSeconds = "heeus_qp_exec_enrich_seconds"
 	execFilterSeconds = "heeus_qp_exec_filter_seconds"
+	execAggSeconds    = "heeus_qp_exec_aggregate_seconds"
 	execOrderSeconds  = "heeus_qp_exec_order_seconds"
 	execCountSeconds  = "heeus_qp_exec_count_seconds"
 	execSendSeconds   = "heeus_qp_exec_send_seconds"
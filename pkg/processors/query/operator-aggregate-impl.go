@@ -0,0 +1,266 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/pipeline"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+type AggregateOperator struct {
+	pipeline.AsyncNOOP
+	elements   []IElement
+	rootFields coreutils.FieldsDef
+	aggregate  IAggregate
+	groups     map[string]*group
+	keys       []string
+	metrics    IMetrics
+}
+
+// group keeps groupBy values and aggregators of the rows with the same groupBy values
+type group struct {
+	values      []interface{}
+	aggregators []*aggregator
+}
+
+type aggregator struct {
+	fn    string
+	count int64
+	sumI  int64
+	sumF  float64
+	float bool
+	sumD  istructs.Decimal
+	dec   bool
+	value interface{}
+}
+
+func newAggregateOperator(elements []IElement, rootFields coreutils.FieldsDef, aggregate IAggregate, metrics IMetrics) pipeline.IAsyncOperator {
+	return &AggregateOperator{
+		elements:   elements,
+		rootFields: rootFields,
+		aggregate:  aggregate,
+		groups:     make(map[string]*group),
+		metrics:    metrics,
+	}
+}
+
+func (o *AggregateOperator) DoAsync(_ context.Context, work pipeline.IWorkpiece) (outWork pipeline.IWorkpiece, err error) {
+	begin := time.Now()
+	defer func() {
+		o.metrics.Increase(execAggSeconds, time.Since(begin).Seconds())
+	}()
+	row := work.(IWorkpiece).OutputRow().Value(rootDocument).([]IOutputRow)[0]
+	fields := o.fields(work.(IWorkpiece).EnrichedRootFields())
+	work.Release()
+	values := make([]interface{}, len(o.aggregate.GroupBy()))
+	for i, field := range o.aggregate.GroupBy() {
+		values[i] = row.Value(field)
+	}
+	g := o.group(values)
+	for i, f := range o.aggregate.Functions() {
+		var value interface{}
+		if f.Field() != "" {
+			value = row.Value(f.Field())
+			if f.Func() == aggregateFunc_Count {
+				// count(field) counts rows with the non-empty field value only like SQL COUNT(field) does
+				empty, err := IsNullFilter{field: f.Field()}.IsMatch(fields, row)
+				if err != nil {
+					return nil, fmt.Errorf("'%s' function of field '%s': %w", f.Func(), f.Field(), err)
+				}
+				if empty {
+					continue
+				}
+			}
+		}
+		if err = g.aggregators[i].add(value); err != nil {
+			return nil, fmt.Errorf("'%s' function of field '%s': %w", f.Func(), f.Field(), err)
+		}
+	}
+	return nil, nil
+}
+
+// fields returns kinds of the root fields and the fields which are added by the enrichment
+func (o *AggregateOperator) fields(enriched coreutils.FieldsDef) coreutils.FieldsDef {
+	if len(enriched) == 0 {
+		return o.rootFields
+	}
+	fields := make(map[string]appdef.DataKind, len(o.rootFields)+len(enriched))
+	for n, k := range o.rootFields {
+		fields[n] = k
+	}
+	for n, k := range enriched {
+		fields[n] = k
+	}
+	return fields
+}
+
+func (o *AggregateOperator) Flush(callback pipeline.OpFuncFlush) (err error) {
+	begin := time.Now()
+	defer func() {
+		o.metrics.Increase(execAggSeconds, time.Since(begin).Seconds())
+	}()
+	if len(o.keys) == 0 && len(o.aggregate.GroupBy()) == 0 {
+		// Aggregation of all rows gives the single row even if there are no rows
+		o.group(nil)
+	}
+	for _, key := range o.keys {
+		callback(workpiece{outputRow: o.outputRow(o.groups[key])})
+	}
+	return nil
+}
+
+// group returns the group of the groupBy values, the new group is created if absent
+func (o *AggregateOperator) group(values []interface{}) *group {
+	key := fmt.Sprintf("%#v", values)
+	g, ok := o.groups[key]
+	if !ok {
+		g = &group{
+			values:      values,
+			aggregators: make([]*aggregator, len(o.aggregate.Functions())),
+		}
+		for i, f := range o.aggregate.Functions() {
+			g.aggregators[i] = &aggregator{fn: f.Func()}
+		}
+		o.groups[key] = g
+		o.keys = append(o.keys, key)
+	}
+	return g
+}
+
+func (o *AggregateOperator) outputRow(g *group) IOutputRow {
+	pathToIdx := make(map[string]int)
+	for i, element := range o.elements {
+		pathToIdx[element.Path().Name()] = i
+	}
+	res := &outputRow{
+		keyToIdx: pathToIdx,
+		values:   make([]interface{}, len(pathToIdx)),
+	}
+	for _, element := range o.elements {
+		res.Set(element.Path().Name(), make([]IOutputRow, 0))
+	}
+
+	fieldToIdx := make(map[string]int)
+	for i, field := range o.aggregate.GroupBy() {
+		fieldToIdx[field] = i
+	}
+	for i, f := range o.aggregate.Functions() {
+		fieldToIdx[f.Alias()] = len(o.aggregate.GroupBy()) + i
+	}
+	row := &outputRow{
+		keyToIdx: fieldToIdx,
+		values:   make([]interface{}, len(fieldToIdx)),
+	}
+	for i, field := range o.aggregate.GroupBy() {
+		row.Set(field, g.values[i])
+	}
+	for i, f := range o.aggregate.Functions() {
+		row.Set(f.Alias(), g.aggregators[i].result())
+	}
+	res.Set(rootDocument, []IOutputRow{row})
+	return res
+}
+
+func (a *aggregator) add(value interface{}) error {
+	a.count++
+	switch a.fn {
+	case aggregateFunc_Count:
+		return nil
+	case aggregateFunc_Sum, aggregateFunc_Avg:
+		switch v := value.(type) {
+		case int32:
+			a.sumI += int64(v)
+		case int64:
+			a.sumI += v
+		case float32:
+			a.sumF += float64(v)
+			a.float = true
+		case float64:
+			a.sumF += v
+			a.float = true
+		case istructs.Decimal:
+			sum, err := a.sumD.Add(v)
+			if err != nil {
+				return err
+			}
+			a.sumD, a.dec = sum, true
+		default:
+			return ErrWrongType
+		}
+		return nil
+	}
+	if a.value == nil {
+		a.value = value
+		return nil
+	}
+	var less bool
+	switch v := value.(type) {
+	case int32:
+		less = v < a.value.(int32)
+	case int64:
+		less = v < a.value.(int64)
+	case float32:
+		less = v < a.value.(float32)
+	case float64:
+		less = v < a.value.(float64)
+	case string:
+		less = v < a.value.(string)
+	case istructs.Decimal:
+		less = v.Cmp(a.value.(istructs.Decimal)) < 0
+	default:
+		return ErrWrongType
+	}
+	if less == (a.fn == aggregateFunc_Min) && value != a.value {
+		a.value = value
+	}
+	return nil
+}
+
+func (a *aggregator) result() interface{} {
+	switch a.fn {
+	case aggregateFunc_Count:
+		return a.count
+	case aggregateFunc_Sum:
+		if a.float {
+			return a.sumF
+		}
+		if a.dec {
+			return a.sumD
+		}
+		return a.sumI
+	case aggregateFunc_Avg:
+		if a.count == 0 {
+			return nil
+		}
+		if a.dec {
+			return a.avgD()
+		}
+		return (a.sumF + float64(a.sumI)) / float64(a.count)
+	default:
+		return a.value
+	}
+}
+
+// avgD returns decimal average with the scale of the decimal sum, rounded half away from zero
+func (a *aggregator) avgD() istructs.Decimal {
+	q, r := a.sumD.Value()/a.count, a.sumD.Value()%a.count
+	if r < 0 {
+		r = -r
+	}
+	if 2*r >= a.count {
+		if a.sumD.Value() < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return istructs.NewDecimal(q, a.sumD.Scale())
+}
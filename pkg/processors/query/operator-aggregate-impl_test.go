@@ -0,0 +1,213 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/pipeline"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+func TestAggregateOperator_Flush(t *testing.T) {
+	work := func(name string, departmentNumber int32, weight float64) pipeline.IWorkpiece {
+		return workpiece{
+			outputRow: &outputRow{
+				keyToIdx: map[string]int{rootDocument: 0},
+				values: []interface{}{
+					[]IOutputRow{
+						&outputRow{
+							keyToIdx: map[string]int{
+								"name":              0,
+								"department_number": 1,
+								"weight":            2,
+							},
+							values: []interface{}{name, departmentNumber, weight},
+						},
+					},
+				},
+			},
+		}
+	}
+	decimalWork := func(price istructs.Decimal) pipeline.IWorkpiece {
+		return workpiece{
+			outputRow: &outputRow{
+				keyToIdx: map[string]int{rootDocument: 0},
+				values: []interface{}{
+					[]IOutputRow{&outputRow{keyToIdx: map[string]int{"price": 0}, values: []interface{}{price}}},
+				},
+			},
+		}
+	}
+	elements := []IElement{element{path: path{rootDocument}}, element{path: path{"articles"}}}
+	rootFields := coreutils.FieldsDef{
+		"name":              appdef.DataKind_string,
+		"department_number": appdef.DataKind_int32,
+		"weight":            appdef.DataKind_float64,
+	}
+	root := func(work pipeline.IWorkpiece) IOutputRow {
+		return work.(workpiece).OutputRow().Value(rootDocument).([]IOutputRow)[0]
+	}
+	flush := func(operator pipeline.IAsyncOperator) (works []pipeline.IWorkpiece) {
+		require.NoError(t, operator.Flush(func(work pipeline.IWorkpiece) {
+			works = append(works, work)
+		}))
+		return works
+	}
+	t.Run("Should aggregate rows by group", func(t *testing.T) {
+		require := require.New(t)
+		operator := newAggregateOperator(elements, rootFields, aggregate{
+			groupBy: []string{"department_number"},
+			functions: []IAggregateFunction{
+				aggregateFunction{fn: aggregateFunc_Count, alias: "count"},
+				aggregateFunction{fn: aggregateFunc_Sum, field: "department_number", alias: "sum_department_number"},
+				aggregateFunction{fn: aggregateFunc_Sum, field: "weight", alias: "sum_weight"},
+				aggregateFunction{fn: aggregateFunc_Min, field: "name", alias: "min_name"},
+				aggregateFunction{fn: aggregateFunc_Max, field: "weight", alias: "max_weight"},
+				aggregateFunction{fn: aggregateFunc_Avg, field: "weight", alias: "avg_weight"},
+			},
+		}, &testMetrics{})
+
+		for _, w := range []pipeline.IWorkpiece{
+			work("Sprite", 100, 2.0),
+			work("Cola", 200, 1.5),
+			work("Pepsi", 100, 1.0),
+			work("Fanta", 100, 3.0),
+		} {
+			outWork, err := operator.DoAsync(context.Background(), w)
+			require.NoError(err)
+			require.Nil(outWork)
+		}
+		works := flush(operator)
+
+		require.Len(works, 2)
+		require.Empty(works[0].(workpiece).OutputRow().Value("articles"))
+		require.Equal([]interface{}{int32(100), int64(3), int64(300), 6.0, "Fanta", 3.0, 2.0}, root(works[0]).Values())
+		require.Equal([]interface{}{int32(200), int64(1), int64(200), 1.5, "Cola", 1.5, 1.5}, root(works[1]).Values())
+		require.Equal(int64(3), root(works[0]).Value("count"))
+	})
+	t.Run("Should aggregate all rows without group by", func(t *testing.T) {
+		require := require.New(t)
+		operator := newAggregateOperator(elements, rootFields, aggregate{
+			functions: []IAggregateFunction{
+				aggregateFunction{fn: aggregateFunc_Count, alias: "count"},
+				aggregateFunction{fn: aggregateFunc_Max, field: "name", alias: "max_name"},
+			},
+		}, &testMetrics{})
+
+		_, _ = operator.DoAsync(context.Background(), work("Sprite", 100, 2.0))
+		_, _ = operator.DoAsync(context.Background(), work("Cola", 200, 1.5))
+		works := flush(operator)
+
+		require.Len(works, 1)
+		require.Equal([]interface{}{int64(2), "Sprite"}, root(works[0]).Values())
+	})
+	t.Run("Should count rows with non-empty field value only", func(t *testing.T) {
+		require := require.New(t)
+		operator := newAggregateOperator(elements, rootFields, aggregate{
+			groupBy: []string{"department_number"},
+			functions: []IAggregateFunction{
+				aggregateFunction{fn: aggregateFunc_Count, alias: "count"},
+				aggregateFunction{fn: aggregateFunc_Count, field: "name", alias: "count_name"},
+				aggregateFunction{fn: aggregateFunc_Count, field: "weight", alias: "count_weight"},
+			},
+		}, &testMetrics{})
+
+		for _, w := range []pipeline.IWorkpiece{
+			work("Sprite", 100, 2.0),
+			work("", 100, 1.5),
+			work("Pepsi", 100, 0),
+			work("", 200, 0),
+		} {
+			_, err := operator.DoAsync(context.Background(), w)
+			require.NoError(err)
+		}
+		works := flush(operator)
+
+		require.Len(works, 2)
+		require.Equal([]interface{}{int32(100), int64(3), int64(2), int64(2)}, root(works[0]).Values())
+		require.Equal([]interface{}{int32(200), int64(1), int64(0), int64(0)}, root(works[1]).Values())
+	})
+	t.Run("Should give single row if there are no rows to aggregate", func(t *testing.T) {
+		require := require.New(t)
+		operator := newAggregateOperator(elements, rootFields, aggregate{
+			functions: []IAggregateFunction{
+				aggregateFunction{fn: aggregateFunc_Count, alias: "count"},
+				aggregateFunction{fn: aggregateFunc_Avg, field: "weight", alias: "avg_weight"},
+			},
+		}, &testMetrics{})
+
+		works := flush(operator)
+
+		require.Len(works, 1)
+		require.Equal([]interface{}{int64(0), nil}, root(works[0]).Values())
+	})
+	t.Run("Should aggregate decimals exactly", func(t *testing.T) {
+		require := require.New(t)
+		operator := newAggregateOperator(elements, rootFields, aggregate{
+			functions: []IAggregateFunction{
+				aggregateFunction{fn: aggregateFunc_Sum, field: "price", alias: "sum_price"},
+				aggregateFunction{fn: aggregateFunc_Avg, field: "price", alias: "avg_price"},
+				aggregateFunction{fn: aggregateFunc_Min, field: "price", alias: "min_price"},
+				aggregateFunction{fn: aggregateFunc_Max, field: "price", alias: "max_price"},
+			},
+		}, &testMetrics{})
+
+		for _, v := range []int64{10, 20, -5} {
+			_, err := operator.DoAsync(context.Background(), decimalWork(istructs.NewDecimal(v, 2)))
+			require.NoError(err)
+		}
+		works := flush(operator)
+
+		require.Len(works, 1)
+		require.Equal([]interface{}{
+			istructs.NewDecimal(25, 2),
+			istructs.NewDecimal(8, 2),
+			istructs.NewDecimal(-5, 2),
+			istructs.NewDecimal(20, 2),
+		}, root(works[0]).Values())
+	})
+	t.Run("Should fail on decimal sum overflow", func(t *testing.T) {
+		require := require.New(t)
+		operator := newAggregateOperator(elements, rootFields, aggregate{
+			functions: []IAggregateFunction{aggregateFunction{fn: aggregateFunc_Sum, field: "price", alias: "sum_price"}},
+		}, &testMetrics{})
+
+		_, err := operator.DoAsync(context.Background(), decimalWork(istructs.NewDecimal(999999999999999999, 0)))
+		require.NoError(err)
+		_, err = operator.DoAsync(context.Background(), decimalWork(istructs.NewDecimal(1, 0)))
+		require.ErrorIs(err, istructs.ErrDecimalOverflow)
+	})
+	t.Run("Should give no rows if there are no rows to group", func(t *testing.T) {
+		operator := newAggregateOperator(elements, rootFields, aggregate{groupBy: []string{"name"}}, &testMetrics{})
+
+		require.Empty(t, flush(operator))
+	})
+}
+
+func TestNewAggregate(t *testing.T) {
+	require := require.New(t)
+
+	a, err := NewAggregate(map[string]interface{}{
+		"groupBy": []interface{}{"name"},
+		"functions": []interface{}{
+			map[string]interface{}{"func": "count"},
+			map[string]interface{}{"func": "sum", "field": "weight"},
+			map[string]interface{}{"func": "avg", "field": "weight", "alias": "avgWeight"},
+		},
+	})
+
+	require.NoError(err)
+	require.Equal([]string{"name"}, a.GroupBy())
+	require.Equal("count", a.Functions()[0].Alias())
+	require.Equal("sum_weight", a.Functions()[1].Alias())
+	require.Equal("avgWeight", a.Functions()[2].Alias())
+	require.Equal(aggregateFunc_Avg, a.Functions()[2].Func())
+	require.Equal("weight", a.Functions()[2].Field())
+}
@@ -18,16 +18,29 @@ type CounterOperator struct {
 	count     int64
 	counter   int64
 	limiter   int64
-	metrics   IMetrics
+	orderBys  []IOrderBy
+	cursor    ICursor
+	skipped   int64
+	// paged is true if the continuation token must be sent when there are more rows than count,
+	// rows without orderBy have no key to continue from, so count is just the limit then
+	paged   bool
+	hasMore bool
+	lastKey []interface{}
+	ties    int64
+	metrics IMetrics
 }
 
-func newCounterOperator(startFrom, count int64, metrics IMetrics) pipeline.IAsyncOperator {
+func newCounterOperator(startFrom, count int64, orderBys []IOrderBy, cursor ICursor, metrics IMetrics) pipeline.IAsyncOperator {
+	paged := count != 0 && startFrom == 0 && len(orderBys) != 0
 	if count == 0 {
 		count = math.MaxInt
 	}
 	return &CounterOperator{
 		startFrom: startFrom,
 		count:     count,
+		orderBys:  orderBys,
+		cursor:    cursor,
+		paged:     paged,
 		metrics:   metrics,
 	}
 }
@@ -37,9 +50,24 @@ func (o *CounterOperator) DoAsync(_ context.Context, work pipeline.IWorkpiece) (
 	defer func() {
 		o.metrics.Increase(execCountSeconds, time.Since(begin).Seconds())
 	}()
+	if o.cursor != nil {
+		skip, err := o.skip(work)
+		if err != nil {
+			return nil, err
+		}
+		if skip {
+			work.Release()
+			return nil, nil
+		}
+	}
 	if o.counter >= o.startFrom && o.limiter < o.count {
 		outWork = work
 		o.limiter += 1
+		if o.paged {
+			o.remember(work)
+		}
+	} else if o.limiter >= o.count {
+		o.hasMore = true
 	}
 	o.counter += 1
 	if outWork == nil {
@@ -47,3 +75,57 @@ func (o *CounterOperator) DoAsync(_ context.Context, work pipeline.IWorkpiece) (
 	}
 	return
 }
+
+// Flush sends the continuation token if there are more rows than count
+func (o *CounterOperator) Flush(callback pipeline.OpFuncFlush) (err error) {
+	if !o.paged || !o.hasMore {
+		return nil
+	}
+	token, err := encodeCursor(o.orderBys, o.lastKey, o.ties)
+	if err != nil {
+		return err
+	}
+	callback(workpiece{cursor: token})
+	return nil
+}
+
+// skip returns true if the row is sent on the previous pages
+func (o *CounterOperator) skip(work pipeline.IWorkpiece) (bool, error) {
+	res, err := compareKey(rowKey(work.(IWorkpiece).OutputRow(), o.orderBys), o.cursor.Key(), o.orderBys)
+	if err != nil {
+		return false, err
+	}
+	if res != 0 {
+		return res < 0, nil
+	}
+	if o.skipped < o.cursor.Skip() {
+		o.skipped++
+		return true, nil
+	}
+	return false, nil
+}
+
+// remember keeps the key of the last sent row and count of the sent rows with the same key
+func (o *CounterOperator) remember(work pipeline.IWorkpiece) {
+	key := rowKey(work.(IWorkpiece).OutputRow(), o.orderBys)
+	if o.lastKey != nil && equalKeys(key, o.lastKey) {
+		o.ties++
+		return
+	}
+	o.lastKey = key
+	o.ties = 1
+	if o.cursor != nil {
+		if res, err := compareKey(key, o.cursor.Key(), o.orderBys); err == nil && res == 0 {
+			o.ties += o.cursor.Skip()
+		}
+	}
+}
+
+func equalKeys(k1, k2 []interface{}) bool {
+	for i := range k1 {
+		if k1[i] != k2[i] {
+			return false
+		}
+	}
+	return true
+}
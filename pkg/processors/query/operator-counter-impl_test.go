@@ -71,7 +71,7 @@ func TestCounterOperator_DoAsync(t *testing.T) {
 			work := testWorkpiece{release: func() {
 				releaseCounter++
 			}}
-			operator := newCounterOperator(test.startFrom, test.count, &testMetrics{})
+			operator := newCounterOperator(test.startFrom, test.count, nil, nil, &testMetrics{})
 
 			require.Equal(t, test.first, outWorkIsPresent(operator.DoAsync(context.Background(), work)))
 			require.Equal(t, test.second, outWorkIsPresent(operator.DoAsync(context.Background(), work)))
@@ -83,3 +83,91 @@ func TestCounterOperator_DoAsync(t *testing.T) {
 		})
 	}
 }
+
+func TestCounterOperator_Cursor(t *testing.T) {
+	work := func(name string, price int64) pipeline.IWorkpiece {
+		return workpiece{
+			outputRow: &outputRow{
+				keyToIdx: map[string]int{rootDocument: 0},
+				values: []interface{}{
+					[]IOutputRow{
+						&outputRow{
+							keyToIdx: map[string]int{"name": 0, "price": 1},
+							values:   []interface{}{name, price},
+						},
+					},
+				},
+			},
+		}
+	}
+	name := func(work pipeline.IWorkpiece) string {
+		return work.(workpiece).OutputRow().Value(rootDocument).([]IOutputRow)[0].Value("name").(string)
+	}
+	// page returns names of the page rows and the continuation token
+	page := func(t *testing.T, orderBys []IOrderBy, token string, rows ...pipeline.IWorkpiece) (names []string, nextToken string) {
+		var cursor ICursor
+		if token != "" {
+			var err error
+			cursor, err = NewCursor(token)
+			require.NoError(t, err)
+		}
+		operator := newCounterOperator(0, 2, orderBys, cursor, &testMetrics{})
+		for _, row := range rows {
+			outWork, err := operator.DoAsync(context.Background(), row)
+			require.NoError(t, err)
+			if outWork != nil {
+				names = append(names, name(outWork))
+			}
+		}
+		require.NoError(t, operator.Flush(func(work pipeline.IWorkpiece) {
+			nextToken = work.(workpiece).cursor
+		}))
+		return names, nextToken
+	}
+	t.Run("Should page by sort key", func(t *testing.T) {
+		require := require.New(t)
+		orderBys := []IOrderBy{orderBy{field: "price", desc: true}}
+		rows := []pipeline.IWorkpiece{work("Cake", 40), work("Wine", 20), work("Cola", 20), work("Beer", 20), work("Water", 10)}
+
+		names, token := page(t, orderBys, "", rows...)
+		require.Equal([]string{"Cake", "Wine"}, names)
+		require.NotEmpty(token)
+
+		// row inserted between pages before the cursor does not shift the next page
+		names, token = page(t, orderBys, token, append([]pipeline.IWorkpiece{work("Pie", 50)}, rows...)...)
+		require.Equal([]string{"Cola", "Beer"}, names)
+		require.NotEmpty(token)
+
+		names, token = page(t, orderBys, token, rows...)
+		require.Equal([]string{"Water"}, names)
+		require.Empty(token)
+	})
+	t.Run("Should not send cursor without orderBy", func(t *testing.T) {
+		require := require.New(t)
+
+		names, token := page(t, nil, "", work("Cake", 40), work("Wine", 20), work("Cola", 20))
+
+		require.Equal([]string{"Cake", "Wine"}, names)
+		require.Empty(token)
+	})
+	t.Run("Should not send cursor if startFrom is used", func(t *testing.T) {
+		operator := newCounterOperator(1, 1, nil, nil, &testMetrics{})
+		for _, row := range []pipeline.IWorkpiece{work("Cake", 40), work("Wine", 20), work("Cola", 20)} {
+			_, _ = operator.DoAsync(context.Background(), row)
+		}
+		require.NoError(t, operator.Flush(func(work pipeline.IWorkpiece) {
+			t.Fatal("unexpected cursor")
+		}))
+	})
+	t.Run("Should return error if cursor key type mismatches", func(t *testing.T) {
+		token, err := encodeCursor([]IOrderBy{orderBy{field: "name"}}, []interface{}{42}, 1)
+		require.NoError(t, err)
+		cursor, err := NewCursor(token)
+		require.NoError(t, err)
+		operator := newCounterOperator(0, 2, []IOrderBy{orderBy{field: "name"}}, cursor, &testMetrics{})
+
+		_, err = operator.DoAsync(context.Background(), work("Cake", 40))
+
+		require.ErrorIs(t, err, ErrWrongType)
+	})
+}
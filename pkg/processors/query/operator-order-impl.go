@@ -5,8 +5,13 @@
 package queryprocessor
 
 import (
+	"bufio"
+	"container/heap"
 	"context"
+	"encoding/gob"
 	"fmt"
+	"io"
+	"os"
 	"sort"
 	"time"
 
@@ -17,15 +22,37 @@ type OrderOperator struct {
 	pipeline.AsyncNOOP
 	orderBys []IOrderBy
 	rows     []IOutputRow
-	metrics  IMetrics
+	// ordered returns true if rows come already ordered
+	ordered func() bool
+	// limit is the count of the first rows which are sent, rows are kept in the top-N heap then.
+	// Zero means that all rows are sent
+	limit int
+	top   topRows
+	// maxRows is the count of rows which are kept in memory, sorted rows are spilled to the temp file then
+	maxRows int
+	spills  []*os.File
+	err     error
+	metrics IMetrics
 }
 
-func newOrderOperator(orderBys []IOrderBy, metrics IMetrics) pipeline.IAsyncOperator {
-	return &OrderOperator{
+func newOrderOperator(orderBys []IOrderBy, ordered func() bool, metrics IMetrics) pipeline.IAsyncOperator {
+	return newBoundedOrderOperator(orderBys, ordered, 0, maxOrderRowsInMemory, metrics)
+}
+
+func newBoundedOrderOperator(orderBys []IOrderBy, ordered func() bool, limit, maxRows int, metrics IMetrics) pipeline.IAsyncOperator {
+	if limit > maxRows {
+		limit = 0
+	}
+	o := &OrderOperator{
 		orderBys: orderBys,
 		rows:     make([]IOutputRow, 0),
+		ordered:  ordered,
+		limit:    limit,
+		maxRows:  maxRows,
 		metrics:  metrics,
 	}
+	o.top.less = o.less
+	return o
 }
 
 func (o *OrderOperator) DoAsync(_ context.Context, work pipeline.IWorkpiece) (outWork pipeline.IWorkpiece, err error) {
@@ -33,50 +60,160 @@ func (o *OrderOperator) DoAsync(_ context.Context, work pipeline.IWorkpiece) (ou
 	defer func() {
 		o.metrics.Increase(execOrderSeconds, time.Since(begin).Seconds())
 	}()
-	o.rows = append(o.rows, work.(IWorkpiece).OutputRow())
+	if o.ordered != nil && o.ordered() {
+		return work, nil
+	}
+	row := work.(IWorkpiece).OutputRow()
 	work.Release()
-	return nil, nil
+	if o.limit != 0 {
+		o.top.add(row, o.limit)
+		return nil, o.err
+	}
+	o.rows = append(o.rows, row)
+	if len(o.rows) >= o.maxRows {
+		err = o.spill()
+	}
+	return nil, err
 }
 
-func (o OrderOperator) Flush(callback pipeline.OpFuncFlush) (err error) {
+func (o *OrderOperator) Flush(callback pipeline.OpFuncFlush) (err error) {
 	begin := time.Now()
 	defer func() {
 		o.metrics.Increase(execOrderSeconds, time.Since(begin).Seconds())
 	}()
-	sort.Slice(o.rows, func(i, j int) bool {
-		for _, orderBy := range o.orderBys {
-			o1 := o.value(i, orderBy.Field())
-			o2 := o.value(j, orderBy.Field())
-			if o1 == o2 {
-				continue
-			}
-			switch o1.(type) {
-			case int32:
-				return compareInt32(o1.(int32), o2.(int32), orderBy.IsDesc())
-			case int64:
-				return compareInt64(o1.(int64), o2.(int64), orderBy.IsDesc())
-			case float32:
-				return compareFloat32(o1.(float32), o2.(float32), orderBy.IsDesc())
-			case float64:
-				return compareFloat64(o1.(float64), o2.(float64), orderBy.IsDesc())
-			case string:
-				return compareString(o1.(string), o2.(string), orderBy.IsDesc())
-			default:
-				err = fmt.Errorf("order by '%s' is impossible: %w", orderBy.Field(), ErrWrongType)
-			}
-		}
-		return false
-	})
-	if err == nil {
+	defer o.removeSpills()
+	if o.limit != 0 {
+		o.rows = o.top.sorted()
+	}
+	o.sort()
+	if o.err != nil {
+		return o.err
+	}
+	if len(o.spills) == 0 {
 		for _, row := range o.rows {
 			callback(workpiece{outputRow: row})
 		}
+		return nil
+	}
+	return o.merge(callback)
+}
+
+func (o *OrderOperator) Close() {
+	o.removeSpills()
+}
+
+// stable sort keeps the order of the rows with the same key, cursor pagination relies on it
+func (o *OrderOperator) sort() {
+	sort.SliceStable(o.rows, func(i, j int) bool {
+		return o.less(o.rows[i], o.rows[j])
+	})
+}
+
+func (o *OrderOperator) less(r1, r2 IOutputRow) bool {
+	for _, orderBy := range o.orderBys {
+		o1 := rootValue(r1, orderBy.Field())
+		o2 := rootValue(r2, orderBy.Field())
+		if o1 == o2 {
+			continue
+		}
+		switch o1.(type) {
+		case int32:
+			return compareInt32(o1.(int32), o2.(int32), orderBy.IsDesc())
+		case int64:
+			return compareInt64(o1.(int64), o2.(int64), orderBy.IsDesc())
+		case float32:
+			return compareFloat32(o1.(float32), o2.(float32), orderBy.IsDesc())
+		case float64:
+			return compareFloat64(o1.(float64), o2.(float64), orderBy.IsDesc())
+		case string:
+			return compareString(o1.(string), o2.(string), orderBy.IsDesc())
+		default:
+			o.err = fmt.Errorf("order by '%s' is impossible: %w", orderBy.Field(), ErrWrongType)
+		}
+	}
+	return false
+}
+
+// spill writes sorted rows to the temp file
+func (o *OrderOperator) spill() (err error) {
+	o.sort()
+	if o.err != nil {
+		return o.err
+	}
+	f, err := os.CreateTemp("", spillFilePattern)
+	if err != nil {
+		return err
+	}
+	o.spills = append(o.spills, f)
+	w := bufio.NewWriter(f)
+	encoder := gob.NewEncoder(w)
+	for _, row := range o.rows {
+		sr, err := newSpillRow(row)
+		if err != nil {
+			return err
+		}
+		if err = encoder.Encode(sr); err != nil {
+			return err
+		}
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	o.rows = make([]IOutputRow, 0)
+	return nil
+}
+
+// merge sends rows of the spilled files and of the memory in order
+func (o *OrderOperator) merge(callback pipeline.OpFuncFlush) (err error) {
+	sources := make([]rowsSource, 0, len(o.spills)+1)
+	for _, f := range o.spills {
+		sources = append(sources, &spillSource{decoder: gob.NewDecoder(bufio.NewReader(f))})
+	}
+	sources = append(sources, &memorySource{rows: o.rows})
+	h := &mergeHeap{less: o.less}
+	for i, src := range sources {
+		row, err := src.next()
+		if err != nil {
+			return err
+		}
+		if row != nil {
+			h.items = append(h.items, mergeItem{row: row, source: i})
+		}
+	}
+	heap.Init(h)
+	for h.Len() > 0 {
+		item := h.items[0]
+		callback(workpiece{outputRow: item.row})
+		row, err := sources[item.source].next()
+		if err != nil {
+			return err
+		}
+		if row == nil {
+			heap.Pop(h)
+		} else {
+			h.items[0].row = row
+			heap.Fix(h, 0)
+		}
+		if o.err != nil {
+			return o.err
+		}
+	}
+	return nil
+}
+
+func (o *OrderOperator) removeSpills() {
+	for _, f := range o.spills {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
 	}
-	return err
+	o.spills = nil
 }
 
-func (o OrderOperator) value(i int, field string) interface{} {
-	return o.rows[i].Value(rootDocument).([]IOutputRow)[0].Value(field)
+func rootValue(row IOutputRow, field string) interface{} {
+	return row.Value(rootDocument).([]IOutputRow)[0].Value(field)
 }
 
 func compareInt32(o1, o2 int32, desc bool) bool {
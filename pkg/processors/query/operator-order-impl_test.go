@@ -5,10 +5,14 @@
 package queryprocessor
 
 import (
+	"bytes"
 	"context"
+	"encoding/gob"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/pipeline"
 )
 
@@ -52,7 +56,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "id",
 				desc:  false,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Cola", 100, 1.15))
@@ -77,7 +81,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "id",
 				desc:  true,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Cola", 100, 1.15))
@@ -102,7 +106,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "name",
 				desc:  false,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Cola", 100, 1.15))
@@ -127,7 +131,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "name",
 				desc:  true,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Cola", 100, 1.15))
@@ -152,7 +156,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "weight",
 				desc:  false,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Cola", 100, 1.15))
@@ -177,7 +181,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "weight",
 				desc:  true,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Cola", 100, 1.15))
@@ -206,7 +210,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "name",
 				desc:  false,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Xenta", 100, 1.45))
@@ -238,7 +242,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "name",
 				desc:  true,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Xenta", 100, 1.45))
@@ -270,7 +274,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "name",
 				desc:  true,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Xenta", 100, 1.45))
@@ -302,7 +306,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "name",
 				desc:  false,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Xenta", 100, 1.45))
@@ -346,7 +350,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				desc:  false,
 			},
 		}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 
 		_, _ = operator.DoAsync(context.Background(), work(true))
 		_, _ = operator.DoAsync(context.Background(), work(false))
@@ -385,7 +389,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 					field: "x",
 					desc:  false,
 				}}
-			operator := newOrderOperator(orders, &testMetrics{})
+			operator := newOrderOperator(orders, nil, &testMetrics{})
 			works := make([]pipeline.IWorkpiece, 0)
 
 			_, _ = operator.DoAsync(context.Background(), work(0, 1))
@@ -407,7 +411,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 					field: "x",
 					desc:  true,
 				}}
-			operator := newOrderOperator(orders, &testMetrics{})
+			operator := newOrderOperator(orders, nil, &testMetrics{})
 			works := make([]pipeline.IWorkpiece, 0)
 
 			_, _ = operator.DoAsync(context.Background(), work(0, 1))
@@ -445,7 +449,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 					field: "x",
 					desc:  false,
 				}}
-			operator := newOrderOperator(orders, &testMetrics{})
+			operator := newOrderOperator(orders, nil, &testMetrics{})
 			works := make([]pipeline.IWorkpiece, 0)
 
 			_, _ = operator.DoAsync(context.Background(), work(22.5))
@@ -467,7 +471,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 					field: "x",
 					desc:  true,
 				}}
-			operator := newOrderOperator(orders, &testMetrics{})
+			operator := newOrderOperator(orders, nil, &testMetrics{})
 			works := make([]pipeline.IWorkpiece, 0)
 
 			_, _ = operator.DoAsync(context.Background(), work(22.5))
@@ -495,10 +499,169 @@ func TestOrderOperator_DoAsync(t *testing.T) {
 			release = true
 		},
 	}
-	operator := newOrderOperator(nil, &testMetrics{})
+	operator := newOrderOperator(nil, nil, &testMetrics{})
 
 	_, _ = operator.DoAsync(context.Background(), work)
 
 	require.Len(operator.(*OrderOperator).rows, 1)
 	require.True(release)
 }
+
+func TestOrderOperator_BoundedMemory(t *testing.T) {
+	work := func(id int64, name string) pipeline.IWorkpiece {
+		return workpiece{
+			outputRow: &outputRow{
+				keyToIdx: map[string]int{rootDocument: 0, "article_prices": 1},
+				values: []interface{}{
+					[]IOutputRow{
+						&outputRow{
+							keyToIdx: map[string]int{"id": 0, "name": 1, "sys.ID": 2},
+							values:   []interface{}{id, name, istructs.RecordID(id)},
+						},
+					},
+					[]IOutputRow{
+						&outputRow{
+							keyToIdx: map[string]int{"price": 0},
+							values:   []interface{}{float32(id) / 2},
+						},
+					},
+				},
+			},
+		}
+	}
+	rows := func() []pipeline.IWorkpiece {
+		return []pipeline.IWorkpiece{
+			work(5, "Pepsi"), work(1, "Cola"), work(7, "Amaretto"), work(3, "Cola"),
+			work(2, "Sprite"), work(6, "Cola"), work(4, "Amaretto"),
+		}
+	}
+	orders := []IOrderBy{orderBy{field: "name"}, orderBy{field: "id", desc: true}}
+	expected := []int64{7, 4, 6, 3, 1, 5, 2}
+	run := func(t *testing.T, operator pipeline.IAsyncOperator) (ids []int64) {
+		for _, w := range rows() {
+			_, err := operator.DoAsync(context.Background(), w)
+			require.NoError(t, err)
+		}
+		require.NoError(t, operator.Flush(func(work pipeline.IWorkpiece) {
+			row := work.(workpiece).OutputRow()
+			ids = append(ids, row.Value(rootDocument).([]IOutputRow)[0].Value("id").(int64))
+		}))
+		operator.Close()
+		return ids
+	}
+	t.Run("Should keep top N rows only", func(t *testing.T) {
+		require := require.New(t)
+		operator := newBoundedOrderOperator(orders, nil, 3, 100, &testMetrics{})
+
+		require.Equal(expected[:3], run(t, operator))
+		require.LessOrEqual(len(operator.(*OrderOperator).top.items), 3)
+	})
+	t.Run("Should keep order of the rows with the same key in top N", func(t *testing.T) {
+		require := require.New(t)
+		operator := newBoundedOrderOperator([]IOrderBy{orderBy{field: "name"}}, nil, 3, 100, &testMetrics{})
+
+		require.Equal([]int64{7, 4, 1}, run(t, operator))
+	})
+	t.Run("Should spill rows to temp files and merge them", func(t *testing.T) {
+		require := require.New(t)
+		operator := newBoundedOrderOperator(orders, nil, 0, 2, &testMetrics{})
+		spills := make([]string, 0)
+		for _, w := range rows() {
+			_, err := operator.DoAsync(context.Background(), w)
+			require.NoError(err)
+		}
+		for _, f := range operator.(*OrderOperator).spills {
+			spills = append(spills, f.Name())
+		}
+		require.Len(spills, 3)
+
+		works := make([]pipeline.IWorkpiece, 0)
+		require.NoError(operator.Flush(func(work pipeline.IWorkpiece) {
+			works = append(works, work)
+		}))
+
+		ids := make([]int64, 0)
+		for _, w := range works {
+			row := w.(workpiece).OutputRow()
+			root := row.Value(rootDocument).([]IOutputRow)[0]
+			ids = append(ids, root.Value("id").(int64))
+			require.Equal(istructs.RecordID(root.Value("id").(int64)), root.Value("sys.ID"))
+			require.Equal(float32(root.Value("id").(int64))/2, row.Value("article_prices").([]IOutputRow)[0].Value("price"))
+		}
+		require.Equal(expected, ids)
+		for _, spill := range spills {
+			require.NoFileExists(spill)
+		}
+	})
+	t.Run("Should remove temp files on close", func(t *testing.T) {
+		require := require.New(t)
+		operator := newBoundedOrderOperator(orders, nil, 0, 2, &testMetrics{})
+		for _, w := range rows() {
+			_, err := operator.DoAsync(context.Background(), w)
+			require.NoError(err)
+		}
+		spill := operator.(*OrderOperator).spills[0].Name()
+		require.FileExists(spill)
+
+		operator.Close()
+
+		require.NoFileExists(spill)
+	})
+}
+
+func TestSpillRowValues(t *testing.T) {
+	require := require.New(t)
+	// values of the output rows by the data kinds, ref. coreutils.ReadByKind
+	values := map[string]interface{}{
+		"int32":     int32(1),
+		"int64":     int64(2),
+		"float32":   float32(3.5),
+		"float64":   4.5,
+		"bytes":     []byte{5},
+		"string":    "six",
+		"recordID":  istructs.RecordID(7),
+		"qName":     "test.QName",
+		"bool":      true,
+		"decimal":   istructs.NewDecimal(-1230, 2),
+		"timestamp": time.Date(2023, 5, 6, 7, 8, 9, 10, time.UTC),
+		"date":      "2023-05-06",
+		"UUID":      istructs.NewUUID(),
+		"record":    map[string]interface{}{"name": "record", "decimal": istructs.NewDecimal(1, 1)},
+	}
+	row := &outputRow{keyToIdx: map[string]int{}}
+	for name, value := range values {
+		row.keyToIdx[name] = len(row.values)
+		row.values = append(row.values, value)
+	}
+	root := &outputRow{keyToIdx: map[string]int{rootDocument: 0}, values: []interface{}{[]IOutputRow{row}}}
+
+	sr, err := newSpillRow(root)
+	require.NoError(err)
+	buf := bytes.Buffer{}
+	require.NoError(gob.NewEncoder(&buf).Encode(sr))
+	spilled, err := (&spillSource{decoder: gob.NewDecoder(&buf)}).next()
+	require.NoError(err)
+
+	spilledRow := spilled.Value(rootDocument).([]IOutputRow)[0]
+	for name, value := range values {
+		require.Equal(value, spilledRow.Value(name), name)
+	}
+}
+
+func TestFailFastSender(t *testing.T) {
+	require := require.New(t)
+	closed := 0
+	sender := &failFastSender{IResultSenderClosable: testResultSenderClosable{
+		sendElement: func(name string, element interface{}) (err error) { return ErrWrongType },
+		close:       func(err error) { closed++ },
+	}}
+
+	sender.Close(nil)
+	require.NoError(sender.Err())
+
+	require.ErrorIs(sender.SendElement("", nil), ErrWrongType)
+	sender.Close(ErrNotFound)
+
+	require.ErrorIs(sender.Err(), ErrWrongType)
+	require.Equal(2, closed)
+}
@@ -0,0 +1,189 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"container/heap"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// values of the output rows are registered by the data kinds, ref. coreutils.ReadByKind
+func init() {
+	gob.Register(istructs.RecordID(0))
+	gob.Register(istructs.Decimal{})
+	gob.Register(time.Time{})
+	gob.Register(istructs.UUID{})
+	gob.Register(map[string]interface{}{})
+	gob.Register([]spillRow{})
+}
+
+// topRows keeps the first rows in the max-heap: the last of the first rows is on top
+type topRows struct {
+	items []topItem
+	seq   int
+	less  func(r1, r2 IOutputRow) bool
+}
+
+type topItem struct {
+	row IOutputRow
+	// seq keeps the order of the rows with the same key
+	seq int
+}
+
+func (t *topRows) Len() int { return len(t.items) }
+func (t *topRows) Less(i, j int) bool {
+	return t.lessItem(t.items[j], t.items[i])
+}
+func (t *topRows) Swap(i, j int)      { t.items[i], t.items[j] = t.items[j], t.items[i] }
+func (t *topRows) Push(x interface{}) { t.items = append(t.items, x.(topItem)) }
+func (t *topRows) Pop() interface{} {
+	item := t.items[len(t.items)-1]
+	t.items = t.items[:len(t.items)-1]
+	return item
+}
+
+func (t *topRows) lessItem(i1, i2 topItem) bool {
+	if t.less(i1.row, i2.row) {
+		return true
+	}
+	return !t.less(i2.row, i1.row) && i1.seq < i2.seq
+}
+
+func (t *topRows) add(row IOutputRow, limit int) {
+	item := topItem{row: row, seq: t.seq}
+	t.seq++
+	if len(t.items) < limit {
+		heap.Push(t, item)
+		return
+	}
+	if t.lessItem(item, t.items[0]) {
+		t.items[0] = item
+		heap.Fix(t, 0)
+	}
+}
+
+func (t *topRows) sorted() []IOutputRow {
+	sort.Slice(t.items, func(i, j int) bool { return t.lessItem(t.items[i], t.items[j]) })
+	rows := make([]IOutputRow, len(t.items))
+	for i, item := range t.items {
+		rows[i] = item.row
+	}
+	return rows
+}
+
+type rowsSource interface {
+	// returns nil row if there are no more rows
+	next() (IOutputRow, error)
+}
+
+type memorySource struct {
+	rows []IOutputRow
+}
+
+func (s *memorySource) next() (row IOutputRow, err error) {
+	if len(s.rows) == 0 {
+		return nil, nil
+	}
+	row, s.rows = s.rows[0], s.rows[1:]
+	return row, nil
+}
+
+type spillSource struct {
+	decoder *gob.Decoder
+}
+
+func (s *spillSource) next() (IOutputRow, error) {
+	sr := spillRow{}
+	if err := s.decoder.Decode(&sr); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return sr.outputRow(), nil
+}
+
+// mergeHeap keeps the current rows of the sorted sources, rows with the same key are taken from the sources in order
+type mergeHeap struct {
+	items []mergeItem
+	less  func(r1, r2 IOutputRow) bool
+}
+
+type mergeItem struct {
+	row    IOutputRow
+	source int
+}
+
+func (h *mergeHeap) Len() int { return len(h.items) }
+func (h *mergeHeap) Less(i, j int) bool {
+	if h.less(h.items[i].row, h.items[j].row) {
+		return true
+	}
+	return !h.less(h.items[j].row, h.items[i].row) && h.items[i].source < h.items[j].source
+}
+func (h *mergeHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *mergeHeap) Push(x interface{}) { h.items = append(h.items, x.(mergeItem)) }
+func (h *mergeHeap) Pop() interface{} {
+	item := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return item
+}
+
+// spillRow is the output row which is written to the temp file
+type spillRow struct {
+	KeyToIdx map[string]int
+	Values   []interface{}
+}
+
+func newSpillRow(row IOutputRow) (sr spillRow, err error) {
+	r, ok := row.(*outputRow)
+	if !ok {
+		return sr, fmt.Errorf("row %T can't be spilled: %w", row, ErrWrongType)
+	}
+	sr.KeyToIdx = r.keyToIdx
+	sr.Values = make([]interface{}, len(r.values))
+	for i, value := range r.values {
+		rows, ok := value.([]IOutputRow)
+		if !ok {
+			sr.Values[i] = value
+			continue
+		}
+		spillRows := make([]spillRow, len(rows))
+		for j, row := range rows {
+			if spillRows[j], err = newSpillRow(row); err != nil {
+				return sr, err
+			}
+		}
+		sr.Values[i] = spillRows
+	}
+	return sr, nil
+}
+
+func (sr spillRow) outputRow() IOutputRow {
+	r := &outputRow{
+		keyToIdx: sr.KeyToIdx,
+		values:   make([]interface{}, len(sr.Values)),
+	}
+	for i, value := range sr.Values {
+		spillRows, ok := value.([]spillRow)
+		if !ok {
+			r.values[i] = value
+			continue
+		}
+		rows := make([]IOutputRow, len(spillRows))
+		for j, spillRow := range spillRows {
+			rows[j] = spillRow.outputRow()
+		}
+		r.values[i] = rows
+	}
+	return r
+}
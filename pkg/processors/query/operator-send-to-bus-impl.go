@@ -23,6 +23,9 @@ func (o *SendToBusOperator) DoAsync(_ context.Context, work pipeline.IWorkpiece)
 	defer func() {
 		o.metrics.Increase(execSendSeconds, time.Since(begin).Seconds())
 	}()
+	if cursor := work.(workpiece).cursor; cursor != "" {
+		return work, o.rs.ObjectSection(cursorSection, nil, cursor)
+	}
 	if !o.initialized {
 		//TODO what to set into sectionType, path?
 		o.rs.StartArraySection("", nil)
@@ -54,3 +54,23 @@ func TestSendToBusOperator_OnError(t *testing.T) {
 
 	require.Equal(1, times)
 }
+
+func TestSendToBusOperator_DoAsync_Cursor(t *testing.T) {
+	require := require.New(t)
+	sent := ""
+	operator := SendToBusOperator{
+		rs: testResultSenderClosable{
+			objectSection: func(sectionType string, path []string, element interface{}) (err error) {
+				require.Equal(cursorSection, sectionType)
+				sent = element.(string)
+				return nil
+			},
+		},
+		metrics: &testMetrics{},
+	}
+
+	_, err := operator.DoAsync(context.Background(), workpiece{cursor: "token"})
+
+	require.NoError(err)
+	require.Equal("token", sent)
+}
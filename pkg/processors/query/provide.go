@@ -4,10 +4,21 @@
 
 package queryprocessor
 
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructsmem"
+)
+
 func ProvideRowsProcessorFactory() RowsProcessorFactory {
 	return implRowsProcessorFactory
 }
 
+// ProvideReadViewQuery adds q.sys.ReadView query function to the application.
+// appDefBuilder is the application definition builder which the config is created with
+func ProvideReadViewQuery(cfg *istructsmem.AppConfigType, appDefBuilder appdef.IAppDefBuilder) {
+	addReadViewQuery(cfg, appDefBuilder)
+}
+
 func ProvideServiceFactory() ServiceFactory {
 	return implServiceFactory
 }
@@ -7,6 +7,7 @@ package queryprocessor
 import (
 	"fmt"
 
+	"github.com/voedger/voedger/pkg/appdef"
 	coreutils "github.com/voedger/voedger/pkg/utils"
 )
 
@@ -14,17 +15,22 @@ type queryParams struct {
 	elements  []IElement
 	filters   []IFilter
 	orderBy   []IOrderBy
+	aggregate IAggregate
+	cursor    ICursor
 	startFrom int64
 	count     int64
 }
 
-func (p queryParams) Elements() []IElement { return p.elements }
-func (p queryParams) Filters() []IFilter   { return p.filters }
-func (p queryParams) OrderBy() []IOrderBy  { return p.orderBy }
-func (p queryParams) StartFrom() int64     { return p.startFrom }
-func (p queryParams) Count() int64         { return p.count }
+func (p queryParams) Elements() []IElement  { return p.elements }
+func (p queryParams) Filters() []IFilter    { return p.filters }
+func (p queryParams) OrderBy() []IOrderBy   { return p.orderBy }
+func (p queryParams) Aggregate() IAggregate { return p.aggregate }
+func (p queryParams) Cursor() ICursor       { return p.cursor }
+func (p queryParams) StartFrom() int64      { return p.startFrom }
+func (p queryParams) Count() int64          { return p.count }
 
-func newQueryParams(data coreutils.MapObject, elementFactory ElementFactory, filterFactory FilterFactory, orderByFactory OrderByFactory, rootFields coreutils.FieldsDef) (res IQueryParams, err error) {
+func newQueryParams(data coreutils.MapObject, elementFactory ElementFactory, filterFactory FilterFactory, orderByFactory OrderByFactory,
+	aggregateFactory AggregateFactory, rootFields coreutils.FieldsDef) (res IQueryParams, err error) {
 	qp := queryParams{}
 	if err = qp.fillArray(data, "elements", func(elem coreutils.MapObject) error {
 		element, err := elementFactory(elem)
@@ -53,12 +59,30 @@ func newQueryParams(data coreutils.MapObject, elementFactory ElementFactory, fil
 	}); err != nil {
 		return nil, fmt.Errorf("orderBy: %w", err)
 	}
+	aggregateData, ok, err := data.AsObject("aggregate")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if qp.aggregate, err = aggregateFactory(aggregateData); err != nil {
+			return nil, err
+		}
+	}
 	if qp.count, _, err = data.AsInt64("count"); err != nil {
 		return nil, err
 	}
 	if qp.startFrom, _, err = data.AsInt64("startFrom"); err != nil {
 		return nil, err
 	}
+	token, ok, err := data.AsString("cursor")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if qp.cursor, err = NewCursor(token); err != nil {
+			return nil, err
+		}
+	}
 	return qp, qp.validate(rootFields)
 }
 
@@ -99,33 +123,118 @@ func (p queryParams) validate(rootFields coreutils.FieldsDef) (err error) {
 			fields[field.Key()] = true
 		}
 	}
-	validateFilter := func(filter, field string) (err error) {
+	validateFilter := func(filter, field string, argName string, args ...interface{}) (err error) {
 		if _, ok := fields[field]; !ok {
 			return fmt.Errorf("'%s' filter has field '%s' that is absent in root element fields/refs, please add or change it: %w", filter, field, ErrUnexpected)
 		}
+		// data kinds of the ref fields are unknown here, they are checked by the filter
+		if kind, ok := rootFields[field]; ok {
+			for _, arg := range args {
+				if err = filterArgOfKind(kind, argName, arg); err != nil {
+					return filterErr(filter, err)
+				}
+			}
+		}
 		return nil
 	}
 	err = validateFilters(p.filters, validateFilter)
 	if err != nil {
 		return fmt.Errorf("filters: %w", err)
 	}
-	return p.validateOrderBy(fields)
+	if p.aggregate != nil {
+		if fields, err = p.validateAggregate(fields, rootFields); err != nil {
+			return fmt.Errorf("aggregate: %w", err)
+		}
+	}
+	if err = p.validateOrderBy(fields); err != nil {
+		return err
+	}
+	if p.cursor != nil {
+		if p.startFrom != 0 {
+			return fmt.Errorf("cursor and startFrom must not be used together: %w", ErrUnexpected)
+		}
+		if len(p.orderBy) == 0 {
+			return fmt.Errorf("cursor requires orderBy: %w", ErrUnexpected)
+		}
+		return validateCursor(p.cursor, p.orderBy)
+	}
+	return nil
 }
 
-func validateFilters(filters []IFilter, validateFilter func(filter, field string) (err error)) (err error) {
+// validateAggregate returns fields of aggregated rows
+func (p queryParams) validateAggregate(fields map[string]bool, rootFields coreutils.FieldsDef) (aggregatedFields map[string]bool, err error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("root element must be present: %w", ErrNotFound)
+	}
+	aggregatedFields = make(map[string]bool)
+	for _, field := range p.aggregate.GroupBy() {
+		if !fields[field] {
+			return nil, fmt.Errorf("groupBy has field '%s' that is absent in root element fields/refs, please add or change it: %w", field, ErrUnexpected)
+		}
+		if aggregatedFields[field] {
+			return nil, fmt.Errorf("groupBy field '%s' must be unique", field)
+		}
+		aggregatedFields[field] = true
+	}
+	for _, f := range p.aggregate.Functions() {
+		if aggregatedFields[f.Alias()] {
+			return nil, fmt.Errorf("'%s' function alias '%s' must be unique", f.Func(), f.Alias())
+		}
+		aggregatedFields[f.Alias()] = true
+		if f.Field() == "" {
+			continue
+		}
+		if !fields[f.Field()] {
+			return nil, fmt.Errorf("'%s' function has field '%s' that is absent in root element fields/refs, please add or change it: %w", f.Func(), f.Field(), ErrUnexpected)
+		}
+		if f.Func() == aggregateFunc_Count {
+			continue
+		}
+		switch rootFields[f.Field()] {
+		case appdef.DataKind_int32, appdef.DataKind_int64, appdef.DataKind_float32, appdef.DataKind_float64, appdef.DataKind_decimal:
+		case appdef.DataKind_string:
+			if f.Func() == aggregateFunc_Min || f.Func() == aggregateFunc_Max {
+				break
+			}
+			fallthrough
+		default:
+			return nil, fmt.Errorf("'%s' function is impossible for field '%s': %w", f.Func(), f.Field(), ErrWrongType)
+		}
+	}
+	return aggregatedFields, nil
+}
+
+func validateFilters(filters []IFilter, validateFilter func(filter, field string, argName string, args ...interface{}) (err error)) (err error) {
 	for _, f := range filters {
 		if err != nil {
 			return
 		}
 		switch filter := f.(type) {
 		case *EqualsFilter:
-			err = validateFilter(filterKind_Eq, filter.field)
+			err = validateFilter(filterKind_Eq, filter.field, "value", filter.value)
 		case *NotEqualsFilter:
-			err = validateFilter(filterKind_NotEq, filter.field)
+			err = validateFilter(filterKind_NotEq, filter.field, "value", filter.value)
 		case *GreaterFilter:
-			err = validateFilter(filterKind_Gt, filter.field)
+			err = validateFilter(filterKind_Gt, filter.field, "value", filter.value)
 		case *LessFilter:
-			err = validateFilter(filterKind_Lt, filter.field)
+			err = validateFilter(filterKind_Lt, filter.field, "value", filter.value)
+		case *InFilter:
+			err = validateFilter(filterKind_In, filter.field, "values", filter.values...)
+		case *BetweenFilter:
+			if err = validateFilter(filterKind_Between, filter.field, "from", filter.from); err == nil {
+				err = validateFilter(filterKind_Between, filter.field, "till", filter.till)
+			}
+		case *StartsWithFilter:
+			err = validateFilter(filterKind_StartsWith, filter.field, "value")
+		case *ContainsFilter:
+			err = validateFilter(filterKind_Contains, filter.field, "value")
+		case *IsNullFilter:
+			err = validateFilter(filterKind_IsNull, filter.field, "")
+		case *NotFilter:
+			err = validateFilters([]IFilter{filter.filter}, validateFilter)
+			if err != nil {
+				err = fmt.Errorf("'%s' filter: %w", filterKind_Not, err)
+			}
 		case *AndFilter:
 			err = validateFilters(filter.filters, validateFilter)
 			if err != nil {
@@ -9,10 +9,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/iauthnzimpl"
 	"github.com/voedger/voedger/pkg/iprocbus"
 	"github.com/voedger/voedger/pkg/istructs"
 	imetrics "github.com/voedger/voedger/pkg/metrics"
+	coreutils "github.com/voedger/voedger/pkg/utils"
 )
 
 func TestWrongTypes(t *testing.T) {
@@ -34,7 +36,7 @@ func TestWrongTypes(t *testing.T) {
 
 	cfgs, appStructsProvider, appTokens := getTestCfg(require, nil)
 	queryProcessor := ProvideServiceFactory()(serviceChannel, resultSenderClosableFactory, appStructsProvider, 3, imetrics.Provide(),
-		"hvm", authn, authz, cfgs)
+		"hvm", authn, authz, cfgs, nil)
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
 		queryProcessor.Run(ctx)
@@ -201,6 +203,56 @@ func TestWrongTypes(t *testing.T) {
 			body: `{"elements":[{"fields":["sys.ID"]}],"filters":[{"expr":"and","args":[{"expr":"eq","args":{"field":"wrong","value":"wrong"}},{"expr":"eq","args":{"field":"sys.ID","value":1}}]}]}`,
 			err:  "filters: 'and' filter: 'eq' filter has field 'wrong' that is absent in root element fields/refs, please add or change it: unexpected",
 		},
+		{
+			name: "In filter values must be present",
+			body: `{"filters":[{"expr":"in","args":{"field":"name","values":[]}}]}`,
+			err:  "filters: 'in' filter: field 'values' must be a non-empty array: not found",
+		},
+		{
+			name: "In filter values must be scalars",
+			body: `{"filters":[{"expr":"in","args":{"field":"name","values":[{}]}}]}`,
+			err:  "filters: 'in' filter: field 'values' must contain numbers, strings or booleans: wrong type",
+		},
+		{
+			name: "Between filter till must be present",
+			body: `{"filters":[{"expr":"between","args":{"field":"name","from":1}}]}`,
+			err:  "filters: 'between' filter: field 'till' must be present: not found",
+		},
+		{
+			name: "Starts with filter value must be a string",
+			body: `{"filters":[{"expr":"startsWith","args":{"field":"name","value":1}}]}`,
+			err:  "filters: 'startsWith' filter: field 'value' must be a string: field type mismatch",
+		},
+		{
+			name: "Contains filter case insensitive option must be a boolean",
+			body: `{"filters":[{"expr":"contains","args":{"field":"name","value":"a","options":{"caseInsensitive":1}}}]}`,
+			err:  "filters: 'contains' filter: field 'caseInsensitive' must be a boolean: field type mismatch",
+		},
+		{
+			name: "Not filter args must be an object",
+			body: `{"filters":[{"expr":"not","args":[]}]}`,
+			err:  "filters: 'not' filter: field 'args' must be an object: wrong type",
+		},
+		{
+			name: "Not filter field must be present in root element fields/refs",
+			body: `{"elements":[{"fields":["sys.ID"]}],"filters":[{"expr":"not","args":{"expr":"isNull","args":{"field":"wrong"}}}]}`,
+			err:  "filters: 'not' filter: 'isNull' filter has field 'wrong' that is absent in root element fields/refs, please add or change it: unexpected",
+		},
+		{
+			name: "In filter values must suit the field data kind",
+			body: `{"elements":[{"fields":["id_department"]}],"filters":[{"expr":"in","args":{"field":"id_department","values":[1,"x"]}}]}`,
+			err:  "filters: 'in' filter: field 'values' must contain numbers for DataKind_int64 field: wrong type",
+		},
+		{
+			name: "Between filter bounds must suit the field data kind",
+			body: `{"elements":[{"fields":["name"]}],"filters":[{"expr":"between","args":{"field":"name","from":"a","till":1}}]}`,
+			err:  "filters: 'between' filter: field 'till' must contain strings for DataKind_string field: wrong type",
+		},
+		{
+			name: "Equals filter value must suit the field data kind",
+			body: `{"elements":[{"fields":["id_department"]}],"filters":[{"expr":"or","args":[{"expr":"eq","args":{"field":"id_department","value":true}}]}]}`,
+			err:  "filters: 'or' filter: 'eq' filter: field 'value' must contain numbers for DataKind_int64 field: wrong type",
+		},
 		{
 			name: "OrderBy must be an array",
 			body: `{"orderBy":{}}`,
@@ -256,6 +308,66 @@ func TestWrongTypes(t *testing.T) {
 			body: `{"elements":[{"fields":["sys.ID"]}],"orderBy":[{"field":"wrong"}]}`,
 			err:  "orderBy has field 'wrong' that is absent in root element fields/refs, please add or change it: unexpected",
 		},
+		{
+			name: "Aggregate must be an object",
+			body: `{"aggregate":[]}`,
+			err:  "field 'aggregate' must be an object: field type mismatch",
+		},
+		{
+			name: "Aggregate function must be known",
+			body: `{"aggregate":{"functions":[{"func":"median","field":"id_department"}]}}`,
+			err:  "aggregate: functions: function 'median' is unknown: wrong type",
+		},
+		{
+			name: "Aggregate function field must be present except count",
+			body: `{"aggregate":{"functions":[{"func":"sum"}]}}`,
+			err:  "aggregate: functions: 'sum' function: field 'field' must be present: not found",
+		},
+		{
+			name: "Aggregate group by field must be present in root element fields/refs",
+			body: `{"elements":[{"fields":["name"]}],"aggregate":{"groupBy":["wrong"]}}`,
+			err:  "aggregate: groupBy has field 'wrong' that is absent in root element fields/refs, please add or change it: unexpected",
+		},
+		{
+			name: "Aggregate function must be applicable to field kind",
+			body: `{"elements":[{"fields":["name"]}],"aggregate":{"functions":[{"func":"sum","field":"name"}]}}`,
+			err:  "aggregate: 'sum' function is impossible for field 'name': wrong type",
+		},
+		{
+			name: "Aggregate function alias must be unique",
+			body: `{"elements":[{"fields":["name","id_department"]}],"aggregate":{"groupBy":["name"],"functions":[{"func":"count","alias":"name"}]}}`,
+			err:  "aggregate: 'count' function alias 'name' must be unique",
+		},
+		{
+			name: "Order by field must be present in aggregated fields",
+			body: `{"elements":[{"fields":["name","id_department"]}],"aggregate":{"groupBy":["name"]},"orderBy":[{"field":"id_department"}]}`,
+			err:  "orderBy has field 'id_department' that is absent in root element fields/refs, please add or change it: unexpected",
+		},
+		{
+			name: "Cursor must be a string",
+			body: `{"cursor":1}`,
+			err:  "field 'cursor' must be a string: field type mismatch",
+		},
+		{
+			name: "Cursor must be well-formed",
+			body: `{"cursor":"#"}`,
+			err:  "cursor: illegal base64 data at input byte 0: wrong type",
+		},
+		{
+			name: "Cursor must be issued for the same orderBy",
+			body: `{"elements":[{"fields":["name"]}],"orderBy":[{"field":"name"}],"cursor":"eyJvcmRlckJ5IjpbeyJmaWVsZCI6Im5hbWUiLCJkZXNjIjp0cnVlfV0sImtleSI6WyJDb2xhIl19"}`,
+			err:  "cursor is issued for another orderBy: unexpected",
+		},
+		{
+			name: "Cursor and startFrom must not be used together",
+			body: `{"startFrom":1,"cursor":"e30"}`,
+			err:  "cursor and startFrom must not be used together: unexpected",
+		},
+		{
+			name: "Cursor requires orderBy",
+			body: `{"cursor":"e30"}`,
+			err:  "cursor requires orderBy: unexpected",
+		},
 		{
 			name: "Each element must have unique path",
 			body: `{"elements":[{"fields":["sys.ID"],"path":"article"},{"fields":["sys.ID"],"path":"article"}]}`,
@@ -277,3 +389,14 @@ func TestWrongTypes(t *testing.T) {
 	cancel()
 	<-done
 }
+
+func TestValidateAggregate_Decimal(t *testing.T) {
+	require := require.New(t)
+	rootFields := coreutils.FieldsDef{"price": appdef.DataKind_decimal}
+	for _, fn := range []string{aggregateFunc_Sum, aggregateFunc_Avg, aggregateFunc_Min, aggregateFunc_Max} {
+		p := queryParams{aggregate: aggregate{functions: []IAggregateFunction{aggregateFunction{fn: fn, field: "price", alias: fn}}}}
+		fields, err := p.validateAggregate(map[string]bool{"price": true}, rootFields)
+		require.NoError(err, fn)
+		require.Equal(map[string]bool{fn: true}, fields, fn)
+	}
+}
@@ -0,0 +1,439 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/state"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+// selectAuthorizer is implemented by the query processor workpiece
+type selectAuthorizer interface {
+	AuthorizeSelect(resource appdef.QName, fields []string) error
+}
+
+// errReadViewDone stops the view read when there are no more rows to send
+var errReadViewDone = errors.New("read view done")
+
+type readViewParams struct {
+	view        appdef.QName
+	key         viewPushDown
+	ranges      []clustRange
+	limit       int32
+	fields      []string
+	valueFields map[string]bool
+}
+
+// clustRange is the inclusive range of the clustering column values converted to the column data kind.
+// Nil bound means that the range is not bounded from that side
+type clustRange struct {
+	field string
+	kind  appdef.DataKind
+	from  interface{}
+	till  interface{}
+	// rows are read in the column values order, so the read is stopped after the till bound
+	stopAfterTill bool
+}
+
+func addReadViewQuery(cfg *istructsmem.AppConfigType, appDefBuilder appdef.IAppDefBuilder) {
+	appDefBuilder.AddStruct(QNameQueryReadViewParams, appdef.DefKind_Object).
+		AddField(Field_ReadView_View, appdef.DataKind_string, true).
+		AddField(Field_ReadView_Key, appdef.DataKind_string, true).
+		AddField(Field_ReadView_Range, appdef.DataKind_string, false).
+		AddField(Field_ReadView_Limit, appdef.DataKind_int32, false)
+
+	cfg.Resources.Add(istructsmem.NewQueryFunctionCustomResult(QNameQueryReadView, QNameQueryReadViewParams, readViewResultDef,
+		func(_ context.Context, _ istructs.IQueryFunction, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) error {
+			return readView(cfg.AppDef, args, callback)
+		}))
+}
+
+// readViewResultDef returns the view as the result definition, so result fields, filters and orderBy of the
+// query params refer to the view fields
+func readViewResultDef(args istructs.PrepareArgs) appdef.QName {
+	view, err := appdef.ParseQName(args.ArgumentObject.AsString(Field_ReadView_View))
+	if err != nil {
+		return appdef.NullQName
+	}
+	return view
+}
+
+func readView(appDef appdef.IAppDef, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) (err error) {
+	params, err := newReadViewParams(appDef, args.ArgumentObject)
+	if err != nil {
+		return coreutils.NewHTTPError(http.StatusBadRequest, err)
+	}
+	authorizer, ok := args.Workpiece.(selectAuthorizer)
+	if !ok {
+		return fmt.Errorf("workpiece does not authorize view read: %w", ErrUnexpected)
+	}
+	if err = authorizer.AuthorizeSelect(params.view, params.fields); err != nil {
+		return err
+	}
+	kb, err := args.State.KeyBuilder(state.ViewRecordsStorage, params.view)
+	if err != nil {
+		return err
+	}
+	params.key.FillKey(kb)
+	count := int32(0)
+	err = args.State.Read(kb, func(key istructs.IKey, value istructs.IStateValue) error {
+		inRange, done := params.inRange(key)
+		if done {
+			return errReadViewDone
+		}
+		if !inRange {
+			return nil
+		}
+		if err := callback(&viewRow{view: params.view, key: key, value: value, valueFields: params.valueFields}); err != nil {
+			return err
+		}
+		count++
+		if params.limit != 0 && count == params.limit {
+			return errReadViewDone
+		}
+		return nil
+	})
+	if errors.Is(err, errReadViewDone) {
+		return nil
+	}
+	return err
+}
+
+// newReadViewParams checks the view, the key and the ranges against the view definition.
+// The key must contain all partition key fields and may contain the clustering columns prefix.
+// Ranges are allowed for clustering columns which are not in the key
+func newReadViewParams(appDef appdef.IAppDef, args istructs.IObject) (params readViewParams, err error) {
+	if params.view, err = appdef.ParseQName(args.AsString(Field_ReadView_View)); err != nil {
+		return params, err
+	}
+	if appDef.DefByName(params.view) == nil || appDef.Def(params.view).Kind() != appdef.DefKind_ViewRecord {
+		return params, fmt.Errorf("view %s: %w", params.view, ErrNotFound)
+	}
+	if params.limit = args.AsInt32(Field_ReadView_Limit); params.limit < 0 {
+		return params, fmt.Errorf("limit must be not negative: %w", ErrWrongType)
+	}
+
+	key, err := readViewJSON(args.AsString(Field_ReadView_Key))
+	if err != nil {
+		return params, fmt.Errorf("key: %w", err)
+	}
+	ranges, err := readViewJSON(args.AsString(Field_ReadView_Range))
+	if err != nil {
+		return params, fmt.Errorf("range: %w", err)
+	}
+
+	fields := make(map[string]bool)
+	appDef.Def(appdef.ViewPartitionKeyDefName(params.view)).Fields(func(f appdef.IField) {
+		fields[f.Name()] = true
+		params.fields = append(params.fields, f.Name())
+		value, ok := key[f.Name()]
+		if !ok {
+			err = errors.Join(err, fmt.Errorf("key: partition key field '%s' is missing: %w", f.Name(), ErrNotFound))
+			return
+		}
+		err = errors.Join(err, params.addKeyValue(f, value))
+	})
+	prefixDone := false
+	appDef.Def(appdef.ViewClusteringColumsDefName(params.view)).Fields(func(f appdef.IField) {
+		fields[f.Name()] = true
+		params.fields = append(params.fields, f.Name())
+		value, ok := key[f.Name()]
+		if ok {
+			if prefixDone {
+				err = errors.Join(err, fmt.Errorf("key: clustering column '%s' follows the missing clustering column: %w", f.Name(), ErrUnexpected))
+				return
+			}
+			err = errors.Join(err, params.addKeyValue(f, value))
+			if _, ok := ranges[f.Name()]; ok {
+				err = errors.Join(err, fmt.Errorf("range: clustering column '%s' is in the key: %w", f.Name(), ErrUnexpected))
+			}
+			return
+		}
+		if r, ok := ranges[f.Name()]; ok {
+			err = errors.Join(err, params.addRange(f, r, !prefixDone))
+		}
+		prefixDone = true
+	})
+	params.valueFields = make(map[string]bool)
+	appDef.Def(appdef.ViewValueDefName(params.view)).Fields(func(f appdef.IField) {
+		params.valueFields[f.Name()] = true
+		if f.IsSys() {
+			return
+		}
+		params.fields = append(params.fields, f.Name())
+	})
+	if err != nil {
+		return params, err
+	}
+
+	for field := range key {
+		if !fields[field] {
+			return params, fmt.Errorf("key: field '%s' is not the view key field: %w", field, ErrNotFound)
+		}
+	}
+	for field := range ranges {
+		if !fields[field] {
+			return params, fmt.Errorf("range: field '%s' is not the view clustering column: %w", field, ErrNotFound)
+		}
+	}
+	params.key.partKeyFilled = true
+	return params, nil
+}
+
+func readViewJSON(data string) (res map[string]interface{}, err error) {
+	res = make(map[string]interface{})
+	if data == "" {
+		return res, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(data)))
+	decoder.UseNumber()
+	if err = decoder.Decode(&res); err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrWrongType)
+	}
+	return res, nil
+}
+
+func (p *readViewParams) addKeyValue(f appdef.IField, value interface{}) error {
+	v, err := keyValueFromJSON(f.DataKind(), value)
+	if err != nil {
+		return fmt.Errorf("key: field '%s': %w", f.Name(), err)
+	}
+	p.key.key = append(p.key.key, keyValue{field: f.Name(), kind: f.DataKind(), value: v})
+	return nil
+}
+
+// addRange adds the range of the clustering column, data is {"from": value, "till": value}
+func (p *readViewParams) addRange(f appdef.IField, data interface{}, first bool) error {
+	bounds, ok := data.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("range: field '%s' must be an object: %w", f.Name(), ErrWrongType)
+	}
+	r := clustRange{field: f.Name(), kind: f.DataKind()}
+	switch f.DataKind() {
+	case appdef.DataKind_string, appdef.DataKind_RecordID:
+		// bytes order of the stored values is the values order
+		r.stopAfterTill = first
+	default:
+		// e.g. negative numbers are stored after positive ones, so the read is never stopped
+	}
+	var err error
+	if from, ok := bounds["from"]; ok && from != nil {
+		if r.from, err = keyValueFromJSON(f.DataKind(), from); err != nil {
+			return fmt.Errorf("range: field '%s': %w", f.Name(), err)
+		}
+	}
+	if till, ok := bounds["till"]; ok && till != nil {
+		if r.till, err = keyValueFromJSON(f.DataKind(), till); err != nil {
+			return fmt.Errorf("range: field '%s': %w", f.Name(), err)
+		}
+	}
+	p.ranges = append(p.ranges, r)
+	return nil
+}
+
+// inRange returns true if the key values are in ranges, done is true if the following keys are out of ranges as well
+func (p *readViewParams) inRange(key istructs.IKey) (inRange bool, done bool) {
+	for _, r := range p.ranges {
+		value := keyValue{field: r.field, kind: r.kind}.read(key)
+		if r.from != nil && compareKeyValues(value, r.from) < 0 {
+			return false, false
+		}
+		if r.till != nil && compareKeyValues(value, r.till) > 0 {
+			return false, r.stopAfterTill
+		}
+	}
+	return true, false
+}
+
+// keyValueFromJSON converts the JSON value to the value of the key field data kind.
+// Numbers are JSON numbers, decimals are JSON numbers or strings, bytes are base64 strings,
+// timestamps, dates and UUIDs are strings in istructs.TimestampLayout, istructs.DateLayout and canonical UUID format
+func keyValueFromJSON(kind appdef.DataKind, value interface{}) (interface{}, error) {
+	wrongType := func(err error) (interface{}, error) { return nil, fmt.Errorf("%v: %w", err, ErrWrongType) }
+	switch kind {
+	case appdef.DataKind_int32, appdef.DataKind_int64, appdef.DataKind_RecordID:
+		n, ok := value.(json.Number)
+		if !ok {
+			return nil, ErrWrongType
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return wrongType(err)
+		}
+		switch kind {
+		case appdef.DataKind_int32:
+			return int32(i), nil
+		case appdef.DataKind_int64:
+			return i, nil
+		default:
+			return istructs.RecordID(i), nil
+		}
+	case appdef.DataKind_float32, appdef.DataKind_float64:
+		n, ok := value.(json.Number)
+		if !ok {
+			return nil, ErrWrongType
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return wrongType(err)
+		}
+		if kind == appdef.DataKind_float32 {
+			return float32(f), nil
+		}
+		return f, nil
+	case appdef.DataKind_decimal:
+		var s string
+		switch v := value.(type) {
+		case json.Number:
+			s = v.String()
+		case string:
+			s = v
+		default:
+			return nil, ErrWrongType
+		}
+		d, err := istructs.ParseDecimal(s)
+		if err != nil {
+			return wrongType(err)
+		}
+		return d, nil
+	case appdef.DataKind_bool:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+		return nil, ErrWrongType
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, ErrWrongType
+	}
+	switch kind {
+	case appdef.DataKind_string:
+		return s, nil
+	case appdef.DataKind_bytes:
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return wrongType(err)
+		}
+		return b, nil
+	case appdef.DataKind_QName:
+		q, err := appdef.ParseQName(s)
+		if err != nil {
+			return wrongType(err)
+		}
+		return q, nil
+	case appdef.DataKind_timestamp:
+		t, err := time.Parse(istructs.TimestampLayout, s)
+		if err != nil {
+			return wrongType(err)
+		}
+		// stored timestamps are truncated to milliseconds
+		return t.Truncate(time.Millisecond), nil
+	case appdef.DataKind_date:
+		t, err := time.Parse(istructs.DateLayout, s)
+		if err != nil {
+			return wrongType(err)
+		}
+		return t, nil
+	case appdef.DataKind_UUID:
+		u, err := istructs.ParseUUID(s)
+		if err != nil {
+			return wrongType(err)
+		}
+		return u, nil
+	}
+	return nil, ErrWrongType
+}
+
+// compareKeyValues compares the values of the same key field data kind
+func compareKeyValues(a, b interface{}) int {
+	switch v := a.(type) {
+	case int32:
+		return compareOrdered(int64(v), int64(b.(int32)))
+	case int64:
+		return compareOrdered(v, b.(int64))
+	case istructs.RecordID:
+		return compareOrdered(int64(v), int64(b.(istructs.RecordID)))
+	case float32:
+		return compareOrdered(v, b.(float32))
+	case float64:
+		return compareOrdered(v, b.(float64))
+	case istructs.Decimal:
+		return v.Cmp(b.(istructs.Decimal))
+	case string:
+		return strings.Compare(v, b.(string))
+	case []byte:
+		return bytes.Compare(v, b.([]byte))
+	case appdef.QName:
+		return strings.Compare(v.String(), b.(appdef.QName).String())
+	case bool:
+		switch {
+		case v == b.(bool):
+			return 0
+		case v:
+			return 1
+		default:
+			return -1
+		}
+	case time.Time:
+		return v.Compare(b.(time.Time))
+	case istructs.UUID:
+		u := b.(istructs.UUID)
+		return bytes.Compare(v[:], u[:])
+	}
+	return 0
+}
+
+// viewRow is the view record as the query function result object
+type viewRow struct {
+	istructs.NullObject
+	view        appdef.QName
+	key         istructs.IKey
+	value       istructs.IValue
+	valueFields map[string]bool
+}
+
+func (r *viewRow) QName() appdef.QName { return r.view }
+
+func (r *viewRow) reader(name string) istructs.IRowReader {
+	if r.valueFields[name] {
+		return r.value
+	}
+	return r.key
+}
+
+func (r *viewRow) AsInt32(name string) int32        { return r.reader(name).AsInt32(name) }
+func (r *viewRow) AsInt64(name string) int64        { return r.reader(name).AsInt64(name) }
+func (r *viewRow) AsFloat32(name string) float32    { return r.reader(name).AsFloat32(name) }
+func (r *viewRow) AsFloat64(name string) float64    { return r.reader(name).AsFloat64(name) }
+func (r *viewRow) AsBytes(name string) []byte       { return r.reader(name).AsBytes(name) }
+func (r *viewRow) AsString(name string) string      { return r.reader(name).AsString(name) }
+func (r *viewRow) AsQName(name string) appdef.QName { return r.reader(name).AsQName(name) }
+func (r *viewRow) AsBool(name string) bool          { return r.reader(name).AsBool(name) }
+func (r *viewRow) AsRecordID(name string) istructs.RecordID {
+	return r.reader(name).AsRecordID(name)
+}
+func (r *viewRow) AsDecimal(name string) istructs.Decimal { return r.reader(name).AsDecimal(name) }
+func (r *viewRow) AsTimestamp(name string) time.Time      { return r.reader(name).AsTimestamp(name) }
+func (r *viewRow) AsDate(name string) time.Time           { return r.reader(name).AsDate(name) }
+func (r *viewRow) AsUUID(name string) istructs.UUID       { return r.reader(name).AsUUID(name) }
+
+func (r *viewRow) FieldNames(cb func(fieldName string)) {
+	r.key.FieldNames(cb)
+	r.value.FieldNames(cb)
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/iauthnzimpl"
+	"github.com/voedger/voedger/pkg/iprocbus"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	imetrics "github.com/voedger/voedger/pkg/metrics"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+type testAuthorizer struct {
+	iauthnz.IAuthorizer
+	deny appdef.QName
+}
+
+func (a testAuthorizer) Authorize(app istructs.IAppStructs, principals []iauthnz.Principal, req iauthnz.AuthzRequest) (bool, error) {
+	if req.Resource == a.deny {
+		return false, nil
+	}
+	return a.IAuthorizer.Authorize(app, principals, req)
+}
+
+func TestReadView(t *testing.T) {
+	require := require.New(t)
+	view := appdef.NewQName("test", "salesView")
+	deniedView := appdef.NewQName("test", "deniedView")
+	paymentsView := appdef.NewQName("test", "paymentsView")
+	const wsid = istructs.WSID(15)
+
+	var adb appdef.IAppDefBuilder
+	cfgs, appStructsProvider, appTokens := getTestCfg(require,
+		func(appDef appdef.IAppDefBuilder) {
+			adb = appDef
+			for _, v := range []appdef.QName{view, deniedView} {
+				appDef.AddView(v).
+					AddPartField("department", appdef.DataKind_int32).
+					AddClustColumn("year", appdef.DataKind_int32).
+					AddClustColumn("name", appdef.DataKind_string).
+					AddValueField("amount", appdef.DataKind_int64, true)
+			}
+			payments := appDef.AddView(paymentsView).
+				AddPartField("day", appdef.DataKind_date).
+				AddPartField("terminal", appdef.DataKind_UUID).
+				AddClustColumn("moment", appdef.DataKind_timestamp)
+			payments.ClustColsDef().AddDecimalField("amount", 10, 2, false)
+			payments.AddValueField("total", appdef.DataKind_int64, true)
+		},
+		func(cfg *istructsmem.AppConfigType) {
+			ProvideReadViewQuery(cfg, adb)
+		})
+
+	as, err := appStructsProvider.AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	batch := make([]istructs.ViewKV, 0)
+	sale := func(department, year int32, name string, amount int64) {
+		kb := as.ViewRecords().KeyBuilder(view)
+		kb.PutInt32("department", department)
+		kb.PutInt32("year", year)
+		kb.PutString("name", name)
+		vb := as.ViewRecords().NewValueBuilder(view)
+		vb.PutInt64("amount", amount)
+		batch = append(batch, istructs.ViewKV{Key: kb, Value: vb})
+	}
+	sale(7, 2021, "Cola", 10)
+	sale(7, 2022, "Cola", 20)
+	sale(7, 2022, "Fanta", 30)
+	sale(7, 2023, "Cola", 40)
+	sale(7, 2023, "Sprite", 50)
+	sale(8, 2022, "Cola", 60)
+
+	day := time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)
+	terminal, err := istructs.ParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	require.NoError(err)
+	payment := func(hour int, amount int64) {
+		kb := as.ViewRecords().KeyBuilder(paymentsView)
+		kb.PutDate("day", day)
+		kb.PutUUID("terminal", terminal)
+		kb.PutTimestamp("moment", day.Add(time.Duration(hour)*time.Hour))
+		kb.PutDecimal("amount", istructs.NewDecimal(amount, 2))
+		vb := as.ViewRecords().NewValueBuilder(paymentsView)
+		vb.PutInt64("total", amount)
+		batch = append(batch, istructs.ViewKV{Key: kb, Value: vb})
+	}
+	payment(9, 150)
+	payment(10, -250)
+	payment(10, 1000)
+	payment(12, 300)
+	require.NoError(as.ViewRecords().PutBatch(wsid, batch))
+
+	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter)
+	authz := testAuthorizer{IAuthorizer: iauthnzimpl.NewDefaultAuthorizer(), deny: deniedView}
+	serviceChannel := make(iprocbus.ServiceChannel)
+	var rows []string
+	errs := make(chan error)
+	rs := testResultSenderClosable{
+		startArraySection: func(sectionType string, path []string) {},
+		sendElement: func(name string, element interface{}) (err error) {
+			bb, err := json.Marshal(element)
+			require.NoError(err)
+			rows = append(rows, string(bb))
+			return nil
+		},
+		close: func(err error) {
+			errs <- err
+		},
+	}
+	queryProcessor := ProvideServiceFactory()(serviceChannel, func(ctx context.Context, sender interface{}) IResultSenderClosable { return rs },
+		appStructsProvider, 3, imetrics.Provide(), "hvm", authn, authz, cfgs, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go queryProcessor.Run(ctx)
+	readViewFields := func(args map[string]interface{}, fields string, extra string) ([]string, error) {
+		rows = nil
+		bb, err := json.Marshal(args)
+		require.NoError(err)
+		body := []byte(`{"args":` + string(bb) + `,"elements":[{"path":"","fields":[` + fields + `]}]` + extra + `}`)
+		resource := as.Resources().QueryResource(QNameQueryReadView)
+		serviceChannel <- NewQueryMessage(context.Background(), istructs.AppQName_test1_app1, wsid, nil, body, resource, "127.0.0.1", getSystemToken(appTokens))
+		return rows, <-errs
+	}
+	readView := func(args map[string]interface{}, extra string) ([]string, error) {
+		return readViewFields(args, `"year","name","amount"`, extra)
+	}
+
+	t.Run("Should read rows by partition key", func(t *testing.T) {
+		rows, err := readView(map[string]interface{}{"View": view.String(), "Key": `{"department":7}`}, "")
+
+		require.NoError(err)
+		require.Equal([]string{
+			`[[[2021,"Cola",10]]]`,
+			`[[[2022,"Cola",20]]]`,
+			`[[[2022,"Fanta",30]]]`,
+			`[[[2023,"Cola",40]]]`,
+			`[[[2023,"Sprite",50]]]`,
+		}, rows)
+	})
+	t.Run("Should read rows by clustering columns prefix", func(t *testing.T) {
+		rows, err := readView(map[string]interface{}{"View": view.String(), "Key": `{"department":7,"year":2023}`}, "")
+
+		require.NoError(err)
+		require.Equal([]string{`[[[2023,"Cola",40]]]`, `[[[2023,"Sprite",50]]]`}, rows)
+	})
+	t.Run("Should read rows in range with limit", func(t *testing.T) {
+		rows, err := readView(map[string]interface{}{
+			"View":  view.String(),
+			"Key":   `{"department":7}`,
+			"Range": `{"year":{"from":2022,"till":2023}}`,
+			"Limit": 3,
+		}, `,"orderBy":[{"field":"amount","desc":true}]`)
+
+		require.NoError(err)
+		require.Equal([]string{`[[[2023,"Cola",40]]]`, `[[[2022,"Fanta",30]]]`, `[[[2022,"Cola",20]]]`}, rows)
+	})
+	t.Run("Should read rows in string range", func(t *testing.T) {
+		rows, err := readView(map[string]interface{}{
+			"View":  view.String(),
+			"Key":   `{"department":7,"year":2022}`,
+			"Range": `{"name":{"till":"D"}}`,
+		}, "")
+
+		require.NoError(err)
+		require.Equal([]string{`[[[2022,"Cola",20]]]`}, rows)
+	})
+	t.Run("Should read rows by date, UUID, timestamp and decimal key fields", func(t *testing.T) {
+		rows, err := readViewFields(map[string]interface{}{
+			"View":  paymentsView.String(),
+			"Key":   `{"day":"2023-04-01","terminal":"6ba7b810-9dad-11d1-80b4-00c04fd430c8","moment":"2023-04-01T10:00:00Z"}`,
+			"Range": `{"amount":{"from":-3,"till":"5.00"}}`,
+		}, `"amount","total"`, "")
+
+		require.NoError(err)
+		require.Equal([]string{`[[[-2.50,-250]]]`}, rows)
+	})
+	t.Run("Should read rows in timestamp range", func(t *testing.T) {
+		rows, err := readViewFields(map[string]interface{}{
+			"View":  paymentsView.String(),
+			"Key":   `{"day":"2023-04-01","terminal":"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}`,
+			"Range": `{"moment":{"from":"2023-04-01T10:00:00Z","till":"2023-04-01T12:00:00+02:00"}}`,
+		}, `"total"`, "")
+
+		require.NoError(err)
+		// negative amounts are stored after positive ones
+		require.Equal([]string{`[[[1000]]]`, `[[[-250]]]`}, rows)
+	})
+	t.Run("Should return bad request on wrong params", func(t *testing.T) {
+		for _, args := range []map[string]interface{}{
+			{"View": view.String(), "Key": `{}`},
+			{"View": view.String(), "Key": `{"department":"7"}`},
+			{"View": view.String(), "Key": `{"department":7,"name":"Cola"}`},
+			{"View": view.String(), "Key": `{"department":7,"amount":10}`},
+			{"View": view.String(), "Key": `{"department":7}`, "Range": `{"amount":{"from":10}}`},
+			{"View": view.String(), "Key": `{"department":7,"year":2022}`, "Range": `{"year":{"from":2022}}`},
+			{"View": view.String(), "Key": `{"department":7}`, "Limit": -1},
+			{"View": paymentsView.String(), "Key": `{"day":"2023-04-01","terminal":"not uuid"}`},
+			{"View": paymentsView.String(), "Key": `{"day":"01.04.2023","terminal":"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}`},
+			{"View": paymentsView.String(), "Key": `{"day":"2023-04-01","terminal":"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}`,
+				"Range": `{"moment":{"from":1680339600000}}`},
+			{"View": "test.unknown", "Key": `{"department":7}`},
+		} {
+			_, err := readView(args, "")
+
+			var se coreutils.SysError
+			require.ErrorAs(err, &se, args)
+			require.Equal(http.StatusBadRequest, se.HTTPStatus, args)
+		}
+	})
+	t.Run("Should return forbidden if view read is denied", func(t *testing.T) {
+		rows, err := readView(map[string]interface{}{"View": deniedView.String(), "Key": `{"department":7}`}, "")
+
+		var se coreutils.SysError
+		require.ErrorAs(err, &se)
+		require.Equal(http.StatusForbidden, se.HTTPStatus)
+		require.Empty(rows)
+	})
+}
@@ -49,10 +49,63 @@ type IOrderBy interface {
 	IsDesc() bool
 }
 
+// AggregateFactory creates IAggregate from data
+type AggregateFactory func(data coreutils.MapObject) (IAggregate, error)
+
+// IAggregate groups root element rows by fields and aggregates them
+type IAggregate interface {
+	GroupBy() []string
+	Functions() []IAggregateFunction
+}
+
+type IAggregateFunction interface {
+	// sum, count, min, max or avg
+	Func() string
+	// Empty for count of rows, count of the field counts rows with non-empty field value only
+	Field() string
+	// Name of the result field
+	Alias() string
+}
+
+// ICursor is the decoded continuation token which is returned in the "cursor" section of the previous page response.
+// Cursor is issued for the paged queries with orderBy only
+type ICursor interface {
+	// Names of the orderBy fields which the Key() values belong to
+	Fields() []string
+	// Values of orderBy fields of the last row of the previous page,
+	// numbers are json.Number
+	Key() []interface{}
+	// Count of rows with the Key() that are sent already
+	Skip() int64
+}
+
+// IQueryWorkpiece is implemented by the workpiece which the query processor passes to the query function as PrepareArgs.Workpiece
+type IQueryWorkpiece interface {
+	// Returns the part of the query params which the query function can apply to the view read
+	GetViewPushDown(view appdef.QName) IViewPushDown
+	// Returns nil if the first page is requested. Query functions which read the views in orderBy order
+	// can seek to the cursor key instead of reading rows sent on the previous pages
+	GetCursor() ICursor
+}
+
+// IViewPushDown is the part of the query params which the query function can apply to the view read
+type IViewPushDown interface {
+	// Puts values of the partition key fields and of the clustering columns prefix which are defined by filters into the key.
+	// Returns false and leaves key unchanged if filters do not define the whole partition key
+	FillKey(key istructs.IKeyBuilder) (ok bool)
+	// Returns true if orderBy matches the clustering columns order, the rows are not ordered in memory then.
+	// Only string clustering columns are read in the values order
+	Ordered() bool
+}
+
 type IQueryParams interface {
 	Elements() []IElement
 	Filters() []IFilter
 	OrderBy() []IOrderBy
+	// Returns nil if rows are not aggregated
+	Aggregate() IAggregate
+	// Returns nil if the first page is requested
+	Cursor() ICursor
 	StartFrom() int64
 	Count() int64
 }
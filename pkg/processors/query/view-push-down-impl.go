@@ -0,0 +1,226 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+type keyValue struct {
+	field string
+	kind  appdef.DataKind
+	value interface{}
+}
+
+type viewPushDown struct {
+	// values of partition key fields and of clustering columns prefix
+	key           []keyValue
+	partKeyFilled bool
+	ordered       bool
+}
+
+func (p viewPushDown) FillKey(key istructs.IKeyBuilder) bool {
+	if !p.partKeyFilled {
+		return false
+	}
+	for _, kv := range p.key {
+		kv.put(key)
+	}
+	return true
+}
+
+// put puts the value to the key, the value type must match the field data kind
+func (kv keyValue) put(key istructs.IKeyBuilder) {
+	switch kv.kind {
+	case appdef.DataKind_int32:
+		key.PutInt32(kv.field, kv.value.(int32))
+	case appdef.DataKind_int64:
+		key.PutInt64(kv.field, kv.value.(int64))
+	case appdef.DataKind_float32:
+		key.PutFloat32(kv.field, kv.value.(float32))
+	case appdef.DataKind_float64:
+		key.PutFloat64(kv.field, kv.value.(float64))
+	case appdef.DataKind_bytes:
+		key.PutBytes(kv.field, kv.value.([]byte))
+	case appdef.DataKind_string:
+		key.PutString(kv.field, kv.value.(string))
+	case appdef.DataKind_QName:
+		key.PutQName(kv.field, kv.value.(appdef.QName))
+	case appdef.DataKind_bool:
+		key.PutBool(kv.field, kv.value.(bool))
+	case appdef.DataKind_RecordID:
+		key.PutRecordID(kv.field, kv.value.(istructs.RecordID))
+	case appdef.DataKind_decimal:
+		key.PutDecimal(kv.field, kv.value.(istructs.Decimal))
+	case appdef.DataKind_timestamp:
+		key.PutTimestamp(kv.field, kv.value.(time.Time))
+	case appdef.DataKind_date:
+		key.PutDate(kv.field, kv.value.(time.Time))
+	case appdef.DataKind_UUID:
+		key.PutUUID(kv.field, kv.value.(istructs.UUID))
+	}
+}
+
+// read returns the value of the key field, the value type is the same as put accepts
+func (kv keyValue) read(key istructs.IRowReader) interface{} {
+	switch kv.kind {
+	case appdef.DataKind_int32:
+		return key.AsInt32(kv.field)
+	case appdef.DataKind_int64:
+		return key.AsInt64(kv.field)
+	case appdef.DataKind_float32:
+		return key.AsFloat32(kv.field)
+	case appdef.DataKind_float64:
+		return key.AsFloat64(kv.field)
+	case appdef.DataKind_bytes:
+		return key.AsBytes(kv.field)
+	case appdef.DataKind_string:
+		return key.AsString(kv.field)
+	case appdef.DataKind_QName:
+		return key.AsQName(kv.field)
+	case appdef.DataKind_bool:
+		return key.AsBool(kv.field)
+	case appdef.DataKind_RecordID:
+		return key.AsRecordID(kv.field)
+	case appdef.DataKind_decimal:
+		return key.AsDecimal(kv.field)
+	case appdef.DataKind_timestamp:
+		return key.AsTimestamp(kv.field)
+	case appdef.DataKind_date:
+		return key.AsDate(kv.field)
+	case appdef.DataKind_UUID:
+		return key.AsUUID(kv.field)
+	}
+	return nil
+}
+
+func (p viewPushDown) Ordered() bool { return p.ordered }
+
+// newViewPushDown finds filters and orderBy that the view read can apply.
+// Filters are pushed down if they are 'eq' filters of the root level or of the root level 'and' filters,
+// the 'startsWith' filter is pushed down for the last string clustering column.
+// Filter field must be the result field of the same data kind as the view key field
+func newViewPushDown(appDef appdef.IAppDef, view appdef.QName, params IQueryParams, rootFields coreutils.FieldsDef) (res viewPushDown) {
+	if appDef.DefByName(view) == nil {
+		return res
+	}
+	eqs := make(map[string]interface{})
+	prefixes := make(map[string]string)
+	collectPushDownFilters(params.Filters(), eqs, prefixes)
+
+	fixed := make(map[string]bool)
+	res.partKeyFilled = true
+	appDef.Def(appdef.ViewPartitionKeyDefName(view)).Fields(func(f appdef.IField) {
+		value, ok := keyFieldValue(f, eqs, rootFields)
+		if !ok {
+			res.partKeyFilled = false
+			return
+		}
+		res.key = append(res.key, keyValue{field: f.Name(), kind: f.DataKind(), value: value})
+		fixed[f.Name()] = true
+	})
+	if !res.partKeyFilled {
+		res.key = nil
+	}
+
+	prefixDone := false
+	clustCols := make([]appdef.IField, 0)
+	appDef.Def(appdef.ViewClusteringColumsDefName(view)).Fields(func(f appdef.IField) {
+		if prefixDone || !res.partKeyFilled {
+			clustCols = append(clustCols, f)
+			return
+		}
+		if value, ok := keyFieldValue(f, eqs, rootFields); ok {
+			res.key = append(res.key, keyValue{field: f.Name(), kind: f.DataKind(), value: value})
+			fixed[f.Name()] = true
+			return
+		}
+		prefixDone = true
+		clustCols = append(clustCols, f)
+		if prefix, ok := prefixes[f.Name()]; ok && f.DataKind() == appdef.DataKind_string && rootFields[f.Name()] == appdef.DataKind_string {
+			res.key = append(res.key, keyValue{field: f.Name(), kind: f.DataKind(), value: prefix})
+		}
+	})
+
+	res.ordered = isClustOrder(params, fixed, clustCols, rootFields)
+	return res
+}
+
+func collectPushDownFilters(filters []IFilter, eqs map[string]interface{}, prefixes map[string]string) {
+	for _, f := range filters {
+		switch filter := f.(type) {
+		case *EqualsFilter:
+			if _, ok := eqs[filter.field]; !ok {
+				eqs[filter.field] = filter.value
+			}
+		case *StartsWithFilter:
+			if _, ok := prefixes[filter.field]; !ok && !filter.caseInsensitive {
+				prefixes[filter.field] = filter.value
+			}
+		case *AndFilter:
+			collectPushDownFilters(filter.filters, eqs, prefixes)
+		}
+	}
+}
+
+// keyFieldValue returns value of the 'eq' filter converted to the key field data kind
+func keyFieldValue(f appdef.IField, eqs map[string]interface{}, rootFields coreutils.FieldsDef) (value interface{}, ok bool) {
+	filterValue, ok := eqs[f.Name()]
+	if !ok || rootFields[f.Name()] != f.DataKind() {
+		return nil, false
+	}
+	switch f.DataKind() {
+	case appdef.DataKind_int32:
+		if v, ok := filterValue.(float64); ok {
+			return int32(v), true
+		}
+	case appdef.DataKind_int64:
+		if v, ok := filterValue.(float64); ok {
+			return int64(v), true
+		}
+	case appdef.DataKind_RecordID:
+		if v, ok := filterValue.(float64); ok {
+			return istructs.RecordID(int64(v)), true
+		}
+	case appdef.DataKind_string:
+		if v, ok := filterValue.(string); ok {
+			return v, true
+		}
+	case appdef.DataKind_bool:
+		if v, ok := filterValue.(bool); ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// isClustOrder returns true if orderBy fields, except fields fixed by filters, are the leading free clustering columns in ascending order.
+// Only string columns are accepted: numbers are stored as big-endian two's complement, so negative values are read after positive ones
+func isClustOrder(params IQueryParams, fixed map[string]bool, clustCols []appdef.IField, rootFields coreutils.FieldsDef) bool {
+	if params.Aggregate() != nil || len(params.OrderBy()) == 0 {
+		return false
+	}
+	i := 0
+	for _, o := range params.OrderBy() {
+		if fixed[o.Field()] {
+			continue
+		}
+		if o.IsDesc() || i >= len(clustCols) || clustCols[i].Name() != o.Field() {
+			return false
+		}
+		if clustCols[i].DataKind() != appdef.DataKind_string {
+			return false
+		}
+		if rootFields[o.Field()] != clustCols[i].DataKind() {
+			return false
+		}
+		i++
+	}
+	return true
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/pipeline"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+type testKeyBuilder struct {
+	istructs.IKeyBuilder
+	puts map[string]interface{}
+}
+
+func (b *testKeyBuilder) PutInt32(name string, value int32)                { b.puts[name] = value }
+func (b *testKeyBuilder) PutInt64(name string, value int64)                { b.puts[name] = value }
+func (b *testKeyBuilder) PutString(name string, value string)              { b.puts[name] = value }
+func (b *testKeyBuilder) PutRecordID(name string, value istructs.RecordID) { b.puts[name] = value }
+func (b *testKeyBuilder) PutBool(name string, value bool)                  { b.puts[name] = value }
+
+func TestViewPushDown(t *testing.T) {
+	view := appdef.NewQName("test", "salesView")
+	adb := appdef.New()
+	adb.AddView(view).
+		AddPartField("department", appdef.DataKind_int32).
+		AddClustColumn("year", appdef.DataKind_int32).
+		AddClustColumn("article", appdef.DataKind_RecordID).
+		AddClustColumn("name", appdef.DataKind_string).
+		AddValueField("amount", appdef.DataKind_int64, true)
+	appDef, err := adb.Build()
+	require.NoError(t, err)
+
+	rootFields := coreutils.FieldsDef{
+		"department": appdef.DataKind_int32,
+		"year":       appdef.DataKind_int32,
+		"article":    appdef.DataKind_RecordID,
+		"name":       appdef.DataKind_string,
+		"amount":     appdef.DataKind_int64,
+	}
+	pushDown := func(params queryParams) (keyFilled bool, key map[string]interface{}, ordered bool) {
+		pd := newViewPushDown(appDef, view, params, rootFields)
+		kb := &testKeyBuilder{puts: make(map[string]interface{})}
+		return pd.FillKey(kb), kb.puts, pd.Ordered()
+	}
+	t.Run("Should fill partition key and clustering columns prefix", func(t *testing.T) {
+		require := require.New(t)
+
+		keyFilled, key, _ := pushDown(queryParams{filters: []IFilter{
+			&AndFilter{filters: []IFilter{
+				&EqualsFilter{field: "year", value: float64(2023)},
+				&EqualsFilter{field: "department", value: float64(7)},
+			}},
+			&EqualsFilter{field: "name", value: "Cola"},
+			&GreaterFilter{field: "amount", value: float64(100)},
+		}})
+
+		require.True(keyFilled)
+		require.Equal(map[string]interface{}{"department": int32(7), "year": int32(2023)}, key)
+	})
+	t.Run("Should fill last string clustering column by prefix", func(t *testing.T) {
+		require := require.New(t)
+
+		keyFilled, key, _ := pushDown(queryParams{filters: []IFilter{
+			&EqualsFilter{field: "department", value: float64(7)},
+			&EqualsFilter{field: "year", value: float64(2023)},
+			&EqualsFilter{field: "article", value: float64(100500)},
+			&StartsWithFilter{field: "name", value: "Co"},
+		}})
+
+		require.True(keyFilled)
+		require.Equal(map[string]interface{}{
+			"department": int32(7),
+			"year":       int32(2023),
+			"article":    istructs.RecordID(100500),
+			"name":       "Co",
+		}, key)
+	})
+	t.Run("Should not fill key if partition key is not defined by filters", func(t *testing.T) {
+		require := require.New(t)
+
+		for _, filters := range [][]IFilter{
+			{&EqualsFilter{field: "year", value: float64(2023)}},
+			{&OrFilter{filters: []IFilter{&EqualsFilter{field: "department", value: float64(7)}}}},
+			{&EqualsFilter{field: "department", value: "7"}},
+		} {
+			keyFilled, key, _ := pushDown(queryParams{filters: filters})
+			require.False(keyFilled)
+			require.Empty(key)
+		}
+	})
+	t.Run("Should detect clustering columns order", func(t *testing.T) {
+		require := require.New(t)
+		department := &EqualsFilter{field: "department", value: float64(7)}
+		year := &EqualsFilter{field: "year", value: float64(2023)}
+		ordered := func(filters []IFilter, orderBys ...IOrderBy) bool {
+			_, _, ordered := pushDown(queryParams{filters: filters, orderBy: orderBys})
+			return ordered
+		}
+
+		article := &EqualsFilter{field: "article", value: float64(100500)}
+
+		require.True(ordered([]IFilter{department, year, article}, orderBy{field: "name"}))
+		require.True(ordered([]IFilter{department, year, article}, orderBy{field: "year"}, orderBy{field: "name"}))
+
+		require.False(ordered([]IFilter{department}))
+		require.False(ordered([]IFilter{department}, orderBy{field: "year"}), "negative numbers are read after positive ones")
+		require.False(ordered(nil, orderBy{field: "name"}))
+		require.False(ordered([]IFilter{department}, orderBy{field: "year", desc: true}))
+		require.False(ordered([]IFilter{department}, orderBy{field: "amount"}))
+		require.False(ordered([]IFilter{department, year}, orderBy{field: "article"}), "RecordID order is not supported by order operator")
+	})
+	t.Run("Should push down nothing for unknown view", func(t *testing.T) {
+		require := require.New(t)
+		pd := newViewPushDown(appDef, appdef.NewQName("test", "unknown"), queryParams{
+			filters: []IFilter{&EqualsFilter{field: "department", value: float64(7)}},
+			orderBy: []IOrderBy{orderBy{field: "year"}},
+		}, rootFields)
+
+		require.False(pd.FillKey(nil))
+		require.False(pd.Ordered())
+	})
+}
+
+func TestOrderOperator_Ordered(t *testing.T) {
+	require := require.New(t)
+	operator := newOrderOperator([]IOrderBy{orderBy{field: "year"}}, func() bool { return true }, &testMetrics{})
+	work := workpiece{outputRow: &outputRow{}}
+
+	outWork, err := operator.DoAsync(context.Background(), work)
+	require.NoError(err)
+	require.Equal(work, outWork)
+
+	require.NoError(operator.Flush(func(pipeline.IWorkpiece) {
+		t.Fatal("rows must not be buffered")
+	}))
+}
+
+func TestViewPushDown_NegativeKeys(t *testing.T) {
+	require := require.New(t)
+	view := appdef.NewQName("test", "balanceView")
+	const wsid = istructs.WSID(1)
+	_, appStructsProvider, _ := getTestCfg(require, func(appDef appdef.IAppDefBuilder) {
+		appDef.AddView(view).
+			AddPartField("department", appdef.DataKind_int32).
+			AddClustColumn("balance", appdef.DataKind_int32).
+			AddValueField("name", appdef.DataKind_string, true)
+	})
+	as, err := appStructsProvider.AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	batch := make([]istructs.ViewKV, 0)
+	for _, balance := range []int32{1, -1, 0} {
+		kb := as.ViewRecords().KeyBuilder(view)
+		kb.PutInt32("department", 7)
+		kb.PutInt32("balance", balance)
+		vb := as.ViewRecords().NewValueBuilder(view)
+		vb.PutString("name", "Cola")
+		batch = append(batch, istructs.ViewKV{Key: kb, Value: vb})
+	}
+	require.NoError(as.ViewRecords().PutBatch(wsid, batch))
+
+	params := queryParams{
+		filters: []IFilter{&EqualsFilter{field: "department", value: float64(7)}},
+		orderBy: []IOrderBy{orderBy{field: "balance"}},
+	}
+	pd := newViewPushDown(as.AppDef(), view, params, coreutils.FieldsDef{"department": appdef.DataKind_int32, "balance": appdef.DataKind_int32})
+	require.False(pd.Ordered())
+
+	operator := newOrderOperator(params.OrderBy(), pd.Ordered, &testMetrics{})
+	read := make([]int32, 0)
+	kb := as.ViewRecords().KeyBuilder(view)
+	require.True(pd.FillKey(kb))
+	require.NoError(as.ViewRecords().Read(context.Background(), wsid, kb, func(key istructs.IKey, _ istructs.IValue) error {
+		read = append(read, key.AsInt32("balance"))
+		_, err := operator.DoAsync(context.Background(), workpiece{outputRow: &outputRow{
+			keyToIdx: map[string]int{rootDocument: 0},
+			values: []interface{}{
+				[]IOutputRow{&outputRow{keyToIdx: map[string]int{"balance": 0}, values: []interface{}{key.AsInt32("balance")}}},
+			},
+		}})
+		return err
+	}))
+	require.Equal([]int32{0, 1, -1}, read, "negative values are stored after positive ones")
+
+	sorted := make([]int32, 0)
+	require.NoError(operator.Flush(func(work pipeline.IWorkpiece) {
+		sorted = append(sorted, rootValue(work.(IWorkpiece).OutputRow(), "balance").(int32))
+	}))
+	require.Equal([]int32{-1, 0, 1}, sorted)
+}
@@ -9,6 +9,7 @@ package projectors
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/untillpro/goutils/logger"
@@ -36,6 +37,9 @@ type asyncActualizer struct {
 	offset   istructs.Offset
 	name     string
 	readCtx  *asyncActualizerContextState
+	// guards readCtx and resetReq, used to interrupt reading when reset is requested
+	resetLock sync.Mutex
+	resetReq  *resetRequest
 }
 
 func (a *asyncActualizer) Prepare(interface{}) error {
@@ -62,6 +66,12 @@ func (a *asyncActualizer) Prepare(interface{}) error {
 }
 func (a *asyncActualizer) Run(ctx context.Context) {
 	var err error
+	if admin, ok := a.conf.Admin.(*actualizersAdmin); ok {
+		key := actualizerKey{app: a.conf.AppQName, partition: a.conf.Partition, projector: a.factory(a.conf.Partition).Name}
+		admin.register(key, a)
+		defer admin.unregister(key, a)
+	}
+	defer a.rejectReset(ctx)
 	for ctx.Err() == nil {
 		if err = a.init(ctx); err == nil {
 			logger.Trace(a.name, "started")
@@ -69,6 +79,9 @@ func (a *asyncActualizer) Run(ctx context.Context) {
 			a.conf.LogError(a.name, err)
 		}
 		a.finit() // even execute if a.init has failed
+		if ctx.Err() == nil && a.resetRequested() {
+			err = a.reset(ctx)
+		}
 		if ctx.Err() == nil && err != nil {
 			a.conf.LogError(a.name, err)
 			select {
@@ -84,13 +97,67 @@ func (a *asyncActualizer) cancelChannel(e error) {
 	a.conf.Broker.WatchChannel(a.readCtx.ctx, a.conf.channel, func(projection in10n.ProjectionKey, offset istructs.Offset) {})
 }
 
+func (a *asyncActualizer) requestReset(req *resetRequest) error {
+	if a.factory(a.conf.Partition).Kind == istructs.ProjectionKind_Lazy {
+		return ErrResetNotSupported
+	}
+	a.resetLock.Lock()
+	defer a.resetLock.Unlock()
+	if a.resetReq != nil {
+		return ErrResetInProgress
+	}
+	a.resetReq = req
+	if a.readCtx != nil {
+		a.readCtx.cancelWithError(errResetRequested)
+	}
+	return nil
+}
+
+func (a *asyncActualizer) resetRequested() bool {
+	a.resetLock.Lock()
+	defer a.resetLock.Unlock()
+	return a.resetReq != nil
+}
+
+func (a *asyncActualizer) reset(ctx context.Context) (err error) {
+	a.resetLock.Lock()
+	req := a.resetReq
+	a.resetReq = nil
+	a.resetLock.Unlock()
+	err = resetActualizer(ctx, a.conf.AppStructs(), a.conf.Partition, a.factory(a.conf.Partition).Name, req.wsids)
+	req.done <- err
+	return err
+}
+
+func (a *asyncActualizer) rejectReset(ctx context.Context) {
+	a.resetLock.Lock()
+	defer a.resetLock.Unlock()
+	if a.resetReq != nil {
+		a.resetReq.done <- ctx.Err()
+		a.resetReq = nil
+	}
+}
+
 func (a *asyncActualizer) init(ctx context.Context) (err error) {
 	a.structs = a.conf.AppStructs()
-	a.readCtx = &asyncActualizerContextState{}
+	readCtx := &asyncActualizerContextState{}
+	readCtx.ctx, readCtx.cancel = context.WithCancel(ctx)
 
-	a.readCtx.ctx, a.readCtx.cancel = context.WithCancel(ctx)
-
-	p := &asyncProjector{partition: a.conf.Partition, metrics: a.conf.Metrics}
+	a.resetLock.Lock()
+	a.readCtx = readCtx
+	if a.resetReq != nil {
+		a.readCtx.cancelWithError(errResetRequested)
+	}
+	a.resetLock.Unlock()
+
+	p := &asyncProjector{
+		ctx:       a.readCtx.ctx,
+		structs:   a.structs,
+		partition: a.conf.Partition,
+		metrics:   a.conf.Metrics,
+		active:    make(map[istructs.WSID]istructs.Offset),
+		pending:   make(map[istructs.WSID]bool),
+	}
 	p.projector = a.factory(a.conf.Partition)
 
 	err = a.readOffset(p.projector.Name)
@@ -99,6 +166,25 @@ func (a *asyncActualizer) init(ctx context.Context) (err error) {
 		return err
 	}
 
+	resets, err := readResets(a.readCtx.ctx, a.structs, a.conf.Partition, p.projector.Name)
+	if err != nil {
+		return err
+	}
+	p.replay = newReplay(resets, a.offset, p.projector.UpdatedViews)
+	if err = p.replay.truncateRegistered(a.readCtx.ctx, a.structs, a.conf.Partition, p.projector.Name); err != nil {
+		return err
+	}
+	if p.metrics != nil {
+		p.metrics.Set(aaReplayTillOffset, p.partition, p.projector.Name, float64(p.replay.till))
+	}
+	opts := append(make([]state.ActualizerStateOptFunc, 0, len(a.conf.Opts)+1), a.conf.Opts...)
+	opts = append(opts, state.WithViewKeyGuard(func(view appdef.QName, wsid istructs.WSID, key istructs.IKeyBuilder) error {
+		if err := p.replay.truncate(a.structs, view, wsid, key); err != nil {
+			return err
+		}
+		return p.replay.register(a.structs, a.conf.Partition, p.projector.Name, view, wsid, key)
+	}))
+
 	p.state = state.ProvideAsyncActualizerStateFactory()(
 		ctx,
 		a.structs,
@@ -114,7 +200,7 @@ func (a *asyncActualizer) init(ctx context.Context) (err error) {
 		a.conf.SecretReader,
 		a.conf.IntentsLimit,
 		a.conf.BundlesLimit,
-		a.conf.Opts...)
+		opts...)
 
 	a.name = fmt.Sprintf("%s [%d]", p.projector.Name, a.conf.Partition)
 
@@ -200,12 +286,19 @@ func (a *asyncActualizer) readOffset(projectorName appdef.QName) (err error) {
 
 type asyncProjector struct {
 	pipeline.AsyncNOOP
+	ctx        context.Context
+	structs    istructs.IAppStructs
 	state      state.IBundledHostState
 	partition  istructs.PartitionID
 	wsid       istructs.WSID
 	projector  istructs.Projector
 	pLogOffset istructs.Offset
 	metrics    AsyncActualizerMetrics
+	replay     *replay
+	// lazy projection only: WLog offsets of the last events fed by ACTIVE workspaces
+	active map[istructs.WSID]istructs.Offset
+	// lazy projection only: workspaces which events were skipped because projection was not ACTIVE
+	pending map[istructs.WSID]bool
 }
 
 func (p *asyncProjector) DoAsync(_ context.Context, work pipeline.IWorkpiece) (outWork pipeline.IWorkpiece, err error) {
@@ -218,13 +311,24 @@ func (p *asyncProjector) DoAsync(_ context.Context, work pipeline.IWorkpiece) (o
 		p.metrics.Set(aaCurrentOffset, p.partition, p.projector.Name, float64(p.pLogOffset))
 	}
 
-	if isAcceptable(p.projector, w.event) {
-		err = p.projector.Func(w.event, p.state, p.state)
-		if err != nil {
-			return nil, err
+	// while replaying, events of the workspaces which are not rebuilt are handled already
+	if p.replay.feeds(p.wsid, p.pLogOffset) {
+		if p.projector.Kind == istructs.ProjectionKind_Lazy {
+			if err = p.feedLazy(w.event); err != nil {
+				return nil, err
+			}
+		} else if isAcceptable(p.projector, w.event) {
+			err = p.projector.Func(w.event, p.state, p.state)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
+	if p.metrics != nil && p.pLogOffset == p.replay.till {
+		p.metrics.Set(aaReplayTillOffset, p.partition, p.projector.Name, 0)
+	}
+
 	readyToFlushBundle, err := p.state.ApplyIntents()
 	if err != nil {
 		return nil, err
@@ -236,8 +340,98 @@ func (p *asyncProjector) DoAsync(_ context.Context, work pipeline.IWorkpiece) (o
 
 	return nil, err
 }
-func (p *asyncProjector) Flush(_ pipeline.OpFuncFlush) (err error) { return p.flush() }
-func (p *asyncProjector) WSIDProvider() istructs.WSID              { return p.wsid }
+func (p *asyncProjector) Flush(_ pipeline.OpFuncFlush) (err error) {
+	if err = p.catchUpPending(); err != nil {
+		return err
+	}
+	if p.pLogOffset == istructs.NullOffset {
+		return p.state.FlushBundles()
+	}
+	return p.flush()
+}
+func (p *asyncProjector) WSIDProvider() istructs.WSID { return p.wsid }
+
+// feedLazy feeds event to lazy projector only if the projection of the event workspace is ACTIVE.
+// Events of the workspace which were not fed by raw actualizer or by this actualizer are read from WLog
+func (p *asyncProjector) feedLazy(event istructs.IPLogEvent) (err error) {
+	activeOffset, active, err := p.activeOffset(event.Workspace())
+	if err != nil {
+		return err
+	}
+	if !active {
+		p.pending[event.Workspace()] = true
+		return nil
+	}
+	if event.WLogOffset() <= activeOffset {
+		return nil
+	}
+	if missed := int(event.WLogOffset() - activeOffset - 1); missed > 0 {
+		if err = p.catchUp(event.Workspace(), activeOffset, missed); err != nil {
+			return err
+		}
+	}
+	return p.feed(event)
+}
+
+// feed feeds event of the ACTIVE workspace to lazy projector
+func (p *asyncProjector) feed(event istructs.IPLogEvent) (err error) {
+	if isAcceptable(p.projector, event) {
+		if err = p.projector.Func(event, p.state, p.state); err != nil {
+			return err
+		}
+	}
+	err = putProjectionStatus(p.state, p.partition, event.Workspace(), p.projector.Name, ProjectionStatus_Active, event.WLogOffset())
+	if err != nil {
+		return err
+	}
+	p.active[event.Workspace()] = event.WLogOffset()
+	return nil
+}
+
+// catchUp feeds WLog events which follow the given WLog offset
+func (p *asyncProjector) catchUp(wsid istructs.WSID, wlogOffset istructs.Offset, toReadCount int) (err error) {
+	return p.structs.Events().ReadWLog(p.ctx, wsid, wlogOffset+1, toReadCount, func(offset istructs.Offset, event istructs.IWLogEvent) (err error) {
+		if err = p.feed(&wlogEvent{IWLogEvent: event, wsid: wsid, wlogOffset: offset}); err != nil {
+			return err
+		}
+		readyToFlushBundle, err := p.state.ApplyIntents()
+		if err != nil || !readyToFlushBundle {
+			return err
+		}
+		return p.state.FlushBundles()
+	})
+}
+
+// catchUpPending catches up workspaces which projections became ACTIVE after their events were skipped
+func (p *asyncProjector) catchUpPending() (err error) {
+	for wsid := range p.pending {
+		activeOffset, active, err := p.activeOffset(wsid)
+		if err != nil {
+			return err
+		}
+		if !active {
+			continue
+		}
+		p.wsid = wsid
+		if err = p.catchUp(wsid, activeOffset, istructs.ReadToTheEnd); err != nil {
+			return err
+		}
+		delete(p.pending, wsid)
+	}
+	return nil
+}
+
+func (p *asyncProjector) activeOffset(wsid istructs.WSID) (wlogOffset istructs.Offset, active bool, err error) {
+	if wlogOffset, active = p.active[wsid]; active {
+		return wlogOffset, true, nil
+	}
+	status, wlogOffset, err := readProjectionStatus(p.structs, p.partition, wsid, p.projector.Name)
+	if err != nil || status != ProjectionStatus_Active {
+		return istructs.NullOffset, false, err
+	}
+	p.active[wsid] = wlogOffset
+	return wlogOffset, true, nil
+}
 func (p *asyncProjector) flush() (err error) {
 	if p.pLogOffset == istructs.NullOffset {
 		return
@@ -245,6 +439,10 @@ func (p *asyncProjector) flush() (err error) {
 	defer func() {
 		p.pLogOffset = istructs.NullOffset
 	}()
+	if p.pLogOffset < p.replay.till {
+		// offset is not stored until replay is finished, so replay restarts from the beginning after failure
+		return p.state.FlushBundles()
+	}
 	key, err := p.state.KeyBuilder(state.ViewRecordsStorage, qnameProjectionOffsets)
 	if err != nil {
 		return
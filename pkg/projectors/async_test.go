@@ -601,9 +601,10 @@ func Test_AsynchronousActualizer_Stress(t *testing.T) {
 }
 
 type simpleMetrics struct {
-	flushesTotal  int64
-	currentOffset int64
-	storedOffset  int64
+	flushesTotal     int64
+	currentOffset    int64
+	storedOffset     int64
+	replayTillOffset int64
 }
 
 func (m *simpleMetrics) Increase(metricName string, partition istructs.PartitionID, projection appdef.QName, valueDelta float64) {
@@ -619,6 +620,8 @@ func (m *simpleMetrics) Increase(metricName string, partition istructs.Partition
 func (m *simpleMetrics) Set(metricName string, partition istructs.PartitionID, projection appdef.QName, value float64) {
 	if metricName == aaCurrentOffset {
 		atomic.StoreInt64(&m.currentOffset, int64(value))
+	} else if metricName == aaReplayTillOffset {
+		atomic.StoreInt64(&m.replayTillOffset, int64(value))
 	} else if metricName == aaFlushesTotal {
 		atomic.StoreInt64(&m.flushesTotal, int64(value))
 	} else {
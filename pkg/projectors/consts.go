@@ -11,13 +11,28 @@ import (
 )
 
 var (
-	qnameProjectionOffsets = appdef.NewQName(appdef.SysPackage, "projectionOffsets")
+	qnameProjectionOffsets    = appdef.NewQName(appdef.SysPackage, "projectionOffsets")
+	qnameProjectionStatuses   = appdef.NewQName(appdef.SysPackage, "projectionStatuses")
+	qnameProjectionResets     = appdef.NewQName(appdef.SysPackage, "projectionResets")
+	qnameProjectionPartitions = appdef.NewQName(appdef.SysPackage, "projectionPartitions")
 )
 
 const (
 	partitionFld     = "partition"
 	projectorNameFld = "projector"
 	offsetFld        = "offset"
+	statusFld        = "status"
+	wlogOffsetFld    = "wlogOffset"
+	wsidFld          = "wsid"
+	replayTillFld    = "replayTill"
+	viewFld          = "view"
+	partKeyFld       = "partKey"
+)
+
+const (
+	ProjectionStatus_Idle ProjectionStatus = iota
+	ProjectionStatus_Raw
+	ProjectionStatus_Active
 )
 
 const (
@@ -26,6 +41,7 @@ const (
 	defaultFlushInterval = time.Millisecond * 100
 	actualizerErrorDelay = time.Second * 30
 	n10nChannelDuration  = 100 * 365 * 24 * time.Hour
+	defaultRawTimeout    = time.Second * 10
 )
 
 var PlogQName = appdef.NewQName(appdef.SysPackage, "PLog")
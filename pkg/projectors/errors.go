@@ -3,3 +3,15 @@
  */
 
 package projectors
+
+import "errors"
+
+var ErrLazyProjectionNotActive = errors.New("lazy projection is not active yet")
+
+var ErrActualizerNotFound = errors.New("actualizer not found")
+
+var ErrResetInProgress = errors.New("actualizer reset is in progress already")
+
+var ErrResetNotSupported = errors.New("reset is not supported by lazy projector")
+
+var errResetRequested = errors.New("actualizer reset requested")
@@ -124,4 +124,23 @@ func provideOffsetsDefImpl(appDef appdef.IAppDefBuilder) {
 	def.AddPartField(partitionFld, appdef.DataKind_int32)
 	def.AddClustColumn(projectorNameFld, appdef.DataKind_QName)
 	def.AddValueField(offsetFld, appdef.DataKind_int64, true)
+
+	def = appDef.AddView(qnameProjectionStatuses)
+	def.AddPartField(partitionFld, appdef.DataKind_int32)
+	def.AddClustColumn(projectorNameFld, appdef.DataKind_QName)
+	def.AddValueField(statusFld, appdef.DataKind_int32, true)
+	def.AddValueField(wlogOffsetFld, appdef.DataKind_int64, true)
+
+	def = appDef.AddView(qnameProjectionResets)
+	def.AddPartField(partitionFld, appdef.DataKind_int32)
+	def.AddClustColumn(projectorNameFld, appdef.DataKind_QName)
+	def.AddClustColumn(wsidFld, appdef.DataKind_int64)
+	def.AddValueField(replayTillFld, appdef.DataKind_int64, true)
+
+	def = appDef.AddView(qnameProjectionPartitions)
+	def.AddPartField(partitionFld, appdef.DataKind_int32)
+	def.AddPartField(projectorNameFld, appdef.DataKind_QName)
+	def.AddClustColumn(wsidFld, appdef.DataKind_int64)
+	def.AddClustColumn(viewFld, appdef.DataKind_QName)
+	def.AddClustColumn(partKeyFld, appdef.DataKind_string)
 }
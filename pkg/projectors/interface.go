@@ -44,6 +44,8 @@ type AsyncActualizerConf struct {
 	Broker  in10n.IN10nBroker
 	channel in10n.ChannelID
 	Opts    []state.ActualizerStateOptFunc
+	// Optional. The running actualizer can be reset using Admin
+	Admin IActualizersAdmin
 }
 
 type AppStructsFunc func() istructs.IAppStructs
@@ -65,6 +67,41 @@ type SyncActualizerConf struct {
 	N10nFunc     state.N10nFunc
 }
 
+type RawActualizersConf struct {
+	// Actualizations outlive the requests which trigger them, so they are bound to this context
+	Ctx                context.Context
+	AppStructsProvider istructs.IAppStructsProvider
+	SecretReader       isecrets.ISecretReader
+	// Timeout to wait for projection to become ACTIVE, optional, default value is 10 seconds
+	Timeout time.Duration
+	//IntentsLimit top limit per event, optional, default value is 100
+	IntentsLimit int
+	//BundlesLimit top limit when bundle size is greater than this value, actualizer flushes changes to underlying storage, optional, default value is 100
+	BundlesLimit int
+	// Optional
+	N10nFunc state.N10nFunc
+}
+
+// IRawActualizers is the pool of actualizers which initialize lazy projections from WLog on demand
+type IRawActualizers interface {
+	// Activate initializes the lazy projection which updates the view in the workspace
+	// Returns nil immediately if the view is not updated by a lazy projector or if the projection is ACTIVE already
+	// Returns ErrLazyProjectionNotActive if projection is not ACTIVE after timeout
+	// @ConcurrentAccess
+	Activate(ctx context.Context, app istructs.AppQName, partition istructs.PartitionID, wsid istructs.WSID, view appdef.QName) error
+}
+
+// IActualizersAdmin is the admin API of the running async actualizers
+type IActualizersAdmin interface {
+	// Reset makes the async actualizer of the projector replay PLog of the partition from the start while the rest of the application keeps running.
+	// If workspaces are specified then events of these workspaces only are replayed.
+	// All partitions of the views updated by the projector (Projector.UpdatedViews) in the reset workspaces are truncated before the replay starts.
+	// Returns when the reset is persisted, replay progress is exposed by AsyncActualizerMetrics.
+	// Returns ErrActualizerNotFound if the actualizer is not running
+	// @ConcurrentAccess
+	Reset(ctx context.Context, app istructs.AppQName, partition istructs.PartitionID, projector appdef.QName, wsids ...istructs.WSID) error
+}
+
 type ViewDefBuilder func(builder appdef.IViewBuilder)
 
 type WorkToEventFunc func(work interface{}) istructs.IPLogEvent
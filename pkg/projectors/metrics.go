@@ -10,4 +10,6 @@ const (
 	aaFlushesTotal  = "heeus_aa_flushes_total"
 	aaCurrentOffset = "heeus_aa_current_offset"
 	aaStoredOffset  = "heeus_aa_stored_offset"
+	// PLog offset till which the actualizer replays events after reset, zero if actualizer is not replaying
+	aaReplayTillOffset = "heeus_aa_replay_till_offset"
 )
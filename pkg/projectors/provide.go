@@ -16,6 +16,15 @@ func ProvideSyncActualizerFactory() SyncActualizerFactory {
 	return syncActualizerFactory
 }
 
+// ProvideRawActualizers returns the pool of raw actualizers which initialize lazy projections from WLog
+func ProvideRawActualizers(conf RawActualizersConf) IRawActualizers {
+	return newRawActualizers(conf)
+}
+
+func ProvideActualizersAdmin() IActualizersAdmin {
+	return newActualizersAdmin()
+}
+
 func ProvideOffsetsDef(appDef appdef.IAppDefBuilder) {
 	provideOffsetsDefImpl(appDef)
 }
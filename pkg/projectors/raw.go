@@ -0,0 +1,199 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package projectors
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/untillpro/goutils/logger"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/state"
+)
+
+type rawActualizationKey struct {
+	app       istructs.AppQName
+	partition istructs.PartitionID
+	wsid      istructs.WSID
+	projector appdef.QName
+}
+
+type rawActualization struct {
+	done chan struct{}
+	err  error
+}
+
+type rawActualizers struct {
+	conf    RawActualizersConf
+	lock    sync.Mutex
+	running map[rawActualizationKey]*rawActualization
+}
+
+func newRawActualizers(conf RawActualizersConf) *rawActualizers {
+	if conf.Timeout == 0 {
+		conf.Timeout = defaultRawTimeout
+	}
+	if conf.IntentsLimit == 0 {
+		conf.IntentsLimit = defaultIntentsLimit
+	}
+	if conf.BundlesLimit == 0 {
+		conf.BundlesLimit = defaultBundlesLimit
+	}
+	if conf.N10nFunc == nil {
+		conf.N10nFunc = func(view appdef.QName, wsid istructs.WSID, offset istructs.Offset) {}
+	}
+	return &rawActualizers{
+		conf:    conf,
+		running: make(map[rawActualizationKey]*rawActualization),
+	}
+}
+
+func (r *rawActualizers) Activate(ctx context.Context, app istructs.AppQName, partition istructs.PartitionID, wsid istructs.WSID, view appdef.QName) (err error) {
+	appStructs, err := r.conf.AppStructsProvider.AppStructs(app)
+	if err != nil {
+		return err
+	}
+	projector, ok := lazyProjector(appStructs, partition, view)
+	if !ok {
+		return nil
+	}
+	status, _, err := readProjectionStatus(appStructs, partition, wsid, projector.Name)
+	if err != nil || status == ProjectionStatus_Active {
+		return err
+	}
+
+	a := r.start(rawActualizationKey{app: app, partition: partition, wsid: wsid, projector: projector.Name}, appStructs, projector)
+
+	timer := time.NewTimer(r.conf.Timeout)
+	defer timer.Stop()
+	select {
+	case <-a.done:
+		return a.err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return fmt.Errorf("%v of workspace %d: %w", view, wsid, ErrLazyProjectionNotActive)
+	}
+}
+
+// start returns the running actualization or starts the new one
+func (r *rawActualizers) start(key rawActualizationKey, appStructs istructs.IAppStructs, projector istructs.Projector) *rawActualization {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if a, ok := r.running[key]; ok {
+		return a
+	}
+	a := &rawActualization{done: make(chan struct{})}
+	r.running[key] = a
+	go func() {
+		a.err = r.actualize(appStructs, key.partition, key.wsid, projector)
+		if a.err != nil {
+			logger.Error(fmt.Sprintf("raw actualizer %s [%d] of workspace %d: %v", projector.Name, key.partition, key.wsid, a.err))
+		}
+		r.lock.Lock()
+		delete(r.running, key)
+		r.lock.Unlock()
+		close(a.done)
+	}()
+	return a
+}
+
+// actualize feeds WLog events to the projector and turns projection status from IDLE through RAW into ACTIVE
+func (r *rawActualizers) actualize(appStructs istructs.IAppStructs, partition istructs.PartitionID, wsid istructs.WSID, projector istructs.Projector) (err error) {
+	status, wlogOffset, err := readProjectionStatus(appStructs, partition, wsid, projector.Name)
+	if err != nil || status == ProjectionStatus_Active {
+		return err
+	}
+	st := state.ProvideAsyncActualizerStateFactory()(
+		r.conf.Ctx,
+		appStructs,
+		state.SimplePartitionIDFunc(partition),
+		state.SimpleWSIDFunc(wsid),
+		r.conf.N10nFunc,
+		r.conf.SecretReader,
+		r.conf.IntentsLimit,
+		r.conf.BundlesLimit)
+
+	err = appStructs.Events().ReadWLog(r.conf.Ctx, wsid, wlogOffset+1, istructs.ReadToTheEnd, func(offset istructs.Offset, event istructs.IWLogEvent) (err error) {
+		e := &wlogEvent{IWLogEvent: event, wsid: wsid, wlogOffset: offset}
+		if isAcceptable(projector, e) {
+			if err = projector.Func(e, st, st); err != nil {
+				return err
+			}
+		}
+		if err = putProjectionStatus(st, partition, wsid, projector.Name, ProjectionStatus_Raw, offset); err != nil {
+			return err
+		}
+		wlogOffset = offset
+		readyToFlushBundle, err := st.ApplyIntents()
+		if err != nil || !readyToFlushBundle {
+			return err
+		}
+		return st.FlushBundles()
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = putProjectionStatus(st, partition, wsid, projector.Name, ProjectionStatus_Active, wlogOffset); err != nil {
+		return err
+	}
+	if _, err = st.ApplyIntents(); err != nil {
+		return err
+	}
+	return st.FlushBundles()
+}
+
+// lazyProjector returns the lazy async projector which updates the view
+func lazyProjector(appStructs istructs.IAppStructs, partition istructs.PartitionID, view appdef.QName) (projector istructs.Projector, ok bool) {
+	for _, factory := range appStructs.AsyncProjectors() {
+		projector = factory(partition)
+		if projector.Kind != istructs.ProjectionKind_Lazy {
+			continue
+		}
+		for _, updatedView := range projector.UpdatedViews {
+			if updatedView == view {
+				return projector, true
+			}
+		}
+	}
+	return istructs.Projector{}, false
+}
+
+func readProjectionStatus(appStructs istructs.IAppStructs, partition istructs.PartitionID, wsid istructs.WSID, projectorName appdef.QName) (status ProjectionStatus, wlogOffset istructs.Offset, err error) {
+	key := appStructs.ViewRecords().KeyBuilder(qnameProjectionStatuses)
+	key.PutInt32(partitionFld, int32(partition))
+	key.PutQName(projectorNameFld, projectorName)
+	value, err := appStructs.ViewRecords().Get(wsid, key)
+	if err == istructsmem.ErrRecordNotFound {
+		return ProjectionStatus_Idle, istructs.NullOffset, nil
+	}
+	if err != nil {
+		return ProjectionStatus_Idle, istructs.NullOffset, err
+	}
+	return ProjectionStatus(value.AsInt32(statusFld)), istructs.Offset(value.AsInt64(wlogOffsetFld)), nil
+}
+
+func putProjectionStatus(st state.IBundledHostState, partition istructs.PartitionID, wsid istructs.WSID, projectorName appdef.QName,
+	status ProjectionStatus, wlogOffset istructs.Offset) (err error) {
+	key, err := st.KeyBuilder(state.ViewRecordsStorage, qnameProjectionStatuses)
+	if err != nil {
+		return
+	}
+	key.PutInt64(state.Field_WSID, int64(wsid))
+	key.PutInt32(partitionFld, int32(partition))
+	key.PutQName(projectorNameFld, projectorName)
+	value, err := st.NewValue(key)
+	if err != nil {
+		return
+	}
+	value.PutInt32(statusFld, int32(status))
+	value.PutInt64(wlogOffsetFld, int64(wlogOffset))
+	return
+}
@@ -0,0 +1,227 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package projectors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/in10nmem"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+)
+
+type testAppStructsProvider struct {
+	app istructs.IAppStructs
+}
+
+func (p *testAppStructsProvider) AppStructs(istructs.AppQName) (istructs.IAppStructs, error) {
+	return p.app, nil
+}
+
+// wLogFiller puts events both to PLog and to WLog
+type wLogFiller struct {
+	pLogFiller
+	wlogOffsets map[istructs.WSID]istructs.Offset
+}
+
+func (f *wLogFiller) fill(wsid istructs.WSID) (offset istructs.Offset) {
+	f.wlogOffsets[wsid]++
+	reb := f.app.Events().GetNewRawEventBuilder(istructs.NewRawEventBuilderParams{
+		GenericRawEventBuilderParams: istructs.GenericRawEventBuilderParams{
+			Workspace:         wsid,
+			HandlingPartition: f.partition,
+			PLogOffset:        f.offset,
+			WLogOffset:        f.wlogOffsets[wsid],
+			QName:             f.cmdQName,
+		},
+	})
+	rawEvent, err := reb.BuildRawEvent()
+	if err != nil {
+		panic(err)
+	}
+	offset = f.offset
+	f.offset++
+	generator := func(istructs.RecordID, appdef.IDef) (storage istructs.RecordID, err error) {
+		return istructs.NullRecordID, nil
+	}
+	event, err := f.app.Events().PutPlog(rawEvent, nil, generator)
+	if err != nil {
+		panic(err)
+	}
+	if _, err = f.app.Events().PutWlog(event); err != nil {
+		panic(err)
+	}
+	return offset
+}
+
+func getProjectionStatus(require *require.Assertions, app istructs.IAppStructs, partition istructs.PartitionID, wsid istructs.WSID) (ProjectionStatus, istructs.Offset) {
+	status, wlogOffset, err := readProjectionStatus(app, partition, wsid, incrementorName)
+	require.NoError(err)
+	return status, wlogOffset
+}
+
+func TestBasicUsage_LazyProjection(t *testing.T) {
+	require := require.New(t)
+
+	cmdQName := appdef.NewQName("test", "test")
+	lazyIncrementorFactory := func(partition istructs.PartitionID) istructs.Projector {
+		return istructs.Projector{
+			Name:         incrementorName,
+			Func:         incrementor,
+			Kind:         istructs.ProjectionKind_Lazy,
+			UpdatedViews: []appdef.QName{incProjectionView},
+		}
+	}
+	app := appStructs(
+		func(appDef appdef.IAppDefBuilder) {
+			ProvideViewDef(appDef, incProjectionView, buildProjectionView)
+			ProvideViewDef(appDef, decProjectionView, buildProjectionView)
+			ProvideOffsetsDef(appDef)
+		},
+		func(cfg *istructsmem.AppConfigType) {
+			cfg.Resources.Add(istructsmem.NewCommandFunction(cmdQName, appdef.NullQName, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec))
+			cfg.AddAsyncProjectors(lazyIncrementorFactory)
+		})
+	partitionNr := istructs.PartitionID(1)
+
+	f := wLogFiller{
+		pLogFiller: pLogFiller{
+			app:       app,
+			partition: partitionNr,
+			offset:    istructs.Offset(1),
+			cmdQName:  cmdQName,
+		},
+		wlogOffsets: make(map[istructs.WSID]istructs.Offset),
+	}
+	f.fill(1001)
+	f.fill(1002)
+	f.fill(1001)
+	topOffset := f.fill(1001)
+
+	withCancel, cancelCtx := context.WithCancel(context.Background())
+
+	broker := in10nmem.Provide(in10n.Quotas{
+		Channels:               1,
+		ChannelsPerSubject:     1,
+		Subsciptions:           1,
+		SubsciptionsPerSubject: 1,
+	})
+	conf := AsyncActualizerConf{
+		Ctx:        withCancel,
+		AppQName:   istructs.AppQName_test1_app1,
+		Partition:  partitionNr,
+		AppStructs: func() istructs.IAppStructs { return app },
+		Broker:     broker,
+	}
+	actualizer, err := ProvideAsyncActualizerFactory()(conf, lazyIncrementorFactory)
+	require.NoError(err)
+	require.NoError(actualizer.DoSync(conf.Ctx, struct{}{}))
+	defer func() {
+		cancelCtx()
+		actualizer.Close()
+	}()
+
+	rawActualizers := ProvideRawActualizers(RawActualizersConf{
+		Ctx:                withCancel,
+		AppStructsProvider: &testAppStructsProvider{app: app},
+	})
+
+	t.Run("Actualizer should skip events of IDLE workspaces", func(t *testing.T) {
+		for getActualizerOffset(require, app, partitionNr, incrementorName) < topOffset {
+			time.Sleep(time.Nanosecond)
+		}
+		require.Equal(int32(0), getProjectionValue(require, app, incProjectionView, istructs.WSID(1001)))
+		status, _ := getProjectionStatus(require, app, partitionNr, istructs.WSID(1001))
+		require.Equal(ProjectionStatus_Idle, status)
+	})
+	t.Run("Activate should initialize projection from WLog", func(t *testing.T) {
+		require.NoError(rawActualizers.Activate(context.Background(), istructs.AppQName_test1_app1, partitionNr, istructs.WSID(1001), incProjectionView))
+
+		require.Equal(int32(3), getProjectionValue(require, app, incProjectionView, istructs.WSID(1001)))
+		status, wlogOffset := getProjectionStatus(require, app, partitionNr, istructs.WSID(1001))
+		require.Equal(ProjectionStatus_Active, status)
+		require.Equal(istructs.Offset(3), wlogOffset)
+	})
+	t.Run("Actualizer should feed events of ACTIVE workspaces only", func(t *testing.T) {
+		f.fill(1002)
+		topOffset = f.fill(1001)
+		broker.Update(in10n.ProjectionKey{
+			App:        istructs.AppQName_test1_app1,
+			Projection: PlogQName,
+			WS:         istructs.WSID(partitionNr),
+		}, topOffset)
+		for getActualizerOffset(require, app, partitionNr, incrementorName) < topOffset {
+			time.Sleep(time.Nanosecond)
+		}
+		require.Equal(int32(4), getProjectionValue(require, app, incProjectionView, istructs.WSID(1001)))
+		require.Equal(int32(0), getProjectionValue(require, app, incProjectionView, istructs.WSID(1002)))
+		_, wlogOffset := getProjectionStatus(require, app, partitionNr, istructs.WSID(1001))
+		require.Equal(istructs.Offset(4), wlogOffset)
+	})
+	t.Run("Activate should do nothing for views of non-lazy projections", func(t *testing.T) {
+		require.NoError(rawActualizers.Activate(context.Background(), istructs.AppQName_test1_app1, partitionNr, istructs.WSID(1002), decProjectionView))
+		status, _ := getProjectionStatus(require, app, partitionNr, istructs.WSID(1002))
+		require.Equal(ProjectionStatus_Idle, status)
+	})
+}
+
+func TestRawActualizers_Timeout(t *testing.T) {
+	require := require.New(t)
+
+	cmdQName := appdef.NewQName("test", "test")
+	release := make(chan struct{})
+	blockingFactory := func(partition istructs.PartitionID) istructs.Projector {
+		return istructs.Projector{
+			Name: incrementorName,
+			Func: func(event istructs.IPLogEvent, s istructs.IState, intents istructs.IIntents) (err error) {
+				<-release
+				return incrementor(event, s, intents)
+			},
+			Kind:         istructs.ProjectionKind_Lazy,
+			UpdatedViews: []appdef.QName{incProjectionView},
+		}
+	}
+	app := appStructs(
+		func(appDef appdef.IAppDefBuilder) {
+			ProvideViewDef(appDef, incProjectionView, buildProjectionView)
+			ProvideOffsetsDef(appDef)
+		},
+		func(cfg *istructsmem.AppConfigType) {
+			cfg.Resources.Add(istructsmem.NewCommandFunction(cmdQName, appdef.NullQName, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec))
+			cfg.AddAsyncProjectors(blockingFactory)
+		})
+	partitionNr := istructs.PartitionID(1)
+	f := wLogFiller{
+		pLogFiller: pLogFiller{
+			app:       app,
+			partition: partitionNr,
+			offset:    istructs.Offset(1),
+			cmdQName:  cmdQName,
+		},
+		wlogOffsets: make(map[istructs.WSID]istructs.Offset),
+	}
+	f.fill(1001)
+	f.fill(1001)
+
+	actualizers := ProvideRawActualizers(RawActualizersConf{
+		Ctx:                context.Background(),
+		AppStructsProvider: &testAppStructsProvider{app: app},
+		Timeout:            10 * time.Millisecond,
+	})
+
+	err := actualizers.Activate(context.Background(), istructs.AppQName_test1_app1, partitionNr, istructs.WSID(1001), incProjectionView)
+	require.ErrorIs(err, ErrLazyProjectionNotActive)
+
+	// the running actualization is reused by the next activation
+	close(release)
+	actualizers.(*rawActualizers).conf.Timeout = time.Minute
+	require.NoError(actualizers.Activate(context.Background(), istructs.AppQName_test1_app1, partitionNr, istructs.WSID(1001), incProjectionView))
+	require.Equal(int32(2), getProjectionValue(require, app, incProjectionView, istructs.WSID(1001)))
+}
@@ -0,0 +1,353 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package projectors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+type actualizerKey struct {
+	app       istructs.AppQName
+	partition istructs.PartitionID
+	projector appdef.QName
+}
+
+type resetRequest struct {
+	wsids []istructs.WSID
+	done  chan error
+}
+
+type actualizersAdmin struct {
+	lock        sync.Mutex
+	actualizers map[actualizerKey]*asyncActualizer
+}
+
+func newActualizersAdmin() *actualizersAdmin {
+	return &actualizersAdmin{actualizers: make(map[actualizerKey]*asyncActualizer)}
+}
+
+func (a *actualizersAdmin) Reset(ctx context.Context, app istructs.AppQName, partition istructs.PartitionID, projector appdef.QName, wsids ...istructs.WSID) error {
+	a.lock.Lock()
+	actualizer, ok := a.actualizers[actualizerKey{app: app, partition: partition, projector: projector}]
+	a.lock.Unlock()
+	if !ok {
+		return fmt.Errorf("%v [%d] of %v: %w", projector, partition, app, ErrActualizerNotFound)
+	}
+	req := &resetRequest{wsids: wsids, done: make(chan error, 1)}
+	if err := actualizer.requestReset(req); err != nil {
+		return err
+	}
+	select {
+	case err := <-req.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *actualizersAdmin) register(key actualizerKey, actualizer *asyncActualizer) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.actualizers[key] = actualizer
+}
+
+func (a *actualizersAdmin) unregister(key actualizerKey, actualizer *asyncActualizer) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.actualizers[key] == actualizer {
+		delete(a.actualizers, key)
+	}
+}
+
+// replay keeps the state of PLog replay after the actualizer reset
+type replay struct {
+	// max replayed offset
+	till istructs.Offset
+	// events of all workspaces are replayed till this offset
+	all istructs.Offset
+	// events of the workspace are replayed till the offset
+	wsids      map[istructs.WSID]istructs.Offset
+	views      map[appdef.QName]bool
+	truncated  map[string]bool
+	registered map[string]bool
+}
+
+func newReplay(resets map[istructs.WSID]istructs.Offset, offset istructs.Offset, views []appdef.QName) *replay {
+	r := &replay{
+		wsids:      make(map[istructs.WSID]istructs.Offset),
+		views:      make(map[appdef.QName]bool, len(views)),
+		truncated:  make(map[string]bool),
+		registered: make(map[string]bool),
+	}
+	for wsid, till := range resets {
+		if till <= offset {
+			continue
+		}
+		if wsid == istructs.NullWSID {
+			r.all = till
+		} else {
+			r.wsids[wsid] = till
+		}
+		if till > r.till {
+			r.till = till
+		}
+	}
+	for _, view := range views {
+		r.views[view] = true
+	}
+	return r
+}
+
+// active returns true if the event with the offset is replayed
+func (r *replay) active(offset istructs.Offset) bool { return offset <= r.till }
+
+// feeds returns true if the event of the workspace must be fed to projector
+func (r *replay) feeds(wsid istructs.WSID, offset istructs.Offset) bool {
+	return !r.active(offset) || r.rebuilds(wsid, offset)
+}
+
+func (r *replay) rebuilds(wsid istructs.WSID, offset istructs.Offset) bool {
+	return offset <= r.all || offset <= r.wsids[wsid]
+}
+
+func (r *replay) resets(wsid istructs.WSID) bool {
+	_, ok := r.wsids[wsid]
+	return ok || r.all != istructs.NullOffset
+}
+
+// truncate deletes the view partition when it is touched first time by the workspace which is rebuilt.
+// Partitions which are not registered yet (see truncateRegistered) are truncated here
+func (r *replay) truncate(appStructs istructs.IAppStructs, view appdef.QName, wsid istructs.WSID, key istructs.IKeyBuilder) (err error) {
+	if !r.views[view] || !r.resets(wsid) {
+		return nil
+	}
+	id := partitionID(appStructs.AppDef(), view, wsid, key)
+	if r.truncated[id] {
+		return nil
+	}
+	if err = appStructs.ViewRecords().DeletePartition(wsid, key); err != nil {
+		return err
+	}
+	r.truncated[id] = true
+	return nil
+}
+
+// register stores the view partition touched by the projector first time since the actualizer start
+func (r *replay) register(appStructs istructs.IAppStructs, partition istructs.PartitionID, projectorName appdef.QName,
+	view appdef.QName, wsid istructs.WSID, key istructs.IKeyBuilder) (err error) {
+	if !r.views[view] {
+		return nil
+	}
+	id := partitionID(appStructs.AppDef(), view, wsid, key)
+	if r.registered[id] {
+		return nil
+	}
+	if err = registerPartition(appStructs, partition, projectorName, view, wsid, key); err != nil {
+		return err
+	}
+	r.registered[id] = true
+	return nil
+}
+
+// truncateRegistered deletes all registered partitions of the views in the workspaces which are rebuilt.
+// Called before the replay is started, so partitions which are not touched by the replayed events are deleted too.
+// If all workspaces are rebuilt then the registered partitions are deleted from the registry, they are registered again by the replay
+func (r *replay) truncateRegistered(ctx context.Context, appStructs istructs.IAppStructs, partition istructs.PartitionID, projectorName appdef.QName) (err error) {
+	if r.till == istructs.NullOffset {
+		return nil
+	}
+	type viewPartition struct {
+		view    appdef.QName
+		wsid    istructs.WSID
+		partKey string
+	}
+	stale := make([]viewPartition, 0)
+	registry := partitionsKey(appStructs, partition, projectorName)
+	err = appStructs.ViewRecords().Read(ctx, istructs.NullWSID, registry, func(key istructs.IKey, _ istructs.IValue) (err error) {
+		p := viewPartition{
+			view:    key.AsQName(viewFld),
+			wsid:    istructs.WSID(key.AsInt64(wsidFld)),
+			partKey: key.AsString(partKeyFld),
+		}
+		if r.views[p.view] && r.resets(p.wsid) {
+			stale = append(stale, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, p := range stale {
+		key := appStructs.ViewRecords().KeyBuilder(p.view)
+		if err = putPartitionKey(appStructs.AppDef(), p.view, key, p.partKey); err != nil {
+			return err
+		}
+		if err = appStructs.ViewRecords().DeletePartition(p.wsid, key); err != nil {
+			return err
+		}
+		r.truncated[partitionID(appStructs.AppDef(), p.view, p.wsid, key)] = true
+	}
+	if r.all != istructs.NullOffset {
+		return appStructs.ViewRecords().DeletePartition(istructs.NullWSID, registry)
+	}
+	return nil
+}
+
+func partitionsKey(appStructs istructs.IAppStructs, partition istructs.PartitionID, projectorName appdef.QName) istructs.IKeyBuilder {
+	key := appStructs.ViewRecords().KeyBuilder(qnameProjectionPartitions)
+	key.PutInt32(partitionFld, int32(partition))
+	key.PutQName(projectorNameFld, projectorName)
+	return key
+}
+
+// registerPartition stores the partition key of the view written by the projector in the registry of the projector partitions
+func registerPartition(appStructs istructs.IAppStructs, partition istructs.PartitionID, projectorName appdef.QName,
+	view appdef.QName, wsid istructs.WSID, key istructs.IKeyBuilder) error {
+	partKey := make(map[string]interface{})
+	row := key.(istructs.IRowReader)
+	appStructs.AppDef().Def(appdef.ViewPartitionKeyDefName(view)).Fields(func(f appdef.IField) {
+		partKey[f.Name()] = coreutils.ReadByKind(f.Name(), f.DataKind(), row)
+	})
+	bb, err := json.Marshal(partKey)
+	if err != nil {
+		return err
+	}
+	registry := partitionsKey(appStructs, partition, projectorName)
+	registry.PutInt64(wsidFld, int64(wsid))
+	registry.PutQName(viewFld, view)
+	registry.PutString(partKeyFld, string(bb))
+	return appStructs.ViewRecords().Put(istructs.NullWSID, registry, appStructs.ViewRecords().NewValueBuilder(qnameProjectionPartitions))
+}
+
+// putPartitionKey fills the partition key of the view by the registered one
+func putPartitionKey(appDef appdef.IAppDef, view appdef.QName, key istructs.IKeyBuilder, partKey string) (err error) {
+	values := make(map[string]interface{})
+	decoder := json.NewDecoder(bytes.NewReader([]byte(partKey)))
+	decoder.UseNumber()
+	if err = decoder.Decode(&values); err != nil {
+		return err
+	}
+	appDef.Def(appdef.ViewPartitionKeyDefName(view)).Fields(func(f appdef.IField) {
+		if err != nil {
+			return
+		}
+		err = putByKind(key, f.Name(), f.DataKind(), values[f.Name()])
+	})
+	return err
+}
+
+func putByKind(row istructs.IRowWriter, name string, kind appdef.DataKind, value interface{}) error {
+	switch kind {
+	case appdef.DataKind_int32, appdef.DataKind_int64, appdef.DataKind_RecordID:
+		n, err := value.(json.Number).Int64()
+		if err != nil {
+			return err
+		}
+		switch kind {
+		case appdef.DataKind_int32:
+			row.PutInt32(name, int32(n))
+		case appdef.DataKind_int64:
+			row.PutInt64(name, n)
+		default:
+			row.PutRecordID(name, istructs.RecordID(n))
+		}
+	case appdef.DataKind_float32, appdef.DataKind_float64:
+		f, err := value.(json.Number).Float64()
+		if err != nil {
+			return err
+		}
+		if kind == appdef.DataKind_float32 {
+			row.PutFloat32(name, float32(f))
+		} else {
+			row.PutFloat64(name, f)
+		}
+	case appdef.DataKind_bool:
+		row.PutBool(name, value.(bool))
+	default:
+		// bytes are marshaled as base64, decimals as numbers, other kinds as strings
+		row.PutChars(name, fmt.Sprint(value))
+	}
+	return nil
+}
+
+func partitionID(appDef appdef.IAppDef, view appdef.QName, wsid istructs.WSID, key istructs.IKeyBuilder) string {
+	id := strings.Builder{}
+	fmt.Fprint(&id, view, "/", wsid)
+	row := key.(istructs.IRowReader)
+	appDef.Def(appdef.ViewPartitionKeyDefName(view)).Fields(func(f appdef.IField) {
+		fmt.Fprint(&id, "/", coreutils.ReadByKind(f.Name(), f.DataKind(), row))
+	})
+	return id.String()
+}
+
+func readResets(ctx context.Context, appStructs istructs.IAppStructs, partition istructs.PartitionID, projectorName appdef.QName) (resets map[istructs.WSID]istructs.Offset, err error) {
+	resets = make(map[istructs.WSID]istructs.Offset)
+	key := appStructs.ViewRecords().KeyBuilder(qnameProjectionResets)
+	key.PutInt32(partitionFld, int32(partition))
+	key.PutQName(projectorNameFld, projectorName)
+	err = appStructs.ViewRecords().Read(ctx, istructs.NullWSID, key, func(key istructs.IKey, value istructs.IValue) (err error) {
+		resets[istructs.WSID(key.AsInt64(wsidFld))] = istructs.Offset(value.AsInt64(replayTillFld))
+		return nil
+	})
+	return resets, err
+}
+
+// resetActualizer persists the resets of the workspaces (NullWSID means all workspaces) and the null actualizer offset.
+// Events are replayed till the last handled offset, replays which are finished already are deactivated
+func resetActualizer(ctx context.Context, appStructs istructs.IAppStructs, partition istructs.PartitionID, projectorName appdef.QName, wsids []istructs.WSID) (err error) {
+	offset, err := ActualizerOffset(appStructs, partition, projectorName)
+	if err != nil {
+		return err
+	}
+	resets, err := readResets(ctx, appStructs, partition, projectorName)
+	if err != nil {
+		return err
+	}
+	till := offset
+	for wsid, replayTill := range resets {
+		if replayTill <= offset {
+			resets[wsid] = istructs.NullOffset
+		} else if replayTill > till {
+			till = replayTill
+		}
+	}
+	if till == istructs.NullOffset {
+		return nil
+	}
+	if len(wsids) == 0 {
+		wsids = []istructs.WSID{istructs.NullWSID}
+	}
+	for _, wsid := range wsids {
+		resets[wsid] = till
+	}
+
+	batch := make([]istructs.ViewKV, 0, len(resets)+1)
+	for wsid, replayTill := range resets {
+		key := appStructs.ViewRecords().KeyBuilder(qnameProjectionResets)
+		key.PutInt32(partitionFld, int32(partition))
+		key.PutQName(projectorNameFld, projectorName)
+		key.PutInt64(wsidFld, int64(wsid))
+		value := appStructs.ViewRecords().NewValueBuilder(qnameProjectionResets)
+		value.PutInt64(replayTillFld, int64(replayTill))
+		batch = append(batch, istructs.ViewKV{Key: key, Value: value})
+	}
+	key := appStructs.ViewRecords().KeyBuilder(qnameProjectionOffsets)
+	key.PutInt32(partitionFld, int32(partition))
+	key.PutQName(projectorNameFld, projectorName)
+	value := appStructs.ViewRecords().NewValueBuilder(qnameProjectionOffsets)
+	value.PutInt64(offsetFld, int64(istructs.NullOffset))
+	batch = append(batch, istructs.ViewKV{Key: key, Value: value})
+
+	return appStructs.ViewRecords().PutBatch(istructs.NullWSID, batch)
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package projectors
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/in10nmem"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+)
+
+func putProjectionValue(require *require.Assertions, appStructs istructs.IAppStructs, qname appdef.QName, wsid istructs.WSID, v int32) {
+	key := appStructs.ViewRecords().KeyBuilder(qname)
+	key.PutInt32("pk", 0)
+	key.PutInt32("cc", 0)
+	value := appStructs.ViewRecords().NewValueBuilder(qname)
+	value.PutInt32(colValue, v)
+	require.NoError(appStructs.ViewRecords().Put(wsid, key, value))
+}
+
+func TestBasicUsage_ActualizerReset(t *testing.T) {
+	require := require.New(t)
+
+	cmdQName := appdef.NewQName("test", "test")
+	factory := func(partition istructs.PartitionID) istructs.Projector {
+		return istructs.Projector{Name: incrementorName, Func: incrementor, UpdatedViews: []appdef.QName{incProjectionView}}
+	}
+	app := appStructs(
+		func(appDef appdef.IAppDefBuilder) {
+			ProvideViewDef(appDef, incProjectionView, buildProjectionView)
+			ProvideOffsetsDef(appDef)
+		},
+		func(cfg *istructsmem.AppConfigType) {
+			cfg.Resources.Add(istructsmem.NewCommandFunction(cmdQName, appdef.NullQName, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec))
+		})
+	partitionNr := istructs.PartitionID(1)
+
+	f := pLogFiller{
+		app:       app,
+		partition: partitionNr,
+		offset:    istructs.Offset(1),
+		cmdQName:  cmdQName,
+	}
+	f.fill(1001)
+	f.fill(1002)
+	f.fill(1001)
+	topOffset := f.fill(1001)
+
+	withCancel, cancelCtx := context.WithCancel(context.Background())
+
+	broker := in10nmem.Provide(in10n.Quotas{
+		Channels:               1,
+		ChannelsPerSubject:     1,
+		Subsciptions:           1,
+		SubsciptionsPerSubject: 1,
+	})
+	metrics := simpleMetrics{}
+	admin := ProvideActualizersAdmin()
+	conf := AsyncActualizerConf{
+		Ctx:        withCancel,
+		AppQName:   istructs.AppQName_test1_app1,
+		Partition:  partitionNr,
+		AppStructs: func() istructs.IAppStructs { return app },
+		Broker:     broker,
+		Metrics:    &metrics,
+		Admin:      admin,
+	}
+	actualizer, err := ProvideAsyncActualizerFactory()(conf, factory)
+	require.NoError(err)
+	require.NoError(actualizer.DoSync(conf.Ctx, struct{}{}))
+	defer func() {
+		cancelCtx()
+		actualizer.Close()
+	}()
+
+	waitForOffset := func() {
+		for getActualizerOffset(require, app, partitionNr, incrementorName) < topOffset {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	reset := func(wsids ...istructs.WSID) error {
+		var err error
+		// actualizer registers itself asynchronously
+		for err = admin.Reset(context.Background(), istructs.AppQName_test1_app1, partitionNr, incrementorName, wsids...); err != nil; {
+			require.ErrorIs(err, ErrActualizerNotFound)
+			time.Sleep(time.Millisecond)
+			err = admin.Reset(context.Background(), istructs.AppQName_test1_app1, partitionNr, incrementorName, wsids...)
+		}
+		return err
+	}
+
+	waitForOffset()
+	require.Equal(int32(3), getProjectionValue(require, app, incProjectionView, istructs.WSID(1001)))
+	require.Equal(int32(1), getProjectionValue(require, app, incProjectionView, istructs.WSID(1002)))
+
+	t.Run("Reset should rebuild views of all workspaces", func(t *testing.T) {
+		putProjectionValue(require, app, incProjectionView, istructs.WSID(1001), 100)
+		putProjectionValue(require, app, incProjectionView, istructs.WSID(1002), 100)
+
+		require.NoError(reset())
+		waitForOffset()
+
+		require.Equal(int32(3), getProjectionValue(require, app, incProjectionView, istructs.WSID(1001)))
+		require.Equal(int32(1), getProjectionValue(require, app, incProjectionView, istructs.WSID(1002)))
+		require.Zero(atomic.LoadInt64(&metrics.replayTillOffset))
+	})
+	t.Run("Reset should truncate partitions which are not touched by replayed events", func(t *testing.T) {
+		stale := istructs.WSID(1003)
+		putProjectionValue(require, app, incProjectionView, stale, 100)
+		key := app.ViewRecords().KeyBuilder(incProjectionView)
+		key.PutInt32("pk", 0)
+		require.NoError(registerPartition(app, partitionNr, incrementorName, incProjectionView, stale, key))
+
+		require.NoError(reset(stale))
+		waitForOffset()
+
+		require.Zero(getProjectionValue(require, app, incProjectionView, stale))
+		require.Equal(int32(3), getProjectionValue(require, app, incProjectionView, istructs.WSID(1001)))
+		require.Equal(int32(1), getProjectionValue(require, app, incProjectionView, istructs.WSID(1002)))
+	})
+	t.Run("Reset should rebuild views of the given workspaces only", func(t *testing.T) {
+		putProjectionValue(require, app, incProjectionView, istructs.WSID(1001), 100)
+		putProjectionValue(require, app, incProjectionView, istructs.WSID(1002), 100)
+
+		require.NoError(reset(istructs.WSID(1002)))
+		waitForOffset()
+
+		require.Equal(int32(100), getProjectionValue(require, app, incProjectionView, istructs.WSID(1001)))
+		require.Equal(int32(1), getProjectionValue(require, app, incProjectionView, istructs.WSID(1002)))
+	})
+	t.Run("Actualizer should continue after reset", func(t *testing.T) {
+		topOffset = f.fill(1002)
+		broker.Update(in10n.ProjectionKey{
+			App:        istructs.AppQName_test1_app1,
+			Projection: PlogQName,
+			WS:         istructs.WSID(partitionNr),
+		}, topOffset)
+		waitForOffset()
+
+		require.Equal(int32(100), getProjectionValue(require, app, incProjectionView, istructs.WSID(1001)))
+		require.Equal(int32(2), getProjectionValue(require, app, incProjectionView, istructs.WSID(1002)))
+	})
+	t.Run("Reset should fail if actualizer is not running", func(t *testing.T) {
+		err := admin.Reset(context.Background(), istructs.AppQName_test1_app1, partitionNr, decrementorName)
+		require.ErrorIs(err, ErrActualizerNotFound)
+	})
+}
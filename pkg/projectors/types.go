@@ -34,6 +34,18 @@ func (s *asyncActualizerContextState) error() error {
 	return s.err
 }
 
+type ProjectionStatus int32
+
+// wlogEvent adapts WLog event to be fed to projector
+type wlogEvent struct {
+	istructs.IWLogEvent
+	wsid       istructs.WSID
+	wlogOffset istructs.Offset
+}
+
+func (e *wlogEvent) Workspace() istructs.WSID    { return e.wsid }
+func (e *wlogEvent) WLogOffset() istructs.Offset { return e.wlogOffset }
+
 func isAcceptable(p istructs.Projector, event istructs.IPLogEvent) bool {
 	if event.QName() == istructs.QNameForError {
 		return p.HandleErrors
@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package recordhistory
+
+import "github.com/voedger/voedger/pkg/appdef"
+
+var (
+	// View with changes of records indexed by record ID
+	QNameRecordHistoryView = appdef.NewQName(appdef.SysPackage, "RecordHistoryView")
+
+	// Sync projector which maintains the record history view
+	QNameProjectorRecordHistory = appdef.NewQName(appdef.SysPackage, "recordHistory")
+
+	// q.sys.RecordHistory query function which returns changes of the record
+	QNameQueryRecordHistory       = appdef.NewQName(appdef.SysPackage, "RecordHistory")
+	QNameQueryRecordHistoryParams = appdef.NewQName(appdef.SysPackage, "RecordHistoryParams")
+	QNameQueryRecordHistoryResult = appdef.NewQName(appdef.SysPackage, "RecordHistoryResult")
+)
+
+// sys.RecordHistoryView fields
+const (
+	Field_RecordID     = "RecordID"     // ID of the changed record, partition key
+	Field_WLogOffset   = "WLogOffset"   // offset of the event which changed the record
+	Field_RegisteredAt = "RegisteredAt" // time of the event, unix milliseconds
+	Field_CommandQName = "CommandQName" // qualified name of the command
+	Field_Principal    = "Principal"    // login of the principal who issued the command, empty if unknown
+	Field_DeviceID     = "DeviceID"     // connected device ID of the synced event, zero otherwise
+	Field_IsNew        = "IsNew"        // true if the record is created by the event
+	Field_Fields       = "Fields"       // JSON object with new values of the changed fields
+)
+
+// q.sys.RecordHistory query function parameters and result fields
+const (
+	Field_ID     = "ID"     // ID of the record
+	Field_Before = "Before" // JSON object with values of the changed fields before the change, null if unknown
+	Field_After  = "After"  // JSON object with values of the changed fields after the change
+)
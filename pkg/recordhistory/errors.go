@@ -0,0 +1,10 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package recordhistory
+
+import "errors"
+
+var ErrRecordIDRequired = errors.New("record ID is required")
+var ErrSelectNotAuthorizable = errors.New("query workpiece does not authorize select")
@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package recordhistory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/state"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+// principalLoginer is implemented by events of the command processor
+type principalLoginer interface {
+	PrincipalLogin() string
+}
+
+// selectAuthorizer is implemented by the query processor workpiece
+type selectAuthorizer interface {
+	AuthorizeSelect(resource appdef.QName, fields []string) error
+}
+
+func recordHistoryProjectorFactory(appDef func() appdef.IAppDef) istructs.ProjectorFactory {
+	return func(istructs.PartitionID) istructs.Projector {
+		return istructs.Projector{
+			Name:         QNameProjectorRecordHistory,
+			Func:         recordHistoryProjector(appDef),
+			UpdatedViews: []appdef.QName{QNameRecordHistoryView},
+		}
+	}
+}
+
+// recordHistoryProjector keeps new values of the changed fields of every CUD
+func recordHistoryProjector(appDef func() appdef.IAppDef) func(event istructs.IPLogEvent, s istructs.IState, intents istructs.IIntents) error {
+	return func(event istructs.IPLogEvent, s istructs.IState, intents istructs.IIntents) (err error) {
+		principal := ""
+		if e, ok := event.(principalLoginer); ok {
+			principal = e.PrincipalLogin()
+		}
+		ad := appDef()
+		return event.CUDs(func(rec istructs.ICUDRow) error {
+			fields, err := changedFields(ad, rec)
+			if err != nil {
+				return fmt.Errorf("record %d %s: %w", rec.ID(), rec.QName(), err)
+			}
+			kb, err := s.KeyBuilder(state.ViewRecordsStorage, QNameRecordHistoryView)
+			if err != nil {
+				return err
+			}
+			kb.PutRecordID(Field_RecordID, rec.ID())
+			kb.PutInt64(Field_WLogOffset, int64(event.WLogOffset()))
+			vb, err := intents.NewValue(kb)
+			if err != nil {
+				return err
+			}
+			vb.PutInt64(Field_RegisteredAt, int64(event.RegisteredAt()))
+			vb.PutQName(Field_CommandQName, event.QName())
+			vb.PutString(Field_Principal, principal)
+			vb.PutInt32(Field_DeviceID, int32(event.DeviceID()))
+			vb.PutBool(Field_IsNew, rec.IsNew())
+			vb.PutString(Field_Fields, fields)
+			return nil
+		})
+	}
+}
+
+// changedFields returns JSON object with new values of the changed user fields.
+// sys.IsActive is always added since it is not known whether it is changed
+func changedFields(appDef appdef.IAppDef, rec istructs.ICUDRow) (string, error) {
+	def := appDef.Def(rec.QName())
+	fd := coreutils.NewFieldsDef(def)
+	fields := make(map[string]interface{})
+	rec.ModifiedFields(func(name string, _ interface{}) {
+		if appdef.IsSysField(name) {
+			return
+		}
+		fields[name] = coreutils.ReadByKind(name, fd[name], rec)
+	})
+	if def.Kind().HasSystemField(appdef.SystemField_IsActive) {
+		fields[appdef.SystemField_IsActive] = rec.AsBool(appdef.SystemField_IsActive)
+	}
+	bb, err := json.Marshal(fields)
+	return string(bb), err
+}
+
+// recordHistoryQueryExec sends the record changes in the events order.
+// Values before the change are taken from the previous changes, so they are unknown if the history is incomplete
+func recordHistoryQueryExec(cfg *istructsmem.AppConfigType) istructsmem.ExecQueryClosure {
+	return func(_ context.Context, _ istructs.IQueryFunction, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) (err error) {
+		id := args.ArgumentObject.AsRecordID(Field_ID)
+		if id == istructs.NullRecordID {
+			return ErrRecordIDRequired
+		}
+		if ok, err := authorizeSelect(cfg.AppDef, args, id); !ok || err != nil {
+			return err
+		}
+		kb, err := args.State.KeyBuilder(state.ViewRecordsStorage, QNameRecordHistoryView)
+		if err != nil {
+			return err
+		}
+		kb.PutRecordID(Field_RecordID, id)
+		values := make(map[string]json.RawMessage)
+		return args.State.Read(kb, func(key istructs.IKey, value istructs.IStateValue) error {
+			after := make(map[string]json.RawMessage)
+			if err := json.Unmarshal([]byte(value.AsString(Field_Fields)), &after); err != nil {
+				return err
+			}
+			before := make(map[string]json.RawMessage)
+			if !value.AsBool(Field_IsNew) {
+				if prev, ok := values[appdef.SystemField_IsActive]; ok && bytes.Equal(prev, after[appdef.SystemField_IsActive]) {
+					delete(after, appdef.SystemField_IsActive)
+				}
+				for name := range after {
+					before[name] = values[name]
+				}
+			}
+			for name, v := range after {
+				values[name] = v
+			}
+			beforeBytes, err := json.Marshal(before)
+			if err != nil {
+				return err
+			}
+			afterBytes, err := json.Marshal(after)
+			if err != nil {
+				return err
+			}
+
+			res := istructsmem.NewIObjectBuilder(cfg, QNameQueryRecordHistoryResult)
+			res.PutInt64(Field_WLogOffset, key.AsInt64(Field_WLogOffset))
+			res.PutInt64(Field_RegisteredAt, value.AsInt64(Field_RegisteredAt))
+			res.PutQName(Field_CommandQName, value.AsQName(Field_CommandQName))
+			res.PutString(Field_Principal, value.AsString(Field_Principal))
+			res.PutInt32(Field_DeviceID, value.AsInt32(Field_DeviceID))
+			res.PutBool(Field_IsNew, value.AsBool(Field_IsNew))
+			res.PutString(Field_Before, string(beforeBytes))
+			res.PutString(Field_After, string(afterBytes))
+			obj, err := res.Build()
+			if err != nil {
+				return err
+			}
+			return callback(obj)
+		})
+	}
+}
+
+// authorizeSelect checks that principals are allowed to read the fields of the record.
+// Returns false if the record is not found, so there is no history to return
+func authorizeSelect(appDef appdef.IAppDef, args istructs.ExecQueryArgs, id istructs.RecordID) (ok bool, err error) {
+	authorizer, ok := args.Workpiece.(selectAuthorizer)
+	if !ok {
+		return false, fmt.Errorf("record %d: %w", id, ErrSelectNotAuthorizable)
+	}
+	kb, err := args.State.KeyBuilder(state.RecordsStorage, appdef.NullQName)
+	if err != nil {
+		return false, err
+	}
+	kb.PutRecordID(state.Field_ID, id)
+	value, ok, err := args.State.CanExist(kb)
+	if !ok || err != nil {
+		return false, err
+	}
+	def := appDef.Def(value.AsRecord("").QName())
+	fields := make([]string, 0, def.FieldCount())
+	def.Fields(func(f appdef.IField) { fields = append(fields, f.Name()) })
+	if err = authorizer.AuthorizeSelect(def.QName(), fields); err != nil {
+		return false, err
+	}
+	return true, nil
+}
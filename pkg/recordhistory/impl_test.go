@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package recordhistory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorageimpl"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+	"github.com/voedger/voedger/pkg/pipeline"
+	"github.com/voedger/voedger/pkg/projectors"
+	"github.com/voedger/voedger/pkg/state"
+)
+
+var qNameArticle = appdef.NewQName("test", "Article")
+
+const (
+	testWSID      = istructs.WSID(1)
+	testPartition = istructs.PartitionID(1)
+)
+
+type testEvent struct {
+	istructs.IPLogEvent
+	login string
+}
+
+func (e testEvent) PrincipalLogin() string { return e.login }
+
+var errSelectDenied = errors.New("select denied")
+
+// testWorkpiece denies select of the records if denied is set
+type testWorkpiece struct {
+	denied     bool
+	authorized map[appdef.QName][]string
+}
+
+func (w *testWorkpiece) AuthorizeSelect(resource appdef.QName, fields []string) error {
+	w.authorized[resource] = fields
+	if w.denied {
+		return errSelectDenied
+	}
+	return nil
+}
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+
+	adb := appdef.New()
+	adb.AddStruct(qNameArticle, appdef.DefKind_CDoc).
+		AddField("name", appdef.DataKind_string, true).
+		AddField("price", appdef.DataKind_int64, false)
+
+	cfgs := make(istructsmem.AppConfigsType)
+	cfg := cfgs.AddConfig(istructs.AppQName_test1_app1, adb)
+	cfg.Resources.Add(istructsmem.NewCommandFunction(istructs.QNameCommandCUD, appdef.NullQName, appdef.NullQName, appdef.NullQName, istructsmem.NullCommandExec))
+	Provide(cfg, adb)
+
+	asp := istructsmem.Provide(cfgs, iratesce.TestBucketsFactory, payloads.TestAppTokensFactory(itokensjwt.TestTokensJWT()), istorageimpl.Provide(istorage.ProvideMem()))
+	app, err := asp.AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	actualizer := projectors.ProvideSyncActualizerFactory()(projectors.SyncActualizerConf{
+		Ctx:        context.Background(),
+		Partition:  testPartition,
+		AppStructs: func() istructs.IAppStructs { return app },
+	}, app.SyncProjectors()[0])
+	processor := pipeline.NewSyncPipeline(context.Background(), "partition processor", pipeline.WireSyncOperator("actualizer", actualizer))
+	defer processor.Close()
+
+	offset := istructs.Offset(0)
+	articleID := istructs.NullRecordID
+	putEvent := func(login string, cuds func(cud istructs.ICUD)) {
+		offset++
+		bld := app.Events().GetSyncRawEventBuilder(istructs.SyncRawEventBuilderParams{
+			GenericRawEventBuilderParams: istructs.GenericRawEventBuilderParams{
+				HandlingPartition: testPartition,
+				PLogOffset:        offset,
+				Workspace:         testWSID,
+				WLogOffset:        offset,
+				QName:             istructs.QNameCommandCUD,
+				RegisteredAt:      istructs.UnixMilli(1000 * offset),
+			},
+			Device:   7,
+			SyncedAt: istructs.UnixMilli(1000 * offset),
+		})
+		cuds(bld.CUDBuilder())
+		rawEvent, err := bld.BuildRawEvent()
+		require.NoError(err)
+		pLogEvent, err := app.Events().PutPlog(rawEvent, nil, func(istructs.RecordID, appdef.IDef) (istructs.RecordID, error) {
+			articleID = istructs.FirstBaseRecordID
+			return articleID, nil
+		})
+		require.NoError(err)
+		require.NoError(app.Records().Apply(pLogEvent))
+		require.NoError(processor.SendSync(testEvent{IPLogEvent: pLogEvent, login: login}))
+	}
+	update := func(cb func(rec istructs.IRowWriter)) func(cud istructs.ICUD) {
+		return func(cud istructs.ICUD) {
+			rec, err := app.Records().Get(testWSID, true, articleID)
+			require.NoError(err)
+			cb(cud.Update(rec))
+		}
+	}
+
+	putEvent("alice", func(cud istructs.ICUD) {
+		rec := cud.Create(qNameArticle)
+		rec.PutRecordID(appdef.SystemField_ID, 1)
+		rec.PutString("name", "Cola")
+	})
+	putEvent("bob", update(func(rec istructs.IRowWriter) {
+		rec.PutString("name", "Pepsi")
+		rec.PutInt64("price", 100)
+	}))
+	putEvent("alice", update(func(rec istructs.IRowWriter) {
+		rec.PutBool(appdef.SystemField_IsActive, false)
+	}))
+
+	workpiece := &testWorkpiece{authorized: make(map[appdef.QName][]string)}
+	history := func(id istructs.RecordID) (res []istructs.IObject, err error) {
+		qf := app.Resources().QueryResource(QNameQueryRecordHistory).(istructs.IQueryFunction)
+		ab := istructsmem.NewIObjectBuilder(cfg, QNameQueryRecordHistoryParams)
+		ab.PutRecordID(Field_ID, id)
+		argument, err := ab.Build()
+		require.NoError(err)
+		args := istructs.ExecQueryArgs{
+			PrepareArgs: istructs.PrepareArgs{ArgumentObject: argument, Workspace: testWSID, Workpiece: workpiece},
+			State: state.ProvideQueryProcessorStateFactory()(context.Background(), app, state.SimplePartitionIDFunc(testPartition),
+				state.SimpleWSIDFunc(testWSID), nil, nil, nil),
+		}
+		err = qf.Exec(context.Background(), args, func(object istructs.IObject) error {
+			res = append(res, object)
+			return nil
+		})
+		return res, err
+	}
+
+	t.Run("Should return record changes", func(t *testing.T) {
+		changes, err := history(articleID)
+		require.NoError(err)
+
+		require.Len(changes, 3)
+		for i, change := range changes {
+			require.Equal(int64(i+1), change.AsInt64(Field_WLogOffset))
+			require.Equal(int64(1000*(i+1)), change.AsInt64(Field_RegisteredAt))
+			require.Equal(istructs.QNameCommandCUD, change.AsQName(Field_CommandQName))
+			require.Equal(int32(7), change.AsInt32(Field_DeviceID))
+		}
+		require.Equal([]string{"alice", "bob", "alice"}, []string{
+			changes[0].AsString(Field_Principal), changes[1].AsString(Field_Principal), changes[2].AsString(Field_Principal)})
+
+		require.True(changes[0].AsBool(Field_IsNew))
+		require.Equal(`{}`, changes[0].AsString(Field_Before))
+		require.JSONEq(`{"name":"Cola","sys.IsActive":true}`, changes[0].AsString(Field_After))
+
+		require.False(changes[1].AsBool(Field_IsNew))
+		require.JSONEq(`{"name":"Cola","price":null}`, changes[1].AsString(Field_Before))
+		require.JSONEq(`{"name":"Pepsi","price":100}`, changes[1].AsString(Field_After))
+
+		require.JSONEq(`{"sys.IsActive":true}`, changes[2].AsString(Field_Before))
+		require.JSONEq(`{"sys.IsActive":false}`, changes[2].AsString(Field_After))
+	})
+	t.Run("Should return nothing for unknown record", func(t *testing.T) {
+		changes, err := history(istructs.FirstBaseRecordID + 1)
+
+		require.NoError(err)
+		require.Empty(changes)
+	})
+	t.Run("Should require record ID", func(t *testing.T) {
+		_, err := history(istructs.NullRecordID)
+
+		require.ErrorIs(err, ErrRecordIDRequired)
+	})
+	t.Run("Should authorize select of the record fields", func(t *testing.T) {
+		_, err := history(articleID)
+		require.NoError(err)
+
+		require.Contains(workpiece.authorized[qNameArticle], "name")
+		require.Contains(workpiece.authorized[qNameArticle], "price")
+	})
+	t.Run("Should fail if select is not authorized", func(t *testing.T) {
+		workpiece.denied = true
+		defer func() { workpiece.denied = false }()
+
+		changes, err := history(articleID)
+
+		require.ErrorIs(err, errSelectDenied)
+		require.Empty(changes)
+	})
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2023-present unTill Pro, Ltd.
+ */
+
+package recordhistory
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructsmem"
+)
+
+// Provide adds the record history view, the sync projector which maintains it and q.sys.RecordHistory query function to the application.
+// appDefBuilder is the application definition builder which the config is created with
+func Provide(cfg *istructsmem.AppConfigType, appDefBuilder appdef.IAppDefBuilder) {
+	appDefBuilder.AddView(QNameRecordHistoryView).
+		AddPartField(Field_RecordID, appdef.DataKind_RecordID).
+		AddClustColumn(Field_WLogOffset, appdef.DataKind_int64).
+		AddValueField(Field_RegisteredAt, appdef.DataKind_int64, true).
+		AddValueField(Field_CommandQName, appdef.DataKind_QName, true).
+		AddValueField(Field_Principal, appdef.DataKind_string, false).
+		AddValueField(Field_DeviceID, appdef.DataKind_int32, false).
+		AddValueField(Field_IsNew, appdef.DataKind_bool, false).
+		AddValueField(Field_Fields, appdef.DataKind_string, true)
+	appDefBuilder.AddStruct(QNameQueryRecordHistoryParams, appdef.DefKind_Object).
+		AddField(Field_ID, appdef.DataKind_RecordID, true)
+	appDefBuilder.AddStruct(QNameQueryRecordHistoryResult, appdef.DefKind_Object).
+		AddField(Field_WLogOffset, appdef.DataKind_int64, true).
+		AddField(Field_RegisteredAt, appdef.DataKind_int64, true).
+		AddField(Field_CommandQName, appdef.DataKind_QName, true).
+		AddField(Field_Principal, appdef.DataKind_string, false).
+		AddField(Field_DeviceID, appdef.DataKind_int32, false).
+		AddField(Field_IsNew, appdef.DataKind_bool, false).
+		AddField(Field_Before, appdef.DataKind_string, true).
+		AddField(Field_After, appdef.DataKind_string, true)
+
+	appDef := func() appdef.IAppDef { return cfg.AppDef }
+	cfg.AddSyncProjectors(recordHistoryProjectorFactory(appDef))
+	cfg.Resources.Add(istructsmem.NewQueryFunction(QNameQueryRecordHistory, QNameQueryRecordHistoryParams, QNameQueryRecordHistoryResult,
+		recordHistoryQueryExec(cfg)))
+}
@@ -18,6 +18,7 @@ var ErrGetBatchNotSupportedByStorage = errors.New("get batch not supported by st
 var ErrReadNotSupportedByStorage = errors.New("read not supported by storage")
 var ErrUpdateNotSupportedByStorage = errors.New("update not supported by storage")
 var ErrInsertNotSupportedByStorage = errors.New("insert not supported by storage")
+var ErrLazyViewReadNotAllowed = errors.New("view of lazy projection can not be read by command processor")
 var errTest = errors.New("test")
 var errCurrentValueIsNotAnArray = errors.New("current value is not an array")
 var errFieldByNameIsNotAnObjectOrArray = errors.New("field by name is not an object or array")
@@ -45,6 +45,14 @@ func put(fieldName string, kind appdef.DataKind, rr istructs.IRowReader, rw istr
 		rw.PutBool(fieldName, rr.AsBool(fieldName))
 	case appdef.DataKind_RecordID:
 		rw.PutRecordID(fieldName, rr.AsRecordID(fieldName))
+	case appdef.DataKind_decimal:
+		rw.PutDecimal(fieldName, rr.AsDecimal(fieldName))
+	case appdef.DataKind_timestamp:
+		rw.PutTimestamp(fieldName, rr.AsTimestamp(fieldName))
+	case appdef.DataKind_date:
+		rw.PutDate(fieldName, rr.AsDate(fieldName))
+	case appdef.DataKind_UUID:
+		rw.PutUUID(fieldName, rr.AsUUID(fieldName))
 	default:
 		panic(fmt.Errorf("illegal state: field - '%s', kind - '%d': %w", fieldName, kind, ErrNotSupported))
 	}
@@ -76,3 +84,19 @@ func cudRowToMap(rec istructs.ICUDRow, cache appDefFunc) (res map[string]interfa
 	res["IsNew"] = rec.IsNew()
 	return res
 }
+
+// isLazyView returns true if view is updated by the lazy async projector
+func isLazyView(appStructs istructs.IAppStructs, partitionID istructs.PartitionID, view appdef.QName) bool {
+	for _, factory := range appStructs.AsyncProjectors() {
+		projector := factory(partitionID)
+		if projector.Kind != istructs.ProjectionKind_Lazy {
+			continue
+		}
+		for _, updatedView := range projector.UpdatedViews {
+			if updatedView == view {
+				return true
+			}
+		}
+	}
+	return false
+}
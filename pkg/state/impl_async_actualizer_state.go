@@ -21,8 +21,16 @@ func WithEmailMessagesChan(messages chan smtptest.Message) ActualizerStateOptFun
 	}
 }
 
+// WithViewKeyGuard sets the guard which is called before view record is read from or written to the underlying storage
+func WithViewKeyGuard(guard ViewKeyGuardFunc) ActualizerStateOptFunc {
+	return func(opts *actualizerStateOpts) {
+		opts.viewKeyGuard = guard
+	}
+}
+
 type actualizerStateOpts struct {
-	messages chan smtptest.Message
+	messages     chan smtptest.Message
+	viewKeyGuard ViewKeyGuardFunc
 }
 
 func implProvideAsyncActualizerState(ctx context.Context, appStructs istructs.IAppStructs, partitionIDFunc PartitionIDFunc, wsidFunc WSIDFunc, n10nFunc N10nFunc, secretReader isecrets.ISecretReader, intentsLimit, bundlesLimit int,
@@ -44,6 +52,7 @@ func implProvideAsyncActualizerState(ctx context.Context, appStructs istructs.IA
 		appDefFunc:      func() appdef.IAppDef { return appStructs.AppDef() },
 		wsidFunc:        wsidFunc,
 		n10nFunc:        n10nFunc,
+		keyGuard:        opts.viewKeyGuard,
 	}, S_GET_BATCH|S_READ|S_INSERT|S_UPDATE)
 
 	state.addStorage(RecordsStorage, &recordsStorage{
@@ -6,6 +6,7 @@ package state
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/isecrets"
@@ -22,6 +23,12 @@ func implProvideCommandProcessorState(ctx context.Context, appStructsFunc AppStr
 		viewRecordsFunc: func() istructs.IViewRecords { return appStructsFunc().ViewRecords() },
 		appDefFunc:      func() appdef.IAppDef { return appStructsFunc().AppDef() },
 		wsidFunc:        wsidFunc,
+		readGuard: func(view appdef.QName, _ istructs.WSID) error {
+			if isLazyView(appStructsFunc(), partitionIDFunc(), view) {
+				return fmt.Errorf("%v: %w", view, ErrLazyViewReadNotAllowed)
+			}
+			return nil
+		},
 	}, S_GET_BATCH)
 
 	bs.addStorage(RecordsStorage, &recordsStorage{
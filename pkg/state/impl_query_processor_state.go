@@ -12,8 +12,25 @@ import (
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
+type QueryProcessorStateOptFunc func(opts *queryProcessorStateOpts)
+
+// Activator is called before view is read. It must initialize lazy projection which updates the view
+func WithLazyViewActivator(activator ViewReadGuardFunc) QueryProcessorStateOptFunc {
+	return func(opts *queryProcessorStateOpts) {
+		opts.lazyViewActivator = activator
+	}
+}
+
+type queryProcessorStateOpts struct {
+	lazyViewActivator ViewReadGuardFunc
+}
+
 func implProvideQueryProcessorState(ctx context.Context, appStructs istructs.IAppStructs, partitionIDFunc PartitionIDFunc, wsidFunc WSIDFunc,
-	secretReader isecrets.ISecretReader, principalsFunc PrincipalsFunc, tokenFunc TokenFunc) IHostState {
+	secretReader isecrets.ISecretReader, principalsFunc PrincipalsFunc, tokenFunc TokenFunc, optFuncs ...QueryProcessorStateOptFunc) IHostState {
+	opts := &queryProcessorStateOpts{}
+	for _, optFunc := range optFuncs {
+		optFunc(opts)
+	}
 	bs := newHostState("QueryProcessor", 0)
 
 	bs.addStorage(ViewRecordsStorage, &viewRecordsStorage{
@@ -21,6 +38,7 @@ func implProvideQueryProcessorState(ctx context.Context, appStructs istructs.IAp
 		viewRecordsFunc: func() istructs.IViewRecords { return appStructs.ViewRecords() },
 		appDefFunc:      func() appdef.IAppDef { return appStructs.AppDef() },
 		wsidFunc:        wsidFunc,
+		readGuard:       opts.lazyViewActivator,
 	}, S_GET_BATCH|S_READ)
 
 	bs.addStorage(RecordsStorage, &recordsStorage{
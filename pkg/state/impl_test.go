@@ -7,6 +7,7 @@ package state
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -32,6 +33,10 @@ func TestSimplePartitionIDFuncDFunc(t *testing.T) {
 }
 func Test_put(t *testing.T) {
 	t.Run("Should be ok", func(t *testing.T) {
+		dec := istructs.NewDecimal(12345, 2)
+		ts := time.UnixMilli(1687000000000).UTC()
+		date := time.Date(2023, time.June, 17, 0, 0, 0, 0, time.UTC)
+		uuid := istructs.UUID{1, 2, 3}
 		mrw := &mockRowWriter{}
 		mrw.
 			On("PutInt32", "int32Fld", int32(1)).
@@ -42,7 +47,11 @@ func Test_put(t *testing.T) {
 			On("PutString", "stringFld", "string").
 			On("PutQName", "qNameFld", testRecordQName1).
 			On("PutBool", "boolFld", true).
-			On("PutRecordID", "recordIDFld", istructs.RecordID(6))
+			On("PutRecordID", "recordIDFld", istructs.RecordID(6)).
+			On("PutDecimal", "decimalFld", dec).
+			On("PutTimestamp", "timestampFld", ts).
+			On("PutDate", "dateFld", date).
+			On("PutUUID", "uuidFld", uuid)
 		mrr := &mockRowReader{}
 		mrr.
 			On("AsInt32", "int32Fld").Return(int32(1)).
@@ -53,7 +62,11 @@ func Test_put(t *testing.T) {
 			On("AsString", "stringFld").Return("string").
 			On("AsQName", "qNameFld").Return(testRecordQName1).
 			On("AsBool", "boolFld").Return(true).
-			On("AsRecordID", "recordIDFld").Return(istructs.RecordID(6))
+			On("AsRecordID", "recordIDFld").Return(istructs.RecordID(6)).
+			On("AsDecimal", "decimalFld").Return(dec).
+			On("AsTimestamp", "timestampFld").Return(ts).
+			On("AsDate", "dateFld").Return(date).
+			On("AsUUID", "uuidFld").Return(uuid)
 
 		put("int32Fld", appdef.DataKind_int32, mrr, mrw)
 		put("int64Fld", appdef.DataKind_int64, mrr, mrw)
@@ -64,12 +77,16 @@ func Test_put(t *testing.T) {
 		put("qNameFld", appdef.DataKind_QName, mrr, mrw)
 		put("boolFld", appdef.DataKind_bool, mrr, mrw)
 		put("recordIDFld", appdef.DataKind_RecordID, mrr, mrw)
+		put("decimalFld", appdef.DataKind_decimal, mrr, mrw)
+		put("timestampFld", appdef.DataKind_timestamp, mrr, mrw)
+		put("dateFld", appdef.DataKind_date, mrr, mrw)
+		put("uuidFld", appdef.DataKind_UUID, mrr, mrw)
 
 		mrw.AssertExpectations(t)
 		mrr.AssertExpectations(t)
 	})
 	t.Run("Should panic when data kind not supported", func(t *testing.T) {
-		require.PanicsWithError(t, "illegal state: field - 'notSupported', kind - '12': not supported", func() {
+		require.PanicsWithError(t, "illegal state: field - 'notSupported', kind - '16': not supported", func() {
 			put("notSupported", appdef.DataKind_FakeLast, nil, nil)
 		})
 	})
@@ -150,6 +167,9 @@ func (s *mockAppStructs) Records() istructs.IRecords { return s.Called().Get(0).
 func (s *mockAppStructs) ViewRecords() istructs.IViewRecords {
 	return s.Called().Get(0).(istructs.IViewRecords)
 }
+func (s *mockAppStructs) AsyncProjectors() []istructs.ProjectorFactory {
+	return s.Called().Get(0).([]istructs.ProjectorFactory)
+}
 
 type mockEvents struct {
 	istructs.IEvents
@@ -328,6 +348,16 @@ func (r *mockRowReader) AsBool(name string) bool { return r.Called(name).Bool(0)
 func (r *mockRowReader) AsRecordID(name string) istructs.RecordID {
 	return r.Called(name).Get(0).(istructs.RecordID)
 }
+func (r *mockRowReader) AsDecimal(name string) istructs.Decimal {
+	return r.Called(name).Get(0).(istructs.Decimal)
+}
+func (r *mockRowReader) AsTimestamp(name string) time.Time {
+	return r.Called(name).Get(0).(time.Time)
+}
+func (r *mockRowReader) AsDate(name string) time.Time { return r.Called(name).Get(0).(time.Time) }
+func (r *mockRowReader) AsUUID(name string) istructs.UUID {
+	return r.Called(name).Get(0).(istructs.UUID)
+}
 
 type mockRowWriter struct {
 	istructs.IRowWriter
@@ -343,6 +373,10 @@ func (w *mockRowWriter) PutString(name, value string)                     { w.Ca
 func (w *mockRowWriter) PutQName(name string, value appdef.QName)         { w.Called(name, value) }
 func (w *mockRowWriter) PutBool(name string, value bool)                  { w.Called(name, value) }
 func (w *mockRowWriter) PutRecordID(name string, value istructs.RecordID) { w.Called(name, value) }
+func (w *mockRowWriter) PutDecimal(name string, value istructs.Decimal)   { w.Called(name, value) }
+func (w *mockRowWriter) PutTimestamp(name string, value time.Time)        { w.Called(name, value) }
+func (w *mockRowWriter) PutDate(name string, value time.Time)             { w.Called(name, value) }
+func (w *mockRowWriter) PutUUID(name string, value istructs.UUID)         { w.Called(name, value) }
 
 func errorFromPanic(f func()) (err error) {
 	defer func() {
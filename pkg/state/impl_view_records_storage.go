@@ -19,6 +19,22 @@ type viewRecordsStorage struct {
 	appDefFunc      appDefFunc
 	wsidFunc        WSIDFunc
 	n10nFunc        N10nFunc
+	readGuard       ViewReadGuardFunc
+	keyGuard        ViewKeyGuardFunc
+}
+
+func (s *viewRecordsStorage) guardRead(view appdef.QName, wsid istructs.WSID) error {
+	if s.readGuard == nil {
+		return nil
+	}
+	return s.readGuard(view, wsid)
+}
+
+func (s *viewRecordsStorage) guardKey(k *viewRecordsKeyBuilder) error {
+	if s.keyGuard == nil {
+		return nil
+	}
+	return s.keyGuard(k.view, k.wsid, k.IKeyBuilder)
 }
 
 func (s *viewRecordsStorage) NewKeyBuilder(entity appdef.QName, _ istructs.IStateKeyBuilder) (newKeyBuilder istructs.IStateKeyBuilder) {
@@ -33,6 +49,12 @@ func (s *viewRecordsStorage) GetBatch(items []GetBatchItem) (err error) {
 	batches := make(map[istructs.WSID][]istructs.ViewRecordGetBatchItem)
 	for itemIdx, item := range items {
 		k := item.key.(*viewRecordsKeyBuilder)
+		if err = s.guardRead(k.view, k.wsid); err != nil {
+			return err
+		}
+		if err = s.guardKey(k); err != nil {
+			return err
+		}
 		wsidToItemIdx[k.wsid] = append(wsidToItemIdx[k.wsid], itemIdx)
 		batches[k.wsid] = append(batches[k.wsid], istructs.ViewRecordGetBatchItem{Key: k.IKeyBuilder})
 	}
@@ -62,6 +84,12 @@ func (s *viewRecordsStorage) Read(kb istructs.IStateKeyBuilder, callback istruct
 		})
 	}
 	vrkb := kb.(*viewRecordsKeyBuilder)
+	if err = s.guardRead(vrkb.view, vrkb.wsid); err != nil {
+		return err
+	}
+	if err = s.guardKey(vrkb); err != nil {
+		return err
+	}
 	return s.viewRecordsFunc().Read(s.ctx, vrkb.wsid, vrkb.IKeyBuilder, cb)
 }
 func (s *viewRecordsStorage) Validate([]ApplyBatchItem) (err error) { return err }
@@ -71,6 +99,9 @@ func (s *viewRecordsStorage) ApplyBatch(items []ApplyBatchItem) (err error) {
 	for _, item := range items {
 		k := item.key.(*viewRecordsKeyBuilder)
 		v := item.value.(*viewRecordsValueBuilder)
+		if err = s.guardKey(k); err != nil {
+			return err
+		}
 		batches[k.wsid] = append(batches[k.wsid], istructs.ViewKV{Key: k.IKeyBuilder, Value: v.IValueBuilder})
 		if nn[n10n{wsid: k.wsid, view: k.view}] < v.offset {
 			nn[n10n{wsid: k.wsid, view: k.view}] = v.offset
@@ -137,6 +137,75 @@ func TestViewRecordsStorage_Read(t *testing.T) {
 		require.ErrorIs(err, errTest)
 	})
 }
+func TestViewRecordsStorage_LazyViews(t *testing.T) {
+	lazyProjector := func(istructs.PartitionID) istructs.Projector {
+		return istructs.Projector{
+			Kind:         istructs.ProjectionKind_Lazy,
+			UpdatedViews: []appdef.QName{testViewRecordQName1},
+		}
+	}
+	newAppStructs := func() *mockAppStructs {
+		appDef := amock.NewAppDef()
+		appDef.AddView(amock.NewView(testViewRecordQName1))
+		appDef.AddView(amock.NewView(testViewRecordQName2))
+		viewRecords := &mockViewRecords{}
+		viewRecords.
+			On("KeyBuilder", testViewRecordQName1).Return(newKeyBuilder(ViewRecordsStorage, testViewRecordQName1)).
+			On("KeyBuilder", testViewRecordQName2).Return(newKeyBuilder(ViewRecordsStorage, testViewRecordQName2)).
+			On("GetBatch", istructs.WSID(1), mock.Anything).Return(nil)
+		appStructs := &mockAppStructs{}
+		appStructs.
+			On("AppDef").Return(appDef).
+			On("Records").Return(&nilRecords{}).
+			On("Events").Return(&nilEvents{}).
+			On("ViewRecords").Return(viewRecords).
+			On("AsyncProjectors").Return([]istructs.ProjectorFactory{lazyProjector})
+		return appStructs
+	}
+	t.Run("Command processor should not read lazy view", func(t *testing.T) {
+		require := require.New(t)
+		appStructs := newAppStructs()
+		s := ProvideCommandProcessorStateFactory()(context.Background(), func() istructs.IAppStructs { return appStructs },
+			SimplePartitionIDFunc(istructs.PartitionID(1)), SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil, nil, 0)
+
+		k, err := s.KeyBuilder(ViewRecordsStorage, testViewRecordQName1)
+		require.NoError(err)
+		_, _, err = s.CanExist(k)
+		require.ErrorIs(err, ErrLazyViewReadNotAllowed)
+
+		k, err = s.KeyBuilder(ViewRecordsStorage, testViewRecordQName2)
+		require.NoError(err)
+		_, ok, err := s.CanExist(k)
+		require.NoError(err)
+		require.False(ok)
+	})
+	t.Run("Query processor should activate view before read", func(t *testing.T) {
+		require := require.New(t)
+		appStructs := newAppStructs()
+		activated := make(map[appdef.QName]istructs.WSID)
+		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil,
+			WithLazyViewActivator(func(view appdef.QName, wsid istructs.WSID) error {
+				activated[view] = wsid
+				if view == testViewRecordQName2 {
+					return errTest
+				}
+				return nil
+			}))
+
+		k, err := s.KeyBuilder(ViewRecordsStorage, testViewRecordQName1)
+		require.NoError(err)
+		_, _, err = s.CanExist(k)
+		require.NoError(err)
+
+		k, err = s.KeyBuilder(ViewRecordsStorage, testViewRecordQName2)
+		require.NoError(err)
+		_, _, err = s.CanExist(k)
+		require.ErrorIs(err, errTest)
+
+		require.Equal(map[appdef.QName]istructs.WSID{testViewRecordQName1: 1, testViewRecordQName2: 1}, activated)
+	})
+}
+
 func TestViewRecordsStorage_ApplyBatch_should_return_error_on_put_batch(t *testing.T) {
 	require := require.New(t)
 
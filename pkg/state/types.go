@@ -27,9 +27,16 @@ type AppStructsFunc func() istructs.IAppStructs
 type CUDFunc func() istructs.ICUD
 type PrincipalsFunc func() []iauthnz.Principal
 type TokenFunc func() string
+
+// ViewReadGuardFunc is called before view records are read, read fails if error is returned
+type ViewReadGuardFunc func(view appdef.QName, wsid istructs.WSID) error
+
+// ViewKeyGuardFunc is called before view record is read from or written to the underlying storage, operation fails if error is returned
+type ViewKeyGuardFunc func(view appdef.QName, wsid istructs.WSID, key istructs.IKeyBuilder) error
 type CommandProcessorStateFactory func(ctx context.Context, appStructsFunc AppStructsFunc, partitionIDFunc PartitionIDFunc, wsidFunc WSIDFunc, secretReader isecrets.ISecretReader, cudFunc CUDFunc, principalPayloadFunc PrincipalsFunc, tokenFunc TokenFunc, intentsLimit int) IHostState
 type SyncActualizerStateFactory func(ctx context.Context, appStructs istructs.IAppStructs, partitionIDFunc PartitionIDFunc, wsidFunc WSIDFunc, n10nFunc N10nFunc, secretReader isecrets.ISecretReader, intentsLimit int) IHostState
-type QueryProcessorStateFactory func(ctx context.Context, appStructs istructs.IAppStructs, partitionIDFunc PartitionIDFunc, wsidFunc WSIDFunc, secretReader isecrets.ISecretReader, principalPayloadFunc PrincipalsFunc, tokenFunc TokenFunc) IHostState
+type QueryProcessorStateFactory func(ctx context.Context, appStructs istructs.IAppStructs, partitionIDFunc PartitionIDFunc, wsidFunc WSIDFunc, secretReader isecrets.ISecretReader, principalPayloadFunc PrincipalsFunc, tokenFunc TokenFunc,
+	opts ...QueryProcessorStateOptFunc) IHostState
 type AsyncActualizerStateFactory func(ctx context.Context, appStructs istructs.IAppStructs, partitionIDFunc PartitionIDFunc, wsidFunc WSIDFunc, n10nFunc N10nFunc, secretReader isecrets.ISecretReader, intentsLimit, bundlesLimit int,
 	opts ...ActualizerStateOptFunc) IBundledHostState
 
@@ -76,6 +83,10 @@ func (b *keyBuilder) PutString(name string, value string)              { b.data[
 func (b *keyBuilder) PutQName(name string, value appdef.QName)         { b.data[name] = value }
 func (b *keyBuilder) PutBool(name string, value bool)                  { b.data[name] = value }
 func (b *keyBuilder) PutRecordID(name string, value istructs.RecordID) { b.data[name] = value }
+func (b *keyBuilder) PutDecimal(name string, value istructs.Decimal)   { b.data[name] = value }
+func (b *keyBuilder) PutTimestamp(name string, value time.Time)        { b.data[name] = value }
+func (b *keyBuilder) PutDate(name string, value time.Time)             { b.data[name] = value }
+func (b *keyBuilder) PutUUID(name string, value istructs.UUID)         { b.data[name] = value }
 func (b *keyBuilder) PutNumber(string, float64)                        { panic(ErrNotSupported) }
 func (b *keyBuilder) PutChars(string, string)                          { panic(ErrNotSupported) }
 func (b *keyBuilder) PartitionKey() istructs.IRowWriter                { panic(ErrNotSupported) }
@@ -206,6 +217,14 @@ func (b *recordsValueBuilder) PutNumber(name string, value float64)     { b.rw.P
 func (b *recordsValueBuilder) PutRecordID(name string, value istructs.RecordID) {
 	b.rw.PutRecordID(name, value)
 }
+func (b *recordsValueBuilder) PutDecimal(name string, value istructs.Decimal) {
+	b.rw.PutDecimal(name, value)
+}
+func (b *recordsValueBuilder) PutTimestamp(name string, value time.Time) {
+	b.rw.PutTimestamp(name, value)
+}
+func (b *recordsValueBuilder) PutDate(name string, value time.Time)     { b.rw.PutDate(name, value) }
+func (b *recordsValueBuilder) PutUUID(name string, value istructs.UUID) { b.rw.PutUUID(name, value) }
 
 type viewRecordsKeyBuilder struct {
 	istructs.IKeyBuilder
@@ -291,6 +310,12 @@ func (v *recordsStorageValue) AsBool(name string) bool          { return v.recor
 func (v *recordsStorageValue) AsRecordID(name string) istructs.RecordID {
 	return v.record.AsRecordID(name)
 }
+func (v *recordsStorageValue) AsDecimal(name string) istructs.Decimal {
+	return v.record.AsDecimal(name)
+}
+func (v *recordsStorageValue) AsTimestamp(name string) time.Time         { return v.record.AsTimestamp(name) }
+func (v *recordsStorageValue) AsDate(name string) time.Time              { return v.record.AsDate(name) }
+func (v *recordsStorageValue) AsUUID(name string) istructs.UUID          { return v.record.AsUUID(name) }
 func (v *recordsStorageValue) AsRecord(string) (record istructs.IRecord) { return v.record }
 func (v *recordsStorageValue) ToJSON(opts ...interface{}) (string, error) {
 	return v.toJSONFunc(v, opts...)
@@ -627,6 +652,14 @@ func (v *viewRecordsStorageValue) AsBool(name string) bool          { return v.v
 func (v *viewRecordsStorageValue) AsRecordID(name string) istructs.RecordID {
 	return v.value.AsRecordID(name)
 }
+func (v *viewRecordsStorageValue) AsDecimal(name string) istructs.Decimal {
+	return v.value.AsDecimal(name)
+}
+func (v *viewRecordsStorageValue) AsTimestamp(name string) time.Time {
+	return v.value.AsTimestamp(name)
+}
+func (v *viewRecordsStorageValue) AsDate(name string) time.Time     { return v.value.AsDate(name) }
+func (v *viewRecordsStorageValue) AsUUID(name string) istructs.UUID { return v.value.AsUUID(name) }
 func (v *viewRecordsStorageValue) AsRecord(name string) istructs.IRecord {
 	return v.value.AsRecord(name)
 }
@@ -665,6 +698,10 @@ func (v *cudRowStorageValue) AsBool(name string) bool {
 func (v *cudRowStorageValue) AsRecordID(name string) istructs.RecordID {
 	return v.value.AsRecordID(name)
 }
+func (v *cudRowStorageValue) AsDecimal(name string) istructs.Decimal { return v.value.AsDecimal(name) }
+func (v *cudRowStorageValue) AsTimestamp(name string) time.Time      { return v.value.AsTimestamp(name) }
+func (v *cudRowStorageValue) AsDate(name string) time.Time           { return v.value.AsDate(name) }
+func (v *cudRowStorageValue) AsUUID(name string) istructs.UUID       { return v.value.AsUUID(name) }
 
 type baseStateValue struct{}
 
@@ -677,6 +714,10 @@ func (v *baseStateValue) AsString(string) string                          { pani
 func (v *baseStateValue) AsQName(string) appdef.QName                     { panic(errNotImplemented) }
 func (v *baseStateValue) AsBool(string) bool                              { panic(errNotImplemented) }
 func (v *baseStateValue) AsRecordID(string) istructs.RecordID             { panic(errNotImplemented) }
+func (v *baseStateValue) AsDecimal(string) istructs.Decimal               { panic(errNotImplemented) }
+func (v *baseStateValue) AsTimestamp(string) time.Time                    { panic(errNotImplemented) }
+func (v *baseStateValue) AsDate(string) time.Time                         { panic(errNotImplemented) }
+func (v *baseStateValue) AsUUID(string) istructs.UUID                     { panic(errNotImplemented) }
 func (v *baseStateValue) RecordIDs(bool, func(string, istructs.RecordID)) { panic(errNotImplemented) }
 func (v *baseStateValue) FieldNames(func(string))                         { panic(errNotImplemented) }
 func (v *baseStateValue) AsRecord(string) istructs.IRecord                { panic(errNotImplemented) }
@@ -45,17 +45,34 @@ func ReadByKind(name string, kind appdef.DataKind, rr istructs.IRowReader) inter
 		return rr.AsQName(name).String()
 	case appdef.DataKind_bool:
 		return rr.AsBool(name)
+	case appdef.DataKind_decimal:
+		return rr.AsDecimal(name) // marshaled to JSON as number with all scale digits
+	case appdef.DataKind_timestamp:
+		return rr.AsTimestamp(name) // marshaled to JSON as RFC 3339 string
+	case appdef.DataKind_date:
+		return rr.AsDate(name).Format(istructs.DateLayout)
+	case appdef.DataKind_UUID:
+		return rr.AsUUID(name) // marshaled to JSON as canonical UUID string
 	default:
 		panic("unsupported kind " + fmt.Sprint(kind) + " for field " + name)
 	}
 }
 
+// NewFieldsDef returns kinds of the definition fields.
+// Fields of the view are fields of its partition key, clustering columns and value
 func NewFieldsDef(def appdef.IDef) FieldsDef {
 	fields := make(map[string]appdef.DataKind)
 	def.Fields(
 		func(f appdef.IField) {
 			fields[f.Name()] = f.DataKind()
 		})
+	if def.Kind() == appdef.DefKind_ViewRecord {
+		for _, container := range []string{appdef.SystemContainer_ViewPartitionKey, appdef.SystemContainer_ViewClusteringCols, appdef.SystemContainer_ViewValue} {
+			def.ContainerDef(container).Fields(func(f appdef.IField) {
+				fields[f.Name()] = f.DataKind()
+			})
+		}
+	}
 	return fields
 }
 
@@ -5,7 +5,9 @@
 package coreutils
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/voedger/voedger/pkg/appdef"
@@ -16,6 +18,7 @@ import (
 var (
 	testQName       = appdef.NewQName("test", "QName")
 	testQNameSimple = appdef.NewQName("test", "QNameSimple")
+	testUUID, _     = istructs.ParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
 	testFieldDefs   = map[string]appdef.DataKind{
 		appdef.SystemField_QName: appdef.DataKind_QName,
 		"int32":                  appdef.DataKind_int32,
@@ -26,6 +29,10 @@ var (
 		"bool":                   appdef.DataKind_bool,
 		"bytes":                  appdef.DataKind_bytes,
 		"recordID":               appdef.DataKind_RecordID,
+		"decimal":                appdef.DataKind_decimal,
+		"timestamp":              appdef.DataKind_timestamp,
+		"date":                   appdef.DataKind_date,
+		"uuid":                   appdef.DataKind_UUID,
 	}
 	def = amock.NewDef(testQName, appdef.DefKind_Object, mockFields(testFieldDefs)...)
 
@@ -48,6 +55,10 @@ var (
 		"bool":                   true,
 		"bytes":                  []byte{5, 6},
 		"recordID":               istructs.RecordID(7),
+		"decimal":                istructs.NewDecimal(-1234, 2),
+		"timestamp":              time.UnixMilli(1680000000123).UTC(),
+		"date":                   time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC),
+		"uuid":                   testUUID,
 		appdef.SystemField_QName: testQName,
 	}
 	testDataSimple = map[string]interface{}{
@@ -63,6 +74,10 @@ var (
 		require.Equal(true, m["bool"])
 		require.Equal([]byte{5, 6}, m["bytes"])
 		require.Equal(istructs.RecordID(7), m["recordID"])
+		require.Equal(istructs.NewDecimal(-1234, 2), m["decimal"])
+		require.Equal(time.UnixMilli(1680000000123).UTC(), m["timestamp"])
+		require.Equal("2023-04-01", m["date"])
+		require.Equal(testUUID, m["uuid"])
 		actualQName, err := appdef.ParseQName(m[appdef.SystemField_QName].(string))
 		require.NoError(err)
 		require.Equal(testQName, actualQName)
@@ -91,6 +106,28 @@ func TestNewFieldsDef(t *testing.T) {
 	require.Equal(t, FieldsDef(testFieldDefs), fd)
 }
 
+func TestNewFieldsDef_View(t *testing.T) {
+	require := require.New(t)
+	view := appdef.NewQName("test", "view")
+	adb := appdef.New()
+	adb.AddView(view).
+		AddPartField("pk", appdef.DataKind_int64).
+		AddClustColumn("cc", appdef.DataKind_string).
+		AddValueField("val", appdef.DataKind_float64, false)
+	appDef, err := adb.Build()
+	require.NoError(err)
+
+	fd := NewFieldsDef(appDef.Def(view))
+
+	require.Equal(FieldsDef{
+		"pk":  appdef.DataKind_int64,
+		"cc":  appdef.DataKind_string,
+		"val": appdef.DataKind_float64,
+
+		appdef.SystemField_QName: appdef.DataKind_QName,
+	}, fd)
+}
+
 func TestToMap_Basic(t *testing.T) {
 	require := require.New(t)
 	obj := &TestObject{
@@ -131,6 +168,19 @@ func TestToMap_Basic(t *testing.T) {
 		testBasic(m, require)
 	})
 
+	t.Run("JSON", func(t *testing.T) {
+		b, err := json.Marshal(FieldsToMap(obj, appDef, Filter(func(name string, _ appdef.DataKind) bool {
+			return name == "decimal" || name == "timestamp" || name == "date" || name == "uuid"
+		})))
+		require.NoError(err)
+		require.JSONEq(`{
+			"decimal": -12.34,
+			"timestamp": "2023-03-28T10:40:00.123Z",
+			"date": "2023-04-01",
+			"uuid": "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+		}`, string(b))
+	})
+
 	t.Run("null QName", func(t *testing.T) {
 		obj = &TestObject{
 			Name: testQName,
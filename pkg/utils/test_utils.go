@@ -45,6 +45,10 @@ func (o *TestObject) PutString(name, value string)                     { o.Data[
 func (o *TestObject) PutQName(name string, value appdef.QName)         { o.Data[name] = value }
 func (o *TestObject) PutBool(name string, value bool)                  { o.Data[name] = value }
 func (o *TestObject) PutRecordID(name string, value istructs.RecordID) { o.Data[name] = value }
+func (o *TestObject) PutDecimal(name string, value istructs.Decimal)   { o.Data[name] = value }
+func (o *TestObject) PutTimestamp(name string, value time.Time)        { o.Data[name] = value }
+func (o *TestObject) PutDate(name string, value time.Time)             { o.Data[name] = value }
+func (o *TestObject) PutUUID(name string, value istructs.UUID)         { o.Data[name] = value }
 func (o *TestObject) PutNumber(name string, value float64)             { o.Data[name] = value }
 func (o *TestObject) PutChars(name string, value string)               { o.Data[name] = value }
 
@@ -107,6 +111,30 @@ func (o *TestObject) AsRecordID(name string) istructs.RecordID {
 	}
 	return istructs.NullRecordID
 }
+func (o *TestObject) AsDecimal(name string) istructs.Decimal {
+	if resIntf, ok := o.Data[name]; ok {
+		return resIntf.(istructs.Decimal)
+	}
+	return istructs.Decimal{}
+}
+func (o *TestObject) AsTimestamp(name string) time.Time {
+	if resIntf, ok := o.Data[name]; ok {
+		return resIntf.(time.Time)
+	}
+	return time.Time{}
+}
+func (o *TestObject) AsDate(name string) time.Time {
+	if resIntf, ok := o.Data[name]; ok {
+		return resIntf.(time.Time)
+	}
+	return time.Time{}
+}
+func (o *TestObject) AsUUID(name string) istructs.UUID {
+	if resIntf, ok := o.Data[name]; ok {
+		return resIntf.(istructs.UUID)
+	}
+	return istructs.NullUUID
+}
 func (o *TestObject) Elements(container string, cb func(el istructs.IElement)) {
 	if objects, ok := o.Containers_[container]; ok {
 		for _, object := range objects {